@@ -0,0 +1,2 @@
+-- Rollback casting promotions
+DROP TABLE IF EXISTS casting_promotions;
@@ -0,0 +1,40 @@
+BEGIN;
+
+-- Продвижение кастингов (featured placement)
+CREATE TABLE IF NOT EXISTS casting_promotions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    casting_id UUID NOT NULL REFERENCES castings(id) ON DELETE CASCADE,
+    employer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    payment_id UUID REFERENCES payment_transactions(id) ON DELETE SET NULL,
+
+    source VARCHAR(20) NOT NULL, -- 'payment', 'plan'
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'active', 'expired', 'cancelled'
+    days INTEGER NOT NULL CHECK (days > 0),
+    amount DECIMAL(10,2) DEFAULT 0,
+    currency VARCHAR(10) DEFAULT 'KZT',
+
+    starts_at TIMESTAMPTZ,
+    ends_at TIMESTAMPTZ,
+
+    impressions BIGINT NOT NULL DEFAULT 0,
+    clicks BIGINT NOT NULL DEFAULT 0
+    );
+
+CREATE TRIGGER set_timestamp_casting_promotions
+    BEFORE UPDATE ON casting_promotions
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_casting_promotions_casting_id ON casting_promotions(casting_id);
+CREATE INDEX IF NOT EXISTS idx_casting_promotions_employer_id ON casting_promotions(employer_id);
+CREATE INDEX IF NOT EXISTS idx_casting_promotions_payment_id ON casting_promotions(payment_id);
+-- Для ранжирования: "есть ли активное промо у кастинга"
+CREATE INDEX IF NOT EXISTS idx_casting_promotions_active ON casting_promotions(casting_id, ends_at) WHERE status = 'active';
+
+-- Назначение платежа (колонка есть в 000_complete_schema, добавляем для старых БД)
+ALTER TABLE payment_transactions ADD COLUMN IF NOT EXISTS description TEXT;
+
+COMMIT;
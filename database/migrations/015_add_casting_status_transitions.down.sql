@@ -0,0 +1,2 @@
+-- Rollback casting status transitions
+DROP TABLE IF EXISTS casting_status_transitions;
@@ -0,0 +1,24 @@
+BEGIN;
+
+-- Журнал переходов статуса кастинга (state machine audit)
+CREATE TABLE IF NOT EXISTS casting_status_transitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    casting_id UUID NOT NULL REFERENCES castings(id) ON DELETE CASCADE,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL для системных переходов
+    actor_type VARCHAR(20) NOT NULL, -- 'employer', 'admin', 'system'
+    reason TEXT
+    );
+
+CREATE TRIGGER set_timestamp_casting_status_transitions
+    BEFORE UPDATE ON casting_status_transitions
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_casting_status_transitions_casting_id ON casting_status_transitions(casting_id, created_at);
+
+COMMIT;
@@ -0,0 +1,2 @@
+-- Rollback employer notes
+DROP TABLE IF EXISTS employer_notes;
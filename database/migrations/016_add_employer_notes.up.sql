@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Приватные заметки, цветные теги и внутренний рейтинг работодателя по откликам и моделям
+CREATE TABLE IF NOT EXISTS employer_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    employer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(20) NOT NULL, -- 'response', 'model'
+    target_id UUID NOT NULL, -- casting_responses.id или users.id модели
+    note TEXT,
+    tags JSONB NOT NULL DEFAULT '[]',
+    rating SMALLINT CHECK (rating >= 1 AND rating <= 5),
+
+    CONSTRAINT uq_employer_note UNIQUE(employer_id, target_type, target_id)
+    );
+
+CREATE TRIGGER set_timestamp_employer_notes
+    BEFORE UPDATE ON employer_notes
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_employer_notes_target ON employer_notes(target_type, target_id);
+
+COMMIT;
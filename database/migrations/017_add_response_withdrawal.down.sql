@@ -0,0 +1,11 @@
+-- Rollback migration 017: Remove response withdrawal fields
+
+BEGIN;
+
+ALTER TABLE casting_responses
+DROP COLUMN IF EXISTS withdrawn_at,
+    DROP COLUMN IF EXISTS withdrawal_reason,
+    DROP COLUMN IF EXISTS withdraw_count,
+    DROP COLUMN IF EXISTS quota_charged;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration 017: Response withdrawal as a status transition
+-- Отзыв отклика больше не удаляет запись: статус 'withdrawn' + причина + счетчик отзывов
+
+BEGIN;
+
+ALTER TABLE casting_responses
+    ADD COLUMN IF NOT EXISTS withdrawn_at TIMESTAMPTZ,                 -- Время последнего отзыва
+    ADD COLUMN IF NOT EXISTS withdrawal_reason TEXT,                   -- Причина (необязательная)
+    ADD COLUMN IF NOT EXISTS withdraw_count INTEGER NOT NULL DEFAULT 0, -- Сколько раз модель отзывала отклик
+    ADD COLUMN IF NOT EXISTS quota_charged BOOLEAN NOT NULL DEFAULT true; -- Списан ли лимит подписки за отклик
+
+CREATE INDEX IF NOT EXISTS idx_casting_responses_status ON casting_responses(status);
+
+COMMIT;
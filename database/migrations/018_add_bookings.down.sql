@@ -0,0 +1,3 @@
+-- Rollback bookings
+DROP TABLE IF EXISTS booking_signatures;
+DROP TABLE IF EXISTS bookings;
@@ -0,0 +1,72 @@
+BEGIN;
+
+-- Бронирования моделей по принятым откликам
+CREATE TABLE IF NOT EXISTS bookings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    response_id UUID NOT NULL REFERENCES casting_responses(id) ON DELETE CASCADE,
+    casting_id UUID NOT NULL REFERENCES castings(id) ON DELETE CASCADE,
+    employer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    model_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'confirmed', 'completed', 'cancelled', 'no_show'
+
+    fee DECIMAL(12,2) NOT NULL CHECK (fee >= 0),
+    currency VARCHAR(10) NOT NULL DEFAULT 'KZT',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    location TEXT NOT NULL,
+    usage_rights TEXT,
+    terms TEXT,
+
+    contract_template VARCHAR(50) NOT NULL,
+    contract_version INTEGER NOT NULL DEFAULT 1,
+    contract_body TEXT NOT NULL,
+    contract_hash VARCHAR(64) NOT NULL,
+
+    status_reason TEXT,
+    confirmed_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_bookings_dates CHECK (ends_at >= starts_at)
+    );
+
+CREATE TRIGGER set_timestamp_bookings
+    BEFORE UPDATE ON bookings
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_bookings_casting_id ON bookings(casting_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_employer_id ON bookings(employer_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_bookings_model_id ON bookings(model_id, starts_at);
+-- Одно действующее бронирование на отклик (после отмены можно создать новое)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_bookings_active_response ON bookings(response_id) WHERE status <> 'cancelled';
+
+-- Подписи договора (typed-name e-signature)
+CREATE TABLE IF NOT EXISTS booking_signatures (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    signer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    signer_role VARCHAR(20) NOT NULL, -- 'employer', 'model'
+    typed_name VARCHAR(255) NOT NULL,
+    contract_version INTEGER NOT NULL,
+    contract_hash VARCHAR(64) NOT NULL,
+    signed_at TIMESTAMPTZ NOT NULL,
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+
+    CONSTRAINT uq_booking_signature UNIQUE (booking_id, signer_role, contract_version)
+    );
+
+CREATE TRIGGER set_timestamp_booking_signatures
+    BEFORE UPDATE ON booking_signatures
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_booking_signatures_booking_id ON booking_signatures(booking_id);
+
+COMMIT;
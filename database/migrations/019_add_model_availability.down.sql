@@ -0,0 +1,3 @@
+-- Rollback model availability calendar
+DROP TABLE IF EXISTS model_availability_weekly;
+DROP TABLE IF EXISTS model_availability_ranges;
@@ -0,0 +1,50 @@
+BEGIN;
+
+-- Календарь доступности модели: разовые интервалы (доступна / недоступна)
+CREATE TABLE IF NOT EXISTS model_availability_ranges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    model_profile_id UUID NOT NULL REFERENCES model_profiles(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL, -- 'available', 'unavailable'
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    note VARCHAR(255),
+
+    CONSTRAINT chk_model_availability_ranges_status CHECK (status IN ('available', 'unavailable')),
+    CONSTRAINT chk_model_availability_ranges_dates CHECK (ends_at > starts_at)
+    );
+
+CREATE TRIGGER set_timestamp_model_availability_ranges
+    BEFORE UPDATE ON model_availability_ranges
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_model_availability_ranges_profile ON model_availability_ranges(model_profile_id, starts_at, ends_at);
+
+-- Еженедельный шаблон (напр. "по воскресеньям недоступна", "будни 10:00-18:00")
+CREATE TABLE IF NOT EXISTS model_availability_weekly (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    model_profile_id UUID NOT NULL REFERENCES model_profiles(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL, -- 'available', 'unavailable'
+    weekday SMALLINT NOT NULL, -- 0 = воскресенье ... 6 = суббота
+    start_minute SMALLINT NOT NULL DEFAULT 0, -- минуты от начала дня (Asia/Almaty)
+    end_minute SMALLINT NOT NULL DEFAULT 1440,
+
+    CONSTRAINT chk_model_availability_weekly_status CHECK (status IN ('available', 'unavailable')),
+    CONSTRAINT chk_model_availability_weekly_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_model_availability_weekly_minutes CHECK (start_minute >= 0 AND end_minute <= 1440 AND end_minute > start_minute)
+    );
+
+CREATE TRIGGER set_timestamp_model_availability_weekly
+    BEFORE UPDATE ON model_availability_weekly
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_model_availability_weekly_profile ON model_availability_weekly(model_profile_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- Rollback double-entry ledger
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_accounts;
+DROP FUNCTION IF EXISTS ledger_check_entry_balanced();
+DROP FUNCTION IF EXISTS ledger_forbid_mutation();
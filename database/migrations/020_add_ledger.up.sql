@@ -0,0 +1,97 @@
+BEGIN;
+
+-- Счета двойной записи: кошельки пользователей, выручка платформы, клиринг платежного провайдера
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    code VARCHAR(100) NOT NULL, -- 'platform:revenue', 'provider:robokassa:clearing', 'wallet:<user_id>'
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL, -- 'asset', 'liability', 'revenue', 'expense'
+    owner_user_id UUID REFERENCES users(id) ON DELETE RESTRICT,
+    currency VARCHAR(10) NOT NULL DEFAULT 'KZT',
+
+    CONSTRAINT uq_ledger_accounts_code UNIQUE (code, currency)
+    );
+
+CREATE TRIGGER set_timestamp_ledger_accounts
+    BEFORE UPDATE ON ledger_accounts
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_ledger_accounts_owner ON ledger_accounts(owner_user_id);
+
+-- Проводки (журнал). Неизменяемы: исправление - только новой сторнирующей проводкой
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+
+    reference VARCHAR(150) NOT NULL, -- ключ идемпотентности, напр. 'payment:<id>:capture'
+    kind VARCHAR(30) NOT NULL, -- 'payment', 'refund', 'adjustment'
+    description TEXT,
+    payment_id UUID REFERENCES payment_transactions(id) ON DELETE RESTRICT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    posted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT uq_ledger_entries_reference UNIQUE (reference)
+    );
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_posted_at ON ledger_entries(posted_at);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_payment_id ON ledger_entries(payment_id);
+
+-- Строки проводки в минимальных единицах валюты (тиын): дебет > 0, кредит < 0
+CREATE TABLE IF NOT EXISTS ledger_postings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+
+    entry_id UUID NOT NULL REFERENCES ledger_entries(id) ON DELETE RESTRICT,
+    account_id UUID NOT NULL REFERENCES ledger_accounts(id) ON DELETE RESTRICT,
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    currency VARCHAR(10) NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_entry_id ON ledger_postings(entry_id);
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_account_id ON ledger_postings(account_id, created_at);
+
+-- Журнал только дописывается
+CREATE OR REPLACE FUNCTION ledger_forbid_mutation()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'ledger is append-only: % on % is not allowed', TG_OP, TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER ledger_entries_immutable
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW
+    EXECUTE PROCEDURE ledger_forbid_mutation();
+
+CREATE TRIGGER ledger_postings_immutable
+    BEFORE UPDATE OR DELETE ON ledger_postings
+    FOR EACH ROW
+    EXECUTE PROCEDURE ledger_forbid_mutation();
+
+-- Сумма строк проводки по каждой валюте равна нулю (проверяется при коммите транзакции)
+CREATE OR REPLACE FUNCTION ledger_check_entry_balanced()
+RETURNS TRIGGER AS $$
+BEGIN
+  IF EXISTS (
+    SELECT 1 FROM ledger_postings
+    WHERE entry_id = NEW.entry_id
+    GROUP BY currency
+    HAVING SUM(amount) <> 0
+  ) THEN
+    RAISE EXCEPTION 'ledger entry % is not balanced', NEW.entry_id;
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_postings_balanced
+    AFTER INSERT ON ledger_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+    EXECUTE PROCEDURE ledger_check_entry_balanced();
+
+COMMIT;
@@ -0,0 +1,4 @@
+-- Rollback invoices and credit notes
+DROP TABLE IF EXISTS invoice_lines;
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_sequences;
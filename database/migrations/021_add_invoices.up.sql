@@ -0,0 +1,77 @@
+BEGIN;
+
+-- Счетчики номеров документов: без пропусков в пределах года и вида документа.
+-- Номер берется в той же транзакции, что и документ, поэтому откат не оставляет дыр.
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    kind VARCHAR(20) NOT NULL, -- 'invoice', 'credit_note'
+    year INTEGER NOT NULL,
+    last_number INTEGER NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (kind, year)
+    );
+
+-- Счета и кредит-ноты по платежам. Реквизиты сторон и суммы фиксируются на момент выставления
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    kind VARCHAR(20) NOT NULL, -- 'invoice', 'credit_note'
+    number VARCHAR(30) NOT NULL, -- 'INV-2026-000001', 'CN-2026-000001'
+    year INTEGER NOT NULL,
+    sequence INTEGER NOT NULL,
+
+    payment_id UUID NOT NULL REFERENCES payment_transactions(id) ON DELETE RESTRICT,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    original_invoice_id UUID REFERENCES invoices(id) ON DELETE RESTRICT, -- для кредит-ноты
+
+    seller_details JSONB NOT NULL,
+    buyer_details JSONB NOT NULL,
+
+    currency VARCHAR(10) NOT NULL DEFAULT 'KZT',
+    vat_rate INTEGER NOT NULL DEFAULT 0, -- в процентах
+    net_amount BIGINT NOT NULL, -- в минимальных единицах (тиын); у кредит-ноты отрицательные
+    vat_amount BIGINT NOT NULL,
+    total_amount BIGINT NOT NULL,
+
+    reason TEXT,
+    issued_at TIMESTAMPTZ NOT NULL,
+    emailed_at TIMESTAMPTZ,
+
+    CONSTRAINT uq_invoices_number UNIQUE (number),
+    CONSTRAINT uq_invoices_sequence UNIQUE (kind, year, sequence),
+    -- Один счет и одна кредит-нота на платеж
+    CONSTRAINT uq_invoices_payment_kind UNIQUE (payment_id, kind)
+    );
+
+CREATE TRIGGER set_timestamp_invoices
+    BEFORE UPDATE ON invoices
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_invoices_user_id ON invoices(user_id, issued_at);
+
+-- Строки документа
+CREATE TABLE IF NOT EXISTS invoice_lines (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    description TEXT NOT NULL,
+    quantity INTEGER NOT NULL DEFAULT 1,
+    unit_price BIGINT NOT NULL, -- с НДС
+    net_amount BIGINT NOT NULL,
+    vat_amount BIGINT NOT NULL,
+    total_amount BIGINT NOT NULL
+    );
+
+CREATE TRIGGER set_timestamp_invoice_lines
+    BEFORE UPDATE ON invoice_lines
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_invoice_lines_invoice_id ON invoice_lines(invoice_id);
+
+COMMIT;
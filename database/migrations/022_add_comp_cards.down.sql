@@ -0,0 +1,2 @@
+-- Rollback comp cards
+DROP TABLE IF EXISTS comp_cards;
@@ -0,0 +1,24 @@
+BEGIN;
+
+-- Настройки композитки (comp card) модели: шаблон и выбранные фото портфолио.
+-- Готовые PDF/PNG лежат в хранилище под ключом-отпечатком данных (cache_key).
+CREATE TABLE IF NOT EXISTS comp_cards (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    model_profile_id UUID NOT NULL REFERENCES model_profiles(id) ON DELETE CASCADE,
+    template VARCHAR(20) NOT NULL DEFAULT 'classic', -- 'classic', 'portrait', 'landscape'
+    portfolio_item_ids JSONB NOT NULL DEFAULT '[]', -- порядок = порядок на карточке, первое фото - главное
+    cache_key VARCHAR(64), -- отпечаток данных последней отрисовки
+
+    CONSTRAINT uq_comp_cards_model_profile UNIQUE (model_profile_id),
+    CONSTRAINT chk_comp_cards_template CHECK (template IN ('classic', 'portrait', 'landscape'))
+    );
+
+CREATE TRIGGER set_timestamp_comp_cards
+    BEFORE UPDATE ON comp_cards
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
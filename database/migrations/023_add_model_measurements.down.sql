@@ -0,0 +1,26 @@
+-- Rollback model measurements
+DROP INDEX IF EXISTS idx_model_profiles_shoe_size_eu;
+DROP INDEX IF EXISTS idx_model_profiles_clothing_size;
+
+ALTER TABLE castings
+    DROP COLUMN IF EXISTS bust_min,
+    DROP COLUMN IF EXISTS bust_max,
+    DROP COLUMN IF EXISTS waist_min,
+    DROP COLUMN IF EXISTS waist_max,
+    DROP COLUMN IF EXISTS hips_min,
+    DROP COLUMN IF EXISTS hips_max,
+    DROP COLUMN IF EXISTS hair_colors,
+    DROP COLUMN IF EXISTS eye_colors,
+    DROP COLUMN IF EXISTS tattoos_allowed,
+    DROP COLUMN IF EXISTS piercings_allowed;
+
+ALTER TABLE model_profiles
+    DROP COLUMN IF EXISTS bust,
+    DROP COLUMN IF EXISTS waist,
+    DROP COLUMN IF EXISTS hips,
+    DROP COLUMN IF EXISTS inseam,
+    DROP COLUMN IF EXISTS hair_color,
+    DROP COLUMN IF EXISTS eye_color,
+    DROP COLUMN IF EXISTS has_tattoos,
+    DROP COLUMN IF EXISTS has_piercings,
+    DROP COLUMN IF EXISTS shoe_size_eu;
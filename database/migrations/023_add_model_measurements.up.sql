@@ -0,0 +1,56 @@
+BEGIN;
+
+-- Расширенные параметры модели. Длины хранятся в сантиметрах,
+-- одежда - международной буквой (XXS...XXXL), обувь - размером EU.
+ALTER TABLE model_profiles
+    ADD COLUMN IF NOT EXISTS bust NUMERIC(5,1) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS waist NUMERIC(5,1) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS hips NUMERIC(5,1) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS inseam NUMERIC(5,1) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS hair_color VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS eye_color VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS has_tattoos BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS has_piercings BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS shoe_size_eu NUMERIC(4,1) NOT NULL DEFAULT 0; -- 0 - не указан
+
+-- Требования кастинга к параметрам (NULL - не важно)
+ALTER TABLE castings
+    ADD COLUMN IF NOT EXISTS bust_min NUMERIC(5,1),
+    ADD COLUMN IF NOT EXISTS bust_max NUMERIC(5,1),
+    ADD COLUMN IF NOT EXISTS waist_min NUMERIC(5,1),
+    ADD COLUMN IF NOT EXISTS waist_max NUMERIC(5,1),
+    ADD COLUMN IF NOT EXISTS hips_min NUMERIC(5,1),
+    ADD COLUMN IF NOT EXISTS hips_max NUMERIC(5,1),
+    ADD COLUMN IF NOT EXISTS hair_colors JSONB, -- подходит любой из цветов
+    ADD COLUMN IF NOT EXISTS eye_colors JSONB,
+    ADD COLUMN IF NOT EXISTS tattoos_allowed BOOLEAN,
+    ADD COLUMN IF NOT EXISTS piercings_allowed BOOLEAN;
+
+-- Приведение старых размеров одежды: буквы и их синонимы
+UPDATE model_profiles
+SET clothing_size = CASE UPPER(TRIM(clothing_size))
+        WHEN '2XS' THEN 'XXS'
+        WHEN '2XL' THEN 'XXL'
+        WHEN '3XL' THEN 'XXXL'
+        ELSE UPPER(TRIM(clothing_size))
+    END
+WHERE UPPER(TRIM(clothing_size)) IN ('XXS', '2XS', 'XS', 'S', 'M', 'L', 'XL', 'XXL', '2XL', 'XXXL', '3XL');
+
+-- Числовые размеры одежды считаем российскими (женские 38-52, мужские 42-56)
+UPDATE model_profiles
+SET clothing_size = (ARRAY['XXS', 'XS', 'S', 'M', 'L', 'XL', 'XXL', 'XXXL'])[(TRIM(clothing_size)::INT - 38) / 2 + 1]
+WHERE gender = 'female' AND TRIM(clothing_size) ~ '^(38|40|42|44|46|48|50|52)$';
+
+UPDATE model_profiles
+SET clothing_size = (ARRAY['XXS', 'XS', 'S', 'M', 'L', 'XL', 'XXL', 'XXXL'])[(TRIM(clothing_size)::INT - 42) / 2 + 1]
+WHERE gender = 'male' AND TRIM(clothing_size) ~ '^(42|44|46|48|50|52|54|56)$';
+
+-- Числовые размеры обуви считаем европейскими
+UPDATE model_profiles
+SET shoe_size_eu = REPLACE(TRIM(shoe_size), ',', '.')::NUMERIC
+WHERE TRIM(shoe_size) ~ '^[0-9]{2}([.,]5)?$';
+
+CREATE INDEX IF NOT EXISTS idx_model_profiles_clothing_size ON model_profiles(clothing_size);
+CREATE INDEX IF NOT EXISTS idx_model_profiles_shoe_size_eu ON model_profiles(shoe_size_eu);
+
+COMMIT;
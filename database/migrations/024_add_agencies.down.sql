@@ -0,0 +1,7 @@
+-- Rollback agencies
+DROP TABLE IF EXISTS model_representations;
+DROP TABLE IF EXISTS agency_profiles;
+
+-- Значение 'agency' в enum user_role не удаляется (PostgreSQL не поддерживает DROP VALUE);
+-- пользователей-агентств блокируем
+UPDATE users SET status = 'suspended' WHERE role = 'agency';
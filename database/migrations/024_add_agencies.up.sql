@@ -0,0 +1,62 @@
+BEGIN;
+
+-- Новая роль пользователя (новое значение enum можно использовать только после COMMIT)
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'agency';
+
+-- Модельные агентства: профиль пользователя с ролью 'agency'
+CREATE TABLE IF NOT EXISTS agency_profiles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    city VARCHAR(100),
+    phone VARCHAR(50),
+    website VARCHAR(255),
+    description TEXT,
+    is_verified BOOLEAN DEFAULT false,
+
+    CONSTRAINT uq_agency_profiles_user UNIQUE (user_id)
+    );
+
+CREATE TRIGGER set_timestamp_agency_profiles
+    BEFORE UPDATE ON agency_profiles
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Представительство модели агентством. Приглашение (pending) модель принимает или отклоняет,
+-- активное представительство любая сторона может прекратить (ended).
+CREATE TABLE IF NOT EXISTS model_representations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    agency_id UUID NOT NULL REFERENCES agency_profiles(id) ON DELETE CASCADE,
+    model_profile_id UUID NOT NULL REFERENCES model_profiles(id) ON DELETE CASCADE,
+    model_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- для маршрутизации уведомлений и чатов
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    copy_model BOOLEAN NOT NULL DEFAULT true, -- модель получает копии уведомлений агентства
+    is_managed BOOLEAN NOT NULL DEFAULT false, -- профиль создан агентством
+    started_at TIMESTAMPTZ,
+    ended_at TIMESTAMPTZ,
+    ended_by VARCHAR(20),
+
+    CONSTRAINT chk_model_representations_status CHECK (status IN ('pending', 'active', 'declined', 'ended'))
+    );
+
+CREATE INDEX IF NOT EXISTS idx_model_representations_agency ON model_representations(agency_id, status);
+CREATE INDEX IF NOT EXISTS idx_model_representations_model_user ON model_representations(model_user_id, status);
+
+-- У модели не больше одного активного агентства и одного открытого приглашения от каждого агентства
+CREATE UNIQUE INDEX IF NOT EXISTS uq_model_representations_active
+    ON model_representations(model_profile_id) WHERE status = 'active';
+CREATE UNIQUE INDEX IF NOT EXISTS uq_model_representations_open
+    ON model_representations(agency_id, model_profile_id) WHERE status IN ('pending', 'active');
+
+CREATE TRIGGER set_timestamp_model_representations
+    BEFORE UPDATE ON model_representations
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
@@ -0,0 +1,5 @@
+-- Rollback employer verifications
+DROP TABLE IF EXISTS employer_verification_transitions;
+DROP TABLE IF EXISTS employer_verifications;
+
+ALTER TABLE employer_profiles DROP COLUMN IF EXISTS verified_until;
@@ -0,0 +1,63 @@
+BEGIN;
+
+-- Срок действия верификации работодателя (NULL - бессрочно, для ручной верификации до появления заявок)
+ALTER TABLE employer_profiles ADD COLUMN IF NOT EXISTS verified_until TIMESTAMPTZ;
+
+-- Заявки работодателей на верификацию. Документы - приватные загрузки модуля 'verification'
+-- (uploads.entity_type = 'employer_verification', uploads.entity_id = id заявки).
+CREATE TABLE IF NOT EXISTS employer_verifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    employer_profile_id UUID NOT NULL REFERENCES employer_profiles(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    bin VARCHAR(12) NOT NULL, -- бизнес-идентификационный номер
+    legal_name VARCHAR(255) NOT NULL,
+    comment TEXT,
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    reviewed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+
+    CONSTRAINT chk_employer_verifications_status CHECK (status IN ('pending', 'needs_info', 'approved', 'rejected', 'expired'))
+    );
+
+CREATE TRIGGER set_timestamp_employer_verifications
+    BEFORE UPDATE ON employer_verifications
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_employer_verifications_profile ON employer_verifications(employer_profile_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_employer_verifications_queue ON employer_verifications(status, submitted_at);
+CREATE INDEX IF NOT EXISTS idx_employer_verifications_expires ON employer_verifications(expires_at) WHERE status = 'approved';
+
+-- Не больше одной заявки на рассмотрении у работодателя
+CREATE UNIQUE INDEX IF NOT EXISTS uq_employer_verifications_open
+    ON employer_verifications(employer_profile_id) WHERE status IN ('pending', 'needs_info');
+
+-- История статусов заявки
+CREATE TABLE IF NOT EXISTS employer_verification_transitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    verification_id UUID NOT NULL REFERENCES employer_verifications(id) ON DELETE CASCADE,
+    from_status VARCHAR(20), -- пусто при подаче заявки
+    to_status VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL, -- NULL для системных переходов
+    actor_type VARCHAR(20) NOT NULL, -- 'employer', 'admin', 'system'
+    note TEXT
+    );
+
+CREATE TRIGGER set_timestamp_employer_verification_transitions
+    BEFORE UPDATE ON employer_verification_transitions
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_employer_verification_transitions_verification
+    ON employer_verification_transitions(verification_id, created_at);
+
+COMMIT;
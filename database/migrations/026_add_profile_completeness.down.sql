@@ -0,0 +1,5 @@
+-- Rollback profile completeness
+DROP INDEX IF EXISTS idx_model_profiles_completeness;
+
+ALTER TABLE model_profiles DROP COLUMN IF EXISTS completeness_nudged_at;
+ALTER TABLE model_profiles DROP COLUMN IF EXISTS completeness_score;
@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Заполненность профиля модели (0-100) и время последнего напоминания заполнить профиль.
+-- Оценку считает приложение (algorithms.ProfileCompleteness): при изменении профиля и портфолио
+-- и в фоновой задаче напоминаний.
+ALTER TABLE model_profiles ADD COLUMN IF NOT EXISTS completeness_score INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE model_profiles ADD COLUMN IF NOT EXISTS completeness_nudged_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_model_profiles_completeness ON model_profiles(completeness_score);
+
+COMMIT;
@@ -0,0 +1,6 @@
+-- Rollback slugs
+DROP TABLE IF EXISTS slug_redirects;
+
+ALTER TABLE castings DROP COLUMN IF EXISTS slug;
+ALTER TABLE employer_profiles DROP COLUMN IF EXISTS slug;
+ALTER TABLE model_profiles DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,34 @@
+BEGIN;
+
+-- Короткие адреса (slug) профилей и кастингов для публичных страниц /share/...
+-- NULL - адрес еще не выбран (назначается при первом запросе ссылки)
+ALTER TABLE model_profiles ADD COLUMN IF NOT EXISTS slug VARCHAR(50);
+ALTER TABLE employer_profiles ADD COLUMN IF NOT EXISTS slug VARCHAR(50);
+ALTER TABLE castings ADD COLUMN IF NOT EXISTS slug VARCHAR(50);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_model_profiles_slug ON model_profiles(slug) WHERE slug IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_employer_profiles_slug ON employer_profiles(slug) WHERE slug IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_castings_slug ON castings(slug) WHERE slug IS NOT NULL;
+
+-- Прежние адреса: старые ссылки перенаправляют на текущий slug и не достаются другим
+CREATE TABLE IF NOT EXISTS slug_redirects (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    entity_type VARCHAR(20) NOT NULL, -- 'model', 'employer', 'casting'
+    entity_id UUID NOT NULL,
+    slug VARCHAR(50) NOT NULL,
+
+    CONSTRAINT chk_slug_redirects_entity_type CHECK (entity_type IN ('model', 'employer', 'casting'))
+    );
+
+CREATE TRIGGER set_timestamp_slug_redirects
+    BEFORE UPDATE ON slug_redirects
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_slug_redirects_slug ON slug_redirects(entity_type, slug);
+CREATE INDEX IF NOT EXISTS idx_slug_redirects_entity ON slug_redirects(entity_type, entity_id);
+
+COMMIT;
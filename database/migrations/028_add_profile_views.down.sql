@@ -0,0 +1,4 @@
+-- Rollback profile views
+DROP TABLE IF EXISTS profile_views;
+
+ALTER TABLE employer_profiles DROP COLUMN IF EXISTS anonymous_browsing;
@@ -0,0 +1,29 @@
+BEGIN;
+
+-- Анонимный просмотр профилей моделей (просмотры не раскрывают компанию)
+ALTER TABLE employer_profiles ADD COLUMN IF NOT EXISTS anonymous_browsing BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Просмотры профилей моделей авторизованными работодателями ("кто смотрел мой профиль").
+-- viewer_user_id хранится и для анонимных просмотров (для защиты от накрутки),
+-- но модели такие просмотры показываются только в виде счетчика.
+CREATE TABLE IF NOT EXISTS profile_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    model_id UUID NOT NULL REFERENCES model_profiles(id) ON DELETE CASCADE,
+    viewer_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    employer_profile_id UUID REFERENCES employer_profiles(id) ON DELETE SET NULL,
+    is_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+    );
+
+CREATE TRIGGER set_timestamp_profile_views
+    BEFORE UPDATE ON profile_views
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE INDEX IF NOT EXISTS idx_profile_views_model ON profile_views(model_id, viewed_at);
+CREATE INDEX IF NOT EXISTS idx_profile_views_viewer ON profile_views(model_id, viewer_user_id, viewed_at);
+
+COMMIT;
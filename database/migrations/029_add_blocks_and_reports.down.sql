@@ -0,0 +1,3 @@
+-- Rollback blocks and reports
+DROP TABLE IF EXISTS reports;
+DROP TABLE IF EXISTS user_blocks;
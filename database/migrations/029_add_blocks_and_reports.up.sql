@@ -0,0 +1,52 @@
+BEGIN;
+
+-- Черный список: заблокированный пользователь не может писать, откликаться и видеть профиль
+-- заблокировавшего (и наоборот - ограничения действуют в обе стороны)
+CREATE TABLE IF NOT EXISTS user_blocks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    blocker_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT user_blocks_not_self CHECK (blocker_id <> blocked_id)
+    );
+
+CREATE TRIGGER set_timestamp_user_blocks
+    BEFORE UPDATE ON user_blocks
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_blocks_pair ON user_blocks(blocker_id, blocked_id);
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks(blocked_id);
+
+-- Жалобы на пользователей и контент, очередь модерации
+CREATE TABLE IF NOT EXISTS reports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_type VARCHAR(30) NOT NULL, -- user, casting, message, review, portfolio_item
+    target_id UUID NOT NULL,
+    target_user_id UUID REFERENCES users(id) ON DELETE SET NULL, -- владелец контента
+    reason VARCHAR(30) NOT NULL,
+    details TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, resolved, dismissed
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    resolution_note TEXT,
+    reviewed_at TIMESTAMPTZ
+    );
+
+CREATE TRIGGER set_timestamp_reports
+    BEFORE UPDATE ON reports
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Одна открытая жалоба пользователя на один объект
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_open_per_reporter
+    ON reports(reporter_id, target_type, target_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_reports_queue ON reports(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_reports_target ON reports(target_type, target_id);
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Rollback content locales
+ALTER TABLE castings DROP COLUMN IF EXISTS description_i18n;
+ALTER TABLE castings DROP COLUMN IF EXISTS title_i18n;
+ALTER TABLE castings DROP COLUMN IF EXISTS content_locale;
+
+ALTER TABLE model_profiles DROP COLUMN IF EXISTS description_i18n;
+ALTER TABLE model_profiles DROP COLUMN IF EXISTS content_locale;
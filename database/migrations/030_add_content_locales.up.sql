@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Мультиязычный контент (ru/kk/en).
+-- Основной текст (description, title) остается в исходных колонках на языке content_locale,
+-- переводы хранятся в jsonb вида {"kk": "...", "en": "..."}.
+ALTER TABLE model_profiles ADD COLUMN IF NOT EXISTS content_locale VARCHAR(2) NOT NULL DEFAULT 'ru';
+ALTER TABLE model_profiles ADD COLUMN IF NOT EXISTS description_i18n JSONB;
+
+ALTER TABLE castings ADD COLUMN IF NOT EXISTS content_locale VARCHAR(2) NOT NULL DEFAULT 'ru';
+ALTER TABLE castings ADD COLUMN IF NOT EXISTS title_i18n JSONB;
+ALTER TABLE castings ADD COLUMN IF NOT EXISTS description_i18n JSONB;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Rollback photo moderation
+ALTER TABLE users DROP COLUMN IF EXISTS photo_auto_approve;
+
+DROP INDEX IF EXISTS idx_uploads_moderation_queue;
+
+ALTER TABLE uploads DROP COLUMN IF EXISTS rejection_reason;
+ALTER TABLE uploads DROP COLUMN IF EXISTS moderated_at;
+ALTER TABLE uploads DROP COLUMN IF EXISTS moderated_by;
+ALTER TABLE uploads DROP COLUMN IF EXISTS moderation_status;
@@ -0,0 +1,18 @@
+BEGIN;
+
+-- Модерация фото: аватары и портфолио публикуются после одобрения модератором.
+-- Уже загруженные файлы и файлы без модерации (документы, вложения) считаются одобренными.
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved'
+    CHECK (moderation_status IN ('pending', 'approved', 'rejected'));
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS moderated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMPTZ;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+
+-- Очередь модерации (старые первыми)
+CREATE INDEX IF NOT EXISTS idx_uploads_moderation_queue ON uploads(created_at)
+    WHERE moderation_status = 'pending';
+
+-- Доверенные пользователи: их фото одобряются автоматически
+ALTER TABLE users ADD COLUMN IF NOT EXISTS photo_auto_approve BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
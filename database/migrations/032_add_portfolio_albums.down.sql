@@ -0,0 +1,8 @@
+-- Rollback portfolio albums
+ALTER TABLE portfolio_albums DROP COLUMN IF EXISTS cover_item_id;
+
+DROP INDEX IF EXISTS idx_portfolio_items_album;
+ALTER TABLE portfolio_items DROP COLUMN IF EXISTS album_order_index;
+ALTER TABLE portfolio_items DROP COLUMN IF EXISTS album_id;
+
+DROP TABLE IF EXISTS portfolio_albums;
@@ -0,0 +1,32 @@
+BEGIN;
+
+-- Альбомы портфолио ("Editorial", "Commercial 2025", "Polaroids").
+-- Элемент портфолио лежит не более чем в одном альбоме; без альбома - в общем списке.
+CREATE TABLE IF NOT EXISTS portfolio_albums (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+
+    model_id UUID NOT NULL REFERENCES model_profiles(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    visibility VARCHAR(20) NOT NULL DEFAULT 'public'
+        CHECK (visibility IN ('public', 'private')),
+    order_index INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_portfolio_albums_model_order ON portfolio_albums(model_id, order_index);
+CREATE TRIGGER set_timestamp
+    BEFORE UPDATE ON portfolio_albums
+    FOR EACH ROW
+    EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- При удалении альбома его элементы возвращаются в общий список
+ALTER TABLE portfolio_items ADD COLUMN IF NOT EXISTS album_id UUID REFERENCES portfolio_albums(id) ON DELETE SET NULL;
+ALTER TABLE portfolio_items ADD COLUMN IF NOT EXISTS album_order_index INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_portfolio_items_album ON portfolio_items(album_id, album_order_index)
+    WHERE album_id IS NOT NULL;
+
+-- Обложка: явно выбранный элемент альбома; без нее - первый элемент
+ALTER TABLE portfolio_albums ADD COLUMN IF NOT EXISTS cover_item_id UUID REFERENCES portfolio_items(id) ON DELETE SET NULL;
+
+COMMIT;
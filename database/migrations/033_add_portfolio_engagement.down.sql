@@ -0,0 +1,8 @@
+-- Rollback portfolio engagement
+DROP INDEX IF EXISTS idx_portfolio_items_featured;
+ALTER TABLE portfolio_items DROP COLUMN IF EXISTS featured_score;
+ALTER TABLE portfolio_items DROP COLUMN IF EXISTS saves_count;
+ALTER TABLE portfolio_items DROP COLUMN IF EXISTS likes_count;
+
+DROP TABLE IF EXISTS portfolio_item_saves;
+DROP TABLE IF EXISTS portfolio_item_likes;
@@ -0,0 +1,29 @@
+BEGIN;
+
+-- Лайки и сохранения работ портфолио (по одному от пользователя на работу)
+CREATE TABLE IF NOT EXISTS portfolio_item_likes (
+    item_id UUID NOT NULL REFERENCES portfolio_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (item_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_portfolio_item_likes_user ON portfolio_item_likes(user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS portfolio_item_saves (
+    item_id UUID NOT NULL REFERENCES portfolio_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (item_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_portfolio_item_saves_user ON portfolio_item_saves(user_id, created_at DESC);
+
+-- Счетчики обновляются вместе с лайком/сохранением, чтобы не считать их на каждом запросе
+ALTER TABLE portfolio_items ADD COLUMN IF NOT EXISTS likes_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE portfolio_items ADD COLUMN IF NOT EXISTS saves_count INTEGER NOT NULL DEFAULT 0;
+
+-- Рейтинг для подборки "featured": пересчитывается фоновой задачей (0 - не попадает в подборку)
+ALTER TABLE portfolio_items ADD COLUMN IF NOT EXISTS featured_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_portfolio_items_featured ON portfolio_items(featured_score DESC)
+    WHERE featured_score > 0;
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Rollback duplicate photo detection
+DROP TABLE IF EXISTS duplicate_photo_matches;
+
+DROP INDEX IF EXISTS idx_uploads_phash_missing;
+DROP INDEX IF EXISTS idx_uploads_phash_b3;
+DROP INDEX IF EXISTS idx_uploads_phash_b2;
+DROP INDEX IF EXISTS idx_uploads_phash_b1;
+DROP INDEX IF EXISTS idx_uploads_phash_b0;
+
+ALTER TABLE uploads DROP COLUMN IF EXISTS phash_b3;
+ALTER TABLE uploads DROP COLUMN IF EXISTS phash_b2;
+ALTER TABLE uploads DROP COLUMN IF EXISTS phash_b1;
+ALTER TABLE uploads DROP COLUMN IF EXISTS phash_b0;
+ALTER TABLE uploads DROP COLUMN IF EXISTS phash;
@@ -0,0 +1,38 @@
+BEGIN;
+
+-- Перцептивный хэш изображения (dHash, 64 бита) и его 4 части по 16 бит.
+-- Части индексируются отдельно: поиск похожих фото проверяет только строки,
+-- у которых совпала хотя бы одна часть (multi-index hashing), а не всю таблицу
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS phash BIGINT;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS phash_b0 INTEGER;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS phash_b1 INTEGER;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS phash_b2 INTEGER;
+ALTER TABLE uploads ADD COLUMN IF NOT EXISTS phash_b3 INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_uploads_phash_b0 ON uploads(phash_b0) WHERE phash IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_uploads_phash_b1 ON uploads(phash_b1) WHERE phash IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_uploads_phash_b2 ON uploads(phash_b2) WHERE phash IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_uploads_phash_b3 ON uploads(phash_b3) WHERE phash IS NOT NULL AND deleted_at IS NULL;
+
+-- Изображения, которые еще предстоит хэшировать фоновой задаче
+CREATE INDEX IF NOT EXISTS idx_uploads_phash_missing ON uploads(created_at)
+    WHERE phash IS NULL AND file_type = 'image' AND deleted_at IS NULL;
+
+-- Совпадения нового фото с фото другого пользователя - очередь проверки для админов
+CREATE TABLE IF NOT EXISTS duplicate_photo_matches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    upload_id UUID NOT NULL REFERENCES uploads(id) ON DELETE CASCADE,
+    matched_upload_id UUID NOT NULL REFERENCES uploads(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    matched_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    distance INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'dismissed')),
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (upload_id, matched_upload_id)
+);
+CREATE INDEX IF NOT EXISTS idx_duplicate_photo_matches_status ON duplicate_photo_matches(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_duplicate_photo_matches_user ON duplicate_photo_matches(user_id);
+
+COMMIT;
@@ -0,0 +1,3 @@
+-- Rollback nullable payment subscription
+DELETE FROM payment_transactions WHERE subscription_id IS NULL;
+ALTER TABLE payment_transactions ALTER COLUMN subscription_id SET NOT NULL;
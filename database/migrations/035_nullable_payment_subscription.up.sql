@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Платежи за услуги (продвижение кастинга) не привязаны к подписке:
+-- у пользователя может не быть подписки на момент оплаты
+ALTER TABLE payment_transactions ALTER COLUMN subscription_id DROP NOT NULL;
+
+COMMIT;
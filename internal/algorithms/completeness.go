@@ -0,0 +1,72 @@
+package algorithms
+
+import (
+	"strings"
+
+	"mwork_backend/internal/models"
+)
+
+const (
+	// MinPortfolioPhotos is the number of portfolio photos that completes the photos item
+	MinPortfolioPhotos = 3
+	// CompleteProfileThreshold - profiles scoring below it count as incomplete
+	// (nudged to fill in, optionally down-ranked in search and matching)
+	CompleteProfileThreshold = 80
+	// minDescriptionLength - shorter "about me" texts don't count
+	minDescriptionLength = 50
+	// incompleteRankFloor is the ranking multiplier of an empty profile
+	incompleteRankFloor = 0.7
+)
+
+// CompletenessItem is one checklist entry; weights of all items add up to 100
+type CompletenessItem struct {
+	Key    string `json:"key"`
+	Label  string `json:"label"`
+	Weight int    `json:"weight"`
+	Done   bool   `json:"done"`
+}
+
+// ProfileCompleteness scores a model profile (0-100) and returns the full checklist.
+// portfolioPhotos is the number of approved photos in the model's portfolio.
+func ProfileCompleteness(model *models.ModelProfile, portfolioPhotos int64) (int, []CompletenessItem) {
+	checklist := []CompletenessItem{
+		{Key: "portfolio_photos", Label: "Add at least 3 portfolio photos", Weight: 25,
+			Done: portfolioPhotos >= MinPortfolioPhotos},
+		{Key: "measurements", Label: "Add bust, waist and hips measurements", Weight: 15,
+			Done: model.Bust > 0 && model.Waist > 0 && model.Hips > 0},
+		{Key: "categories", Label: "Choose the categories you work in", Weight: 15,
+			Done: len(model.GetCategories()) > 0},
+		{Key: "sizes", Label: "Add clothing and shoe sizes", Weight: 10,
+			Done: model.ClothingSize != "" && model.ShoeSize != ""},
+		{Key: "appearance", Label: "Add hair and eye color", Weight: 10,
+			Done: model.HairColor != "" && model.EyeColor != ""},
+		{Key: "description", Label: "Write a short description about yourself", Weight: 10,
+			Done: len([]rune(strings.TrimSpace(model.Description))) >= minDescriptionLength},
+		{Key: "height_weight", Label: "Add height and weight", Weight: 5,
+			Done: model.Height > 0 && model.Weight > 0},
+		{Key: "languages", Label: "Add the languages you speak", Weight: 5,
+			Done: len(model.GetLanguages()) > 0},
+		{Key: "hourly_rate", Label: "Set your hourly rate", Weight: 5,
+			Done: model.HourlyRate > 0},
+	}
+
+	score := 0
+	for _, item := range checklist {
+		if item.Done {
+			score += item.Weight
+		}
+	}
+	return score, checklist
+}
+
+// CompletenessRankFactor is a ranking multiplier: 1 for complete profiles,
+// falling linearly to incompleteRankFloor for empty ones
+func CompletenessRankFactor(score int) float64 {
+	if score >= CompleteProfileThreshold {
+		return 1
+	}
+	if score < 0 {
+		score = 0
+	}
+	return incompleteRankFloor + (1-incompleteRankFloor)*float64(score)/CompleteProfileThreshold
+}
@@ -0,0 +1,53 @@
+package algorithms
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// featuredHalfLife - an item loses half of its featured score every two weeks
+	featuredHalfLife = 14 * 24 * time.Hour
+	// featuredSaveWeight - a save says more about an item than a like
+	featuredSaveWeight = 3
+	// featuredRatingBoost - the best rated profiles get up to +50%
+	featuredRatingBoost = 0.5
+	// maxProfileRating is the top of the review rating scale
+	maxProfileRating = 5.0
+)
+
+// FeaturedSignals are the inputs of the featured ranking of one portfolio item
+type FeaturedSignals struct {
+	Likes     int
+	Saves     int
+	CreatedAt time.Time
+	// Approved - the photo passed moderation; unapproved items are never featured
+	Approved bool
+	// ProfileRating is the average review rating of the model (0-5)
+	ProfileRating float64
+	// CompletenessScore is the model's profile completeness (0-100)
+	CompletenessScore int
+}
+
+// FeaturedScore ranks a portfolio item for the featured feed. Engagement grows
+// logarithmically so a handful of viral items can't take over the feed, decays
+// with the item's age and is scaled by the quality of the model's profile.
+// Every approved item scores above zero, so fresh work without likes still shows up.
+func FeaturedScore(s FeaturedSignals, now time.Time) float64 {
+	if !s.Approved {
+		return 0
+	}
+
+	engagement := 1 + math.Log1p(float64(s.Likes+featuredSaveWeight*s.Saves))
+
+	age := now.Sub(s.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+	decay := math.Pow(0.5, float64(age)/float64(featuredHalfLife))
+
+	rating := math.Max(0, math.Min(s.ProfileRating, maxProfileRating))
+	quality := CompletenessRankFactor(s.CompletenessScore) * (1 + featuredRatingBoost*rating/maxProfileRating)
+
+	return engagement * decay * quality
+}
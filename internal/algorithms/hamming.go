@@ -0,0 +1,65 @@
+package algorithms
+
+import "math/bits"
+
+// Perceptual hashes are indexed with multi-index hashing: the 64-bit hash is split
+// into PerceptualHashBands bands stored in separately indexed columns. If two hashes
+// differ in at most d bits, then by the pigeonhole principle at least one band
+// differs in at most d/PerceptualHashBands bits. A lookup therefore probes every
+// band value within that radius and checks the exact distance only on the few
+// rows that match, instead of scanning the whole table.
+const (
+	PerceptualHashBands = 4
+	perceptualBandBits  = 64 / PerceptualHashBands
+	perceptualBandMask  = 1<<perceptualBandBits - 1
+	// MaxPerceptualHashDistance keeps the probe count per band small (radius 2 = 137 values)
+	MaxPerceptualHashDistance = 3*PerceptualHashBands - 1
+)
+
+// HammingDistance is the number of differing bits between two hashes
+func HammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// PerceptualHashBandValues splits a hash into its indexed bands (lowest bits first)
+func PerceptualHashBandValues(hash uint64) [PerceptualHashBands]int {
+	var bands [PerceptualHashBands]int
+	for i := range bands {
+		bands[i] = int(hash >> (i * perceptualBandBits) & perceptualBandMask)
+	}
+	return bands
+}
+
+// PerceptualHashBandProbes returns, for each band, the band values to look up so
+// that every hash within maxDistance of hash is found. Distances above
+// MaxPerceptualHashDistance are clamped.
+func PerceptualHashBandProbes(hash uint64, maxDistance int) [PerceptualHashBands][]int {
+	if maxDistance > MaxPerceptualHashDistance {
+		maxDistance = MaxPerceptualHashDistance
+	}
+	if maxDistance < 0 {
+		maxDistance = 0
+	}
+	radius := maxDistance / PerceptualHashBands
+
+	var probes [PerceptualHashBands][]int
+	for i, band := range PerceptualHashBandValues(hash) {
+		probes[i] = bandNeighbours(band, radius)
+	}
+	return probes
+}
+
+// bandNeighbours lists all band values within radius bits of value (value included)
+func bandNeighbours(value, radius int) []int {
+	result := []int{value}
+	for i := 0; i < perceptualBandBits && radius >= 1; i++ {
+		flipped := value ^ (1 << i)
+		result = append(result, flipped)
+		if radius >= 2 {
+			for j := i + 1; j < perceptualBandBits; j++ {
+				result = append(result, flipped^(1<<j))
+			}
+		}
+	}
+	return result
+}
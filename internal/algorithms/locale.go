@@ -0,0 +1,177 @@
+package algorithms
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"mwork_backend/internal/models"
+)
+
+var (
+	// localeAliases maps common tags and misspellings to supported content locales
+	localeAliases = map[string]string{
+		"ru": models.LocaleRU, "kk": models.LocaleKK, "kz": models.LocaleKK,
+		"kaz": models.LocaleKK, "rus": models.LocaleRU, "en": models.LocaleEN, "eng": models.LocaleEN,
+	}
+
+	// fallbackChains - the order in which variants are tried for each locale.
+	// Kazakh readers are expected to read Russian before English.
+	fallbackChains = map[string][]string{
+		models.LocaleKK: {models.LocaleKK, models.LocaleRU, models.LocaleEN},
+		models.LocaleRU: {models.LocaleRU, models.LocaleEN, models.LocaleKK},
+		models.LocaleEN: {models.LocaleEN, models.LocaleRU, models.LocaleKK},
+	}
+
+	// kazakhLetters are Cyrillic letters that don't occur in Russian
+	kazakhLetters = "әғқңөұүһіӘҒҚҢӨҰҮҺІ"
+)
+
+// NormalizeLocale reduces a language tag ("kk-KZ", "KZ", "en_US") to a supported
+// content locale. Returns "" for unsupported languages.
+func NormalizeLocale(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return localeAliases[tag]
+}
+
+// ParseAcceptLanguage returns supported locales from an Accept-Language header,
+// best first. Entries with q=0 and unsupported languages are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale string
+		q      float64
+		pos    int
+	}
+
+	var entries []weighted
+	for pos, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		locale := NormalizeLocale(fields[0])
+		if locale == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		entries = append(entries, weighted{locale: locale, q: q, pos: pos})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].q != entries[j].q {
+			return entries[i].q > entries[j].q
+		}
+		return entries[i].pos < entries[j].pos
+	})
+
+	var locales []string
+	seen := map[string]bool{}
+	for _, e := range entries {
+		if !seen[e.locale] {
+			seen[e.locale] = true
+			locales = append(locales, e.locale)
+		}
+	}
+	return locales
+}
+
+// NegotiateLocale picks the response locale: an explicit query parameter wins,
+// then the best Accept-Language match, then the default locale.
+func NegotiateLocale(param, acceptLanguage string) string {
+	if locale := NormalizeLocale(param); locale != "" {
+		return locale
+	}
+	if locales := ParseAcceptLanguage(acceptLanguage); len(locales) > 0 {
+		return locales[0]
+	}
+	return models.DefaultLocale
+}
+
+// FallbackChain returns the locales to try, in order, when serving content in locale
+func FallbackChain(locale string) []string {
+	if chain, ok := fallbackChains[NormalizeLocale(locale)]; ok {
+		return chain
+	}
+	return fallbackChains[models.DefaultLocale]
+}
+
+// LocalizedValue resolves a translatable text. base is the original text written in
+// baseLocale, variants holds the translations. Returns the text and the locale it is in;
+// if nothing in the chain is filled in, base is returned as is.
+func LocalizedValue(variants map[string]string, base, baseLocale, locale string) (string, string) {
+	if baseLocale == "" {
+		baseLocale = models.DefaultLocale
+	}
+	for _, candidate := range FallbackChain(locale) {
+		if candidate == baseLocale && base != "" {
+			return base, baseLocale
+		}
+		if text := variants[candidate]; text != "" {
+			return text, candidate
+		}
+	}
+	return base, baseLocale
+}
+
+// DetectLocale guesses the language of a short text such as a search query:
+// Kazakh-specific letters mean kk, other Cyrillic means ru, Latin means en.
+// Returns "" when there are no letters to judge by.
+func DetectLocale(text string) string {
+	cyrillic, latin := 0, 0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune(kazakhLetters, r):
+			return models.LocaleKK
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	switch {
+	case cyrillic == 0 && latin == 0:
+		return ""
+	case cyrillic >= latin:
+		return models.LocaleRU
+	default:
+		return models.LocaleEN
+	}
+}
+
+// SearchLocales returns the translation variants a text search should look into:
+// the language the query is written in and the locale the client asked for.
+// Russian Cyrillic is ambiguous with Kazakh, so such queries search both.
+func SearchLocales(query, requested string) []string {
+	var locales []string
+	add := func(locale string) {
+		if locale == "" {
+			return
+		}
+		for _, l := range locales {
+			if l == locale {
+				return
+			}
+		}
+		locales = append(locales, locale)
+	}
+
+	detected := DetectLocale(query)
+	add(detected)
+	if detected == models.LocaleRU {
+		add(models.LocaleKK)
+	}
+	add(NormalizeLocale(requested))
+	return locales
+}
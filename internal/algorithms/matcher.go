@@ -2,9 +2,15 @@ package algorithms
 
 import (
 	"encoding/json"
+	"math"
 	"mwork_backend/internal/models"
 )
 
+// maxMatchScore is the sum of all criteria in CalculateMatchScore: city 30, age 20,
+// height 15, weight 10, gender 10, categories 25, measurements 40, experience 10,
+// languages 10, price 10 and the rating bonus 10
+const maxMatchScore = 190.0
+
 // CalculateMatchScore calculates how well a model matches a casting (0-100)
 func CalculateMatchScore(casting *models.Casting, model *models.ModelProfile) (float64, []string) {
 	score := 0.0
@@ -71,17 +77,10 @@ func CalculateMatchScore(casting *models.Casting, model *models.ModelProfile) (f
 		reasons = append(reasons, "Matching categories")
 	}
 
-	// Clothing size match (5 points)
-	if casting.ClothingSize != nil && *casting.ClothingSize == model.ClothingSize {
-		score += 5
-		reasons = append(reasons, "Clothing size matches")
-	}
-
-	// Shoe size match (5 points)
-	if casting.ShoeSize != nil && *casting.ShoeSize == model.ShoeSize {
-		score += 5
-		reasons = append(reasons, "Shoe size matches")
-	}
+	// Sizes and measurements (40 points)
+	measurementScore, measurementReasons := calculateMeasurementScore(CastingMeasurementRequirements(casting), model)
+	score += measurementScore
+	reasons = append(reasons, measurementReasons...)
 
 	// Experience match (10 points)
 	if casting.ExperienceLevel != nil {
@@ -134,8 +133,8 @@ func CalculateMatchScore(casting *models.Casting, model *models.ModelProfile) (f
 		score += 5
 	}
 
-	// Normalize to 0-100 scale (max possible is ~145, normalize to 100)
-	normalizedScore := (score / 145.0) * 100.0
+	// Normalize to 0-100 scale
+	normalizedScore := (score / maxMatchScore) * 100.0
 	if normalizedScore > 100 {
 		normalizedScore = 100
 	}
@@ -184,3 +183,71 @@ func calculateLanguageOverlap(castingLanguages, modelLanguages []string) float64
 	overlapPercent := float64(matches) / float64(len(castingLanguages))
 	return overlapPercent * 10.0
 }
+
+// calculateMeasurementScore scores sizes and measurements (0-40 points):
+// clothing and shoe size 5 each (a neighbouring size gets half), bust/waist/hips 5 each,
+// hair and eye colour 5 each, tattoos and piercings 2.5 each.
+// Requirements the casting doesn't set give half points, as elsewhere in the matcher.
+func calculateMeasurementScore(r MeasurementRequirements, model *models.ModelProfile) (float64, []string) {
+	score := 0.0
+	reasons := []string{}
+
+	if r.ClothingSize != nil && *r.ClothingSize != "" {
+		required, actual := ClothingSizeRank(*r.ClothingSize), ClothingSizeRank(model.ClothingSize)
+		if required >= 0 && actual >= 0 {
+			switch diff := required - actual; diff {
+			case 0:
+				score += 5
+				reasons = append(reasons, "Clothing size matches")
+			case 1, -1:
+				score += 2.5
+				reasons = append(reasons, "Clothing size is close")
+			}
+		}
+	}
+
+	if r.ShoeSize != nil && *r.ShoeSize != "" {
+		required, actual := ParseShoeSize(*r.ShoeSize), modelShoeSizeEU(model)
+		if required > 0 && actual > 0 {
+			diff := math.Abs(required - actual)
+			if diff <= 0.5 {
+				score += 5
+				reasons = append(reasons, "Shoe size matches")
+			} else if diff <= 1 {
+				score += 2.5
+				reasons = append(reasons, "Shoe size is close")
+			}
+		}
+	}
+
+	for _, m := range r.ranges(model) {
+		if m.min == nil && m.max == nil {
+			score += 2.5
+			continue
+		}
+		if inRange(m.value, m.min, m.max) {
+			score += 5
+			reasons = append(reasons, m.name+" matches requirements")
+		}
+	}
+
+	for _, c := range r.colors(model) {
+		if len(c.required) == 0 {
+			score += 2.5
+			continue
+		}
+		if contains(c.required, c.actual) {
+			score += 5
+			reasons = append(reasons, c.name+" matches")
+		}
+	}
+
+	if allowed(r.TattoosAllowed, model.HasTattoos) {
+		score += 2.5
+	}
+	if allowed(r.PiercingsAllowed, model.HasPiercings) {
+		score += 2.5
+	}
+
+	return score, reasons
+}
@@ -0,0 +1,152 @@
+package algorithms
+
+import (
+	"math"
+	"mwork_backend/internal/models"
+)
+
+// MeasurementRequirements are a casting's requirements on sizes and body measurements
+// (cm, canonical sizes); nil and empty fields mean "doesn't matter"
+type MeasurementRequirements struct {
+	ClothingSize     *string // international letter
+	ShoeSize         *string // EU
+	BustMin          *float64
+	BustMax          *float64
+	WaistMin         *float64
+	WaistMax         *float64
+	HipsMin          *float64
+	HipsMax          *float64
+	HairColors       []string // any of
+	EyeColors        []string
+	TattoosAllowed   *bool
+	PiercingsAllowed *bool
+}
+
+// CastingMeasurementRequirements extracts the measurement requirements of a casting
+func CastingMeasurementRequirements(casting *models.Casting) MeasurementRequirements {
+	return MeasurementRequirements{
+		ClothingSize:     casting.ClothingSize,
+		ShoeSize:         casting.ShoeSize,
+		BustMin:          casting.BustMin,
+		BustMax:          casting.BustMax,
+		WaistMin:         casting.WaistMin,
+		WaistMax:         casting.WaistMax,
+		HipsMin:          casting.HipsMin,
+		HipsMax:          casting.HipsMax,
+		HairColors:       casting.GetHairColors(),
+		EyeColors:        casting.GetEyeColors(),
+		TattoosAllowed:   casting.TattoosAllowed,
+		PiercingsAllowed: casting.PiercingsAllowed,
+	}
+}
+
+type measurementRange struct {
+	name     string
+	min, max *float64
+	value    float64
+}
+
+func (r MeasurementRequirements) ranges(model *models.ModelProfile) []measurementRange {
+	return []measurementRange{
+		{"Bust", r.BustMin, r.BustMax, model.Bust},
+		{"Waist", r.WaistMin, r.WaistMax, model.Waist},
+		{"Hips", r.HipsMin, r.HipsMax, model.Hips},
+	}
+}
+
+type colorRequirement struct {
+	name     string
+	required []string
+	actual   string
+}
+
+func (r MeasurementRequirements) colors(model *models.ModelProfile) []colorRequirement {
+	return []colorRequirement{
+		{"Hair colour", r.HairColors, model.HairColor},
+		{"Eye colour", r.EyeColors, model.EyeColor},
+	}
+}
+
+// Criteria returns one entry per requirement the casting sets: whether the model meets it.
+// Sizes match exactly (shoes within half a size); unknown measurements never match.
+func (r MeasurementRequirements) Criteria(model *models.ModelProfile) []bool {
+	var criteria []bool
+	if r.ClothingSize != nil && *r.ClothingSize != "" {
+		rank := ClothingSizeRank(*r.ClothingSize)
+		criteria = append(criteria, rank >= 0 && rank == ClothingSizeRank(model.ClothingSize))
+	}
+	if r.ShoeSize != nil && *r.ShoeSize != "" {
+		required, actual := ParseShoeSize(*r.ShoeSize), modelShoeSizeEU(model)
+		criteria = append(criteria, actual > 0 && math.Abs(required-actual) <= 0.5)
+	}
+	for _, m := range r.ranges(model) {
+		if m.min != nil || m.max != nil {
+			criteria = append(criteria, inRange(m.value, m.min, m.max))
+		}
+	}
+	for _, c := range r.colors(model) {
+		if len(c.required) > 0 {
+			criteria = append(criteria, contains(c.required, c.actual))
+		}
+	}
+	if r.TattoosAllowed != nil {
+		criteria = append(criteria, allowed(r.TattoosAllowed, model.HasTattoos))
+	}
+	if r.PiercingsAllowed != nil {
+		criteria = append(criteria, allowed(r.PiercingsAllowed, model.HasPiercings))
+	}
+	return criteria
+}
+
+// Excludes reports whether the model fails a hard requirement: a filled-in measurement
+// out of range, a colour not in the list, or tattoos/piercings where they are not allowed.
+// Measurements the model hasn't filled in are not held against them.
+func (r MeasurementRequirements) Excludes(model *models.ModelProfile) bool {
+	for _, m := range r.ranges(model) {
+		if m.value > 0 && !inRange(m.value, m.min, m.max) {
+			return true
+		}
+	}
+	for _, c := range r.colors(model) {
+		if c.actual != "" && len(c.required) > 0 && !contains(c.required, c.actual) {
+			return true
+		}
+	}
+	return !allowed(r.TattoosAllowed, model.HasTattoos) || !allowed(r.PiercingsAllowed, model.HasPiercings)
+}
+
+// inRange checks an optional [min, max] range; an unknown (zero) value never matches
+func inRange(value float64, min, max *float64) bool {
+	if value <= 0 {
+		return false
+	}
+	if min != nil && value < *min {
+		return false
+	}
+	if max != nil && value > *max {
+		return false
+	}
+	return true
+}
+
+// allowed - only an explicit "not allowed" fails, and only when the model has it
+func allowed(requirement *bool, has bool) bool {
+	return requirement == nil || *requirement || !has
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// modelShoeSizeEU falls back to the display value for profiles saved before shoe_size_eu existed
+func modelShoeSizeEU(model *models.ModelProfile) float64 {
+	if model.ShoeSizeEU > 0 {
+		return model.ShoeSizeEU
+	}
+	return ParseShoeSize(model.ShoeSize)
+}
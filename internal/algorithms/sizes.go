@@ -0,0 +1,291 @@
+package algorithms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Size systems accepted on input; canonical storage is the international letter
+// for clothing and EU for shoes
+const (
+	SizeSystemInternational = "int" // XXS ... XXXL
+	SizeSystemEU            = "eu"
+	SizeSystemRU            = "ru"
+	SizeSystemUS            = "us"
+	SizeSystemUK            = "uk"
+)
+
+// SizeSystems lists all supported systems
+var SizeSystems = []string{SizeSystemInternational, SizeSystemEU, SizeSystemRU, SizeSystemUS, SizeSystemUK}
+
+// Measurement units of body measurements on input; storage is always metric (cm, kg)
+const (
+	UnitsMetric   = "metric"   // cm, kg
+	UnitsImperial = "imperial" // inches, pounds
+)
+
+var (
+	ErrUnknownSize         = errors.New("unknown size")
+	ErrUnknownSizeSystem   = errors.New("unknown size system")
+	ErrSizeGenderRequired  = errors.New("gender is required to convert this size")
+	ErrSizeSystemAmbiguous = errors.New("numeric sizes are not part of the international system")
+)
+
+// ClothingSizes are the canonical letters from smallest to largest
+var ClothingSizes = []string{"XXS", "XS", "S", "M", "L", "XL", "XXL", "XXXL"}
+
+var clothingAliases = map[string]string{
+	"2XS": "XXS",
+	"2XL": "XXL",
+	"3XL": "XXXL",
+}
+
+// clothingRow is one letter in numeric systems
+type clothingRow struct {
+	eu, ru, us, uk int
+}
+
+// Women's and men's rows are indexed like ClothingSizes
+var (
+	womenClothing = []clothingRow{
+		{32, 38, 0, 4}, {34, 40, 2, 6}, {36, 42, 4, 8}, {38, 44, 6, 10},
+		{40, 46, 8, 12}, {42, 48, 10, 14}, {44, 50, 12, 16}, {46, 52, 14, 18},
+	}
+	menClothing = []clothingRow{
+		{42, 42, 32, 32}, {44, 44, 34, 34}, {46, 46, 36, 36}, {48, 48, 38, 38},
+		{50, 50, 40, 40}, {52, 52, 42, 42}, {54, 54, 44, 44}, {56, 56, 46, 46},
+	}
+)
+
+func (r clothingRow) value(system string) int {
+	switch system {
+	case SizeSystemEU:
+		return r.eu
+	case SizeSystemRU:
+		return r.ru
+	case SizeSystemUS:
+		return r.us
+	default:
+		return r.uk
+	}
+}
+
+func clothingTable(gender string) ([]clothingRow, error) {
+	switch strings.ToLower(gender) {
+	case "female":
+		return womenClothing, nil
+	case "male":
+		return menClothing, nil
+	default:
+		return nil, ErrSizeGenderRequired
+	}
+}
+
+// IsSizeSystem reports whether the system is supported
+func IsSizeSystem(system string) bool {
+	for _, s := range SizeSystems {
+		if s == system {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSizeValue extracts an explicit system prefix, e.g. "US 10" -> ("us", "10")
+func splitSizeValue(value, system string) (string, string) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	for _, s := range SizeSystems[1:] {
+		prefix := strings.ToUpper(s)
+		if strings.HasPrefix(value, prefix) {
+			return s, strings.TrimSpace(strings.TrimPrefix(value, prefix))
+		}
+	}
+	return strings.ToLower(system), value
+}
+
+// ClothingSizeRank returns the position of a canonical letter, -1 when unknown
+func ClothingSizeRank(size string) int {
+	size = strings.ToUpper(strings.TrimSpace(size))
+	if alias, ok := clothingAliases[size]; ok {
+		size = alias
+	}
+	for i, s := range ClothingSizes {
+		if s == size {
+			return i
+		}
+	}
+	return -1
+}
+
+// NormalizeClothingSize converts a clothing size to the canonical letter.
+// Letters are accepted in any system; numbers need the system (RU by default) and gender.
+func NormalizeClothingSize(value, system, gender string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	if rank := ClothingSizeRank(value); rank >= 0 {
+		return ClothingSizes[rank], nil
+	}
+
+	system, number := splitSizeValue(value, system)
+	if system == "" {
+		system = SizeSystemRU
+	}
+	if system == SizeSystemInternational {
+		return "", ErrSizeSystemAmbiguous
+	}
+	if !IsSizeSystem(system) {
+		return "", ErrUnknownSizeSystem
+	}
+	n, err := strconv.Atoi(number)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrUnknownSize, value)
+	}
+	table, err := clothingTable(gender)
+	if err != nil {
+		return "", err
+	}
+	for i, row := range table {
+		if row.value(system) == n {
+			return ClothingSizes[i], nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownSize, value)
+}
+
+// ConvertClothingSize shows a canonical letter in another system ("" when it can't be converted)
+func ConvertClothingSize(size, system, gender string) string {
+	rank := ClothingSizeRank(size)
+	if rank < 0 {
+		return ""
+	}
+	if system == SizeSystemInternational {
+		return ClothingSizes[rank]
+	}
+	table, err := clothingTable(gender)
+	if err != nil || !IsSizeSystem(system) {
+		return ""
+	}
+	return strconv.Itoa(table[rank].value(system))
+}
+
+// Shoe sizes: RU is one size below EU, UK follows the Paris point formula,
+// US is UK+1 for men and UK+2 for women
+const (
+	shoeMinEU = 30
+	shoeMaxEU = 52
+)
+
+// roundHalf rounds to the nearest half size
+func roundHalf(v float64) float64 {
+	return math.Round(v*2) / 2
+}
+
+func usShoeOffset(gender string) (float64, error) {
+	switch strings.ToLower(gender) {
+	case "female":
+		return 2, nil
+	case "male":
+		return 1, nil
+	default:
+		return 0, ErrSizeGenderRequired
+	}
+}
+
+// NormalizeShoeSize converts a shoe size to EU (EU by default); 0 means not specified
+func NormalizeShoeSize(value, system, gender string) (float64, error) {
+	if strings.TrimSpace(value) == "" {
+		return 0, nil
+	}
+	system, number := splitSizeValue(value, system)
+	if system == "" {
+		system = SizeSystemEU
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(number, ",", "."), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownSize, value)
+	}
+
+	var eu float64
+	switch system {
+	case SizeSystemEU:
+		eu = n
+	case SizeSystemRU:
+		eu = n + 1
+	case SizeSystemUK:
+		eu = (n + 25) * 1.27
+	case SizeSystemUS:
+		offset, err := usShoeOffset(gender)
+		if err != nil {
+			return 0, err
+		}
+		eu = (n - offset + 25) * 1.27
+	default:
+		return 0, ErrUnknownSizeSystem
+	}
+
+	eu = roundHalf(eu)
+	if eu < shoeMinEU || eu > shoeMaxEU {
+		return 0, fmt.Errorf("%w: %q", ErrUnknownSize, value)
+	}
+	return eu, nil
+}
+
+// ConvertShoeSize shows an EU shoe size in another system (0 when it can't be converted)
+func ConvertShoeSize(eu float64, system, gender string) float64 {
+	if eu <= 0 {
+		return 0
+	}
+	switch system {
+	case SizeSystemEU:
+		return eu
+	case SizeSystemRU:
+		return eu - 1
+	case SizeSystemUK:
+		return roundHalf(eu/1.27 - 25)
+	case SizeSystemUS:
+		offset, err := usShoeOffset(gender)
+		if err != nil {
+			return 0
+		}
+		return roundHalf(eu/1.27 - 25 + offset)
+	default:
+		return 0
+	}
+}
+
+// FormatShoeSize prints a half size without trailing zeros: 38, 38.5
+func FormatShoeSize(size float64) string {
+	if size <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(size, 'f', -1, 64)
+}
+
+// ParseShoeSize reads a canonical (EU) shoe size; 0 when empty or not a number
+func ParseShoeSize(size string) float64 {
+	v, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(size), ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// ToCentimeters converts a length in the given units to cm (rounded to 0.5)
+func ToCentimeters(v float64, units string) float64 {
+	if units == UnitsImperial {
+		return roundHalf(v * 2.54)
+	}
+	return v
+}
+
+// ToKilograms converts a weight in the given units to kg (rounded to 0.5)
+func ToKilograms(v float64, units string) float64 {
+	if units == UnitsImperial {
+		return roundHalf(v * 0.45359237)
+	}
+	return v
+}
@@ -0,0 +1,71 @@
+package algorithms
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// MinSlugLength and MaxSlugLength bound user-chosen and generated slugs
+	MinSlugLength = 3
+	MaxSlugLength = 50
+)
+
+var (
+	slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+	// reservedSlugs would clash with app routes or mislead visitors
+	reservedSlugs = map[string]bool{
+		"admin": true, "api": true, "me": true, "new": true, "edit": true, "settings": true,
+		"search": true, "share": true, "login": true, "register": true, "support": true,
+		"help": true, "mwork": true, "moderator": true, "null": true, "undefined": true,
+	}
+
+	// slugTranslit covers Russian and Kazakh Cyrillic
+	slugTranslit = map[rune]string{
+		'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+		'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+		'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "ts",
+		'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+		'я': "ya", 'ә': "a", 'ғ': "g", 'қ': "q", 'ң': "n", 'ө': "o", 'ұ': "u", 'ү': "u",
+		'һ': "h", 'і': "i",
+	}
+)
+
+// Slugify turns a display name into a URL slug: lowercase latin letters, digits
+// and single hyphens. Cyrillic is transliterated, everything else becomes a separator.
+// The result may be empty or shorter than MinSlugLength.
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		var part string
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		default:
+			part = slugTranslit[r]
+		}
+		if part == "" {
+			hyphen = b.Len() > 0
+			continue
+		}
+		if hyphen {
+			b.WriteByte('-')
+			hyphen = false
+		}
+		b.WriteString(part)
+	}
+
+	slug := b.String()
+	if len(slug) > MaxSlugLength {
+		slug = strings.TrimRight(slug[:MaxSlugLength], "-")
+	}
+	return slug
+}
+
+// ValidSlug reports whether slug is an acceptable vanity URL
+func ValidSlug(slug string) bool {
+	return len(slug) >= MinSlugLength && len(slug) <= MaxSlugLength &&
+		slugPattern.MatchString(slug) && !reservedSlugs[slug]
+}
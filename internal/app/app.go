@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"mwork_backend/internal/config"
 	"mwork_backend/internal/email"
 	"mwork_backend/internal/handlers"
+	"mwork_backend/internal/imageprocessor"
 	"mwork_backend/internal/logger"
 	"mwork_backend/internal/middleware"
 	"mwork_backend/internal/repositories"
@@ -15,6 +17,7 @@ import (
 	"mwork_backend/internal/services"
 	"mwork_backend/internal/storage"
 	"mwork_backend/internal/validator"
+	"mwork_backend/internal/workers"
 	"mwork_backend/ws"
 
 	"github.com/gin-gonic/gin"
@@ -54,7 +57,12 @@ func Run() {
 	}
 
 	// ▼▼▼ ИЗМЕНЕНИЕ: SetupRouter теперь просто возвращает *gin.Engine ▼▼▼
-	ginRouter := SetupRouter(cfg, gormDB, sqlDB)
+	ginRouter, serviceContainer := setupApp(cfg, gormDB, sqlDB)
+
+	// Фоновые задачи останавливаются вместе с сервером
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	startWorkers(ctx, gormDB, serviceContainer)
 
 	address := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
 	logger.Info(fmt.Sprintf("🚀 Server starting on %s", address))
@@ -64,6 +72,12 @@ func Run() {
 }
 
 func SetupRouter(cfg *config.Config, gormDB *gorm.DB, sqlDB *sql.DB) *gin.Engine {
+	ginRouter, _ := setupApp(cfg, gormDB, sqlDB)
+	return ginRouter
+}
+
+// setupApp собирает сервисы и роутер; фоновые задачи запускает только Run
+func setupApp(cfg *config.Config, gormDB *gorm.DB, sqlDB *sql.DB) (*gin.Engine, *services.ServiceContainer) {
 	storageInstance, err := storage.NewStorage(storage.Config{
 		Type:       cfg.Storage.Type,
 		BasePath:   cfg.Storage.BasePath,
@@ -82,10 +96,12 @@ func SetupRouter(cfg *config.Config, gormDB *gorm.DB, sqlDB *sql.DB) *gin.Engine
 	logger.Info("Storage initialized", "type", cfg.Storage.Type)
 
 	// 1. Инициализируем сервисы
-	serviceContainer := initializeServices(cfg, gormDB, sqlDB, storageInstance)
+	// Водяной знак общий для раздачи файлов и документов с фото (композитки, шортлисты)
+	watermark := initializeWatermark(cfg)
+	serviceContainer := initializeServices(cfg, gormDB, sqlDB, storageInstance, watermark)
 
 	// 2. Инициализируем хэндлеры
-	appHandlers := initializeHandlers(serviceContainer, storageInstance, gormDB)
+	appHandlers := initializeHandlers(serviceContainer, storageInstance, gormDB, watermark)
 
 	// 3. Инициализируем WebSocket
 	wsManager := ws.NewWebSocketManager(
@@ -99,16 +115,31 @@ func SetupRouter(cfg *config.Config, gormDB *gorm.DB, sqlDB *sql.DB) *gin.Engine
 
 	// 4. Инициализируем Gin
 	ginRouter := initializeGinRouter(gormDB)
+	// IP клиента (журналы, подпись договоров) берется из X-Forwarded-For только от своих прокси
+	if err := ginRouter.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", "error", err)
+	}
 
 	// 5. ▼▼▼ ГЛАВНОЕ ИЗМЕНЕНИЕ: Делегируем регистрацию маршрутов пакету 'routes' ▼▼▼
 	routes.RegisterRoutes(ginRouter, appHandlers, wsHandler)
 	// ▲▲▲
 
-	return ginRouter
+	return ginRouter, serviceContainer
+}
+
+// startWorkers запускает периодические фоновые задачи
+func startWorkers(ctx context.Context, gormDB *gorm.DB, container *services.ServiceContainer) {
+	workers.NewCastingWorker(gormDB, container.CastingService, container.PromotionService).Start(ctx)
+	workers.NewPortfolioWorker(gormDB, container.PortfolioService).Start(ctx)
+	workers.NewVerificationWorker(gormDB, container.VerificationService).Start(ctx)
+	workers.NewOnboardingWorker(gormDB, container.OnboardingService).Start(ctx)
+	workers.NewProfileViewWorker(gormDB, container.ProfileViewService).Start(ctx)
+	workers.NewPhotoHashWorker(gormDB, container.PhotoModerationService).Start(ctx)
+	logger.Info("Background workers started")
 }
 
 // ▼▼▼ ИЗМЕНЕНИЕ: Функция теперь возвращает *services.ServiceContainer ▼▼▼
-func initializeServices(cfg *config.Config, gormDB *gorm.DB, sqlDB *sql.DB, storageInstance storage.Storage) *services.ServiceContainer {
+func initializeServices(cfg *config.Config, gormDB *gorm.DB, sqlDB *sql.DB, storageInstance storage.Storage, watermark *imageprocessor.Watermark) *services.ServiceContainer {
 
 	// ... (логика с MockEmailProvider остается) ...
 	var emailService email.Provider
@@ -129,51 +160,101 @@ func initializeServices(cfg *config.Config, gormDB *gorm.DB, sqlDB *sql.DB, stor
 	chatRepo := repositories.NewChatRepository()
 	analyticsRepo := repositories.NewAnalyticsRepository()
 	uploadRepo := repositories.NewUploadRepository()
+	promotionRepo := repositories.NewPromotionRepository()
+	employerNoteRepo := repositories.NewEmployerNoteRepository()
+	bookingRepo := repositories.NewBookingRepository()
+	availabilityRepo := repositories.NewAvailabilityRepository()
+	ledgerRepo := repositories.NewLedgerRepository()
+	invoiceRepo := repositories.NewInvoiceRepository()
+	compCardRepo := repositories.NewCompCardRepository()
+	agencyRepo := repositories.NewAgencyRepository()
+	verificationRepo := repositories.NewVerificationRepository()
+	slugRepo := repositories.NewSlugRepository()
+	profileViewRepo := repositories.NewProfileViewRepository()
+	blockRepo := repositories.NewBlockRepository()
+	reportRepo := repositories.NewReportRepository()
+	duplicatePhotoRepo := repositories.NewDuplicatePhotoRepository()
 
 	// --- Инициализация сервисов ---
 	// ... (NewUploadService, NewUserService, NewAuthService... и т.д.) ...
 	uploadConfig := services.GetDefaultUploadConfig()
-	uploadService := services.NewUploadService(uploadRepo, storageInstance, uploadConfig)
+	uploadConfig.BlockDuplicatePhotos = cfg.Upload.BlockDuplicatePhotos
+	uploadService := services.NewUploadService(uploadRepo, userRepo, duplicatePhotoRepo, storageInstance, uploadConfig)
 	userService := services.NewUserService(userRepo, profileRepo)
-	authService := services.NewAuthService(userRepo, profileRepo, subscriptionRepo, emailService, refreshTokenRepo)
-	profileService := services.NewProfileService(profileRepo, userRepo, portfolioRepo, reviewRepo, notificationRepo)
-	castingService := services.NewCastingService(castingRepo, userRepo, profileRepo, subscriptionRepo, notificationRepo, reviewRepo, responseRepo)
-	responseService := services.NewResponseService(responseRepo, castingRepo, userRepo, subscriptionRepo, notificationRepo, reviewRepo)
+	authService := services.NewAuthService(userRepo, profileRepo, subscriptionRepo, emailService, refreshTokenRepo, agencyRepo)
+	profileService := services.NewProfileService(profileRepo, userRepo, portfolioRepo, reviewRepo, notificationRepo, agencyRepo, profileViewRepo, blockRepo)
+	castingService := services.NewCastingService(castingRepo, userRepo, profileRepo, subscriptionRepo, notificationRepo, reviewRepo, responseRepo, promotionRepo, chatRepo)
+	responseService := services.NewResponseService(responseRepo, castingRepo, userRepo, subscriptionRepo, notificationRepo, reviewRepo, chatRepo, employerNoteRepo, blockRepo, services.GetDefaultResponseConfig())
 	notificationService := services.NewNotificationService(notificationRepo, userRepo, profileRepo)
 	portfolioService := services.NewPortfolioService(portfolioRepo, userRepo, profileRepo, uploadService)
 	reviewService := services.NewReviewService(reviewRepo, userRepo, profileRepo, castingRepo, notificationRepo)
-	searchService := services.NewSearchService(castingRepo, profileRepo, portfolioRepo, reviewRepo)
-	matchingService := services.NewMatchingService(profileRepo, castingRepo, reviewRepo, portfolioRepo, notificationRepo, userRepo)
+	searchService := services.NewSearchService(castingRepo, profileRepo, portfolioRepo, reviewRepo, promotionRepo)
+	availabilityService := services.NewAvailabilityService(availabilityRepo, profileRepo)
+	matchingService := services.NewMatchingService(profileRepo, castingRepo, reviewRepo, portfolioRepo, notificationRepo, userRepo, availabilityService)
 	analyticsService := services.NewAnalyticsService(userRepo, profileRepo, castingRepo, reviewRepo, notificationRepo, portfolioRepo, subscriptionRepo, chatRepo, analyticsRepo)
-	subscriptionService := services.NewSubscriptionService(subscriptionRepo, userRepo, notificationRepo)
-	chatService := services.NewChatService(chatRepo, userRepo, castingRepo, profileRepo, notificationRepo, responseRepo, uploadService)
+	ledgerService := services.NewLedgerService(ledgerRepo)
+	invoiceService := services.NewInvoiceService(invoiceRepo, subscriptionRepo, userRepo, profileRepo, emailService, services.GetDefaultInvoiceConfig())
+	subscriptionService := services.NewSubscriptionService(subscriptionRepo, userRepo, notificationRepo, promotionRepo, ledgerService, invoiceService)
+	promotionService := services.NewPromotionService(promotionRepo, castingRepo, userRepo, subscriptionRepo, subscriptionService, services.GetDefaultPromotionConfig())
+	employerNoteService := services.NewEmployerNoteService(employerNoteRepo, responseRepo, castingRepo, userRepo)
+	shortlistExportService := services.NewShortlistExportService(responseRepo, castingRepo, userRepo, profileRepo, portfolioRepo, employerNoteRepo, storageInstance, watermark)
+	bookingService := services.NewBookingService(bookingRepo, responseRepo, castingRepo, userRepo, profileRepo, notificationRepo)
+	compCardConfig := services.GetDefaultCompCardConfig()
+	compCardConfig.Watermark = watermark
+	compCardService := services.NewCompCardService(compCardRepo, profileRepo, portfolioRepo, storageInstance, compCardConfig)
+	chatService := services.NewChatService(chatRepo, userRepo, castingRepo, profileRepo, notificationRepo, responseRepo, agencyRepo, blockRepo, uploadService)
+	agencyService := services.NewAgencyService(agencyRepo, userRepo, profileRepo, subscriptionRepo, chatRepo, notificationRepo, blockRepo, profileService, responseService)
+	verificationService := services.NewVerificationService(verificationRepo, profileRepo, uploadRepo, notificationRepo)
+	onboardingService := services.NewOnboardingService(profileRepo, portfolioRepo, userRepo, notificationRepo, emailService)
+	profileViewService := services.NewProfileViewService(profileViewRepo, profileRepo, subscriptionRepo, notificationRepo)
+	blockService := services.NewBlockService(blockRepo, userRepo)
+	reportService := services.NewReportService(reportRepo, userRepo, castingRepo, chatRepo, reviewRepo, portfolioRepo, notificationRepo)
+	photoModerationService := services.NewPhotoModerationService(uploadRepo, userRepo, notificationRepo, duplicatePhotoRepo, profileRepo, portfolioRepo, storageInstance)
+	shareService := services.NewShareService(slugRepo, profileRepo, castingRepo, userRepo, portfolioRepo, uploadRepo, storageInstance, services.GetDefaultShareConfig())
 
 	// ▼▼▼ ИЗМЕНЕНИЕ: Возвращаем *services.ServiceContainer ▼▼▼
 	return &services.ServiceContainer{
-		UserService:         userService,
-		AuthService:         authService,
-		ProfileService:      profileService,
-		CastingService:      castingService,
-		ResponseService:     responseService,
-		ReviewService:       reviewService,
-		PortfolioService:    portfolioService,
-		MatchingService:     matchingService,
-		NotificationService: notificationService,
-		SubscriptionService: subscriptionService,
-		SearchService:       searchService,
-		AnalyticsService:    analyticsService,
-		ChatService:         chatService,
-		UploadService:       uploadService,
-		EmailService:        emailService,
+		UserService:            userService,
+		AuthService:            authService,
+		ProfileService:         profileService,
+		CastingService:         castingService,
+		ResponseService:        responseService,
+		ReviewService:          reviewService,
+		PortfolioService:       portfolioService,
+		MatchingService:        matchingService,
+		NotificationService:    notificationService,
+		SubscriptionService:    subscriptionService,
+		SearchService:          searchService,
+		AnalyticsService:       analyticsService,
+		ChatService:            chatService,
+		UploadService:          uploadService,
+		EmailService:           emailService,
+		PromotionService:       promotionService,
+		EmployerNoteService:    employerNoteService,
+		ShortlistExportService: shortlistExportService,
+		BookingService:         bookingService,
+		AvailabilityService:    availabilityService,
+		LedgerService:          ledgerService,
+		InvoiceService:         invoiceService,
+		CompCardService:        compCardService,
+		AgencyService:          agencyService,
+		VerificationService:    verificationService,
+		OnboardingService:      onboardingService,
+		ShareService:           shareService,
+		ProfileViewService:     profileViewService,
+		BlockService:           blockService,
+		ReportService:          reportService,
+		PhotoModerationService: photoModerationService,
 	}
 }
 
 // ▼▼▼ ИЗMENT: Принимает *services.ServiceContainer, возвращает *handlers.AppHandlers ▼▼▼
-func initializeHandlers(services *services.ServiceContainer, storageInstance storage.Storage, gormDB *gorm.DB) *handlers.AppHandlers {
+func initializeHandlers(services *services.ServiceContainer, storageInstance storage.Storage, gormDB *gorm.DB, watermark *imageprocessor.Watermark) *handlers.AppHandlers {
 	customValidator := validator.New()
 	baseHandler := handlers.NewBaseHandler(customValidator)
 
 	uploadRepo := repositories.NewUploadRepository()
+	responseRepo := repositories.NewResponseRepository()
 
 	// ▼▼▼ ИЗМЕНЕНИЕ: Возвращаем *handlers.AppHandlers ▼▼▼
 	return &handlers.AppHandlers{
@@ -190,9 +271,41 @@ func initializeHandlers(services *services.ServiceContainer, storageInstance sto
 		SearchHandler:       handlers.NewSearchHandler(baseHandler, services.SearchService),
 		AnalyticsHandler:    handlers.NewAnalyticsHandler(baseHandler, services.AnalyticsService),
 		ChatHandler:         handlers.NewChatHandler(baseHandler, services.ChatService),
-		FileHandler:         handlers.NewFileHandler(baseHandler, storageInstance, uploadRepo),
+		FileHandler:         handlers.NewFileHandler(baseHandler, storageInstance, uploadRepo, responseRepo, watermark),
 		UploadHandler:       handlers.NewUploadHandler(baseHandler, services.UploadService),
+		PromotionHandler:    handlers.NewPromotionHandler(baseHandler, services.PromotionService),
+		EmployerNoteHandler: handlers.NewEmployerNoteHandler(baseHandler, services.EmployerNoteService),
+		ShortlistHandler:    handlers.NewShortlistHandler(baseHandler, services.ShortlistExportService),
+		BookingHandler:      handlers.NewBookingHandler(baseHandler, services.BookingService),
+		AvailabilityHandler: handlers.NewAvailabilityHandler(baseHandler, services.AvailabilityService),
+		LedgerHandler:       handlers.NewLedgerHandler(baseHandler, services.LedgerService),
+		InvoiceHandler:      handlers.NewInvoiceHandler(baseHandler, services.InvoiceService),
+		CompCardHandler:     handlers.NewCompCardHandler(baseHandler, services.CompCardService),
+		AgencyHandler:       handlers.NewAgencyHandler(baseHandler, services.AgencyService),
+		VerificationHandler: handlers.NewVerificationHandler(baseHandler, services.VerificationService),
+		ShareHandler:        handlers.NewShareHandler(baseHandler, services.ShareService),
+		ProfileViewHandler:  handlers.NewProfileViewHandler(baseHandler, services.ProfileViewService),
+		ModerationHandler:   handlers.NewModerationHandler(baseHandler, services.BlockService, services.ReportService, services.PhotoModerationService),
+	}
+}
+
+// initializeWatermark - водяной знак для публичных фото портфолио (nil - отключен).
+// Ошибка загрузки логотипа не мешает старту: фото отдаются без водяного знака.
+func initializeWatermark(cfg *config.Config) *imageprocessor.Watermark {
+	if !cfg.Watermark.Enabled {
+		return nil
+	}
+	watermark, err := imageprocessor.NewWatermark(
+		cfg.Watermark.Text,
+		cfg.Watermark.LogoPath,
+		imageprocessor.WatermarkPosition(cfg.Watermark.Position),
+		cfg.Watermark.Opacity,
+	)
+	if err != nil {
+		logger.Error("Failed to initialize watermark, watermarking disabled", "error", err)
+		return nil
 	}
+	return watermark
 }
 
 // ▼▼▼ ИЗМЕНЕНИЕ: Используем middleware из пакета 'middleware' ▼▼▼
@@ -14,6 +14,8 @@ type Config struct {
 		Host string
 		Port int
 		Env  string
+		// TrustedProxies - прокси, чьим X-Forwarded-For можно верить (пусто - IP берется из соединения)
+		TrustedProxies []string
 	}
 
 	Database struct {
@@ -54,6 +56,17 @@ type Config struct {
 		MaxUserStorage int64
 		AllowedTypes   []string
 		ImageQuality   int
+		// BlockDuplicatePhotos - отклонять фото, похожие на фото других пользователей
+		BlockDuplicatePhotos bool
+	}
+
+	// Watermark - водяной знак на публичных копиях фото портфолио (оригиналы не меняются)
+	Watermark struct {
+		Enabled  bool
+		Text     string
+		LogoPath string // PNG-логотип; если задан, используется вместо текста
+		Position string // top-left, top-right, bottom-left, bottom-right, center, tile
+		Opacity  int    // 0-100
 	}
 
 	FirstAdminEmail    string `mapstructure:"FIRST_ADMIN_EMAIL"`
@@ -89,6 +102,7 @@ func LoadConfig() {
 	cfg.Server.Host = getEnv("SERVER_HOST", "localhost")
 	cfg.Server.Port = getEnvAsInt("SERVER_PORT", 4000)
 	cfg.Server.Env = getEnv("SERVER_ENV", "development")
+	cfg.Server.TrustedProxies = getEnvAsSlice("SERVER_TRUSTED_PROXIES", nil)
 
 	// Database Configuration
 	cfg.Database.DSN = getEnv("DATABASE_DSN", "")
@@ -134,6 +148,14 @@ func LoadConfig() {
 		"video/mp4", "video/quicktime",
 	})
 	cfg.Upload.ImageQuality = getEnvAsInt("UPLOAD_IMAGE_QUALITY", 85)
+	cfg.Upload.BlockDuplicatePhotos = getEnvAsBool("UPLOAD_BLOCK_DUPLICATE_PHOTOS", false)
+
+	// Watermark Configuration
+	cfg.Watermark.Enabled = getEnvAsBool("WATERMARK_ENABLED", true)
+	cfg.Watermark.Text = getEnv("WATERMARK_TEXT", "MWork")
+	cfg.Watermark.LogoPath = getEnv("WATERMARK_LOGO_PATH", "")
+	cfg.Watermark.Position = getEnv("WATERMARK_POSITION", "bottom-right")
+	cfg.Watermark.Opacity = getEnvAsInt("WATERMARK_OPACITY", 50)
 
 	cfg.FirstAdminEmail = getEnv("FIRST_ADMIN_EMAIL", "")
 	cfg.FirstAdminPassword = getEnv("FIRST_ADMIN_PASSWORD", "")
@@ -0,0 +1,178 @@
+package document
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+)
+
+// A4 page size in pixels at DPI
+const (
+	A4Width  = 1240
+	A4Height = 1754
+)
+
+var (
+	ColorWhite     = color.RGBA{255, 255, 255, 255}
+	ColorBlack     = color.RGBA{20, 20, 20, 255}
+	ColorGray      = color.RGBA{120, 120, 120, 255}
+	ColorLightGray = color.RGBA{230, 230, 230, 255}
+)
+
+// Canvas is a raster page with simple drawing helpers
+type Canvas struct {
+	img *image.RGBA
+}
+
+// NewCanvas creates a white canvas of the given size
+func NewCanvas(width, height int) *Canvas {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: ColorWhite}, image.Point{}, draw.Src)
+	return &Canvas{img: img}
+}
+
+// Image returns the underlying image
+func (c *Canvas) Image() *image.RGBA {
+	return c.img
+}
+
+// FillRect fills a rectangle with a solid color
+func (c *Canvas) FillRect(r image.Rectangle, col color.Color) {
+	draw.Draw(c.img, r, &image.Uniform{C: col}, image.Point{}, draw.Src)
+}
+
+// StrokeRect draws a rectangle outline of the given width
+func (c *Canvas) StrokeRect(r image.Rectangle, width int, col color.Color) {
+	c.FillRect(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+width), col)
+	c.FillRect(image.Rect(r.Min.X, r.Max.Y-width, r.Max.X, r.Max.Y), col)
+	c.FillRect(image.Rect(r.Min.X, r.Min.Y, r.Min.X+width, r.Max.Y), col)
+	c.FillRect(image.Rect(r.Max.X-width, r.Min.Y, r.Max.X, r.Max.Y), col)
+}
+
+// DrawImageFit scales src to fit into r keeping aspect ratio and centers it
+func (c *Canvas) DrawImageFit(src image.Image, r image.Rectangle) {
+	c.drawImageScaled(src, r, false)
+}
+
+// DrawImageCover scales src to fill r keeping aspect ratio, cropping the overflow
+func (c *Canvas) DrawImageCover(src image.Image, r image.Rectangle) {
+	c.drawImageScaled(src, r, true)
+}
+
+func (c *Canvas) drawImageScaled(src image.Image, r image.Rectangle, cover bool) {
+	sb := src.Bounds()
+	if sb.Dx() == 0 || sb.Dy() == 0 || r.Dx() == 0 || r.Dy() == 0 {
+		return
+	}
+
+	scaleX := float64(r.Dx()) / float64(sb.Dx())
+	scaleY := float64(r.Dy()) / float64(sb.Dy())
+	scale := scaleX
+	if (cover && scaleY > scale) || (!cover && scaleY < scale) {
+		scale = scaleY
+	}
+
+	if cover {
+		// Crop the source so that after scaling it exactly covers r
+		cropW := int(float64(r.Dx()) / scale)
+		cropH := int(float64(r.Dy()) / scale)
+		x0 := sb.Min.X + (sb.Dx()-cropW)/2
+		y0 := sb.Min.Y + (sb.Dy()-cropH)/2
+		xdraw.CatmullRom.Scale(c.img, r, src, image.Rect(x0, y0, x0+cropW, y0+cropH), xdraw.Over, nil)
+		return
+	}
+
+	w := int(float64(sb.Dx()) * scale)
+	h := int(float64(sb.Dy()) * scale)
+	x0 := r.Min.X + (r.Dx()-w)/2
+	y0 := r.Min.Y + (r.Dy()-h)/2
+	xdraw.CatmullRom.Scale(c.img, image.Rect(x0, y0, x0+w, y0+h), src, sb, xdraw.Over, nil)
+}
+
+// DrawText draws a single line with its baseline at (x, y)
+func (c *Canvas) DrawText(face font.Face, col color.Color, x, y int, text string) {
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  &image.Uniform{C: col},
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// DrawTextCentered draws a single line horizontally centered in [x0, x1]
+func (c *Canvas) DrawTextCentered(face font.Face, col color.Color, x0, x1, y int, text string) {
+	width := font.MeasureString(face, text).Ceil()
+	c.DrawText(face, col, x0+(x1-x0-width)/2, y, text)
+}
+
+// DrawParagraph draws word-wrapped text starting with the first baseline at (x, y).
+// At most maxLines lines are drawn (0 = unlimited); the last one gets an ellipsis if cut.
+// Returns the baseline y after the last drawn line.
+func (c *Canvas) DrawParagraph(face font.Face, col color.Color, x, y, width, maxLines int, text string) int {
+	lines := WrapText(face, text, width)
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = truncateToWidth(face, lines[maxLines-1]+"…", width)
+	}
+
+	lineHeight := LineHeight(face)
+	for _, line := range lines {
+		c.DrawText(face, col, x, y, line)
+		y += lineHeight
+	}
+	return y
+}
+
+// LineHeight returns the recommended distance between baselines
+func LineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil()
+}
+
+// WrapText splits text into lines not wider than width (explicit newlines are kept)
+func WrapText(face font.Face, text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		current := ""
+		for _, word := range words {
+			candidate := word
+			if current != "" {
+				candidate = current + " " + word
+			}
+			if font.MeasureString(face, candidate).Ceil() <= width {
+				current = candidate
+				continue
+			}
+			if current != "" {
+				lines = append(lines, current)
+			}
+			current = truncateToWidth(face, word, width)
+		}
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// truncateToWidth cuts a single word/line so that it fits into width
+func truncateToWidth(face font.Face, text string, width int) string {
+	runes := []rune(text)
+	for len(runes) > 0 && font.MeasureString(face, string(runes)).Ceil() > width {
+		if runes[len(runes)-1] == '…' && len(runes) > 1 {
+			runes = append(runes[:len(runes)-2], '…')
+			continue
+		}
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes)
+}
@@ -0,0 +1,315 @@
+package document
+
+import (
+	"image"
+	"image/draw"
+	"strings"
+
+	"golang.org/x/image/font"
+)
+
+// Comp card layout templates
+const (
+	CompCardTemplateClassic   = "classic"   // two pages: hero shot on the front, photo grid and stats on the back
+	CompCardTemplatePortrait  = "portrait"  // one portrait page: hero shot, thumbnail strip, stats
+	CompCardTemplateLandscape = "landscape" // one landscape page: hero shot left, stats and grid right
+)
+
+// CompCardTemplates lists the supported templates, the first one is the default
+var CompCardTemplates = []string{CompCardTemplateClassic, CompCardTemplatePortrait, CompCardTemplateLandscape}
+
+// CompCardMaxPhotos is the hero shot plus up to four secondary photos
+const CompCardMaxPhotos = 5
+
+// Comp card size: the industry standard 5.5 x 8.5 in at DPI
+const (
+	compCardShortSide = 825
+	compCardLongSide  = 1275
+)
+
+// CompCard is a model's printable card; Photos[0] is the hero shot
+type CompCard struct {
+	Template string
+	Name     string
+	Stats    []CompCardStat
+	Photos   []image.Image // nil entries render a placeholder
+	Footer   string        // contact line
+}
+
+// CompCardStat is one measurement, e.g. "Рост" / "178"
+type CompCardStat struct {
+	Label string
+	Value string
+}
+
+// IsCompCardTemplate reports whether the template is supported
+func IsCompCardTemplate(template string) bool {
+	for _, t := range CompCardTemplates {
+		if t == template {
+			return true
+		}
+	}
+	return false
+}
+
+// compCardFaces are the fonts of one rendering, already multiplied by the scale
+type compCardFaces struct {
+	name, title, label, value, small font.Face
+}
+
+// RenderCompCard renders the card pages at DPI*scale (1 for PDF, 2 and more for print-quality PNG)
+func RenderCompCard(card *CompCard, scale int) ([]image.Image, error) {
+	if scale < 1 {
+		scale = 1
+	}
+	faces, err := loadCompCardFaces(scale)
+	if err != nil {
+		return nil, err
+	}
+	r := &compCardRenderer{card: card, faces: faces, scale: scale}
+
+	switch card.Template {
+	case CompCardTemplatePortrait:
+		return []image.Image{r.portrait()}, nil
+	case CompCardTemplateLandscape:
+		return []image.Image{r.landscape()}, nil
+	default:
+		return []image.Image{r.classicFront(), r.classicBack()}, nil
+	}
+}
+
+// JoinPages lays pages out side by side (front | back) so that a multi-page card fits one image
+func JoinPages(pages []image.Image, gap int) image.Image {
+	if len(pages) == 1 {
+		return pages[0]
+	}
+	width, height := 0, 0
+	for i, page := range pages {
+		if i > 0 {
+			width += gap
+		}
+		width += page.Bounds().Dx()
+		if page.Bounds().Dy() > height {
+			height = page.Bounds().Dy()
+		}
+	}
+	canvas := NewCanvas(width, height)
+	canvas.FillRect(canvas.Image().Bounds(), ColorLightGray)
+	x := 0
+	for _, page := range pages {
+		b := page.Bounds()
+		draw.Draw(canvas.Image(), image.Rect(x, 0, x+b.Dx(), b.Dy()), page, b.Min, draw.Src)
+		x += b.Dx() + gap
+	}
+	return canvas.Image()
+}
+
+func loadCompCardFaces(scale int) (*compCardFaces, error) {
+	s := float64(scale)
+	faces := &compCardFaces{}
+	var err error
+	if faces.name, err = Face(26*s, true); err != nil {
+		return nil, err
+	}
+	if faces.title, err = Face(15*s, true); err != nil {
+		return nil, err
+	}
+	if faces.label, err = Face(7.5*s, false); err != nil {
+		return nil, err
+	}
+	if faces.value, err = Face(11*s, true); err != nil {
+		return nil, err
+	}
+	if faces.small, err = Face(7*s, false); err != nil {
+		return nil, err
+	}
+	return faces, nil
+}
+
+// compCardRenderer holds one rendering; layout is written in 1x pixels and scaled by u()
+type compCardRenderer struct {
+	card  *CompCard
+	faces *compCardFaces
+	scale int
+}
+
+func (r *compCardRenderer) u(v int) int {
+	return v * r.scale
+}
+
+func (r *compCardRenderer) rect(x0, y0, x1, y1 int) image.Rectangle {
+	return image.Rect(r.u(x0), r.u(y0), r.u(x1), r.u(y1))
+}
+
+func (r *compCardRenderer) newPage(width, height int) *Canvas {
+	return NewCanvas(r.u(width), r.u(height))
+}
+
+// photo returns the i-th photo or nil (also nil when out of range)
+func (r *compCardRenderer) photo(i int) image.Image {
+	if i < len(r.card.Photos) {
+		return r.card.Photos[i]
+	}
+	return nil
+}
+
+// secondary returns up to limit photos after the hero shot
+func (r *compCardRenderer) secondary(limit int) []image.Image {
+	if len(r.card.Photos) <= 1 {
+		return nil
+	}
+	photos := r.card.Photos[1:]
+	if len(photos) > limit {
+		photos = photos[:limit]
+	}
+	return photos
+}
+
+func (r *compCardRenderer) drawPhoto(canvas *Canvas, img image.Image, rect image.Rectangle) {
+	if img == nil {
+		canvas.FillRect(rect, ColorLightGray)
+		return
+	}
+	canvas.DrawImageCover(img, rect)
+}
+
+// drawGrid places photos in two columns inside rect
+func (r *compCardRenderer) drawGrid(canvas *Canvas, photos []image.Image, rect image.Rectangle) {
+	if len(photos) == 0 {
+		return
+	}
+	gap := r.u(16)
+	cols := 2
+	if len(photos) == 1 {
+		cols = 1
+	}
+	rows := (len(photos) + cols - 1) / cols
+	cellW := (rect.Dx() - gap*(cols-1)) / cols
+	cellH := (rect.Dy() - gap*(rows-1)) / rows
+	for i, img := range photos {
+		x := rect.Min.X + (i%cols)*(cellW+gap)
+		y := rect.Min.Y + (i/cols)*(cellH+gap)
+		w := cellW
+		if i == len(photos)-1 && i%cols == 0 {
+			// The last photo of an odd count takes the whole row
+			w = rect.Dx()
+		}
+		r.drawPhoto(canvas, img, image.Rect(x, y, x+w, y+cellH))
+	}
+}
+
+// statsColumns - at least min columns, and no more than two rows
+func (r *compCardRenderer) statsColumns(min int) int {
+	cols := (len(r.card.Stats) + 1) / 2
+	if cols < min {
+		cols = min
+	}
+	return cols
+}
+
+// statsHeight is the scaled height of the stats block in cols columns
+func (r *compCardRenderer) statsHeight(cols int) int {
+	rows := (len(r.card.Stats) + cols - 1) / cols
+	return rows * (LineHeight(r.faces.label) + LineHeight(r.faces.value) + r.u(12))
+}
+
+// drawStats places label/value pairs in columns; returns the bottom y of the block
+func (r *compCardRenderer) drawStats(canvas *Canvas, x0, x1, y, cols int) int {
+	labelHeight := LineHeight(r.faces.label)
+	valueHeight := LineHeight(r.faces.value)
+	rowHeight := labelHeight + valueHeight + r.u(12)
+	cellW := (x1 - x0) / cols
+	for i, stat := range r.card.Stats {
+		x := x0 + (i%cols)*cellW
+		top := y + (i/cols)*rowHeight
+		canvas.DrawText(r.faces.label, ColorGray, x, top+labelHeight, strings.ToUpper(stat.Label))
+		canvas.DrawText(r.faces.value, ColorBlack, x, top+labelHeight+valueHeight, truncateToWidth(r.faces.value, stat.Value, cellW-r.u(8)))
+	}
+	return y + r.statsHeight(cols)
+}
+
+func (r *compCardRenderer) drawFooter(canvas *Canvas, width, baseline int) {
+	if r.card.Footer == "" {
+		return
+	}
+	canvas.DrawTextCentered(r.faces.small, ColorGray, 0, r.u(width), r.u(baseline), r.card.Footer)
+}
+
+func (r *compCardRenderer) upperName(face font.Face, width int) string {
+	return truncateToWidth(face, strings.ToUpper(r.card.Name), width)
+}
+
+// classicFront - full hero shot with the name under it
+func (r *compCardRenderer) classicFront() image.Image {
+	w, h := compCardShortSide, compCardLongSide
+	canvas := r.newPage(w, h)
+	r.drawPhoto(canvas, r.photo(0), r.rect(40, 40, w-40, 1110))
+	canvas.DrawTextCentered(r.faces.name, ColorBlack, 0, r.u(w), r.u(1185), r.upperName(r.faces.name, r.u(w-80)))
+	r.drawFooter(canvas, w, 1240)
+	return canvas.Image()
+}
+
+// classicBack - secondary photos grid, stats strip at the bottom
+func (r *compCardRenderer) classicBack() image.Image {
+	w, h := compCardShortSide, compCardLongSide
+	canvas := r.newPage(w, h)
+	canvas.DrawText(r.faces.title, ColorBlack, r.u(40), r.u(80), r.upperName(r.faces.title, r.u(w-80)))
+
+	cols := r.statsColumns(4)
+	statsTop := r.u(1200) - r.statsHeight(cols)
+	photos := r.secondary(CompCardMaxPhotos - 1)
+	if len(photos) > 0 {
+		r.drawGrid(canvas, photos, image.Rect(r.u(40), r.u(110), r.u(w-40), statsTop-r.u(30)))
+	}
+	canvas.FillRect(image.Rect(r.u(40), statsTop-r.u(12), r.u(w-40), statsTop-r.u(10)), ColorBlack)
+	r.drawStats(canvas, r.u(40), r.u(w-40), statsTop, cols)
+	r.drawFooter(canvas, w, 1240)
+	return canvas.Image()
+}
+
+// portrait - hero shot, a strip of up to three photos, name and stats
+func (r *compCardRenderer) portrait() image.Image {
+	w, h := compCardShortSide, compCardLongSide
+	canvas := r.newPage(w, h)
+
+	photos := r.secondary(3)
+	heroBottom := 1016
+	if len(photos) > 0 {
+		heroBottom = 800
+		gap := 16
+		cellW := (w - 80 - gap*(len(photos)-1)) / len(photos)
+		for i, img := range photos {
+			x := 40 + i*(cellW+gap)
+			right := x + cellW
+			if i == len(photos)-1 {
+				right = w - 40
+			}
+			r.drawPhoto(canvas, img, r.rect(x, 816, right, 1016))
+		}
+	}
+	r.drawPhoto(canvas, r.photo(0), r.rect(40, 40, w-40, heroBottom))
+
+	canvas.DrawText(r.faces.title, ColorBlack, r.u(40), r.u(1070), r.upperName(r.faces.title, r.u(w-80)))
+	r.drawStats(canvas, r.u(40), r.u(w-40), r.u(1090), r.statsColumns(4))
+	r.drawFooter(canvas, w, 1245)
+	return canvas.Image()
+}
+
+// landscape - hero shot on the left half, name, stats and photo grid on the right
+func (r *compCardRenderer) landscape() image.Image {
+	w, h := compCardLongSide, compCardShortSide
+	canvas := r.newPage(w, h)
+	r.drawPhoto(canvas, r.photo(0), r.rect(40, 40, 600, h-70))
+
+	right := 630
+	canvas.DrawText(r.faces.name, ColorBlack, r.u(right), r.u(95), r.upperName(r.faces.name, r.u(w-40-right)))
+	statsBottom := r.drawStats(canvas, r.u(right), r.u(w-40), r.u(120), r.statsColumns(3))
+
+	photos := r.secondary(CompCardMaxPhotos - 1)
+	if len(photos) > 0 {
+		top := statsBottom + r.u(10)
+		r.drawGrid(canvas, photos, image.Rect(r.u(right), top, r.u(w-40), r.u(h-70)))
+	}
+	r.drawFooter(canvas, w, h-30)
+	return canvas.Image()
+}
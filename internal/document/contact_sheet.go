@@ -0,0 +1,117 @@
+package document
+
+import (
+	"fmt"
+	"image"
+)
+
+// ContactSheetPerPage is how many applicants fit on one A4 page
+const ContactSheetPerPage = 4
+
+// ContactSheet is a printable list of applicants (photo + key facts + message)
+type ContactSheet struct {
+	Title    string
+	Subtitle string
+	Entries  []ContactSheetEntry
+}
+
+// ContactSheetEntry is one applicant row
+type ContactSheetEntry struct {
+	Name    string
+	Photo   image.Image // nil renders a placeholder
+	Details []string    // short "label: value" lines
+	Message string
+}
+
+// RenderContactSheet renders the sheet into A4 raster pages
+func RenderContactSheet(sheet *ContactSheet) ([]image.Image, error) {
+	titleFace, err := Face(18, true)
+	if err != nil {
+		return nil, err
+	}
+	nameFace, err := Face(13, true)
+	if err != nil {
+		return nil, err
+	}
+	textFace, err := Face(9.5, false)
+	if err != nil {
+		return nil, err
+	}
+	smallFace, err := Face(8, false)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		margin       = 70
+		headerHeight = 140
+		footerHeight = 50
+		photoWidth   = 270
+		gap          = 30
+	)
+	cellHeight := (A4Height - 2*margin - headerHeight - footerHeight) / ContactSheetPerPage
+
+	pageCount := (len(sheet.Entries) + ContactSheetPerPage - 1) / ContactSheetPerPage
+	if pageCount == 0 {
+		pageCount = 1
+	}
+
+	pages := make([]image.Image, 0, pageCount)
+	for page := 0; page < pageCount; page++ {
+		canvas := NewCanvas(A4Width, A4Height)
+
+		// Header
+		canvas.DrawText(titleFace, ColorBlack, margin, margin+LineHeight(titleFace), sheet.Title)
+		if sheet.Subtitle != "" {
+			canvas.DrawText(textFace, ColorGray, margin, margin+LineHeight(titleFace)+LineHeight(textFace)+10, sheet.Subtitle)
+		}
+		canvas.FillRect(image.Rect(margin, margin+headerHeight-20, A4Width-margin, margin+headerHeight-17), ColorBlack)
+
+		// Entries
+		start := page * ContactSheetPerPage
+		end := start + ContactSheetPerPage
+		if end > len(sheet.Entries) {
+			end = len(sheet.Entries)
+		}
+		for i, entry := range sheet.Entries[start:end] {
+			top := margin + headerHeight + i*cellHeight
+			photoRect := image.Rect(margin, top+15, margin+photoWidth, top+cellHeight-15)
+
+			if entry.Photo != nil {
+				canvas.DrawImageCover(entry.Photo, photoRect)
+			} else {
+				canvas.FillRect(photoRect, ColorLightGray)
+				canvas.DrawTextCentered(textFace, ColorGray, photoRect.Min.X, photoRect.Max.X, photoRect.Min.Y+photoRect.Dy()/2, "Нет фото")
+			}
+
+			textX := photoRect.Max.X + gap
+			textWidth := A4Width - margin - textX
+			y := top + 15 + LineHeight(nameFace)
+			canvas.DrawText(nameFace, ColorBlack, textX, y, entry.Name)
+			y += LineHeight(nameFace) / 2
+
+			for _, line := range entry.Details {
+				y += LineHeight(textFace)
+				canvas.DrawText(textFace, ColorBlack, textX, y, truncateToWidth(textFace, line, textWidth))
+			}
+
+			if entry.Message != "" {
+				y += LineHeight(textFace) + 10
+				remaining := (top + cellHeight - 15 - y) / LineHeight(textFace)
+				if remaining > 0 {
+					canvas.DrawParagraph(textFace, ColorGray, textX, y, textWidth, remaining, "«"+entry.Message+"»")
+				}
+			}
+
+			if i < end-start-1 {
+				canvas.FillRect(image.Rect(margin, top+cellHeight-1, A4Width-margin, top+cellHeight), ColorLightGray)
+			}
+		}
+
+		// Footer
+		canvas.DrawTextCentered(smallFace, ColorGray, 0, A4Width, A4Height-margin, fmt.Sprintf("%d / %d", page+1, pageCount))
+		pages = append(pages, canvas.Image())
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,131 @@
+package document
+
+import (
+	"fmt"
+	"image"
+)
+
+// Contract is a printable agreement: plain-text body plus a signature block
+type Contract struct {
+	Title      string
+	Subtitle   string
+	Body       string // paragraphs separated by newlines
+	Signatures []ContractSignature
+	Footer     string // e.g. document hash, printed on every page
+}
+
+// ContractSignature is one party's line in the signature block
+type ContractSignature struct {
+	Party   string   // "Заказчик", "Модель"
+	Name    string   // typed name, empty if not signed yet
+	Details []string // signed at, IP, etc.
+}
+
+// RenderContract renders the contract into A4 raster pages
+func RenderContract(contract *Contract) ([]image.Image, error) {
+	titleFace, err := Face(16, true)
+	if err != nil {
+		return nil, err
+	}
+	textFace, err := Face(10, false)
+	if err != nil {
+		return nil, err
+	}
+	boldFace, err := Face(10, true)
+	if err != nil {
+		return nil, err
+	}
+	smallFace, err := Face(8, false)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		margin       = 110
+		footerHeight = 60
+	)
+	textWidth := A4Width - 2*margin
+	bottom := A4Height - margin - footerHeight
+	lineHeight := LineHeight(textFace)
+
+	var pages []*Canvas
+	var canvas *Canvas
+	y := 0
+	newPage := func() {
+		canvas = NewCanvas(A4Width, A4Height)
+		pages = append(pages, canvas)
+		y = margin + lineHeight
+	}
+	// ensure starts a new page if the next block of the given height does not fit
+	ensure := func(height int) {
+		if y+height > bottom {
+			newPage()
+		}
+	}
+
+	newPage()
+
+	// Header
+	canvas.DrawTextCentered(titleFace, ColorBlack, margin, A4Width-margin, y+LineHeight(titleFace)/2, contract.Title)
+	y += LineHeight(titleFace) + lineHeight/2
+	if contract.Subtitle != "" {
+		canvas.DrawTextCentered(textFace, ColorGray, margin, A4Width-margin, y, contract.Subtitle)
+		y += lineHeight
+	}
+	y += lineHeight
+
+	// Body: line by line, so that long contracts flow onto the next pages
+	for _, line := range WrapText(textFace, contract.Body, textWidth) {
+		ensure(lineHeight)
+		canvas.DrawText(textFace, ColorBlack, margin, y, line)
+		y += lineHeight
+	}
+
+	// Signature block: parties side by side, never split between pages
+	if len(contract.Signatures) > 0 {
+		maxDetails := 0
+		for _, signature := range contract.Signatures {
+			if len(signature.Details) > maxDetails {
+				maxDetails = len(signature.Details)
+			}
+		}
+		ensure(lineHeight * (maxDetails + 5))
+		y += lineHeight
+
+		columnWidth := textWidth / len(contract.Signatures)
+		for i, signature := range contract.Signatures {
+			x := margin + i*columnWidth
+			width := columnWidth - 30
+			lineY := y
+
+			canvas.DrawText(boldFace, ColorBlack, x, lineY, signature.Party)
+			lineY += lineHeight * 2
+
+			name := signature.Name
+			col := ColorBlack
+			if name == "" {
+				name = "не подписано"
+				col = ColorGray
+			}
+			canvas.DrawText(textFace, col, x, lineY, truncateToWidth(textFace, name, width))
+			canvas.FillRect(image.Rect(x, lineY+8, x+width, lineY+10), ColorBlack)
+			lineY += lineHeight + 10
+
+			for _, detail := range signature.Details {
+				canvas.DrawText(smallFace, ColorGray, x, lineY, truncateToWidth(smallFace, detail, width))
+				lineY += LineHeight(smallFace)
+			}
+		}
+	}
+
+	result := make([]image.Image, 0, len(pages))
+	for i, page := range pages {
+		footer := fmt.Sprintf("%d / %d", i+1, len(pages))
+		if contract.Footer != "" {
+			footer = contract.Footer + "   " + footer
+		}
+		page.DrawTextCentered(smallFace, ColorGray, 0, A4Width, A4Height-margin, footer)
+		result = append(result, page.Image())
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package document
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// DPI used for all rendered documents (A4 at 150 DPI = 1240x1754 px)
+const DPI = 150
+
+var (
+	fontsOnce   sync.Once
+	fontsErr    error
+	regularFont *opentype.Font
+	boldFont    *opentype.Font
+)
+
+// loadFonts parses the embedded Go fonts (they cover Latin and Cyrillic)
+func loadFonts() error {
+	fontsOnce.Do(func() {
+		regularFont, fontsErr = opentype.Parse(goregular.TTF)
+		if fontsErr != nil {
+			fontsErr = fmt.Errorf("failed to parse regular font: %w", fontsErr)
+			return
+		}
+		boldFont, fontsErr = opentype.Parse(gobold.TTF)
+		if fontsErr != nil {
+			fontsErr = fmt.Errorf("failed to parse bold font: %w", fontsErr)
+		}
+	})
+	return fontsErr
+}
+
+// Face returns a new font face of the given point size. Faces keep glyph caches
+// and are not safe for concurrent use, so each render creates its own; only the
+// parsed fonts are shared.
+func Face(size float64, bold bool) (font.Face, error) {
+	if err := loadFonts(); err != nil {
+		return nil, err
+	}
+
+	src := regularFont
+	if bold {
+		src = boldFont
+	}
+	face, err := opentype.NewFace(src, &opentype.FaceOptions{
+		Size:    size,
+		DPI:     DPI,
+		Hinting: font.HintingFull,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	return face, nil
+}
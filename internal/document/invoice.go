@@ -0,0 +1,212 @@
+package document
+
+import (
+	"fmt"
+	"image"
+
+	"golang.org/x/image/font"
+)
+
+// Invoice is a printable invoice or credit note; all amounts are preformatted by the caller
+type Invoice struct {
+	Title    string // "Счет № INV-2026-000001"
+	Subtitle string // issue date, reference to the original invoice
+	Seller   InvoiceParty
+	Buyer    InvoiceParty
+	Columns  []string // line table header
+	Lines    []InvoiceLine
+	Totals   []InvoiceTotal
+	Note     string
+	Footer   string
+}
+
+// InvoiceParty is a labelled block of requisites
+type InvoiceParty struct {
+	Label   string // "Продавец", "Покупатель"
+	Name    string
+	Details []string // address, tax id, contacts
+}
+
+// InvoiceLine is one row of the line table, cells in the order of Invoice.Columns
+type InvoiceLine struct {
+	Cells []string
+}
+
+// InvoiceTotal is a label/value row under the table
+type InvoiceTotal struct {
+	Label string
+	Value string
+	Bold  bool
+}
+
+// invoiceColumnWeights - relative widths: description is wide, numeric columns narrow
+var invoiceColumnWeights = []int{1, 8, 2, 3, 3, 3}
+
+// RenderInvoice renders the invoice into A4 raster pages
+func RenderInvoice(invoice *Invoice) ([]image.Image, error) {
+	titleFace, err := Face(16, true)
+	if err != nil {
+		return nil, err
+	}
+	textFace, err := Face(10, false)
+	if err != nil {
+		return nil, err
+	}
+	boldFace, err := Face(10, true)
+	if err != nil {
+		return nil, err
+	}
+	smallFace, err := Face(8, false)
+	if err != nil {
+		return nil, err
+	}
+
+	const (
+		margin       = 110
+		footerHeight = 60
+		cellPadding  = 8
+	)
+	textWidth := A4Width - 2*margin
+	bottom := A4Height - margin - footerHeight
+	lineHeight := LineHeight(textFace)
+
+	var pages []*Canvas
+	var canvas *Canvas
+	y := 0
+	newPage := func() {
+		canvas = NewCanvas(A4Width, A4Height)
+		pages = append(pages, canvas)
+		y = margin + lineHeight
+	}
+	ensure := func(height int) {
+		if y+height > bottom {
+			newPage()
+		}
+	}
+
+	newPage()
+
+	// Header
+	canvas.DrawText(titleFace, ColorBlack, margin, y+LineHeight(titleFace)/2, invoice.Title)
+	y += LineHeight(titleFace) + lineHeight/2
+	if invoice.Subtitle != "" {
+		canvas.DrawText(textFace, ColorGray, margin, y, invoice.Subtitle)
+		y += lineHeight
+	}
+	y += lineHeight
+
+	// Parties side by side
+	columnWidth := textWidth / 2
+	partiesBottom := y
+	for i, party := range []InvoiceParty{invoice.Seller, invoice.Buyer} {
+		x := margin + i*columnWidth
+		width := columnWidth - 30
+		partyY := y
+		canvas.DrawText(smallFace, ColorGray, x, partyY, party.Label)
+		partyY += LineHeight(smallFace) + 4
+		partyY = canvas.DrawParagraph(boldFace, ColorBlack, x, partyY, width, 2, party.Name)
+		for _, detail := range party.Details {
+			partyY = canvas.DrawParagraph(textFace, ColorBlack, x, partyY, width, 2, detail)
+		}
+		if partyY > partiesBottom {
+			partiesBottom = partyY
+		}
+	}
+	y = partiesBottom + lineHeight
+
+	// Line table
+	widths := invoiceColumnWidths(len(invoice.Columns), textWidth)
+	drawRow := func(face font.Face, cells []string, shaded bool) {
+		// Cells wrap; the row is as tall as its longest cell
+		wrapped := make([][]string, len(widths))
+		rowLines := 1
+		for i, width := range widths {
+			if i < len(cells) {
+				wrapped[i] = WrapText(face, cells[i], width-2*cellPadding)
+				if len(wrapped[i]) > rowLines {
+					rowLines = len(wrapped[i])
+				}
+			}
+		}
+		rowHeight := rowLines*lineHeight + 2*cellPadding
+		ensure(rowHeight)
+		top := y - lineHeight + 4
+		if shaded {
+			canvas.FillRect(image.Rect(margin, top-cellPadding, margin+textWidth, top-cellPadding+rowHeight), ColorLightGray)
+		}
+		x := margin
+		for i, width := range widths {
+			for j, line := range wrapped[i] {
+				canvas.DrawText(face, ColorBlack, x+cellPadding, y+cellPadding/2+j*lineHeight, line)
+			}
+			x += width
+		}
+		canvas.FillRect(image.Rect(margin, top-cellPadding+rowHeight-1, margin+textWidth, top-cellPadding+rowHeight), ColorGray)
+		y += rowHeight
+	}
+	drawRow(boldFace, invoice.Columns, true)
+	for _, line := range invoice.Lines {
+		drawRow(textFace, line.Cells, false)
+	}
+	y += lineHeight
+
+	// Totals, right aligned block
+	ensure(lineHeight * (len(invoice.Totals) + 1))
+	labelX := margin + textWidth/2
+	for _, total := range invoice.Totals {
+		face := textFace
+		if total.Bold {
+			face = boldFace
+		}
+		canvas.DrawText(face, ColorBlack, labelX, y, total.Label)
+		valueWidth := font.MeasureString(face, total.Value).Ceil()
+		canvas.DrawText(face, ColorBlack, margin+textWidth-valueWidth, y, total.Value)
+		y += lineHeight
+	}
+
+	if invoice.Note != "" {
+		y += lineHeight
+		for _, line := range WrapText(textFace, invoice.Note, textWidth) {
+			ensure(lineHeight)
+			canvas.DrawText(textFace, ColorGray, margin, y, line)
+			y += lineHeight
+		}
+	}
+
+	result := make([]image.Image, 0, len(pages))
+	for i, page := range pages {
+		footer := fmt.Sprintf("%d / %d", i+1, len(pages))
+		if invoice.Footer != "" {
+			footer = invoice.Footer + "   " + footer
+		}
+		page.DrawTextCentered(smallFace, ColorGray, 0, A4Width, A4Height-margin, footer)
+		result = append(result, page.Image())
+	}
+	return result, nil
+}
+
+// invoiceColumnWidths splits the table width by invoiceColumnWeights (equal parts for other column counts)
+func invoiceColumnWidths(columns, total int) []int {
+	if columns == 0 {
+		return nil
+	}
+	weights := invoiceColumnWeights
+	if columns != len(weights) {
+		weights = make([]int, columns)
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+	sum := 0
+	for _, w := range weights {
+		sum += w
+	}
+	widths := make([]int, columns)
+	used := 0
+	for i, w := range weights {
+		widths[i] = total * w / sum
+		used += widths[i]
+	}
+	widths[columns-1] += total - used
+	return widths
+}
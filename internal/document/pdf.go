@@ -0,0 +1,120 @@
+package document
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+)
+
+// A4 page size in PDF points (1/72 inch)
+const (
+	a4PointsWidth  = 595.28
+	a4PointsHeight = 841.89
+)
+
+// EncodePDF writes raster pages as a PDF document. A4-sized pages map to exact A4,
+// other sizes (e.g. comp cards) are sized from their pixels at DPI.
+// Each page is embedded as a full-page JPEG (DCTDecode), so no external tools are required.
+func EncodePDF(w io.Writer, pages []image.Image, quality int) error {
+	if len(pages) == 0 {
+		return fmt.Errorf("pdf: no pages to encode")
+	}
+	if quality <= 0 || quality > 100 {
+		quality = 85
+	}
+
+	pw := &pdfWriter{w: bufio.NewWriter(w)}
+	pw.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Object layout: 1 - catalog, 2 - pages, then 3 objects per page (page, content, image)
+	pageCount := len(pages)
+	kids := &bytes.Buffer{}
+	for i := 0; i < pageCount; i++ {
+		fmt.Fprintf(kids, "%d 0 R ", 3+i*3)
+	}
+
+	pw.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	pw.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", kids.String(), pageCount))
+
+	for i, page := range pages {
+		pageObj := 3 + i*3
+		contentObj := pageObj + 1
+		imageObj := pageObj + 2
+
+		bounds := page.Bounds()
+		width, height := pagePoints(bounds)
+		pw.object(pageObj, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /XObject << /Im0 %d 0 R >> >> /Contents %d 0 R >>",
+			width, height, imageObj, contentObj,
+		))
+
+		content := fmt.Sprintf("q %.2f 0 0 %.2f 0 0 cm /Im0 Do Q", width, height)
+		pw.stream(contentObj, "", []byte(content))
+
+		var jpg bytes.Buffer
+		if err := jpeg.Encode(&jpg, page, &jpeg.Options{Quality: quality}); err != nil {
+			return fmt.Errorf("pdf: failed to encode page %d: %w", i+1, err)
+		}
+		pw.stream(imageObj, fmt.Sprintf(
+			"/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode",
+			bounds.Dx(), bounds.Dy(),
+		), jpg.Bytes())
+	}
+
+	xrefOffset := pw.offset
+	total := 2 + pageCount*3
+	pw.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", total+1))
+	for _, off := range pw.offsets {
+		pw.write(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	pw.write(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", total+1, xrefOffset))
+
+	if pw.err != nil {
+		return pw.err
+	}
+	return pw.w.Flush()
+}
+
+// pagePoints returns the page size in PDF points for a raster page rendered at DPI
+func pagePoints(bounds image.Rectangle) (float64, float64) {
+	if bounds.Dx() == A4Width && bounds.Dy() == A4Height {
+		return a4PointsWidth, a4PointsHeight
+	}
+	return float64(bounds.Dx()) * 72 / DPI, float64(bounds.Dy()) * 72 / DPI
+}
+
+// pdfWriter tracks byte offsets of objects for the xref table
+type pdfWriter struct {
+	w       *bufio.Writer
+	offset  int
+	offsets []int
+	err     error
+}
+
+func (p *pdfWriter) write(s string) {
+	p.writeBytes([]byte(s))
+}
+
+func (p *pdfWriter) writeBytes(b []byte) {
+	if p.err != nil {
+		return
+	}
+	n, err := p.w.Write(b)
+	p.offset += n
+	p.err = err
+}
+
+func (p *pdfWriter) object(num int, body string) {
+	p.offsets = append(p.offsets, p.offset)
+	p.write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", num, body))
+}
+
+func (p *pdfWriter) stream(num int, dict string, data []byte) {
+	p.offsets = append(p.offsets, p.offset)
+	p.write(fmt.Sprintf("%d 0 obj\n<< %s /Length %d >>\nstream\n", num, dict, len(data)))
+	p.writeBytes(data)
+	p.write("\nendstream\nendobj\n")
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AgencyHandler - модельные агентства: профиль, модели агентства, действия от их имени;
+// со стороны модели - приглашения и уход из агентства
+type AgencyHandler struct {
+	*BaseHandler
+	agencyService services.AgencyService
+}
+
+func NewAgencyHandler(base *BaseHandler, agencyService services.AgencyService) *AgencyHandler {
+	return &AgencyHandler{
+		BaseHandler:   base,
+		agencyService: agencyService,
+	}
+}
+
+func (h *AgencyHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Agency only (modelId = model_profiles.id)
+	agency := r.Group("/agency")
+	agency.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleAgency))
+	{
+		agency.GET("/me", h.GetMyAgency)
+		agency.PUT("/me", h.UpdateMyAgency)
+		agency.GET("/models", h.ListModels)
+		agency.POST("/models", h.CreateManagedModel)
+		agency.POST("/models/invite", h.InviteModel)
+		agency.PUT("/models/:modelId", h.UpdateModelProfile)
+		agency.DELETE("/models/:modelId", h.EndRepresentation)
+		agency.POST("/models/:modelId/responses", h.RespondToCasting)
+	}
+
+	// Protected routes - Model only
+	my := r.Group("/profiles/me/representation")
+	my.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleModel))
+	{
+		my.GET("", h.GetMyRepresentation)
+		my.PUT("", h.UpdateMyRepresentation)
+		my.DELETE("", h.LeaveAgency)
+		my.POST("/invitations/:representationId/accept", h.AcceptInvitation)
+		my.POST("/invitations/:representationId/decline", h.DeclineInvitation)
+	}
+}
+
+// --- Agency ---
+
+func (h *AgencyHandler) GetMyAgency(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	agency, err := h.agencyService.GetMyAgency(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, agency)
+}
+
+func (h *AgencyHandler) UpdateMyAgency(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAgencyProfileRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	agency, err := h.agencyService.UpdateMyAgency(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, agency)
+}
+
+// ListModels - модели агентства (?status=active; по умолчанию приглашения и активные)
+func (h *AgencyHandler) ListModels(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.AgencyModelsQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+
+	representations, err := h.agencyService.ListModels(h.GetDB(c), userID, &query)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"models": representations,
+		"total":  len(representations),
+	})
+}
+
+// CreateManagedModel - аккаунт и профиль новой модели агентства
+func (h *AgencyHandler) CreateManagedModel(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateManagedModelRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	representation, err := h.agencyService.CreateManagedModel(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, representation)
+}
+
+// InviteModel - приглашение существующей модели
+func (h *AgencyHandler) InviteModel(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.InviteModelRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	representation, err := h.agencyService.InviteModel(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, representation)
+}
+
+// UpdateModelProfile - изменение профиля представляемой модели (поля как в PUT /profiles/me)
+func (h *AgencyHandler) UpdateModelProfile(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	if err := h.agencyService.UpdateModelProfile(h.GetDB(c), userID, c.Param("modelId"), &req); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Model profile updated successfully"})
+}
+
+func (h *AgencyHandler) EndRepresentation(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.agencyService.EndRepresentation(h.GetDB(c), userID, c.Param("modelId")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Representation ended successfully"})
+}
+
+// RespondToCasting - отклик на кастинг от имени представляемой модели
+func (h *AgencyHandler) RespondToCasting(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AgencyResponseRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	response, err := h.agencyService.RespondToCasting(h.GetDB(c), userID, c.Param("modelId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// --- Model ---
+
+// GetMyRepresentation - текущее агентство модели и приглашения
+func (h *AgencyHandler) GetMyRepresentation(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	representation, err := h.agencyService.GetMyRepresentation(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, representation)
+}
+
+// UpdateMyRepresentation - копии уведомлений агентства для модели
+func (h *AgencyHandler) UpdateMyRepresentation(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateRepresentationRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	representation, err := h.agencyService.UpdateMyRepresentation(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, representation)
+}
+
+func (h *AgencyHandler) LeaveAgency(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.agencyService.LeaveAgency(h.GetDB(c), userID); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "You have left the agency"})
+}
+
+func (h *AgencyHandler) AcceptInvitation(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	representation, err := h.agencyService.AcceptInvitation(h.GetDB(c), userID, c.Param("representationId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, representation)
+}
+
+func (h *AgencyHandler) DeclineInvitation(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.agencyService.DeclineInvitation(h.GetDB(c), userID, c.Param("representationId")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation declined"})
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AvailabilityHandler - календарь доступности модели (интервалы, еженедельный шаблон, автоблокировки)
+type AvailabilityHandler struct {
+	*BaseHandler
+	availabilityService services.AvailabilityService
+}
+
+func NewAvailabilityHandler(base *BaseHandler, availabilityService services.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		BaseHandler:         base,
+		availabilityService: availabilityService,
+	}
+}
+
+func (h *AvailabilityHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Model only
+	my := r.Group("/profiles/me/availability")
+	my.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleModel))
+	{
+		my.GET("", h.GetMyCalendar)
+		my.POST("/ranges", h.CreateRange)
+		my.DELETE("/ranges/:rangeId", h.DeleteRange)
+		my.PUT("/weekly", h.ReplaceWeekly)
+	}
+
+	// Protected routes - календарь модели для работодателей
+	modelsGroup := r.Group("/profiles/models")
+	modelsGroup.Use(middleware.AuthMiddleware())
+	{
+		modelsGroup.GET("/:modelId/availability", h.GetModelCalendar)
+	}
+}
+
+// GetMyCalendar - календарь текущей модели за период (?from=2026-01-01&to=2026-02-01)
+func (h *AvailabilityHandler) GetMyCalendar(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.AvailabilityPeriodQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+
+	calendar, err := h.availabilityService.GetMyCalendar(h.GetDB(c), userID, &query)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// CreateRange - разовый интервал доступности или недоступности
+func (h *AvailabilityHandler) CreateRange(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAvailabilityRangeRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	availabilityRange, err := h.availabilityService.CreateRange(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, availabilityRange)
+}
+
+func (h *AvailabilityHandler) DeleteRange(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.availabilityService.DeleteRange(h.GetDB(c), userID, c.Param("rangeId")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Availability range deleted successfully"})
+}
+
+// ReplaceWeekly - задает еженедельный шаблон целиком
+func (h *AvailabilityHandler) ReplaceWeekly(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReplaceWeeklyAvailabilityRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	rules, err := h.availabilityService.ReplaceWeekly(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rules": rules,
+		"total": len(rules),
+	})
+}
+
+// GetModelCalendar - занятость модели (modelId = model_profiles.id) без личных заметок
+func (h *AvailabilityHandler) GetModelCalendar(c *gin.Context) {
+	if _, ok := h.GetAndAuthorizeUserID(c); !ok {
+		return
+	}
+
+	var query dto.AvailabilityPeriodQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+
+	calendar, err := h.availabilityService.GetModelCalendar(h.GetDB(c), c.Param("modelId"), &query)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/logger"
 	"mwork_backend/internal/validator"
 	"mwork_backend/pkg/apperrors"
@@ -159,6 +160,22 @@ func (h *BaseHandler) GetAndAuthorizeUserID(c *gin.Context) (string, bool) {
 	return userIDStr, true
 }
 
+// optionalUserID - ID пользователя на публичных маршрутах с OptionalAuthMiddleware ("" для гостя)
+func optionalUserID(c *gin.Context) string {
+	userID, _ := c.Get("userID")
+	userIDStr, _ := userID.(string)
+	return userIDStr
+}
+
+// RequestLocale - язык контента ответа: ?lang=, затем Accept-Language, затем язык по умолчанию.
+// Выбранный язык отдается в Content-Language, ответ варьируется по Accept-Language.
+func RequestLocale(c *gin.Context) string {
+	locale := algorithms.NegotiateLocale(c.Query("lang"), c.GetHeader("Accept-Language"))
+	c.Header("Content-Language", locale)
+	c.Header("Vary", "Accept-Language")
+	return locale
+}
+
 // HandleValidationError обрабатывает ошибки, возвращаемые кастомным валидатором.
 func (h *BaseHandler) HandleValidationError(c *gin.Context, err error) {
 	verr, ok := err.(*validator.ValidationError)
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookingHandler - бронирования по принятым откликам: условия, договор, подписи и статусы
+type BookingHandler struct {
+	*BaseHandler
+	bookingService services.BookingService
+}
+
+func NewBookingHandler(base *BaseHandler, bookingService services.BookingService) *BookingHandler {
+	return &BookingHandler{
+		BaseHandler:    base,
+		bookingService: bookingService,
+	}
+}
+
+func (h *BookingHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - обе стороны бронирования
+	bookings := r.Group("/bookings")
+	bookings.Use(middleware.AuthMiddleware())
+	{
+		bookings.GET("/my", h.GetMyBookings)
+		bookings.GET("/:bookingId", h.GetBooking)
+		bookings.PATCH("/:bookingId/status", h.UpdateBookingStatus)
+		bookings.GET("/:bookingId/contract", h.GetContract)
+		bookings.GET("/:bookingId/contract/pdf", h.ExportContractPDF)
+		bookings.POST("/:bookingId/sign", h.SignContract)
+	}
+
+	// Employer only
+	employerBookings := r.Group("/bookings")
+	employerBookings.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer, models.UserRoleAdmin))
+	{
+		employerBookings.POST("", h.CreateBooking)
+		employerBookings.PUT("/:bookingId/terms", h.UpdateBookingTerms)
+	}
+}
+
+// CreateBooking - бронирование по принятому отклику
+func (h *BookingHandler) CreateBooking(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateBookingRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	booking, err := h.bookingService.CreateBooking(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, booking)
+}
+
+// GetMyBookings - бронирования текущего пользователя (как работодателя или как модели)
+func (h *BookingHandler) GetMyBookings(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var filter dto.BookingListFilter
+	if !h.BindAndValidate_Query(c, &filter) {
+		return
+	}
+
+	result, err := h.bookingService.GetMyBookings(h.GetDB(c), userID, &filter)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *BookingHandler) GetBooking(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	booking, err := h.bookingService.GetBooking(h.GetDB(c), c.Param("bookingId"), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
+
+// UpdateBookingTerms - новые условия до подписания (новая версия договора)
+func (h *BookingHandler) UpdateBookingTerms(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateBookingTermsRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	booking, err := h.bookingService.UpdateBookingTerms(h.GetDB(c), c.Param("bookingId"), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
+
+// UpdateBookingStatus - completed / cancelled / no_show
+func (h *BookingHandler) UpdateBookingStatus(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateBookingStatusRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	booking, err := h.bookingService.UpdateBookingStatus(h.GetDB(c), c.Param("bookingId"), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
+
+// GetContract - текст текущей версии договора и хэш для подписи
+func (h *BookingHandler) GetContract(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	contract, err := h.bookingService.GetContract(h.GetDB(c), c.Param("bookingId"), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contract)
+}
+
+// ExportContractPDF - договор с подписями в PDF
+func (h *BookingHandler) ExportContractPDF(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	file, err := h.bookingService.ExportContractPDF(h.GetDB(c), c.Param("bookingId"), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
+
+// SignContract - подпись договора набранным именем; фиксируются время, IP и User-Agent
+func (h *BookingHandler) SignContract(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SignBookingRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	sigCtx := dto.SignatureContext{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+
+	booking, err := h.bookingService.SignContract(h.GetDB(c), c.Param("bookingId"), userID, &req, sigCtx)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, booking)
+}
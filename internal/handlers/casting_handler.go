@@ -34,7 +34,7 @@ func (h *CastingHandler) RegisterRoutes(r *gin.RouterGroup) {
 	// Public routes
 	public := r.Group("/castings")
 	{
-		public.GET("", h.SearchCastings)
+		public.GET("", middleware.OptionalAuthMiddleware(), h.SearchCastings)
 		public.GET("/:castingId", h.GetCasting)
 		public.GET("/active", h.GetActiveCastings)
 		public.GET("/city/:city", h.GetCastingsByCity)
@@ -49,6 +49,7 @@ func (h *CastingHandler) RegisterRoutes(r *gin.RouterGroup) {
 		castings.PUT("/:castingId", h.UpdateCasting)
 		castings.DELETE("/:castingId", h.DeleteCasting)
 		castings.PUT("/:castingId/status", h.UpdateCastingStatus)
+		castings.GET("/:castingId/status-history", h.GetCastingStatusHistory)
 		castings.GET("/:castingId/stats", h.GetCastingStatsForCasting)
 		castings.GET("/stats/my", h.GetMyStats)
 
@@ -89,11 +90,14 @@ func (h *CastingHandler) SearchCastings(c *gin.Context) {
 		return
 	}
 	criteria.Page, criteria.PageSize = ParsePagination(c)
+	criteria.RequesterID = optionalUserID(c)
+	criteria.Locale = RequestLocale(c)
 	castings, total, err := h.castingService.SearchCastings(h.GetDB(c), criteria)
 	if err != nil {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(castings, criteria.Locale)
 	c.JSON(http.StatusOK, gin.H{
 		"castings": castings,
 		"total":    total,
@@ -118,6 +122,7 @@ func (h *CastingHandler) GetCasting(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(casting, RequestLocale(c))
 	c.JSON(http.StatusOK, casting)
 }
 
@@ -131,6 +136,7 @@ func (h *CastingHandler) GetActiveCastings(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(castings, RequestLocale(c))
 	c.JSON(http.StatusOK, gin.H{
 		"castings": castings,
 		"total":    len(castings),
@@ -148,6 +154,7 @@ func (h *CastingHandler) GetCastingsByCity(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(castings, RequestLocale(c))
 	c.JSON(http.StatusOK, gin.H{
 		"castings": castings,
 		"total":    len(castings),
@@ -227,17 +234,33 @@ func (h *CastingHandler) UpdateCastingStatus(c *gin.Context) {
 		return
 	}
 	castingID := c.Param("castingId")
-	var req struct {
-		Status models.CastingStatus `json:"status" binding:"required"`
-	}
+	var req dto.UpdateCastingStatusRequest
 	if !h.BindAndValidate_JSON(c, &req) {
 		return
 	}
-	if err := h.castingService.UpdateCastingStatus(h.GetDB(c), castingID, employerID, req.Status); err != nil {
+	result, err := h.castingService.UpdateCastingStatus(h.GetDB(c), castingID, employerID, &req)
+	if err != nil {
 		h.HandleServiceError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Casting status updated successfully"})
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Casting status updated successfully",
+		"result":  result,
+	})
+}
+
+func (h *CastingHandler) GetCastingStatusHistory(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	castingID := c.Param("castingId")
+	history, err := h.castingService.GetCastingStatusHistory(h.GetDB(c), castingID, userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"transitions": history})
 }
 
 func (h *CastingHandler) GetCastingStatsForCasting(c *gin.Context) {
@@ -413,8 +436,14 @@ func (h *CastingHandler) GetCastingResponses(c *gin.Context) {
 	}
 	castingID := c.Param("castingId")
 
+	// Фильтры по приватным пометкам работодателя (tag, min_rating, has_note)
+	var filter dto.ResponseListFilter
+	if !h.BindAndValidate_Query(c, &filter) {
+		return
+	}
+
 	// Вызов сервиса (Этот вызов УЖЕ БЫЛ ПРАВИЛЬНЫМ)
-	responses, err := h.responseService.GetCastingResponses(h.GetDB(c), castingID, employerID)
+	responses, err := h.responseService.GetCastingResponses(h.GetDB(c), castingID, employerID, &filter)
 	if err != nil {
 		h.HandleServiceError(c, err)
 		return
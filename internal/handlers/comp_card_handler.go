@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompCardHandler - композитка модели (настройки, PDF/PNG)
+type CompCardHandler struct {
+	*BaseHandler
+	compCardService services.CompCardService
+}
+
+func NewCompCardHandler(base *BaseHandler, compCardService services.CompCardService) *CompCardHandler {
+	return &CompCardHandler{
+		BaseHandler:     base,
+		compCardService: compCardService,
+	}
+}
+
+func (h *CompCardHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Model only
+	my := r.Group("/profiles/me/comp-card")
+	my.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleModel))
+	{
+		my.GET("", h.GetMyCompCard)
+		my.PUT("", h.UpdateMyCompCard)
+		my.GET("/download", h.DownloadMyCompCard)
+	}
+
+	// Protected routes - композитка публичной модели для работодателей
+	modelsGroup := r.Group("/profiles/models")
+	modelsGroup.Use(middleware.AuthMiddleware())
+	{
+		modelsGroup.GET("/:modelId/comp-card", h.DownloadModelCompCard)
+	}
+}
+
+// GetMyCompCard - шаблон и фото композитки текущей модели
+func (h *CompCardHandler) GetMyCompCard(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	card, err := h.compCardService.GetMyCompCard(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+// UpdateMyCompCard - выбор шаблона и фото из портфолио
+func (h *CompCardHandler) UpdateMyCompCard(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateCompCardRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	card, err := h.compCardService.UpdateMyCompCard(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+// DownloadMyCompCard - файл композитки текущей модели (?format=pdf|png)
+func (h *CompCardHandler) DownloadMyCompCard(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var query dto.CompCardDownloadQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+
+	file, err := h.compCardService.RenderMyCompCard(h.GetDB(c), c.Request.Context(), userID, query.Format)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.sendFile(c, file)
+}
+
+// DownloadModelCompCard - файл композитки публичного профиля модели (?format=pdf|png)
+func (h *CompCardHandler) DownloadModelCompCard(c *gin.Context) {
+	if _, ok := h.GetAndAuthorizeUserID(c); !ok {
+		return
+	}
+
+	var query dto.CompCardDownloadQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+
+	file, err := h.compCardService.RenderModelCompCard(h.GetDB(c), c.Request.Context(), c.Param("modelId"), query.Format)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	h.sendFile(c, file)
+}
+
+func (h *CompCardHandler) sendFile(c *gin.Context, file *dto.ExportFile) {
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmployerNoteHandler - приватные пометки работодателя (заметка, цветные теги, рейтинг 1-5).
+// Модели эти данные никогда не видят.
+type EmployerNoteHandler struct {
+	*BaseHandler
+	noteService services.EmployerNoteService
+}
+
+func NewEmployerNoteHandler(base *BaseHandler, noteService services.EmployerNoteService) *EmployerNoteHandler {
+	return &EmployerNoteHandler{
+		BaseHandler: base,
+		noteService: noteService,
+	}
+}
+
+func (h *EmployerNoteHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Employer only
+	notes := r.Group("/employer/notes")
+	notes.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer, models.UserRoleAdmin))
+	{
+		notes.GET("/responses/:responseId", h.GetResponseNote)
+		notes.PUT("/responses/:responseId", h.UpsertResponseNote)
+		notes.DELETE("/responses/:responseId", h.DeleteResponseNote)
+
+		notes.GET("/models", h.ListModelNotes)
+		notes.GET("/models/:modelId", h.GetModelNote)
+		notes.PUT("/models/:modelId", h.UpsertModelNote)
+		notes.DELETE("/models/:modelId", h.DeleteModelNote)
+	}
+}
+
+// GetResponseNote - пометка по отклику
+func (h *EmployerNoteHandler) GetResponseNote(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	note, err := h.noteService.GetResponseNote(h.GetDB(c), userID, c.Param("responseId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// UpsertResponseNote - создает или перезаписывает пометку по отклику
+func (h *EmployerNoteHandler) UpsertResponseNote(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpsertEmployerNoteRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	note, err := h.noteService.UpsertResponseNote(h.GetDB(c), userID, c.Param("responseId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// DeleteResponseNote - удаляет пометку по отклику
+func (h *EmployerNoteHandler) DeleteResponseNote(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.noteService.DeleteResponseNote(h.GetDB(c), userID, c.Param("responseId")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Note deleted successfully"})
+}
+
+// ListModelNotes - пометки по моделям с фильтром ?tag=green&min_rating=4
+func (h *EmployerNoteHandler) ListModelNotes(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var filter dto.ModelNoteListFilter
+	if !h.BindAndValidate_Query(c, &filter) {
+		return
+	}
+
+	notes, err := h.noteService.ListModelNotes(h.GetDB(c), userID, filter.Tag, filter.MinRating)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+// GetModelNote - пометка по профилю модели
+func (h *EmployerNoteHandler) GetModelNote(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	note, err := h.noteService.GetModelNote(h.GetDB(c), userID, c.Param("modelId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// UpsertModelNote - создает или перезаписывает пометку по профилю модели
+func (h *EmployerNoteHandler) UpsertModelNote(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpsertEmployerNoteRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	note, err := h.noteService.UpsertModelNote(h.GetDB(c), userID, c.Param("modelId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// DeleteModelNote - удаляет пометку по профилю модели
+func (h *EmployerNoteHandler) DeleteModelNote(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.noteService.DeleteModelNote(h.GetDB(c), userID, c.Param("modelId")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Note deleted successfully"})
+}
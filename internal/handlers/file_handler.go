@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"mwork_backend/internal/imageprocessor"
 	"mwork_backend/internal/middleware"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
@@ -21,15 +24,20 @@ import (
 // ▼▼▼ ИЗМЕНЕНО (Проблема 1) ▼▼▼
 type FileHandler struct {
 	*BaseHandler
-	storage    storage.Storage
-	uploadRepo repositories.UploadRepository // Изменено с portfolioRepo
+	storage      storage.Storage
+	uploadRepo   repositories.UploadRepository // Изменено с portfolioRepo
+	responseRepo repositories.ResponseRepository
+	// watermark is applied to public portfolio photos; nil disables watermarking
+	watermark *imageprocessor.Watermark
 }
 
-func NewFileHandler(base *BaseHandler, storage storage.Storage, uploadRepo repositories.UploadRepository) *FileHandler {
+func NewFileHandler(base *BaseHandler, storage storage.Storage, uploadRepo repositories.UploadRepository, responseRepo repositories.ResponseRepository, watermark *imageprocessor.Watermark) *FileHandler {
 	return &FileHandler{
-		BaseHandler: base,
-		storage:     storage,
-		uploadRepo:  uploadRepo, // Изменено с portfolioRepo
+		BaseHandler:  base,
+		storage:      storage,
+		uploadRepo:   uploadRepo, // Изменено с portfolioRepo
+		responseRepo: responseRepo,
+		watermark:    watermark,
 	}
 }
 
@@ -39,8 +47,9 @@ func (h *FileHandler) RegisterRoutes(r *gin.RouterGroup) {
 	files := r.Group("/files")
 	{
 		// Public file serving
-		files.GET("/:uploadId", h.ServeFile)
-		files.GET("/:uploadId/:size", h.ServeResizedImage)
+		// Optional auth: owners and employers with an accepted response get clean images
+		files.GET("/:uploadId", middleware.OptionalAuthMiddleware(), h.ServeFile)
+		files.GET("/:uploadId/:size", middleware.OptionalAuthMiddleware(), h.ServeResizedImage)
 
 		// Protected file operations
 		files.GET("/:uploadId/signed-url", middleware.AuthMiddleware(), h.GetSignedURL)
@@ -61,21 +70,20 @@ func (h *FileHandler) ServeFile(c *gin.Context) {
 		return
 	}
 
-	// Check if file is public or user has access
-	if !upload.IsPublic {
-		userID, exists := c.Get("userID")
-		if !exists || userID.(string) != upload.UserID {
-			// Check if user is admin
-			userRole, roleExists := c.Get("userRole")
-			if !roleExists || userRole.(string) != string(models.UserRoleAdmin) {
-				apperrors.HandleError(c, apperrors.NewForbiddenError("Access denied"))
-				return
-			}
-		}
+	// Check if file is public (and passed photo moderation) or user has access
+	if (!upload.IsPublic || !upload.IsApproved()) && !h.isOwnerOrAdmin(c, upload) {
+		apperrors.HandleError(c, apperrors.NewForbiddenError("Access denied"))
+		return
+	}
+
+	// Other viewers of a protected photo get the watermarked large version instead
+	if h.needsWatermark(c, upload) {
+		h.serveWatermarked(c, upload, imageprocessor.SizeLarge)
+		return
 	}
 
 	// Get file from storage
-	reader, err := h.storage.Get(c.Request.Context(), upload.Path)
+	reader, size, err := h.openOriginal(c.Request.Context(), upload)
 	if err != nil {
 		apperrors.HandleError(c, apperrors.NewNotFoundError("File not found in storage"))
 		return
@@ -84,8 +92,8 @@ func (h *FileHandler) ServeFile(c *gin.Context) {
 
 	// Set headers
 	c.Header("Content-Type", upload.MimeType)
-	c.Header("Content-Length", strconv.FormatInt(upload.Size, 10))
-	c.Header("Cache-Control", "public, max-age=31536000") // Cache for 1 year
+	c.Header("Content-Length", strconv.FormatInt(size, 10))
+	c.Header("Cache-Control", h.cacheControl(upload))
 	c.Header("ETag", fmt.Sprintf(`"%s"`, upload.ID))
 
 	// Set Content-Disposition for downloads
@@ -103,20 +111,38 @@ func (h *FileHandler) ServeFile(c *gin.Context) {
 	}
 }
 
+// openOriginal opens the stored original. JPEG/PNG files uploaded before EXIF
+// stripping was introduced are sanitized on the fly, so location and device data
+// is never served.
+func (h *FileHandler) openOriginal(ctx context.Context, upload *models.Upload) (io.ReadCloser, int64, error) {
+	reader, err := h.storage.Get(ctx, upload.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	if upload.IsMetadataStripped() || (upload.MimeType != "image/jpeg" && upload.MimeType != "image/png") {
+		return reader, upload.Size, nil
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, 0, err
+	}
+	sanitized, err := imageprocessor.NewProcessor(0).SanitizeImage(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return io.NopCloser(bytes.NewReader(sanitized.Data)), int64(len(sanitized.Data)), nil
+}
+
 // ServeResizedImage serves a resized version of an image
 func (h *FileHandler) ServeResizedImage(c *gin.Context) {
 	uploadID := c.Param("uploadId")
 	size := c.Param("size")
 
 	// Validate size parameter
-	validSizes := map[string]bool{
-		"thumbnail": true,
-		"small":     true,
-		"medium":    true,
-		"large":     true,
-	}
-
-	if !validSizes[size] {
+	imageSize, ok := imageprocessor.SizeByName(size)
+	if !ok {
 		apperrors.HandleError(c, apperrors.NewBadRequestError("Invalid size parameter"))
 		return
 	}
@@ -137,15 +163,15 @@ func (h *FileHandler) ServeResizedImage(c *gin.Context) {
 	}
 
 	// Check access permissions
-	if !upload.IsPublic {
-		userID, exists := c.Get("userID")
-		if !exists || userID.(string) != upload.UserID {
-			userRole, roleExists := c.Get("userRole")
-			if !roleExists || userRole.(string) != string(models.UserRoleAdmin) {
-				apperrors.HandleError(c, apperrors.NewForbiddenError("Access denied"))
-				return
-			}
-		}
+	if (!upload.IsPublic || !upload.IsApproved()) && !h.isOwnerOrAdmin(c, upload) {
+		apperrors.HandleError(c, apperrors.NewForbiddenError("Access denied"))
+		return
+	}
+
+	// Public derivatives of protected photos are watermarked
+	if h.needsWatermark(c, upload) {
+		h.serveWatermarked(c, upload, imageSize)
+		return
 	}
 
 	// Check if resized version exists in storage
@@ -162,7 +188,7 @@ func (h *FileHandler) ServeResizedImage(c *gin.Context) {
 		}
 	} else {
 		// Serve original (resizing on-the-fly can be added later)
-		reader, err = h.storage.Get(c.Request.Context(), upload.Path)
+		reader, _, err = h.openOriginal(c.Request.Context(), upload)
 		if err != nil {
 			apperrors.HandleError(c, apperrors.NewNotFoundError("File not found in storage"))
 			return
@@ -172,7 +198,7 @@ func (h *FileHandler) ServeResizedImage(c *gin.Context) {
 
 	// Set headers
 	c.Header("Content-Type", upload.MimeType)
-	c.Header("Cache-Control", "public, max-age=31536000")
+	c.Header("Cache-Control", h.cacheControl(upload))
 	c.Header("ETag", fmt.Sprintf(`"%s-%s"`, upload.ID, size))
 	c.Header("Content-Disposition", "inline")
 
@@ -182,6 +208,95 @@ func (h *FileHandler) ServeResizedImage(c *gin.Context) {
 	}
 }
 
+// serveWatermarked serves the watermarked derivative of an image, rendering it
+// from the original and caching it in storage on first request
+func (h *FileHandler) serveWatermarked(c *gin.Context, upload *models.Upload, size imageprocessor.ImageSize) {
+	ctx := c.Request.Context()
+	watermarkedPath := h.getResizedPath(upload.Path, imageprocessor.WatermarkedSizeName(size))
+
+	var data []byte
+	if exists, _ := h.storage.Exists(ctx, watermarkedPath); exists {
+		if reader, err := h.storage.Get(ctx, watermarkedPath); err == nil {
+			data, err = io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				data = nil
+			}
+		}
+	}
+
+	if data == nil {
+		original, _, err := h.openOriginal(ctx, upload)
+		if err != nil {
+			apperrors.HandleError(c, apperrors.NewNotFoundError("File not found in storage"))
+			return
+		}
+		defer original.Close()
+
+		processed, err := imageprocessor.NewProcessor(0).WithWatermark(h.watermark).ProcessImage(original, size, "")
+		if err != nil {
+			apperrors.HandleError(c, apperrors.NewInternalServerError("Failed to process image"))
+			return
+		}
+		if data, err = io.ReadAll(processed); err != nil {
+			apperrors.HandleError(c, apperrors.NewInternalServerError("Failed to process image"))
+			return
+		}
+		// Caching is best effort: on failure the next request renders again
+		if err := h.storage.Save(ctx, watermarkedPath, bytes.NewReader(data), upload.MimeType); err != nil {
+			c.Error(err)
+		}
+	}
+
+	c.Header("Content-Type", upload.MimeType)
+	c.Header("Content-Length", strconv.Itoa(len(data)))
+	c.Header("Cache-Control", "public, max-age=31536000")
+	c.Header("ETag", fmt.Sprintf(`"%s-%s"`, upload.ID, imageprocessor.WatermarkedSizeName(size)))
+	c.Header("Content-Disposition", "inline")
+	c.Data(http.StatusOK, upload.MimeType, data)
+}
+
+// needsWatermark reports whether the viewer must get a watermarked copy: public
+// portfolio photos are watermarked for everyone except the owner, admins and
+// employers who accepted the model's response
+func (h *FileHandler) needsWatermark(c *gin.Context, upload *models.Upload) bool {
+	if h.watermark == nil || !isWatermarkedUpload(upload) || h.isOwnerOrAdmin(c, upload) {
+		return false
+	}
+	if userID := optionalUserID(c); userID != "" {
+		accepted, err := h.responseRepo.HasAcceptedResponse(h.GetDB(c), userID, upload.UserID)
+		if err == nil && accepted {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheControl keeps clean copies of watermarked photos out of shared caches,
+// since the same URL serves a watermarked version to other viewers
+func (h *FileHandler) cacheControl(upload *models.Upload) string {
+	if h.watermark != nil && isWatermarkedUpload(upload) {
+		return "private, max-age=3600"
+	}
+	return "public, max-age=31536000" // Cache for 1 year
+}
+
+// isOwnerOrAdmin reports whether the current user owns the upload or is an admin
+func (h *FileHandler) isOwnerOrAdmin(c *gin.Context, upload *models.Upload) bool {
+	if userID := optionalUserID(c); userID != "" && userID == upload.UserID {
+		return true
+	}
+	role, _ := c.Get("role")
+	roleStr, _ := role.(string)
+	return roleStr == string(models.UserRoleAdmin)
+}
+
+// isWatermarkedUpload - public portfolio photos in formats the processor can render
+func isWatermarkedUpload(upload *models.Upload) bool {
+	return upload.IsPublic && upload.Usage == "portfolio_photo" &&
+		(upload.MimeType == "image/jpeg" || upload.MimeType == "image/png")
+}
+
 // GetSignedURL generates a temporary signed URL for private files
 func (h *FileHandler) GetSignedURL(c *gin.Context) {
 	userID, ok := h.GetAndAuthorizeUserID(c)
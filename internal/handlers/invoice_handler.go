@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvoiceHandler - счета и кредит-ноты по платежам (PDF)
+type InvoiceHandler struct {
+	*BaseHandler
+	invoiceService services.InvoiceService
+}
+
+func NewInvoiceHandler(base *BaseHandler, invoiceService services.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		BaseHandler:    base,
+		invoiceService: invoiceService,
+	}
+}
+
+func (h *InvoiceHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - владелец платежа или администратор
+	payments := r.Group("/payments")
+	payments.Use(middleware.AuthMiddleware())
+	{
+		payments.GET("/:paymentId/invoice", h.DownloadInvoice)
+		payments.GET("/:paymentId/credit-note", h.DownloadCreditNote)
+	}
+}
+
+// DownloadInvoice - счет по оплаченному платежу
+func (h *InvoiceHandler) DownloadInvoice(c *gin.Context) {
+	h.download(c, models.InvoiceKindInvoice)
+}
+
+// DownloadCreditNote - кредит-нота по возвращенному платежу
+func (h *InvoiceHandler) DownloadCreditNote(c *gin.Context) {
+	h.download(c, models.InvoiceKindCreditNote)
+}
+
+func (h *InvoiceHandler) download(c *gin.Context, kind string) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	file, err := h.invoiceService.GetInvoicePDF(h.GetDB(c), c.Param("paymentId"), userID, kind)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LedgerHandler - журнал двойной записи: кошелек пользователя, счета, проводки и сверка с провайдером
+type LedgerHandler struct {
+	*BaseHandler
+	ledgerService services.LedgerService
+}
+
+func NewLedgerHandler(base *BaseHandler, ledgerService services.LedgerService) *LedgerHandler {
+	return &LedgerHandler{
+		BaseHandler:   base,
+		ledgerService: ledgerService,
+	}
+}
+
+func (h *LedgerHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - собственный кошелек
+	ledger := r.Group("/ledger")
+	ledger.Use(middleware.AuthMiddleware())
+	{
+		ledger.GET("/wallet", h.GetMyWallet)
+	}
+
+	// Admin only
+	adminLedger := r.Group("/admin/ledger")
+	adminLedger.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleAdmin))
+	{
+		adminLedger.GET("/accounts", h.ListAccounts)
+		adminLedger.GET("/accounts/:accountId/postings", h.GetAccountPostings)
+		adminLedger.GET("/entries/:entryId", h.GetEntry)
+		adminLedger.POST("/reconciliation", h.Reconcile)
+	}
+}
+
+// GetMyWallet - остаток и движения по кошельку текущего пользователя
+func (h *LedgerHandler) GetMyWallet(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var filter dto.LedgerPostingFilter
+	if !h.BindAndValidate_Query(c, &filter) {
+		return
+	}
+
+	wallet, err := h.ledgerService.GetMyWallet(h.GetDB(c), userID, &filter)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wallet)
+}
+
+// ListAccounts - счета с остатками и контроль баланса журнала
+func (h *LedgerHandler) ListAccounts(c *gin.Context) {
+	if _, ok := h.GetAndAuthorizeUserID(c); !ok {
+		return
+	}
+
+	var filter dto.LedgerAccountFilter
+	if !h.BindAndValidate_Query(c, &filter) {
+		return
+	}
+
+	accounts, err := h.ledgerService.ListAccounts(h.GetDB(c), &filter)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+// GetAccountPostings - выписка по счету
+func (h *LedgerHandler) GetAccountPostings(c *gin.Context) {
+	if _, ok := h.GetAndAuthorizeUserID(c); !ok {
+		return
+	}
+
+	var filter dto.LedgerPostingFilter
+	if !h.BindAndValidate_Query(c, &filter) {
+		return
+	}
+
+	postings, err := h.ledgerService.GetAccountPostings(h.GetDB(c), c.Param("accountId"), &filter)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, postings)
+}
+
+// GetEntry - проводка со всеми строками
+func (h *LedgerHandler) GetEntry(c *gin.Context) {
+	if _, ok := h.GetAndAuthorizeUserID(c); !ok {
+		return
+	}
+
+	entry, err := h.ledgerService.GetEntry(h.GetDB(c), c.Param("entryId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// Reconcile - сверка журнала с выпиской платежного провайдера
+func (h *LedgerHandler) Reconcile(c *gin.Context) {
+	if _, ok := h.GetAndAuthorizeUserID(c); !ok {
+		return
+	}
+
+	var req dto.ReconciliationRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	report, err := h.ledgerService.Reconcile(h.GetDB(c), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -91,7 +91,8 @@ func (h *MatchingHandler) FindMatchingModels(c *gin.Context) {
 }
 
 func (h *MatchingHandler) FindModelsByCriteria(c *gin.Context) {
-	if _, ok := h.GetAndAuthorizeUserID(c); !ok {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
 		return
 	}
 
@@ -107,6 +108,7 @@ func (h *MatchingHandler) FindModelsByCriteria(c *gin.Context) {
 	if criteria.MinScore == 0 {
 		criteria.MinScore = 50.0
 	}
+	criteria.HiddenForUserID = userID
 
 	// ✅ DB: Используем h.GetDB(c)
 	matches, err := h.matchingService.FindModelsByCriteria(h.GetDB(c), &criteria)
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationHandler - черный список пользователей, жалобы, очередь модерации жалоб и фото
+type ModerationHandler struct {
+	*BaseHandler
+	blockService           services.BlockService
+	reportService          services.ReportService
+	photoModerationService services.PhotoModerationService
+}
+
+func NewModerationHandler(base *BaseHandler, blockService services.BlockService, reportService services.ReportService, photoModerationService services.PhotoModerationService) *ModerationHandler {
+	return &ModerationHandler{
+		BaseHandler:            base,
+		blockService:           blockService,
+		reportService:          reportService,
+		photoModerationService: photoModerationService,
+	}
+}
+
+func (h *ModerationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - All authenticated users
+	blocks := r.Group("/blocks")
+	blocks.Use(middleware.AuthMiddleware())
+	{
+		blocks.GET("", h.GetBlockedUsers)
+		blocks.POST("/:userId", h.BlockUser)
+		blocks.DELETE("/:userId", h.UnblockUser)
+	}
+
+	reports := r.Group("/reports")
+	reports.Use(middleware.AuthMiddleware())
+	{
+		reports.POST("", h.CreateReport)
+	}
+
+	// Admin only
+	admin := r.Group("/admin/reports")
+	admin.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleAdmin))
+	{
+		admin.GET("", h.ListReports)
+		admin.GET("/:reportId", h.GetReport)
+		admin.POST("/:reportId/resolve", h.ResolveReport)
+		admin.POST("/:reportId/dismiss", h.DismissReport)
+	}
+
+	photos := r.Group("/admin/photos")
+	photos.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleAdmin))
+	{
+		photos.GET("", h.GetPhotoQueue)
+		photos.POST("/approve", h.ApprovePhotos)
+		photos.POST("/reject", h.RejectPhotos)
+		photos.PUT("/trusted/:userId", h.SetPhotoAutoApprove)
+		photos.GET("/duplicates", h.GetDuplicatePhotoMatches)
+		photos.POST("/duplicates/:matchId/resolve", h.ResolveDuplicatePhotoMatch)
+	}
+}
+
+// --- Blocks ---
+
+func (h *ModerationHandler) GetBlockedUsers(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ParsePagination(c)
+
+	users, total, err := h.blockService.GetBlockedUsers(h.GetDB(c), userID, page, pageSize)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blocked": users,
+		"total":   total,
+		"page":    page,
+		"pages":   (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// BlockUser - заблокированный не сможет писать, откликаться на кастинги и видеть профиль
+func (h *ModerationHandler) BlockUser(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	block, err := h.blockService.BlockUser(h.GetDB(c), userID, c.Param("userId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, block)
+}
+
+func (h *ModerationHandler) UnblockUser(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.blockService.UnblockUser(h.GetDB(c), userID, c.Param("userId")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User unblocked"})
+}
+
+// --- Reports ---
+
+// CreateReport - жалоба на пользователя, кастинг, сообщение, отзыв или работу в портфолио
+func (h *ModerationHandler) CreateReport(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateReportRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	report, err := h.reportService.CreateReport(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// --- Admin ---
+
+func (h *ModerationHandler) ListReports(c *gin.Context) {
+	var query dto.ReportQueueQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+	query.Page, query.PageSize = ParsePagination(c)
+
+	reports, total, err := h.reportService.ListReports(h.GetDB(c), &query)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reports": reports,
+		"total":   total,
+		"page":    query.Page,
+		"pages":   (total + int64(query.PageSize) - 1) / int64(query.PageSize),
+	})
+}
+
+func (h *ModerationHandler) GetReport(c *gin.Context) {
+	report, err := h.reportService.GetReport(h.GetDB(c), c.Param("reportId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *ModerationHandler) ResolveReport(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReportDecisionRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	report, err := h.reportService.ResolveReport(h.GetDB(c), adminID, c.Param("reportId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *ModerationHandler) DismissReport(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReportDecisionRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	report, err := h.reportService.DismissReport(h.GetDB(c), adminID, c.Param("reportId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// --- Photo moderation ---
+
+func (h *ModerationHandler) GetPhotoQueue(c *gin.Context) {
+	var query dto.PhotoModerationQueueQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+	query.Page, query.PageSize = ParsePagination(c)
+
+	photos, total, err := h.photoModerationService.GetQueue(c.Request.Context(), h.GetDB(c), &query)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"photos": photos,
+		"total":  total,
+		"page":   query.Page,
+		"pages":  (total + int64(query.PageSize) - 1) / int64(query.PageSize),
+	})
+}
+
+// ApprovePhotos - массовое одобрение; по каждому фото возвращается отдельный результат
+func (h *ModerationHandler) ApprovePhotos(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ApprovePhotosRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	result, err := h.photoModerationService.ApprovePhotos(h.GetDB(c), adminID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RejectPhotos - массовое отклонение с причиной, которую увидят владельцы фото
+func (h *ModerationHandler) RejectPhotos(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RejectPhotosRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	result, err := h.photoModerationService.RejectPhotos(h.GetDB(c), adminID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ModerationHandler) SetPhotoAutoApprove(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PhotoAutoApproveRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	if err := h.photoModerationService.SetAutoApprove(h.GetDB(c), adminID, c.Param("userId"), &req); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Photo auto-approval updated", "enabled": req.Enabled})
+}
+
+// GetDuplicatePhotoMatches - фото, похожие на фото других пользователей
+func (h *ModerationHandler) GetDuplicatePhotoMatches(c *gin.Context) {
+	var query dto.DuplicateMatchQueueQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+	query.Page, query.PageSize = ParsePagination(c)
+
+	matches, total, err := h.photoModerationService.GetDuplicateMatches(c.Request.Context(), h.GetDB(c), &query)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"matches": matches,
+		"total":   total,
+		"page":    query.Page,
+		"pages":   (total + int64(query.PageSize) - 1) / int64(query.PageSize),
+	})
+}
+
+// ResolveDuplicatePhotoMatch - подтверждение копии (фото отклоняется) или ложное срабатывание
+func (h *ModerationHandler) ResolveDuplicatePhotoMatch(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ResolveDuplicateMatchRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	match, err := h.photoModerationService.ResolveDuplicateMatch(h.GetDB(c), adminID, c.Param("matchId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
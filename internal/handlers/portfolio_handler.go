@@ -1,15 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
 	"mwork_backend/internal/middleware"
-	// "mwork_backend/internal/models" // (Больше не нужен, т.к. admin middleware ушло)
+	"mwork_backend/internal/models"
 	"mwork_backend/internal/services"
 	"mwork_backend/internal/services/dto"
 	"mwork_backend/pkg/apperrors"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type PortfolioHandler struct {
@@ -29,8 +31,10 @@ func (h *PortfolioHandler) RegisterRoutes(r *gin.RouterGroup) {
 	// Public routes
 	public := r.Group("/portfolio")
 	{
-		public.GET("/:itemId", h.GetPortfolioItem)
-		public.GET("/model/:modelId", h.GetModelPortfolio)
+		public.GET("/:itemId", middleware.OptionalAuthMiddleware(), h.GetPortfolioItem)
+		public.GET("/model/:modelId", middleware.OptionalAuthMiddleware(), h.GetModelPortfolio)
+		public.GET("/model/:modelId/albums", middleware.OptionalAuthMiddleware(), h.GetModelAlbums)
+		public.GET("/albums/:albumId", middleware.OptionalAuthMiddleware(), h.GetAlbum)
 		public.GET("/featured", h.GetFeaturedPortfolio)
 		public.GET("/recent", h.GetRecentPortfolio)
 	}
@@ -43,8 +47,26 @@ func (h *PortfolioHandler) RegisterRoutes(r *gin.RouterGroup) {
 		portfolio.PUT("/:itemId", h.UpdatePortfolioItem)
 		portfolio.DELETE("/:itemId", h.DeletePortfolioItem)
 		portfolio.PUT("/reorder", h.UpdatePortfolioOrder)
+		portfolio.PUT("/move", h.MovePortfolioItems)
 		portfolio.PUT("/:itemId/visibility", h.TogglePortfolioVisibility)
 		portfolio.GET("/stats/:modelId", h.GetPortfolioStats)
+
+		portfolio.GET("/saved", h.GetSavedItems)
+		portfolio.POST("/:itemId/like", h.LikeItem)
+		portfolio.DELETE("/:itemId/like", h.UnlikeItem)
+		portfolio.POST("/:itemId/save", h.SaveItem)
+		portfolio.DELETE("/:itemId/save", h.UnsaveItem)
+
+		portfolio.POST("/albums", h.CreateAlbum)
+		portfolio.PUT("/albums/:albumId", h.UpdateAlbum)
+		portfolio.DELETE("/albums/:albumId", h.DeleteAlbum)
+	}
+
+	// Admin only
+	admin := r.Group("/admin/portfolio")
+	admin.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleAdmin))
+	{
+		admin.POST("/featured/recompute", h.RecomputeFeatured)
 	}
 
 	// ▼▼▼ УДАЛЕНО: Все маршруты /uploads и /admin/uploads ▼▼▼
@@ -93,7 +115,7 @@ func (h *PortfolioHandler) GetPortfolioItem(c *gin.Context) {
 	itemID := c.Param("itemId")
 
 	// ✅ DB + Context
-	response, err := h.portfolioService.GetPortfolioItem(c.Request.Context(), h.GetDB(c), itemID)
+	response, err := h.portfolioService.GetPortfolioItem(c.Request.Context(), h.GetDB(c), itemID, optionalUserID(c))
 	if err != nil {
 		h.HandleServiceError(c, err)
 		return
@@ -106,7 +128,7 @@ func (h *PortfolioHandler) GetModelPortfolio(c *gin.Context) {
 	modelID := c.Param("modelId")
 
 	// ✅ DB + Context
-	responses, err := h.portfolioService.GetModelPortfolio(c.Request.Context(), h.GetDB(c), modelID)
+	responses, err := h.portfolioService.GetModelPortfolio(c.Request.Context(), h.GetDB(c), modelID, optionalUserID(c))
 	if err != nil {
 		h.HandleServiceError(c, err)
 		return
@@ -244,6 +266,171 @@ func (h *PortfolioHandler) GetRecentPortfolio(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// --- Album handlers ---
+
+func (h *PortfolioHandler) CreateAlbum(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateAlbumRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	album, err := h.portfolioService.CreateAlbum(c.Request.Context(), h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, album)
+}
+
+func (h *PortfolioHandler) GetModelAlbums(c *gin.Context) {
+	albums, err := h.portfolioService.GetModelAlbums(c.Request.Context(), h.GetDB(c), c.Param("modelId"), optionalUserID(c))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"albums": albums,
+		"total":  len(albums),
+	})
+}
+
+func (h *PortfolioHandler) GetAlbum(c *gin.Context) {
+	album, err := h.portfolioService.GetAlbum(c.Request.Context(), h.GetDB(c), c.Param("albumId"), optionalUserID(c))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, album)
+}
+
+func (h *PortfolioHandler) UpdateAlbum(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateAlbumRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	album, err := h.portfolioService.UpdateAlbum(c.Request.Context(), h.GetDB(c), userID, c.Param("albumId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, album)
+}
+
+func (h *PortfolioHandler) DeleteAlbum(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.portfolioService.DeleteAlbum(c.Request.Context(), h.GetDB(c), userID, c.Param("albumId")); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Album deleted successfully"})
+}
+
+// MovePortfolioItems - перенос элементов в альбом или обратно в общий список
+func (h *PortfolioHandler) MovePortfolioItems(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.MovePortfolioItemsRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	if err := h.portfolioService.MovePortfolioItems(c.Request.Context(), h.GetDB(c), userID, &req); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Portfolio items moved successfully"})
+}
+
+// --- Likes & saves ---
+
+func (h *PortfolioHandler) LikeItem(c *gin.Context) {
+	h.react(c, h.portfolioService.LikeItem)
+}
+
+func (h *PortfolioHandler) UnlikeItem(c *gin.Context) {
+	h.react(c, h.portfolioService.UnlikeItem)
+}
+
+func (h *PortfolioHandler) SaveItem(c *gin.Context) {
+	h.react(c, h.portfolioService.SaveItem)
+}
+
+func (h *PortfolioHandler) UnsaveItem(c *gin.Context) {
+	h.react(c, h.portfolioService.UnsaveItem)
+}
+
+func (h *PortfolioHandler) GetSavedItems(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ParsePagination(c)
+
+	items, total, err := h.portfolioService.GetSavedItems(c.Request.Context(), h.GetDB(c), userID, page, pageSize)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+		"total": total,
+		"page":  page,
+		"pages": (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// RecomputeFeatured - внеочередной пересчет рейтинга featured (обычно его делает фоновая задача)
+func (h *PortfolioHandler) RecomputeFeatured(c *gin.Context) {
+	featured, err := h.portfolioService.RecomputeFeaturedScores(c.Request.Context(), h.GetDB(c))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"featured": featured})
+}
+
+// react - общий обработчик лайков и сохранений
+func (h *PortfolioHandler) react(c *gin.Context, action func(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error)) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	response, err := action(c.Request.Context(), h.GetDB(c), userID, c.Param("itemId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // --- ▼▼▼ УДАЛЕНО: Все обработчики Uploads ▼▼▼ ---
 //
 // func (h *PortfolioHandler) UploadFile(c *gin.Context) { ... }
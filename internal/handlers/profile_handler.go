@@ -32,9 +32,9 @@ func (h *ProfileHandler) RegisterRoutes(r *gin.RouterGroup) {
 	// Public routes
 	public := r.Group("/profiles")
 	{
-		public.GET("/:userId", h.GetProfile)
-		public.GET("/models/search", h.SearchModels)
-		public.GET("/employers/search", h.SearchEmployers)
+		public.GET("/:userId", middleware.OptionalAuthMiddleware(), h.GetProfile)
+		public.GET("/models/search", middleware.OptionalAuthMiddleware(), h.SearchModels)
+		public.GET("/employers/search", middleware.OptionalAuthMiddleware(), h.SearchEmployers)
 	}
 
 	// Protected routes
@@ -46,6 +46,7 @@ func (h *ProfileHandler) RegisterRoutes(r *gin.RouterGroup) {
 		profiles.PUT("/me", h.UpdateMyProfile)
 		profiles.PUT("/me/visibility", h.ToggleVisibility)
 		profiles.GET("/me/stats", h.GetMyStats)
+		profiles.GET("/me/completeness", h.GetMyCompleteness)
 	}
 }
 
@@ -114,6 +115,7 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(profile, RequestLocale(c))
 
 	c.JSON(http.StatusOK, profile)
 }
@@ -174,6 +176,8 @@ func (h *ProfileHandler) SearchModels(c *gin.Context) {
 
 	// 8. Use ParsePagination
 	criteria.Page, criteria.PageSize = ParsePagination(c)
+	criteria.RequesterID = optionalUserID(c)
+	criteria.Locale = RequestLocale(c)
 
 	// ✅ DB: Используем h.GetDB(c)
 	// ⭐ ИСПРАВЛЕНИЕ: передаем &criteria
@@ -182,6 +186,7 @@ func (h *ProfileHandler) SearchModels(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(paginatedResponse, criteria.Locale)
 
 	// ⭐ ИСПРАВЛЕНИЕ: Ответ адаптирован под dto.PaginatedResponse
 	c.JSON(http.StatusOK, gin.H{
@@ -200,6 +205,7 @@ func (h *ProfileHandler) SearchEmployers(c *gin.Context) {
 	}
 
 	criteria.Page, criteria.PageSize = ParsePagination(c)
+	criteria.RequesterID = optionalUserID(c)
 
 	// ✅ DB: Используем h.GetDB(c)
 	// ⭐ ИСПРАВЛЕНИЕ: передаем &criteria
@@ -220,6 +226,22 @@ func (h *ProfileHandler) SearchEmployers(c *gin.Context) {
 
 // --- Stats handlers ---
 
+// GetMyCompleteness - балл заполненности профиля модели и чек-лист недостающих пунктов
+func (h *ProfileHandler) GetMyCompleteness(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	completeness, err := h.profileService.GetMyCompleteness(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, completeness)
+}
+
 func (h *ProfileHandler) GetMyStats(c *gin.Context) {
 	userID, ok := h.GetAndAuthorizeUserID(c)
 	if !ok {
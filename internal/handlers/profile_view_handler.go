@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileViewHandler - "кто смотрел мой профиль" и анонимный просмотр для работодателей
+type ProfileViewHandler struct {
+	*BaseHandler
+	profileViewService services.ProfileViewService
+}
+
+func NewProfileViewHandler(base *BaseHandler, profileViewService services.ProfileViewService) *ProfileViewHandler {
+	return &ProfileViewHandler{
+		BaseHandler:        base,
+		profileViewService: profileViewService,
+	}
+}
+
+func (h *ProfileViewHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Model only (тариф с функцией profile_viewers)
+	viewers := r.Group("/profiles/me/viewers")
+	viewers.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleModel))
+	{
+		viewers.GET("", h.GetMyViewers)
+		viewers.GET("/summary", h.GetMyViewSummary)
+	}
+
+	// Protected routes - Employer only
+	privacy := r.Group("/employer/privacy")
+	privacy.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer))
+	{
+		privacy.GET("", h.GetPrivacySettings)
+		privacy.PUT("", h.UpdatePrivacySettings)
+	}
+}
+
+// --- Model ---
+
+// GetMyViewers - работодатели, смотревшие профиль за последние 30 дней
+func (h *ProfileViewHandler) GetMyViewers(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := ParsePagination(c)
+
+	viewers, total, err := h.profileViewService.GetMyViewers(h.GetDB(c), userID, page, pageSize)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"viewers": viewers,
+		"total":   total,
+		"page":    page,
+		"pages":   (total + int64(pageSize) - 1) / int64(pageSize),
+	})
+}
+
+// GetMyViewSummary - просмотры за неделю (включая анонимные) и динамика к прошлой неделе
+func (h *ProfileViewHandler) GetMyViewSummary(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.profileViewService.GetMyViewSummary(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// --- Employer ---
+
+func (h *ProfileViewHandler) GetPrivacySettings(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.profileViewService.GetPrivacySettings(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdatePrivacySettings - включение анонимного просмотра профилей моделей
+func (h *ProfileViewHandler) UpdatePrivacySettings(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.EmployerPrivacySettings
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	settings, err := h.profileViewService.UpdatePrivacySettings(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionHandler struct {
+	*BaseHandler
+	promotionService services.PromotionService
+}
+
+func NewPromotionHandler(base *BaseHandler, promotionService services.PromotionService) *PromotionHandler {
+	return &PromotionHandler{
+		BaseHandler:      base,
+		promotionService: promotionService,
+	}
+}
+
+func (h *PromotionHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Employer only
+	castings := r.Group("/castings")
+	castings.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer, models.UserRoleAdmin))
+	{
+		castings.POST("/:castingId/promotions", h.CreatePromotion)
+		castings.GET("/:castingId/promotions", h.GetCastingPromotions)
+	}
+
+	promotions := r.Group("/promotions")
+	promotions.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer, models.UserRoleAdmin))
+	{
+		promotions.GET("/my", h.GetMyPromotions)
+	}
+}
+
+// CreatePromotion - продвигает кастинг (через оплату или за счет тарифа)
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	castingID := c.Param("castingId")
+
+	var req dto.CreatePromotionRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	result, err := h.promotionService.CreatePromotion(h.GetDB(c), castingID, userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetCastingPromotions - история продвижений кастинга со статистикой показов и кликов
+func (h *PromotionHandler) GetCastingPromotions(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	castingID := c.Param("castingId")
+
+	result, err := h.promotionService.GetCastingPromotions(h.GetDB(c), castingID, userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetMyPromotions - все продвижения текущего работодателя
+func (h *PromotionHandler) GetMyPromotions(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	promotions, err := h.promotionService.GetMyPromotions(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"promotions": promotions,
+		"total":      len(promotions),
+	})
+}
@@ -17,4 +17,17 @@ type AppHandlers struct {
 	ChatHandler         *ChatHandler
 	FileHandler         *FileHandler
 	UploadHandler       *UploadHandler
+	PromotionHandler    *PromotionHandler
+	EmployerNoteHandler *EmployerNoteHandler
+	ShortlistHandler    *ShortlistHandler
+	BookingHandler      *BookingHandler
+	AvailabilityHandler *AvailabilityHandler
+	LedgerHandler       *LedgerHandler
+	InvoiceHandler      *InvoiceHandler
+	CompCardHandler     *CompCardHandler
+	AgencyHandler       *AgencyHandler
+	VerificationHandler *VerificationHandler
+	ShareHandler        *ShareHandler
+	ProfileViewHandler  *ProfileViewHandler
+	ModerationHandler   *ModerationHandler
 }
@@ -32,13 +32,16 @@ func (h *ResponseHandler) RegisterRoutes(r *gin.RouterGroup) {
 		// Model routes
 		responses.POST("/castings/:castingId", middleware.RoleMiddleware(models.UserRoleModel), h.CreateResponse)
 		responses.GET("/my", middleware.RoleMiddleware(models.UserRoleModel), h.GetMyResponses)
-		responses.DELETE("/:responseId", middleware.RoleMiddleware(models.UserRoleModel), h.DeleteResponse)
+		responses.POST("/:responseId/withdraw", middleware.RoleMiddleware(models.UserRoleModel), h.WithdrawResponse)
+		responses.DELETE("/:responseId", middleware.RoleMiddleware(models.UserRoleModel), h.WithdrawResponse) // legacy: тоже отзыв, а не удаление
 
 		// Employer routes
 		responses.GET("/castings/:castingId/list", middleware.RoleMiddleware(models.UserRoleEmployer), h.GetCastingResponses)
 		responses.PUT("/:responseId/status", middleware.RoleMiddleware(models.UserRoleEmployer), h.UpdateResponseStatus)
 		responses.PUT("/:responseId/viewed", middleware.RoleMiddleware(models.UserRoleEmployer), h.MarkResponseAsViewed)
 		responses.GET("/castings/:castingId/stats", middleware.RoleMiddleware(models.UserRoleEmployer), h.GetResponseStats)
+		responses.POST("/castings/:castingId/reject-pending", middleware.RoleMiddleware(models.UserRoleEmployer), h.BulkRejectPending)
+		responses.POST("/castings/:castingId/bulk-status", middleware.RoleMiddleware(models.UserRoleEmployer), h.BulkUpdateStatus)
 
 		// Common routes
 		responses.GET("/:responseId", h.GetResponse)
@@ -91,20 +94,27 @@ func (h *ResponseHandler) GetMyResponses(c *gin.Context) {
 	})
 }
 
-func (h *ResponseHandler) DeleteResponse(c *gin.Context) {
+// WithdrawResponse - модель отзывает отклик (причина необязательна).
+// Отклик остается у работодателя со статусом withdrawn.
+func (h *ResponseHandler) WithdrawResponse(c *gin.Context) {
 	modelID, ok := h.GetAndAuthorizeUserID(c)
 	if !ok {
 		return
 	}
 	responseID := c.Param("responseId")
 
+	var req dto.WithdrawResponseRequest
+	if c.Request.ContentLength > 0 && !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
 	// ✅ DB: Используем h.GetDB(c)
-	if err := h.responseService.DeleteResponse(h.GetDB(c), modelID, responseID); err != nil {
+	if err := h.responseService.WithdrawResponse(h.GetDB(c), modelID, responseID, &req); err != nil {
 		h.HandleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Response deleted successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Response withdrawn successfully"})
 }
 
 // --- Employer handlers ---
@@ -116,8 +126,14 @@ func (h *ResponseHandler) GetCastingResponses(c *gin.Context) {
 	}
 	castingID := c.Param("castingId")
 
+	// Фильтры по приватным пометкам работодателя (tag, min_rating, has_note)
+	var filter dto.ResponseListFilter
+	if !h.BindAndValidate_Query(c, &filter) {
+		return
+	}
+
 	// ✅ DB: Используем h.GetDB(c)
-	responses, err := h.responseService.GetCastingResponses(h.GetDB(c), castingID, employerID)
+	responses, err := h.responseService.GetCastingResponses(h.GetDB(c), castingID, employerID, &filter)
 	if err != nil {
 		h.HandleServiceError(c, err)
 		return
@@ -186,6 +202,51 @@ func (h *ResponseHandler) GetResponseStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+func (h *ResponseHandler) BulkRejectPending(c *gin.Context) {
+	employerID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	castingID := c.Param("castingId")
+
+	// Тело необязательно: без него отклики отклоняются со стандартным уведомлением
+	var req dto.BulkRejectPendingRequest
+	if c.Request.ContentLength > 0 && !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	// ✅ DB: Используем h.GetDB(c)
+	result, err := h.responseService.BulkRejectPending(h.GetDB(c), employerID, castingID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *ResponseHandler) BulkUpdateStatus(c *gin.Context) {
+	employerID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	castingID := c.Param("castingId")
+
+	var req dto.BulkUpdateResponsesRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	// ✅ DB: Используем h.GetDB(c)
+	result, err := h.responseService.BulkUpdateStatus(h.GetDB(c), employerID, castingID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // --- Common handlers ---
 
 func (h *ResponseHandler) GetResponse(c *gin.Context) {
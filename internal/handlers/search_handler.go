@@ -29,17 +29,17 @@ func (h *SearchHandler) RegisterRoutes(r *gin.RouterGroup) {
 	// Public search routes
 	search := r.Group("/search")
 	{
-		search.POST("/castings", h.SearchCastings)
-		search.POST("/castings/advanced", h.SearchCastingsAdvanced)
+		search.POST("/castings", middleware.OptionalAuthMiddleware(), h.SearchCastings)
+		search.POST("/castings/advanced", middleware.OptionalAuthMiddleware(), h.SearchCastingsAdvanced)
 		search.GET("/castings/suggestions", h.GetCastingSearchSuggestions)
 
-		search.POST("/models", h.SearchModels)
-		search.POST("/models/advanced", h.SearchModelsAdvanced)
+		search.POST("/models", middleware.OptionalAuthMiddleware(), h.SearchModels)
+		search.POST("/models/advanced", middleware.OptionalAuthMiddleware(), h.SearchModelsAdvanced)
 		search.GET("/models/suggestions", h.GetModelSearchSuggestions)
 
-		search.POST("/employers", h.SearchEmployers)
+		search.POST("/employers", middleware.OptionalAuthMiddleware(), h.SearchEmployers)
 
-		search.POST("/unified", h.UnifiedSearch)
+		search.POST("/unified", middleware.OptionalAuthMiddleware(), h.UnifiedSearch)
 		search.GET("/autocomplete", h.GetSearchAutoComplete)
 
 		search.GET("/popular", h.GetPopularSearches)
@@ -77,6 +77,8 @@ func (h *SearchHandler) SearchCastings(c *gin.Context) {
 	if req.PageSize == 0 {
 		req.PageSize = 20
 	}
+	req.RequesterID = optionalUserID(c)
+	req.Locale = RequestLocale(c)
 
 	// ▼▼▼ ИЗМЕНЕНО ▼▼▼
 	response, err := h.searchService.SearchCastings(h.GetDB(c), &req)
@@ -85,6 +87,7 @@ func (h *SearchHandler) SearchCastings(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(response, req.Locale)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -101,6 +104,8 @@ func (h *SearchHandler) SearchCastingsAdvanced(c *gin.Context) {
 	if req.PageSize == 0 {
 		req.PageSize = 20
 	}
+	req.RequesterID = optionalUserID(c)
+	req.Locale = RequestLocale(c)
 
 	// ▼▼▼ ИЗМЕНЕНО ▼▼▼
 	response, err := h.searchService.SearchCastingsAdvanced(h.GetDB(c), &req)
@@ -109,6 +114,7 @@ func (h *SearchHandler) SearchCastingsAdvanced(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(response, req.Locale)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -153,6 +159,8 @@ func (h *SearchHandler) SearchModels(c *gin.Context) {
 	if req.PageSize == 0 {
 		req.PageSize = 20
 	}
+	req.RequesterID = optionalUserID(c)
+	req.Locale = RequestLocale(c)
 
 	// ▼▼▼ ИЗМЕНЕНО ▼▼▼
 	response, err := h.searchService.SearchModels(h.GetDB(c), &req)
@@ -161,6 +169,7 @@ func (h *SearchHandler) SearchModels(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(response, req.Locale)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -177,6 +186,8 @@ func (h *SearchHandler) SearchModelsAdvanced(c *gin.Context) {
 	if req.PageSize == 0 {
 		req.PageSize = 20
 	}
+	req.RequesterID = optionalUserID(c)
+	req.Locale = RequestLocale(c)
 
 	// ▼▼▼ ИЗМЕНЕНО ▼▼▼
 	response, err := h.searchService.SearchModelsAdvanced(h.GetDB(c), &req)
@@ -185,6 +196,7 @@ func (h *SearchHandler) SearchModelsAdvanced(c *gin.Context) {
 		h.HandleServiceError(c, err)
 		return
 	}
+	dto.Localize(response, req.Locale)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -229,6 +241,7 @@ func (h *SearchHandler) SearchEmployers(c *gin.Context) {
 	if req.PageSize == 0 {
 		req.PageSize = 20
 	}
+	req.RequesterID = optionalUserID(c)
 
 	// ▼▼▼ ИЗМЕНЕНО ▼▼▼
 	response, err := h.searchService.SearchEmployers(h.GetDB(c), &req)
@@ -255,6 +268,7 @@ func (h *SearchHandler) UnifiedSearch(c *gin.Context) {
 	if req.PageSize == 0 {
 		req.PageSize = 30
 	}
+	req.RequesterID = optionalUserID(c)
 
 	// ▼▼▼ ИЗМЕНЕНО ▼▼▼
 	response, err := h.searchService.UnifiedSearch(h.GetDB(c), &req)
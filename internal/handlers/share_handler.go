@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sharePageTemplate - серверная страница для превью ссылок (Instagram, Telegram, поисковики).
+// html/template экранирует пользовательские данные, в том числе в атрибутах метатегов.
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="ru" prefix="og: https://ogp.me/ns#">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>{{ .Title }}</title>
+    <meta name="description" content="{{ .Description }}">
+    <link rel="canonical" href="{{ .URL }}">
+
+    <meta property="og:type" content="{{ .OGType }}">
+    <meta property="og:site_name" content="{{ .SiteName }}">
+    <meta property="og:locale" content="ru_RU">
+    <meta property="og:title" content="{{ .Title }}">
+    <meta property="og:description" content="{{ .Description }}">
+    <meta property="og:url" content="{{ .URL }}">
+    {{ if .ImageURL }}<meta property="og:image" content="{{ .ImageURL }}">{{ end }}
+
+    <meta name="twitter:card" content="summary_large_image">
+    <meta name="twitter:title" content="{{ .Title }}">
+    <meta name="twitter:description" content="{{ .Description }}">
+    {{ if .ImageURL }}<meta name="twitter:image" content="{{ .ImageURL }}">{{ end }}
+</head>
+<body>
+<main>
+    {{ if .ImageURL }}<img src="{{ .ImageURL }}" alt="{{ .Title }}" width="600">{{ end }}
+    <h1>{{ .Title }}</h1>
+    <p>{{ .Description }}</p>
+    <a href="{{ .AppURL }}">Открыть в {{ .SiteName }}</a>
+</main>
+</body>
+</html>`))
+
+// ShareHandler - короткие адреса профилей и кастингов и публичные страницы для шеринга
+type ShareHandler struct {
+	*BaseHandler
+	shareService services.ShareService
+}
+
+func NewShareHandler(base *BaseHandler, shareService services.ShareService) *ShareHandler {
+	return &ShareHandler{
+		BaseHandler:  base,
+		shareService: shareService,
+	}
+}
+
+func (h *ShareHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Public routes: данные страницы в JSON (для фронтенда)
+	r.GET("/share/:entityType/:slug", h.GetSharePageData)
+
+	// Protected routes - Model and Employer
+	profiles := r.Group("/profiles/me")
+	profiles.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleModel, models.UserRoleEmployer))
+	{
+		profiles.GET("/share", h.GetMyShareLink)
+		profiles.PUT("/slug", h.UpdateMySlug)
+	}
+
+	// Protected routes - Employer only
+	castings := r.Group("/castings")
+	castings.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer))
+	{
+		castings.GET("/:castingId/share", h.GetCastingShareLink)
+		castings.PUT("/:castingId/slug", h.UpdateCastingSlug)
+	}
+}
+
+// RegisterPageRoutes - HTML-страницы вне /api/v1: /share/{models|employers|castings}/:slug
+func (h *ShareHandler) RegisterPageRoutes(r *gin.RouterGroup) {
+	r.GET("/share/:entityType/:slug", h.RenderSharePage)
+}
+
+// --- Owner ---
+
+// GetMyShareLink - ссылка на публичную страницу профиля (адрес создается из имени при первом запросе)
+func (h *ShareHandler) GetMyShareLink(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.shareService.GetMyShareLink(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+func (h *ShareHandler) UpdateMySlug(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateSlugRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	link, err := h.shareService.UpdateMySlug(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+func (h *ShareHandler) GetCastingShareLink(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	link, err := h.shareService.GetCastingShareLink(h.GetDB(c), userID, c.Param("castingId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+func (h *ShareHandler) UpdateCastingSlug(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateSlugRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	link, err := h.shareService.UpdateCastingSlug(h.GetDB(c), userID, c.Param("castingId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// --- Public ---
+
+// GetSharePageData - данные страницы; по прежнему адресу - redirected=true и актуальный url
+func (h *ShareHandler) GetSharePageData(c *gin.Context) {
+	page, err := h.shareService.GetSharePage(c.Request.Context(), h.GetDB(c), c.Param("entityType"), c.Param("slug"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// RenderSharePage - HTML с OpenGraph/Twitter метатегами; прежний адрес - 301 на текущий
+func (h *ShareHandler) RenderSharePage(c *gin.Context) {
+	page, err := h.shareService.GetSharePage(c.Request.Context(), h.GetDB(c), c.Param("entityType"), c.Param("slug"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+	if page.Redirected {
+		c.Redirect(http.StatusMovedPermanently, page.URL)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := sharePageTemplate.Execute(&buf, page); err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
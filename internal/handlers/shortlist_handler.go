@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ShortlistHandler - экспорт шортлиста откликов (PDF контакт-лист / CSV)
+type ShortlistHandler struct {
+	*BaseHandler
+	exportService services.ShortlistExportService
+}
+
+func NewShortlistHandler(base *BaseHandler, exportService services.ShortlistExportService) *ShortlistHandler {
+	return &ShortlistHandler{
+		BaseHandler:   base,
+		exportService: exportService,
+	}
+}
+
+func (h *ShortlistHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Employer only
+	responses := r.Group("/responses")
+	responses.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer, models.UserRoleAdmin))
+	{
+		responses.POST("/castings/:castingId/shortlist/export", h.ExportShortlist)
+	}
+}
+
+// ExportShortlist - отдает файл контакт-листа выбранных откликов
+func (h *ShortlistHandler) ExportShortlist(c *gin.Context) {
+	employerID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+	castingID := c.Param("castingId")
+
+	var req dto.ShortlistExportRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	file, err := h.exportService.ExportShortlist(h.GetDB(c), c.Request.Context(), castingID, employerID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	c.Data(http.StatusOK, file.ContentType, file.Data)
+}
@@ -8,6 +8,7 @@ import (
 	"mwork_backend/internal/middleware" // <-- Все еще нужен для RegisterRoutes
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
 
 	"github.com/gin-gonic/gin"
 )
@@ -84,6 +85,13 @@ func (h *SubscriptionHandler) RegisterRoutes(r *gin.RouterGroup) {
 		adminSubscriptions.GET("/expired", h.GetExpiredSubscriptions)
 		adminSubscriptions.POST("/process-expired", h.ProcessExpiredSubscriptions)
 	}
+
+	// Admin routes - Payment management
+	adminPayments := r.Group("/admin/payments")
+	adminPayments.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware(models.UserRoleAdmin))
+	{
+		adminPayments.POST("/:paymentId/refund", h.RefundPayment)
+	}
 }
 
 // --- Plan handlers ---
@@ -556,3 +564,24 @@ func (h *SubscriptionHandler) ProcessExpiredSubscriptions(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Expired subscriptions processed successfully"})
 }
+
+// RefundPayment - полный возврат платежа; проводится через журнал
+func (h *SubscriptionHandler) RefundPayment(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RefundPaymentRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	payment, err := h.subscriptionService.RefundPayment(h.GetDB(c), adminID, c.Param("paymentId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payment)
+}
@@ -211,8 +211,8 @@ func (h *UploadHandler) GetUpload(c *gin.Context) {
 		return
 	}
 
-	// Проверка доступа
-	if !upload.IsPublic && upload.UserID != userID {
+	// Проверка доступа (фото на модерации видит только владелец)
+	if (!upload.IsPublic || !upload.IsApproved()) && upload.UserID != userID {
 		apperrors.HandleError(c, apperrors.NewForbiddenError("access denied"))
 		return
 	}
@@ -264,10 +264,10 @@ func (h *UploadHandler) GetEntityUploads(c *gin.Context) {
 		return
 	}
 
-	// Фильтруем приватные файлы
+	// Фильтруем приватные файлы и фото, не прошедшие модерацию
 	var filteredUploads []*dto.UploadResponse
 	for _, upload := range uploads {
-		if upload.IsPublic || upload.UserID == userID {
+		if (upload.IsPublic && upload.IsApproved()) || upload.UserID == userID {
 			// Преобразуем в response
 			response := &dto.UploadResponse{
 				ID:         upload.ID,
@@ -281,6 +281,9 @@ func (h *UploadHandler) GetEntityUploads(c *gin.Context) {
 				Size:       upload.Size,
 				IsPublic:   upload.IsPublic,
 				CreatedAt:  upload.CreatedAt,
+
+				ModerationStatus: upload.ModerationStatus,
+				RejectionReason:  upload.RejectionReason,
 			}
 			filteredUploads = append(filteredUploads, response)
 		}
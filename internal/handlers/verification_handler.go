@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"net/http"
+
+	"mwork_backend/internal/middleware"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services"
+	"mwork_backend/internal/services/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VerificationHandler - верификация работодателей: заявка с документами и очередь модерации
+type VerificationHandler struct {
+	*BaseHandler
+	verificationService services.VerificationService
+}
+
+func NewVerificationHandler(base *BaseHandler, verificationService services.VerificationService) *VerificationHandler {
+	return &VerificationHandler{
+		BaseHandler:         base,
+		verificationService: verificationService,
+	}
+}
+
+func (h *VerificationHandler) RegisterRoutes(r *gin.RouterGroup) {
+	// Protected routes - Employer only
+	my := r.Group("/employer/verification")
+	my.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleEmployer))
+	{
+		my.GET("", h.GetMyVerification)
+		my.POST("", h.SubmitVerification)
+		my.PUT("", h.UpdateMyVerification)
+	}
+
+	// Admin only
+	admin := r.Group("/admin/verifications")
+	admin.Use(middleware.AuthMiddleware(), middleware.RequireRoles(models.UserRoleAdmin))
+	{
+		admin.GET("", h.ListVerifications)
+		admin.GET("/:verificationId", h.GetVerification)
+		admin.POST("/:verificationId/approve", h.ApproveVerification)
+		admin.POST("/:verificationId/reject", h.RejectVerification)
+		admin.POST("/:verificationId/request-info", h.RequestMoreInfo)
+	}
+}
+
+// --- Employer ---
+
+// GetMyVerification - последняя заявка с историей статусов и документами
+func (h *VerificationHandler) GetMyVerification(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	verification, err := h.verificationService.GetMyVerification(h.GetDB(c), userID)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// SubmitVerification - заявка с БИН, юр. названием и загруженными документами
+func (h *VerificationHandler) SubmitVerification(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SubmitVerificationRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	verification, err := h.verificationService.SubmitVerification(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, verification)
+}
+
+// UpdateMyVerification - дополнение заявки по запросу модератора
+func (h *VerificationHandler) UpdateMyVerification(c *gin.Context) {
+	userID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateVerificationRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	verification, err := h.verificationService.UpdateMyVerification(h.GetDB(c), userID, &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// --- Admin ---
+
+// ListVerifications - очередь модерации (?status=pending по умолчанию)
+func (h *VerificationHandler) ListVerifications(c *gin.Context) {
+	var query dto.VerificationQueueQuery
+	if !h.BindAndValidate_Query(c, &query) {
+		return
+	}
+	query.Page, query.PageSize = ParsePagination(c)
+
+	verifications, total, err := h.verificationService.ListVerifications(h.GetDB(c), &query)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"verifications": verifications,
+		"total":         total,
+		"page":          query.Page,
+		"pages":         (total + int64(query.PageSize) - 1) / int64(query.PageSize),
+	})
+}
+
+func (h *VerificationHandler) GetVerification(c *gin.Context) {
+	verification, err := h.verificationService.GetVerification(h.GetDB(c), c.Param("verificationId"))
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+func (h *VerificationHandler) ApproveVerification(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ApproveVerificationRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	verification, err := h.verificationService.ApproveVerification(h.GetDB(c), adminID, c.Param("verificationId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+func (h *VerificationHandler) RejectVerification(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewNoteRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	verification, err := h.verificationService.RejectVerification(h.GetDB(c), adminID, c.Param("verificationId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
+
+// RequestMoreInfo - вернуть заявку работодателю за дополнительными документами
+func (h *VerificationHandler) RequestMoreInfo(c *gin.Context) {
+	adminID, ok := h.GetAndAuthorizeUserID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ReviewNoteRequest
+	if !h.BindAndValidate_JSON(c, &req) {
+		return
+	}
+
+	verification, err := h.verificationService.RequestMoreInfo(h.GetDB(c), adminID, c.Param("verificationId"), &req)
+	if err != nil {
+		h.HandleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, verification)
+}
@@ -0,0 +1,172 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"time"
+)
+
+// EXIF tags the pipeline cares about
+const (
+	tagOrientation        = 0x0112
+	tagExifIFD            = 0x8769
+	tagGPSIFD             = 0x8825
+	tagDateTime           = 0x0132
+	tagDateTimeOriginal   = 0x9003
+	tagOffsetTimeOriginal = 0x9011
+
+	exifDateLayout = "2006:01:02 15:04:05"
+	// maxIFDEntries guards against corrupted counts
+	maxIFDEntries = 1000
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// EXIFInfo is the subset of EXIF data the upload pipeline uses
+type EXIFInfo struct {
+	// Orientation is the EXIF orientation tag (1-8); 1 when absent
+	Orientation int
+	// CapturedAt is DateTimeOriginal (or DateTime), nil when absent or unparsable
+	CapturedAt *time.Time
+	// HasGPS reports whether the image carried a GPS block
+	HasGPS bool
+}
+
+// ReadEXIF extracts EXIF data from a JPEG or PNG file. Images without EXIF
+// (or with a corrupted block) return an info with Orientation 1.
+func ReadEXIF(data []byte) EXIFInfo {
+	info := EXIFInfo{Orientation: 1}
+
+	var tiff []byte
+	switch {
+	case isJPEG(data):
+		walkJPEG(data, func(marker byte, payload []byte) {
+			if tiff == nil && marker == 0xE1 && bytes.HasPrefix(payload, exifHeader) {
+				tiff = payload[len(exifHeader):]
+			}
+		})
+	case isPNG(data):
+		walkPNG(data, func(chunkType string, payload []byte) {
+			if tiff == nil && chunkType == "eXIf" {
+				tiff = bytes.TrimPrefix(payload, exifHeader)
+			}
+		})
+	}
+	if tiff != nil {
+		parseTIFF(tiff, &info)
+	}
+	return info
+}
+
+// parseTIFF reads IFD0 and the Exif sub-IFD of a TIFF-structured EXIF block
+func parseTIFF(tiff []byte, info *EXIFInfo) {
+	if len(tiff) < 8 {
+		return
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return
+	}
+
+	var dateTime, dateTimeOriginal, offsetOriginal string
+	exifOffset := uint32(0)
+
+	readIFD(tiff, order, order.Uint32(tiff[4:8]), func(tag, typ uint16, count uint32, value []byte) {
+		switch tag {
+		case tagOrientation:
+			if typ == 3 && len(value) >= 2 {
+				if o := int(order.Uint16(value)); o >= 1 && o <= 8 {
+					info.Orientation = o
+				}
+			}
+		case tagDateTime:
+			dateTime = asciiValue(value)
+		case tagExifIFD:
+			if len(value) >= 4 {
+				exifOffset = order.Uint32(value)
+			}
+		case tagGPSIFD:
+			info.HasGPS = true
+		}
+	})
+
+	if exifOffset != 0 {
+		readIFD(tiff, order, exifOffset, func(tag, typ uint16, count uint32, value []byte) {
+			switch tag {
+			case tagDateTimeOriginal:
+				dateTimeOriginal = asciiValue(value)
+			case tagOffsetTimeOriginal:
+				offsetOriginal = asciiValue(value)
+			}
+		})
+	}
+
+	if dateTimeOriginal == "" {
+		dateTimeOriginal, offsetOriginal = dateTime, ""
+	}
+	info.CapturedAt = parseEXIFTime(dateTimeOriginal, offsetOriginal)
+}
+
+// readIFD calls fn for every entry of the IFD at offset with the entry's value bytes
+// (inline or pointed to). Entries pointing outside the block are skipped.
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32, fn func(tag, typ uint16, count uint32, value []byte)) {
+	if uint64(offset)+2 > uint64(len(tiff)) {
+		return
+	}
+	entries := int(order.Uint16(tiff[offset:]))
+	if entries > maxIFDEntries {
+		return
+	}
+	typeSizes := map[uint16]uint32{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 6: 1, 7: 1, 8: 2, 9: 4, 10: 8, 11: 4, 12: 8}
+
+	for i := 0; i < entries; i++ {
+		start := uint64(offset) + 2 + uint64(i)*12
+		if start+12 > uint64(len(tiff)) {
+			return
+		}
+		entry := tiff[start : start+12]
+		tag, typ, count := order.Uint16(entry[0:2]), order.Uint16(entry[2:4]), order.Uint32(entry[4:8])
+
+		size := uint64(typeSizes[typ]) * uint64(count)
+		value := entry[8:12]
+		if size > 4 {
+			valueOffset := uint64(order.Uint32(entry[8:12]))
+			if valueOffset+size > uint64(len(tiff)) {
+				continue
+			}
+			value = tiff[valueOffset : valueOffset+size]
+		}
+		fn(tag, typ, count, value)
+	}
+}
+
+func asciiValue(value []byte) string {
+	return strings.TrimSpace(strings.TrimRight(string(value), "\x00"))
+}
+
+// parseEXIFTime parses "2006:01:02 15:04:05" with an optional "+05:00" offset;
+// without an offset the camera's local time is taken as UTC
+func parseEXIFTime(value, offset string) *time.Time {
+	if value == "" || strings.HasPrefix(value, "0000") {
+		return nil
+	}
+	if offset != "" {
+		if t, err := time.Parse(exifDateLayout+"-07:00", value+offset); err == nil {
+			return &t
+		}
+	}
+	t, err := time.Parse(exifDateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
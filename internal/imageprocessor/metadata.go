@@ -0,0 +1,256 @@
+package imageprocessor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"time"
+)
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are ancillary PNG chunks that may carry EXIF, GPS, device or
+// authoring data. Color chunks (iCCP, gAMA, sRGB...) are kept.
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true,
+}
+
+// SanitizedImage is an image with metadata removed and orientation applied
+type SanitizedImage struct {
+	Data []byte
+	// Width and Height as displayed (after orientation); 0 when the image can't be decoded
+	Width  int
+	Height int
+	// CapturedAt is the EXIF capture date, if the camera recorded one
+	CapturedAt *time.Time
+	// Rotated reports that pixels were transformed to honor the EXIF orientation
+	Rotated bool
+	// HadGPS reports that location data was found and removed
+	HadGPS bool
+}
+
+// SanitizeImage removes EXIF, XMP, IPTC and comment metadata from a JPEG or PNG
+// file. Metadata is stripped losslessly; the image is re-encoded only when its EXIF
+// orientation requires rotating the pixels. Other formats are returned unchanged.
+// Malformed files are handled leniently: everything after the first unparsable
+// byte is kept as is, so they are never rejected here.
+func (p *Processor) SanitizeImage(data []byte) (*SanitizedImage, error) {
+	info := ReadEXIF(data)
+	result := &SanitizedImage{Data: data, CapturedAt: info.CapturedAt, HadGPS: info.HasGPS}
+
+	switch {
+	case isJPEG(data):
+		result.Data = stripJPEG(data)
+	case isPNG(data):
+		result.Data = stripPNG(data)
+	default:
+		return result, nil
+	}
+
+	if info.Orientation > 1 {
+		img, format, err := image.Decode(bytes.NewReader(data))
+		if err == nil {
+			var buf bytes.Buffer
+			oriented := applyOrientation(img, info.Orientation)
+			switch format {
+			case "png":
+				err = png.Encode(&buf, oriented)
+			default:
+				err = jpeg.Encode(&buf, oriented, &jpeg.Options{Quality: p.quality})
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode oriented image: %w", err)
+			}
+			result.Data = buf.Bytes()
+			result.Rotated = true
+		}
+	}
+
+	if cfg, _, err := image.DecodeConfig(bytes.NewReader(result.Data)); err == nil {
+		result.Width, result.Height = cfg.Width, cfg.Height
+	}
+	return result, nil
+}
+
+// DecodeOriented decodes an image and applies its EXIF orientation, so files
+// stored before sanitization render upright too
+func DecodeOriented(data []byte) (image.Image, string, error) {
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if o := ReadEXIF(data).Orientation; o > 1 {
+		img = applyOrientation(img, o)
+	}
+	return img, format, nil
+}
+
+func isJPEG(data []byte) bool {
+	return len(data) >= 3 && data[0] == 0xFF && data[1] == 0xD8 && data[2] == 0xFF
+}
+
+func isPNG(data []byte) bool {
+	return bytes.HasPrefix(data, pngSignature)
+}
+
+// walkJPEG calls fn for every marker segment before the image data (SOS)
+func walkJPEG(data []byte, fn func(marker byte, payload []byte)) {
+	forEachJPEGSegment(data, func(marker byte, segment []byte) bool {
+		if len(segment) >= 4 {
+			fn(marker, segment[4:])
+		}
+		return true
+	})
+}
+
+// stripJPEG drops APP1-APP15 (EXIF, XMP, IPTC, vendor blocks) and COM segments.
+// APP0 (JFIF), the ICC color profile in APP2 and the Adobe APP14 color transform
+// are kept because they affect how the image renders.
+func stripJPEG(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	rest := forEachJPEGSegment(data, func(marker byte, segment []byte) bool {
+		payload := []byte{}
+		if len(segment) > 4 {
+			payload = segment[4:]
+		}
+		keep := true
+		switch {
+		case marker == 0xFE:
+			keep = false
+		case marker == 0xE2:
+			keep = bytes.HasPrefix(payload, []byte("ICC_PROFILE\x00"))
+		case marker == 0xEE:
+			keep = bytes.HasPrefix(payload, []byte("Adobe"))
+		case marker >= 0xE1 && marker <= 0xEF:
+			keep = false
+		}
+		if keep {
+			out = append(out, segment...)
+		}
+		return true
+	})
+	return append(out, rest...)
+}
+
+// forEachJPEGSegment walks the marker segments after SOI and returns the remaining
+// bytes, starting at SOS (entropy-coded data) or at the first byte it can't parse
+func forEachJPEGSegment(data []byte, fn func(marker byte, segment []byte) bool) []byte {
+	i := 2
+	for i+4 <= len(data) {
+		if data[i] != 0xFF {
+			return data[i:]
+		}
+		marker := data[i+1]
+		if marker == 0xFF { // fill byte
+			i++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 {
+			return data[i:]
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			if !fn(marker, data[i:i+2]) {
+				return data[i+2:]
+			}
+			i += 2
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			return data[i:]
+		}
+		if !fn(marker, data[i:i+2+length]) {
+			return data[i+2+length:]
+		}
+		i += 2 + length
+	}
+	return data[i:]
+}
+
+// walkPNG calls fn for every chunk of a PNG file
+func walkPNG(data []byte, fn func(chunkType string, payload []byte)) {
+	forEachPNGChunk(data, func(chunkType string, chunk []byte) {
+		fn(chunkType, chunk[8:len(chunk)-4])
+	})
+}
+
+// stripPNG drops the text, time and EXIF chunks
+func stripPNG(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	rest := forEachPNGChunk(data, func(chunkType string, chunk []byte) {
+		if !pngMetadataChunks[chunkType] {
+			out = append(out, chunk...)
+		}
+	})
+	return append(out, rest...)
+}
+
+// forEachPNGChunk walks the chunks after the signature and returns any bytes it
+// couldn't parse as chunks
+func forEachPNGChunk(data []byte, fn func(chunkType string, chunk []byte)) []byte {
+	i := len(pngSignature)
+	for i+12 <= len(data) {
+		length := int(binary.BigEndian.Uint32(data[i : i+4]))
+		if length < 0 || i+12+length > len(data) {
+			break
+		}
+		chunkType := string(data[i+4 : i+8])
+		fn(chunkType, data[i:i+12+length])
+		i += 12 + length
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return data[i:]
+}
+
+// applyOrientation transforms pixels so the image displays upright for the given
+// EXIF orientation (2-8: mirrored and/or rotated variants)
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	src := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // needs 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // needs 90 counter-clockwise
+				dx, dy = y, w-1-x
+			}
+			si := src.PixOffset(x, y)
+			di := dst.PixOffset(dx, dy)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,39 @@
+package imageprocessor
+
+import (
+	"image"
+	_ "image/gif" // GIF uploads are hashed too
+
+	"golang.org/x/image/draw"
+)
+
+// DifferenceHash computes a 64-bit perceptual hash (dHash): the image is reduced to
+// a 9x8 grayscale grid and each bit records whether a cell is brighter than its
+// right neighbour. Re-encoding, resizing and small color edits change only a few
+// bits, so near-identical photos have a small Hamming distance.
+func DifferenceHash(img image.Image) uint64 {
+	gray := image.NewGray(image.Rect(0, 0, 9, 8))
+	draw.BiLinear.Scale(gray, gray.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	var hash uint64
+	bit := 0
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if gray.GrayAt(x, y).Y > gray.GrayAt(x+1, y).Y {
+				hash |= 1 << bit
+			}
+			bit++
+		}
+	}
+	return hash
+}
+
+// PerceptualHash decodes an image file (applying EXIF orientation) and returns its
+// difference hash
+func PerceptualHash(data []byte) (uint64, error) {
+	img, _, err := DecodeOriented(data)
+	if err != nil {
+		return 0, err
+	}
+	return DifferenceHash(img), nil
+}
@@ -26,9 +26,25 @@ var (
 	SizeLarge     = ImageSize{Name: "large", Width: 1600, Height: 1600}
 )
 
+// SizeByName looks up a predefined size by its name
+func SizeByName(name string) (ImageSize, bool) {
+	for _, size := range []ImageSize{SizeThumbnail, SizeSmall, SizeMedium, SizeLarge} {
+		if size.Name == name {
+			return size, true
+		}
+	}
+	return ImageSize{}, false
+}
+
+// WatermarkedSizeName is the storage suffix for the watermarked variant of a size
+func WatermarkedSizeName(size ImageSize) string {
+	return "wm_" + size.Name
+}
+
 // Processor handles image processing operations
 type Processor struct {
-	quality int // JPEG quality (1-100)
+	quality   int        // JPEG quality (1-100)
+	watermark *Watermark // applied after resizing when set
 }
 
 // NewProcessor creates a new image processor
@@ -43,14 +59,21 @@ func NewProcessor(quality int) *Processor {
 
 // ProcessImage processes an image: decodes, resizes, and encodes
 func (p *Processor) ProcessImage(reader io.Reader, size ImageSize, format string) (io.Reader, error) {
-	// Decode image
-	img, imgFormat, err := image.Decode(reader)
+	// Decode image, applying its EXIF orientation
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	img, imgFormat, err := DecodeOriented(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode image: %w", err)
 	}
 
 	// Resize image
 	resized := p.resize(img, size.Width, size.Height)
+	if p.watermark != nil {
+		resized = p.watermark.Apply(resized)
+	}
 
 	// Encode image
 	var buf bytes.Buffer
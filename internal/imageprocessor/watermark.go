@@ -0,0 +1,166 @@
+package imageprocessor
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/png" // logo files are PNG
+	"os"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// WatermarkPosition is where the watermark is placed on the image
+type WatermarkPosition string
+
+const (
+	WatermarkTopLeft     WatermarkPosition = "top-left"
+	WatermarkTopRight    WatermarkPosition = "top-right"
+	WatermarkBottomLeft  WatermarkPosition = "bottom-left"
+	WatermarkBottomRight WatermarkPosition = "bottom-right"
+	WatermarkCenter      WatermarkPosition = "center"
+	// WatermarkTile repeats the mark across the whole image
+	WatermarkTile WatermarkPosition = "tile"
+)
+
+const (
+	// watermarkWidthRatio is the mark width relative to the image width
+	watermarkWidthRatio = 0.25
+	// watermarkMarginRatio is the distance from the edges relative to the shorter side
+	watermarkMarginRatio = 0.03
+)
+
+// Watermark is a text or logo overlay applied to public derivatives
+type Watermark struct {
+	// mark is the rendered text or the logo, drawn scaled to the target image
+	mark     image.Image
+	position WatermarkPosition
+	opacity  uint8
+}
+
+// NewWatermark creates a watermark from a PNG logo, or from text when logoPath is
+// empty. Opacity is a percentage (0-100); unknown positions fall back to bottom-right.
+func NewWatermark(text, logoPath string, position WatermarkPosition, opacity int) (*Watermark, error) {
+	var mark image.Image
+	if logoPath != "" {
+		f, err := os.Open(logoPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open watermark logo: %w", err)
+		}
+		defer f.Close()
+		if mark, _, err = image.Decode(f); err != nil {
+			return nil, fmt.Errorf("failed to decode watermark logo: %w", err)
+		}
+	} else {
+		if text == "" {
+			return nil, fmt.Errorf("watermark needs a text or a logo")
+		}
+		mark = renderText(text)
+	}
+
+	switch position {
+	case WatermarkTopLeft, WatermarkTopRight, WatermarkBottomLeft, WatermarkBottomRight, WatermarkCenter, WatermarkTile:
+	default:
+		position = WatermarkBottomRight
+	}
+	if opacity < 0 {
+		opacity = 0
+	} else if opacity > 100 {
+		opacity = 100
+	}
+
+	return &Watermark{mark: mark, position: position, opacity: uint8(opacity * 255 / 100)}, nil
+}
+
+// WithWatermark returns a copy of the processor that watermarks every processed image
+func (p *Processor) WithWatermark(w *Watermark) *Processor {
+	clone := *p
+	clone.watermark = w
+	return &clone
+}
+
+// Apply draws the watermark over a copy of img
+func (w *Watermark) Apply(img image.Image) image.Image {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+
+	// Scale the mark to a fixed share of the image width
+	markBounds := w.mark.Bounds()
+	markW := int(float64(b.Dx()) * watermarkWidthRatio)
+	if markW < 1 {
+		return dst
+	}
+	markH := markW * markBounds.Dy() / markBounds.Dx()
+	if markH < 1 {
+		markH = 1
+	}
+	mark := image.NewRGBA(image.Rect(0, 0, markW, markH))
+	draw.CatmullRom.Scale(mark, mark.Bounds(), w.mark, markBounds, draw.Src, nil)
+
+	alpha := image.NewUniform(color.Alpha{A: w.opacity})
+	for _, pt := range w.placements(dst.Bounds(), mark.Bounds().Size()) {
+		rect := image.Rectangle{Min: pt, Max: pt.Add(mark.Bounds().Size())}
+		draw.DrawMask(dst, rect, mark, image.Point{}, alpha, image.Point{}, draw.Over)
+	}
+	return dst
+}
+
+// placements returns the top-left corners where the mark is drawn
+func (w *Watermark) placements(bounds image.Rectangle, size image.Point) []image.Point {
+	short := bounds.Dx()
+	if bounds.Dy() < short {
+		short = bounds.Dy()
+	}
+	margin := int(float64(short) * watermarkMarginRatio)
+	left, top := margin, margin
+	right, bottom := bounds.Dx()-size.X-margin, bounds.Dy()-size.Y-margin
+
+	switch w.position {
+	case WatermarkTopLeft:
+		return []image.Point{{left, top}}
+	case WatermarkTopRight:
+		return []image.Point{{right, top}}
+	case WatermarkBottomLeft:
+		return []image.Point{{left, bottom}}
+	case WatermarkCenter:
+		return []image.Point{{(bounds.Dx() - size.X) / 2, (bounds.Dy() - size.Y) / 2}}
+	case WatermarkTile:
+		var points []image.Point
+		stepX, stepY := size.X*2, size.Y*4
+		for row, y := 0, margin; y < bounds.Dy(); row, y = row+1, y+stepY {
+			// Every other row is shifted so the marks form a staggered grid
+			offset := (row % 2) * size.X
+			for x := margin - offset; x < bounds.Dx(); x += stepX {
+				points = append(points, image.Point{x, y})
+			}
+		}
+		return points
+	default:
+		return []image.Point{{right, bottom}}
+	}
+}
+
+// renderText draws white text with a dark outline so the mark stays readable on
+// both light and dark photos
+func renderText(text string) image.Image {
+	face := basicfont.Face7x13
+	width := font.MeasureString(face, text).Ceil() + 2
+	height := face.Metrics().Height.Ceil() + 2
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	baseline := 1 + face.Metrics().Ascent.Ceil()
+	drawAt := func(c color.Color, dx, dy int) {
+		d := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face, Dot: fixed.P(1+dx, baseline+dy)}
+		d.DrawString(text)
+	}
+	shadow := color.RGBA{A: 160}
+	for _, off := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		drawAt(shadow, off[0], off[1])
+	}
+	drawAt(color.White, 0, 0)
+	return img
+}
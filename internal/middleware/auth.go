@@ -47,6 +47,26 @@ func AuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware - для публичных маршрутов: при валидном JWT кладет пользователя в контекст,
+// без токена (или с невалидным) запрос обрабатывается как анонимный
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			c.Next()
+			return
+		}
+
+		claims, err := auth.ParseToken(strings.TrimPrefix(authHeader, "Bearer "))
+		if err == nil {
+			c.Set("userID", claims.UserID)
+			c.Set("role", claims.Role)
+			c.Request = c.Request.WithContext(logger.WithUserID(c.Request.Context(), claims.UserID))
+		}
+		c.Next()
+	}
+}
+
 // RoleMiddleware - middleware ограничения по ролям
 func RoleMiddleware(requiredRole models.UserRole) gin.HandlerFunc {
 	return func(c *gin.Context) {
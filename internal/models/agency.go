@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// AgencyProfile - профиль модельного агентства (пользователь с ролью agency)
+type AgencyProfile struct {
+	BaseModel
+	UserID      string `gorm:"uniqueIndex;not null" json:"user_id"`
+	Name        string `gorm:"not null" json:"name"`
+	City        string `json:"city"`
+	Phone       string `json:"phone,omitempty"`
+	Website     string `json:"website,omitempty"`
+	Description string `json:"description,omitempty"`
+	IsVerified  bool   `gorm:"default:false" json:"is_verified"`
+}
+
+func (AgencyProfile) TableName() string {
+	return "agency_profiles"
+}
+
+// Статусы представительства модели агентством
+const (
+	RepresentationStatusPending  = "pending"  // приглашение агентства ждет ответа модели
+	RepresentationStatusActive   = "active"   // агентство ведет модель
+	RepresentationStatusDeclined = "declined" // модель отклонила приглашение
+	RepresentationStatusEnded    = "ended"    // представительство прекращено моделью или агентством
+)
+
+// Кто прекратил представительство
+const (
+	RepresentationEndedByModel  = "model"
+	RepresentationEndedByAgency = "agency"
+)
+
+// ModelRepresentation - агентство представляет модель: ведет профиль, откликается на кастинги,
+// получает ее сообщения и уведомления. У модели не больше одного активного агентства.
+type ModelRepresentation struct {
+	BaseModel
+	AgencyID       string     `gorm:"not null;index" json:"agency_id"`
+	ModelProfileID string     `gorm:"not null;index" json:"model_profile_id"`
+	ModelUserID    string     `gorm:"not null;index" json:"model_user_id"`
+	Status         string     `gorm:"not null;default:pending" json:"status"`
+	CopyModel      bool       `json:"copy_model"`                      // модель получает копии уведомлений
+	IsManaged      bool       `gorm:"default:false" json:"is_managed"` // профиль модели создан агентством
+	StartedAt      *time.Time `json:"started_at,omitempty"`
+	EndedAt        *time.Time `json:"ended_at,omitempty"`
+	EndedBy        string     `json:"ended_by,omitempty"`
+
+	// Relations
+	Agency       *AgencyProfile `gorm:"foreignKey:AgencyID" json:"agency,omitempty"`
+	ModelProfile *ModelProfile  `gorm:"foreignKey:ModelProfileID" json:"model_profile,omitempty"`
+}
+
+func (ModelRepresentation) TableName() string {
+	return "model_representations"
+}
+
+// IsOpen - приглашение или активное представительство
+func (r *ModelRepresentation) IsOpen() bool {
+	return r.Status == RepresentationStatusPending || r.Status == RepresentationStatusActive
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// AvailabilityLocation - часовой пояс календаря (дни и еженедельные правила считаются по нему)
+var AvailabilityLocation = loadAvailabilityLocation()
+
+func loadAvailabilityLocation() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Almaty"); err == nil {
+		return loc
+	}
+	return time.FixedZone("Asia/Almaty", 5*60*60)
+}
+
+// CalendarDay - границы календарного дня, в который попадает t
+func CalendarDay(t time.Time) (time.Time, time.Time) {
+	local := t.In(AvailabilityLocation)
+	start := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, AvailabilityLocation)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// Статусы записей календаря доступности
+const (
+	AvailabilityAvailable   = "available"
+	AvailabilityUnavailable = "unavailable"
+)
+
+// ModelAvailabilityRange - разовый интервал в календаре модели (отпуск, поездка, свободные дни)
+type ModelAvailabilityRange struct {
+	BaseModel
+	ModelProfileID string    `gorm:"not null;index" json:"model_profile_id"`
+	Status         string    `gorm:"not null" json:"status"` // AvailabilityAvailable / AvailabilityUnavailable
+	StartsAt       time.Time `gorm:"not null" json:"starts_at"`
+	EndsAt         time.Time `gorm:"not null" json:"ends_at"`
+	Note           string    `json:"note,omitempty"`
+}
+
+func (ModelAvailabilityRange) TableName() string {
+	return "model_availability_ranges"
+}
+
+// Overlaps - пересекается ли интервал с [from, to)
+func (r *ModelAvailabilityRange) Overlaps(from, to time.Time) bool {
+	return r.StartsAt.Before(to) && r.EndsAt.After(from)
+}
+
+// ModelAvailabilityWeekly - повторяющееся каждую неделю правило; время - по Asia/Almaty
+type ModelAvailabilityWeekly struct {
+	BaseModel
+	ModelProfileID string `gorm:"not null;index" json:"model_profile_id"`
+	Status         string `gorm:"not null" json:"status"`
+	Weekday        int    `gorm:"not null" json:"weekday"`       // 0 = воскресенье ... 6 = суббота
+	StartMinute    int    `gorm:"default:0" json:"start_minute"` // минуты от начала дня
+	EndMinute      int    `gorm:"default:1440" json:"end_minute"`
+}
+
+func (ModelAvailabilityWeekly) TableName() string {
+	return "model_availability_weekly"
+}
+
+// ModelBusySlot - автоматическая блокировка: принятый отклик на кастинг (весь день кастинга)
+// или бронирование. Не хранится отдельно, вычисляется из casting_responses и bookings.
+type ModelBusySlot struct {
+	ModelProfileID string    `json:"model_profile_id"`
+	Source         string    `json:"source"`    // ModelBusySource*
+	SourceID       string    `json:"source_id"` // castings.id или bookings.id
+	Title          string    `json:"title"`
+	StartsAt       time.Time `json:"starts_at"`
+	EndsAt         time.Time `json:"ends_at"`
+}
+
+// Источники автоматических блокировок
+const (
+	ModelBusySourceCasting = "casting"
+	ModelBusySourceBooking = "booking"
+)
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// Booking - договоренность с моделью по принятому отклику: даты, гонорар, условия и договор.
+// Статус confirmed наступает только после подписи договора обеими сторонами.
+type Booking struct {
+	BaseModel
+	ResponseID string        `gorm:"not null;index" json:"response_id"`
+	CastingID  string        `gorm:"not null;index" json:"casting_id"`
+	EmployerID string        `gorm:"not null;index" json:"employer_id"` // users.id работодателя
+	ModelID    string        `gorm:"not null;index" json:"model_id"`    // users.id модели
+	Status     BookingStatus `gorm:"default:'pending'" json:"status"`
+
+	// Согласованные условия
+	Fee         float64   `gorm:"not null" json:"fee"`
+	Currency    string    `gorm:"default:'KZT'" json:"currency"`
+	StartsAt    time.Time `gorm:"not null" json:"starts_at"`
+	EndsAt      time.Time `gorm:"not null" json:"ends_at"`
+	Location    string    `gorm:"not null" json:"location"`
+	UsageRights string    `json:"usage_rights"`
+	Terms       string    `json:"terms,omitempty"`
+
+	// Договор: текст генерируется из шаблона, подписи привязаны к хэшу конкретной версии
+	ContractTemplate string `gorm:"not null" json:"contract_template"`
+	ContractVersion  int    `gorm:"default:1" json:"contract_version"`
+	ContractBody     string `gorm:"type:text" json:"-"`
+	ContractHash     string `json:"contract_hash"`
+
+	StatusReason *string    `json:"status_reason,omitempty"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"` // completed / cancelled / no_show
+
+	// Relations
+	Signatures []BookingSignature `gorm:"foreignKey:BookingID" json:"signatures,omitempty"`
+	Casting    Casting            `gorm:"foreignKey:CastingID" json:"-"`
+}
+
+// BookingSignature - подпись договора набранным именем (typed-name e-signature)
+type BookingSignature struct {
+	BaseModel
+	BookingID       string    `gorm:"not null;index" json:"booking_id"`
+	SignerID        string    `gorm:"not null" json:"signer_id"` // users.id
+	SignerRole      string    `gorm:"not null" json:"signer_role"`
+	TypedName       string    `gorm:"not null" json:"typed_name"`
+	ContractVersion int       `gorm:"not null" json:"contract_version"`
+	ContractHash    string    `gorm:"not null" json:"contract_hash"`
+	SignedAt        time.Time `gorm:"not null" json:"signed_at"`
+	IPAddress       string    `json:"ip_address"`
+	UserAgent       string    `json:"user_agent,omitempty"`
+}
+
+// Стороны договора
+const (
+	BookingPartyEmployer = "employer"
+	BookingPartyModel    = "model"
+)
+
+// IsClosed - финальный статус, дальнейшие переходы невозможны
+func (b *Booking) IsClosed() bool {
+	return b.Status == BookingStatusCompleted || b.Status == BookingStatusCancelled || b.Status == BookingStatusNoShow
+}
+
+// PartyOf - роль пользователя в бронировании ("" если он не участник)
+func (b *Booking) PartyOf(userID string) string {
+	switch userID {
+	case b.EmployerID:
+		return BookingPartyEmployer
+	case b.ModelID:
+		return BookingPartyModel
+	}
+	return ""
+}
+
+// SignatureOf - подпись стороны для текущей версии договора
+func (b *Booking) SignatureOf(party string) *BookingSignature {
+	for i := range b.Signatures {
+		signature := &b.Signatures[i]
+		if signature.SignerRole == party && signature.ContractVersion == b.ContractVersion {
+			return signature
+		}
+	}
+	return nil
+}
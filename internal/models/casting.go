@@ -9,30 +9,51 @@ import (
 
 type Casting struct {
 	BaseModel
-	EmployerID      string         `gorm:"not null;index" json:"employer_id"`
-	Title           string         `gorm:"not null" json:"title"`
-	Description     string         `json:"description,omitempty"`
-	PaymentMin      float64        `json:"payment_min"`
-	PaymentMax      float64        `json:"payment_max"`
-	CastingDate     *time.Time     `gorm:"column:event_date" json:"casting_date,omitempty"`
-	CastingTime     *string        `gorm:"column:event_time" json:"casting_time,omitempty"`
-	Address         *string        `json:"address,omitempty"`
-	City            string         `gorm:"not null" json:"city"`
-	Categories      datatypes.JSON `gorm:"type:jsonb" json:"categories,omitempty"`
-	Gender          string         `json:"gender,omitempty"`
-	AgeMin          *int           `json:"age_min,omitempty"`
-	AgeMax          *int           `json:"age_max,omitempty"`
-	HeightMin       *float64       `json:"height_min,omitempty"`
-	HeightMax       *float64       `json:"height_max,omitempty"`
-	WeightMin       *float64       `json:"weight_min,omitempty"`
-	WeightMax       *float64       `json:"weight_max,omitempty"`
-	ClothingSize    *string        `json:"clothing_size,omitempty"`
-	ShoeSize        *string        `json:"shoe_size,omitempty"`
-	ExperienceLevel *string        `json:"experience_level,omitempty"`
-	Languages       datatypes.JSON `gorm:"type:jsonb" json:"languages,omitempty"`
-	JobType         string         `json:"job_type"` // "one_time", "permanent"
-	Status          CastingStatus  `gorm:"default:'draft'" json:"status"`
-	Views           int            `gorm:"default:0" json:"views"`
+	EmployerID       string         `gorm:"not null;index" json:"employer_id"`
+	Title            string         `gorm:"not null" json:"title"`
+	Description      string         `json:"description,omitempty"`
+	PaymentMin       float64        `json:"payment_min"`
+	PaymentMax       float64        `json:"payment_max"`
+	CastingDate      *time.Time     `gorm:"column:event_date" json:"casting_date,omitempty"`
+	CastingTime      *string        `gorm:"column:event_time" json:"casting_time,omitempty"`
+	Address          *string        `json:"address,omitempty"`
+	City             string         `gorm:"not null" json:"city"`
+	Categories       datatypes.JSON `gorm:"type:jsonb" json:"categories,omitempty"`
+	Gender           string         `json:"gender,omitempty"`
+	AgeMin           *int           `json:"age_min,omitempty"`
+	AgeMax           *int           `json:"age_max,omitempty"`
+	HeightMin        *float64       `json:"height_min,omitempty"`
+	HeightMax        *float64       `json:"height_max,omitempty"`
+	WeightMin        *float64       `json:"weight_min,omitempty"`
+	WeightMax        *float64       `json:"weight_max,omitempty"`
+	ClothingSize     *string        `json:"clothing_size,omitempty"` // Международная буква
+	ShoeSize         *string        `json:"shoe_size,omitempty"`     // Размер EU
+	BustMin          *float64       `json:"bust_min,omitempty"`
+	BustMax          *float64       `json:"bust_max,omitempty"`
+	WaistMin         *float64       `json:"waist_min,omitempty"`
+	WaistMax         *float64       `json:"waist_max,omitempty"`
+	HipsMin          *float64       `json:"hips_min,omitempty"`
+	HipsMax          *float64       `json:"hips_max,omitempty"`
+	HairColors       datatypes.JSON `gorm:"type:jsonb" json:"hair_colors,omitempty"` // Любой из перечисленных
+	EyeColors        datatypes.JSON `gorm:"type:jsonb" json:"eye_colors,omitempty"`
+	TattoosAllowed   *bool          `json:"tattoos_allowed,omitempty"` // nil - не важно
+	PiercingsAllowed *bool          `json:"piercings_allowed,omitempty"`
+	ExperienceLevel  *string        `json:"experience_level,omitempty"`
+	Languages        datatypes.JSON `gorm:"type:jsonb" json:"languages,omitempty"`
+	JobType          string         `json:"job_type"` // "one_time", "permanent"
+	Status           CastingStatus  `gorm:"default:'draft'" json:"status"`
+	Views            int            `gorm:"default:0" json:"views"`
+	Slug             *string        `json:"slug,omitempty"` // адрес публичной страницы /share/castings/:slug
+
+	// Мультиязычный контент: Title/Description написаны на ContentLocale, остальные языки - в *I18n
+	ContentLocale   string         `gorm:"default:'ru'" json:"content_locale"`
+	TitleI18n       datatypes.JSON `gorm:"type:jsonb" json:"title_i18n,omitempty"`
+	DescriptionI18n datatypes.JSON `gorm:"type:jsonb" json:"description_i18n,omitempty"`
+	// Язык, на котором отданы Title/Description (см. dto.Localize); не хранится
+	Locale string `gorm:"-" json:"locale,omitempty"`
+
+	// Вычисляемое поле: кастинг сейчас продвигается (см. CastingPromotion)
+	IsPromoted bool `gorm:"-" json:"is_promoted"`
 
 	// Relations
 	Employer  EmployerProfile   `gorm:"foreignKey:EmployerID" json:"employer,omitempty"`
@@ -48,6 +69,22 @@ func (c *Casting) GetCategories() []string {
 	return cats
 }
 
+func (c *Casting) GetHairColors() []string {
+	var colors []string
+	if len(c.HairColors) > 0 {
+		_ = json.Unmarshal(c.HairColors, &colors)
+	}
+	return colors
+}
+
+func (c *Casting) GetEyeColors() []string {
+	var colors []string
+	if len(c.EyeColors) > 0 {
+		_ = json.Unmarshal(c.EyeColors, &colors)
+	}
+	return colors
+}
+
 func (c *Casting) GetLanguages() []string {
 	var langs []string
 	if len(c.Languages) > 0 {
@@ -67,6 +104,24 @@ func (c *Casting) SetLanguages(languages []string) {
 	c.Languages = datatypes.JSON(data)
 }
 
+// GetTitleI18n / GetDescriptionI18n возвращают варианты текста по языкам
+func (c *Casting) GetTitleI18n() map[string]string {
+	return decodeLocalized(c.TitleI18n)
+}
+
+func (c *Casting) GetDescriptionI18n() map[string]string {
+	return decodeLocalized(c.DescriptionI18n)
+}
+
+// SetTitleI18n / SetDescriptionI18n устанавливают варианты (пустые строки удаляют вариант)
+func (c *Casting) SetTitleI18n(variants map[string]string) {
+	c.TitleI18n = encodeLocalized(variants)
+}
+
+func (c *Casting) SetDescriptionI18n(variants map[string]string) {
+	c.DescriptionI18n = encodeLocalized(variants)
+}
+
 type CastingResponse struct {
 	BaseModel
 	CastingID string         `gorm:"not null;index" json:"casting_id"`
@@ -74,11 +129,36 @@ type CastingResponse struct {
 	Message   *string        `json:"message,omitempty"`
 	Status    ResponseStatus `gorm:"default:'pending'" json:"status"`
 
+	// Отзыв отклика моделью (запись сохраняется, работодатель видит статус withdrawn)
+	WithdrawnAt      *time.Time `json:"withdrawn_at,omitempty"`
+	WithdrawalReason *string    `json:"withdrawal_reason,omitempty"`
+	WithdrawCount    int        `gorm:"default:0" json:"withdraw_count"`
+	// QuotaCharged - списан ли лимит подписки за этот отклик (списывается не более одного раза на кастинг)
+	QuotaCharged bool `gorm:"default:true" json:"-"`
+
 	// Relations
 	Model   ModelProfile `gorm:"foreignKey:ModelID" json:"model,omitempty"`
 	Casting Casting      `gorm:"foreignKey:CastingID" json:"casting,omitempty"`
 }
 
+// CastingStatusTransition - журнал переходов статуса кастинга (кто, когда и почему)
+type CastingStatusTransition struct {
+	BaseModel
+	CastingID  string        `gorm:"not null;index" json:"casting_id"`
+	FromStatus CastingStatus `gorm:"not null" json:"from_status"`
+	ToStatus   CastingStatus `gorm:"not null" json:"to_status"`
+	ActorID    *string       `json:"actor_id,omitempty"`         // users.id; nil для системных переходов
+	ActorType  string        `gorm:"not null" json:"actor_type"` // "employer", "admin", "system"
+	Reason     string        `json:"reason,omitempty"`
+}
+
+// Типы инициаторов перехода статуса кастинга
+const (
+	CastingActorEmployer = "employer"
+	CastingActorAdmin    = "admin"
+	CastingActorSystem   = "system"
+)
+
 type PlatformStats struct {
 	TotalCastings    int64
 	ActiveCastings   int64
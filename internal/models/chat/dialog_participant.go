@@ -6,7 +6,7 @@ type DialogParticipant struct {
 	ID          string `gorm:"primaryKey;type:uuid;default:gen_random_uuid()"`
 	DialogID    string `gorm:"index;not null"`
 	UserID      string `gorm:"index;not null"`
-	Role        string `gorm:"default:'member'"` // member, admin, owner
+	Role        string `gorm:"default:'member'"` // member, admin, owner, agent
 	JoinedAt    time.Time
 	LastSeenAt  time.Time
 	IsMuted     bool
@@ -14,6 +14,10 @@ type DialogParticipant struct {
 	LeftAt      *time.Time
 }
 
+// ParticipantRoleAgent - агентство, представляющее модель-участника диалога:
+// читает и пишет от ее имени, добавляется и удаляется вместе с представительством
+const ParticipantRoleAgent = "agent"
+
 // ✅ ИСПРАВЛЕНИЕ: Указываем схему "chat"
 func (DialogParticipant) TableName() string {
 	return "chat.dialog_participants"
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+)
+
+// CompCard - настройки композитки модели. Сама карточка не хранится в БД:
+// PDF/PNG рендерятся по профилю и портфолио и кэшируются в хранилище.
+type CompCard struct {
+	BaseModel
+	ModelProfileID   string         `gorm:"uniqueIndex;not null" json:"model_profile_id"`
+	Template         string         `gorm:"not null;default:classic" json:"template"`
+	PortfolioItemIDs datatypes.JSON `gorm:"type:jsonb;not null" json:"portfolio_item_ids"` // первое фото - главное
+	CacheKey         string         `json:"-"`                                             // отпечаток данных последней отрисовки
+}
+
+func (CompCard) TableName() string {
+	return "comp_cards"
+}
+
+// GetPortfolioItemIDs возвращает выбранные фото в порядке на карточке
+func (c *CompCard) GetPortfolioItemIDs() []string {
+	var ids []string
+	if len(c.PortfolioItemIDs) > 0 {
+		_ = json.Unmarshal(c.PortfolioItemIDs, &ids)
+	}
+	return ids
+}
+
+// SetPortfolioItemIDs устанавливает выбранные фото
+func (c *CompCard) SetPortfolioItemIDs(ids []string) {
+	if ids == nil {
+		ids = []string{}
+	}
+	data, _ := json.Marshal(ids)
+	c.PortfolioItemIDs = datatypes.JSON(data)
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+type DuplicatePhotoMatchStatus string
+
+const (
+	DuplicateMatchPending DuplicatePhotoMatchStatus = "pending"
+	// DuplicateMatchConfirmed - админ подтвердил кражу фото, новое фото отклонено
+	DuplicateMatchConfirmed DuplicatePhotoMatchStatus = "confirmed"
+	// DuplicateMatchDismissed - ложное срабатывание (например, общий фотограф)
+	DuplicateMatchDismissed DuplicatePhotoMatchStatus = "dismissed"
+)
+
+// DuplicatePhotoMatch - новое фото пользователя почти совпадает с фото другого пользователя
+type DuplicatePhotoMatch struct {
+	ID              string                    `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id"`
+	UploadID        string                    `gorm:"type:uuid;not null" json:"upload_id"`
+	MatchedUploadID string                    `gorm:"type:uuid;not null" json:"matched_upload_id"`
+	UserID          string                    `gorm:"type:uuid;not null" json:"user_id"`
+	MatchedUserID   string                    `gorm:"type:uuid;not null" json:"matched_user_id"`
+	Distance        int                       `gorm:"not null" json:"distance"` // расстояние Хэмминга между хэшами
+	Status          DuplicatePhotoMatchStatus `gorm:"type:varchar(20);not null;default:'pending'" json:"status"`
+	ReviewedBy      *string                   `gorm:"type:uuid" json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time                `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time                 `json:"created_at"`
+
+	// Relations
+	Upload        *Upload `gorm:"foreignKey:UploadID" json:"upload,omitempty"`
+	MatchedUpload *Upload `gorm:"foreignKey:MatchedUploadID" json:"matched_upload,omitempty"`
+}
+
+func (DuplicatePhotoMatch) TableName() string {
+	return "duplicate_photo_matches"
+}
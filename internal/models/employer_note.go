@@ -0,0 +1,54 @@
+package models
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+)
+
+// EmployerNote - приватная пометка работодателя о соискателе: заметка, цветные теги и
+// внутренний рейтинг 1-5. Видна только автору, модели никогда не отдается.
+type EmployerNote struct {
+	BaseModel
+	EmployerID string         `gorm:"not null;index" json:"employer_id"` // users.id работодателя
+	TargetType string         `gorm:"not null" json:"target_type"`       // EmployerNoteTarget*
+	TargetID   string         `gorm:"not null" json:"target_id"`         // casting_responses.id или users.id модели
+	Note       string         `json:"note,omitempty"`
+	Tags       datatypes.JSON `gorm:"type:jsonb" json:"tags,omitempty"` // ["red", "green"]
+	Rating     *int           `json:"rating,omitempty"`
+}
+
+// К чему привязана пометка
+const (
+	EmployerNoteTargetResponse = "response"
+	EmployerNoteTargetModel    = "model"
+)
+
+// EmployerNoteColors - допустимые цветные теги
+var EmployerNoteColors = []string{"red", "orange", "yellow", "green", "blue", "purple", "gray"}
+
+func (n *EmployerNote) GetTags() []string {
+	var tags []string
+	if len(n.Tags) > 0 {
+		_ = json.Unmarshal(n.Tags, &tags)
+	}
+	return tags
+}
+
+func (n *EmployerNote) SetTags(tags []string) {
+	if tags == nil {
+		tags = []string{}
+	}
+	data, _ := json.Marshal(tags)
+	n.Tags = datatypes.JSON(data)
+}
+
+// HasTag - есть ли у пометки указанный цветной тег
+func (n *EmployerNote) HasTag(tag string) bool {
+	for _, t := range n.GetTags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Статусы заявки работодателя на верификацию
+const (
+	VerificationStatusPending   = "pending"    // ждет проверки модератором
+	VerificationStatusNeedsInfo = "needs_info" // модератор запросил дополнительные документы
+	VerificationStatusApproved  = "approved"   // работодатель верифицирован до ExpiresAt
+	VerificationStatusRejected  = "rejected"   // заявка отклонена
+	VerificationStatusExpired   = "expired"    // срок верификации истек, нужна повторная заявка
+)
+
+// Инициаторы перехода статуса заявки
+const (
+	VerificationActorEmployer = "employer"
+	VerificationActorAdmin    = "admin"
+	VerificationActorSystem   = "system"
+)
+
+// VerificationValidityMonths - срок действия верификации по умолчанию
+const VerificationValidityMonths = 12
+
+// EmployerVerification - заявка работодателя на верификацию с регистрационными данными компании.
+// Документы (свидетельство о регистрации, сертификаты) - загрузки модуля "verification",
+// привязанные к заявке (entity_type = "employer_verification").
+type EmployerVerification struct {
+	BaseModel
+	EmployerProfileID string     `gorm:"not null;index" json:"employer_profile_id"`
+	UserID            string     `gorm:"not null;index" json:"user_id"`
+	Status            string     `gorm:"not null;default:pending" json:"status"`
+	BIN               string     `gorm:"column:bin;not null" json:"bin"` // бизнес-идентификационный номер (12 цифр)
+	LegalName         string     `gorm:"not null" json:"legal_name"`
+	Comment           string     `json:"comment,omitempty"` // комментарий работодателя к заявке
+	ReviewerID        *string    `json:"reviewer_id,omitempty"`
+	ReviewNote        string     `json:"review_note,omitempty"` // причина отказа или запрос документов
+	SubmittedAt       time.Time  `gorm:"not null" json:"submitted_at"`
+	ReviewedAt        *time.Time `json:"reviewed_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+
+	// Relations
+	EmployerProfile *EmployerProfile                 `gorm:"foreignKey:EmployerProfileID" json:"employer_profile,omitempty"`
+	Transitions     []EmployerVerificationTransition `gorm:"foreignKey:VerificationID" json:"transitions,omitempty"`
+}
+
+func (EmployerVerification) TableName() string {
+	return "employer_verifications"
+}
+
+// IsOpen - заявка еще на рассмотрении (ждет модератора или работодателя)
+func (v *EmployerVerification) IsOpen() bool {
+	return v.Status == VerificationStatusPending || v.Status == VerificationStatusNeedsInfo
+}
+
+// EmployerVerificationTransition - история статусов заявки (кто, когда и почему)
+type EmployerVerificationTransition struct {
+	BaseModel
+	VerificationID string  `gorm:"not null;index" json:"verification_id"`
+	FromStatus     string  `json:"from_status,omitempty"` // пусто при подаче заявки
+	ToStatus       string  `gorm:"not null" json:"to_status"`
+	ActorID        *string `json:"actor_id,omitempty"`         // users.id; nil для системных переходов
+	ActorType      string  `gorm:"not null" json:"actor_type"` // "employer", "admin", "system"
+	Note           string  `json:"note,omitempty"`
+}
+
+func (EmployerVerificationTransition) TableName() string {
+	return "employer_verification_transitions"
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Виды документов
+const (
+	InvoiceKindInvoice    = "invoice"
+	InvoiceKindCreditNote = "credit_note"
+)
+
+// InvoiceParty - реквизиты стороны на момент выставления документа
+type InvoiceParty struct {
+	Name        string `json:"name"`
+	CompanyType string `json:"company_type,omitempty"`
+	TaxID       string `json:"tax_id,omitempty"` // БИН/ИИН
+	Address     string `json:"address,omitempty"`
+	City        string `json:"city,omitempty"`
+	Contact     string `json:"contact,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	Email       string `json:"email,omitempty"`
+	BankAccount string `json:"bank_account,omitempty"`
+}
+
+// Invoice - счет по оплаченному платежу или кредит-нота по возврату.
+// Номер сквозной без пропусков в пределах года и вида документа.
+type Invoice struct {
+	BaseModel
+	Kind              string         `gorm:"not null" json:"kind"`   // InvoiceKind*
+	Number            string         `gorm:"not null" json:"number"` // INV-2026-000001 / CN-2026-000001
+	Year              int            `gorm:"not null" json:"year"`
+	Sequence          int            `gorm:"not null" json:"sequence"`
+	PaymentID         string         `gorm:"not null;index" json:"payment_id"`
+	UserID            string         `gorm:"not null;index" json:"user_id"`
+	OriginalInvoiceID *string        `json:"original_invoice_id,omitempty"`
+	SellerDetails     datatypes.JSON `gorm:"type:jsonb;not null" json:"seller_details"`
+	BuyerDetails      datatypes.JSON `gorm:"type:jsonb;not null" json:"buyer_details"`
+	Currency          string         `gorm:"not null" json:"currency"`
+	VATRate           int            `gorm:"column:vat_rate;not null" json:"vat_rate"` // в процентах
+	NetAmount         int64          `gorm:"not null" json:"net_amount"`               // минимальные единицы; у кредит-ноты < 0
+	VATAmount         int64          `gorm:"column:vat_amount;not null" json:"vat_amount"`
+	TotalAmount       int64          `gorm:"not null" json:"total_amount"`
+	Reason            string         `json:"reason,omitempty"`
+	IssuedAt          time.Time      `gorm:"not null" json:"issued_at"`
+	EmailedAt         *time.Time     `json:"emailed_at,omitempty"`
+
+	Lines []InvoiceLine `gorm:"foreignKey:InvoiceID" json:"lines,omitempty"`
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+func (i *Invoice) GetSeller() InvoiceParty {
+	var party InvoiceParty
+	if len(i.SellerDetails) > 0 {
+		_ = json.Unmarshal(i.SellerDetails, &party)
+	}
+	return party
+}
+
+func (i *Invoice) SetSeller(party InvoiceParty) {
+	data, _ := json.Marshal(party)
+	i.SellerDetails = datatypes.JSON(data)
+}
+
+func (i *Invoice) GetBuyer() InvoiceParty {
+	var party InvoiceParty
+	if len(i.BuyerDetails) > 0 {
+		_ = json.Unmarshal(i.BuyerDetails, &party)
+	}
+	return party
+}
+
+func (i *Invoice) SetBuyer(party InvoiceParty) {
+	data, _ := json.Marshal(party)
+	i.BuyerDetails = datatypes.JSON(data)
+}
+
+// InvoiceLine - строка документа; цены включают НДС
+type InvoiceLine struct {
+	BaseModel
+	InvoiceID   string `gorm:"not null;index" json:"invoice_id"`
+	Position    int    `gorm:"not null" json:"position"`
+	Description string `gorm:"not null" json:"description"`
+	Quantity    int    `gorm:"not null;default:1" json:"quantity"`
+	UnitPrice   int64  `gorm:"not null" json:"unit_price"`
+	NetAmount   int64  `gorm:"not null" json:"net_amount"`
+	VATAmount   int64  `gorm:"column:vat_amount;not null" json:"vat_amount"`
+	TotalAmount int64  `gorm:"not null" json:"total_amount"`
+}
+
+func (InvoiceLine) TableName() string {
+	return "invoice_lines"
+}
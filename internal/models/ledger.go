@@ -0,0 +1,150 @@
+package models
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// LedgerAccountType - тип счета, определяет сторону нормального остатка
+type LedgerAccountType string
+
+const (
+	LedgerAccountAsset     LedgerAccountType = "asset"     // клиринг провайдера: провайдер должен платформе
+	LedgerAccountLiability LedgerAccountType = "liability" // кошелек пользователя: платформа должна пользователю
+	LedgerAccountRevenue   LedgerAccountType = "revenue"
+	LedgerAccountExpense   LedgerAccountType = "expense"
+)
+
+// Коды системных счетов; счет кошелька - LedgerWalletCode(userID)
+const (
+	LedgerCodePlatformRevenue  = "platform:revenue"
+	LedgerCodeProviderClearing = "provider:robokassa:clearing"
+	ledgerCodeWalletPrefix     = "wallet:"
+)
+
+// LedgerBaseCurrency - валюта расчетов с Robokassa
+const LedgerBaseCurrency = "KZT"
+
+// Виды проводок
+const (
+	LedgerEntryPayment    = "payment"
+	LedgerEntryRefund     = "refund"
+	LedgerEntryAdjustment = "adjustment"
+)
+
+// LedgerWalletCode - код счета кошелька пользователя
+func LedgerWalletCode(userID string) string {
+	return ledgerCodeWalletPrefix + userID
+}
+
+// IsLedgerWalletCode - является ли код счетом кошелька
+func IsLedgerWalletCode(code string) bool {
+	return strings.HasPrefix(code, ledgerCodeWalletPrefix)
+}
+
+// LedgerAccount - счет двойной записи (один счет на код и валюту)
+type LedgerAccount struct {
+	BaseModel
+	Code        string            `gorm:"not null" json:"code"`
+	Name        string            `gorm:"not null" json:"name"`
+	Type        LedgerAccountType `gorm:"not null" json:"type"`
+	OwnerUserID *string           `gorm:"type:uuid" json:"owner_user_id,omitempty"`
+	Currency    string            `gorm:"not null;default:'KZT'" json:"currency"`
+}
+
+func (LedgerAccount) TableName() string {
+	return "ledger_accounts"
+}
+
+// NormalBalance - остаток счета в его естественном знаке. В журнале дебет > 0, кредит < 0,
+// поэтому у пассивных и доходных счетов сумма строк инвертируется.
+func (a *LedgerAccount) NormalBalance(postingsSum int64) int64 {
+	switch a.Type {
+	case LedgerAccountLiability, LedgerAccountRevenue:
+		return -postingsSum
+	default:
+		return postingsSum
+	}
+}
+
+// LedgerEntry - проводка журнала. Неизменяема (запрещено триггером в БД),
+// поэтому без updated_at: ошибка исправляется новой проводкой.
+type LedgerEntry struct {
+	ID          string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	CreatedAt   time.Time `gorm:"default:now()" json:"created_at"`
+	Reference   string    `gorm:"not null;uniqueIndex" json:"reference"`
+	Kind        string    `gorm:"not null" json:"kind"` // LedgerEntry*
+	Description string    `json:"description,omitempty"`
+	PaymentID   *string   `gorm:"type:uuid" json:"payment_id,omitempty"`
+	CreatedBy   *string   `gorm:"type:uuid" json:"created_by,omitempty"`
+	PostedAt    time.Time `gorm:"not null" json:"posted_at"`
+
+	Postings []LedgerPosting `gorm:"foreignKey:EntryID" json:"postings,omitempty"`
+}
+
+func (LedgerEntry) TableName() string {
+	return "ledger_entries"
+}
+
+// IsBalanced - сумма строк по каждой валюте равна нулю, и строк не меньше двух
+func (e *LedgerEntry) IsBalanced() bool {
+	if len(e.Postings) < 2 {
+		return false
+	}
+	sums := make(map[string]int64)
+	for _, p := range e.Postings {
+		if p.Amount == 0 {
+			return false
+		}
+		sums[p.Currency] += p.Amount
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// LedgerPosting - строка проводки в минимальных единицах валюты: дебет > 0, кредит < 0
+type LedgerPosting struct {
+	ID        string    `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()" json:"id"`
+	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
+	EntryID   string    `gorm:"type:uuid;not null;index" json:"entry_id"`
+	AccountID string    `gorm:"type:uuid;not null;index" json:"account_id"`
+	Amount    int64     `gorm:"not null" json:"amount"`
+	Currency  string    `gorm:"not null" json:"currency"`
+
+	Entry *LedgerEntry `gorm:"foreignKey:EntryID" json:"entry,omitempty"`
+}
+
+func (LedgerPosting) TableName() string {
+	return "ledger_postings"
+}
+
+// currencyMinorDigits - число знаков минимальной единицы (по умолчанию 2: тиын, копейка, цент)
+var currencyMinorDigits = map[string]int{
+	"KZT": 2,
+	"RUB": 2,
+	"USD": 2,
+	"EUR": 2,
+}
+
+func minorFactor(currency string) float64 {
+	digits, ok := currencyMinorDigits[strings.ToUpper(currency)]
+	if !ok {
+		digits = 2
+	}
+	return math.Pow10(digits)
+}
+
+// ToMinorUnits - перевод суммы из float (как в PaymentTransaction.Amount) в минимальные единицы
+func ToMinorUnits(amount float64, currency string) int64 {
+	return int64(math.Round(amount * minorFactor(currency)))
+}
+
+// FromMinorUnits - обратный перевод, только для отображения
+func FromMinorUnits(amount int64, currency string) float64 {
+	return float64(amount) / minorFactor(currency)
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+)
+
+// Языки контента профилей и кастингов
+const (
+	LocaleRU = "ru"
+	LocaleKK = "kk"
+	LocaleEN = "en"
+
+	// DefaultLocale - язык основного текста, если автор не указал другой
+	DefaultLocale = LocaleRU
+)
+
+var Locales = []string{LocaleRU, LocaleKK, LocaleEN}
+
+// IsLocale проверяет код языка контента
+func IsLocale(locale string) bool {
+	return containsString(Locales, locale)
+}
+
+// decodeLocalized - варианты текста {"kk": "...", "en": "..."} из jsonb
+func decodeLocalized(data datatypes.JSON) map[string]string {
+	variants := map[string]string{}
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &variants)
+	}
+	return variants
+}
+
+// encodeLocalized - пустые варианты не сохраняются
+func encodeLocalized(variants map[string]string) datatypes.JSON {
+	clean := make(map[string]string, len(variants))
+	for locale, text := range variants {
+		if text != "" {
+			clean[locale] = text
+		}
+	}
+	data, _ := json.Marshal(clean)
+	return datatypes.JSON(data)
+}
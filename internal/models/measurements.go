@@ -0,0 +1,38 @@
+package models
+
+// Допустимые цвета волос и глаз модели (и требований кастинга)
+var (
+	HairColors = []string{"blonde", "light_brown", "brown", "black", "red", "gray", "other"}
+	EyeColors  = []string{"blue", "gray", "green", "hazel", "brown", "black", "other"}
+)
+
+// Названия цветов для документов (композитка)
+var (
+	HairColorNames = map[string]string{
+		"blonde": "Блонд", "light_brown": "Русые", "brown": "Каштановые", "black": "Черные",
+		"red": "Рыжие", "gray": "Седые", "other": "Другой",
+	}
+	EyeColorNames = map[string]string{
+		"blue": "Голубые", "gray": "Серые", "green": "Зеленые", "hazel": "Ореховые",
+		"brown": "Карие", "black": "Черные", "other": "Другой",
+	}
+)
+
+// IsHairColor проверяет цвет волос
+func IsHairColor(color string) bool {
+	return containsString(HairColors, color)
+}
+
+// IsEyeColor проверяет цвет глаз
+func IsEyeColor(color string) bool {
+	return containsString(EyeColors, color)
+}
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
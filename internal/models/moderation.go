@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// UserBlock - пользователь BlockerID добавил BlockedID в черный список.
+// Ограничения (сообщения, отклики, приглашения, поиск) действуют в обе стороны.
+type UserBlock struct {
+	BaseModel
+	BlockerID string `gorm:"not null" json:"blocker_id"`
+	BlockedID string `gorm:"not null;index" json:"blocked_id"`
+}
+
+func (UserBlock) TableName() string {
+	return "user_blocks"
+}
+
+// Объекты жалоб
+const (
+	ReportTargetUser          = "user"
+	ReportTargetCasting       = "casting"
+	ReportTargetMessage       = "message"
+	ReportTargetReview        = "review"
+	ReportTargetPortfolioItem = "portfolio_item"
+)
+
+// Причины жалоб
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonHarassment    = "harassment"
+	ReportReasonInappropriate = "inappropriate_content"
+	ReportReasonFake          = "fake_profile"
+	ReportReasonScam          = "scam"
+	ReportReasonOther         = "other"
+)
+
+// Статусы жалобы
+const (
+	ReportStatusPending   = "pending"   // ждет модератора
+	ReportStatusResolved  = "resolved"  // нарушение подтверждено, приняты меры
+	ReportStatusDismissed = "dismissed" // нарушения нет
+)
+
+// Report - жалоба пользователя на другого пользователя или его контент.
+// TargetUserID - владелец контента (для группировки жалоб в очереди модерации).
+type Report struct {
+	BaseModel
+	ReporterID     string     `gorm:"not null;index" json:"reporter_id"`
+	TargetType     string     `gorm:"not null" json:"target_type"`
+	TargetID       string     `gorm:"not null" json:"target_id"`
+	TargetUserID   *string    `json:"target_user_id,omitempty"`
+	Reason         string     `gorm:"not null" json:"reason"`
+	Details        string     `json:"details,omitempty"`
+	Status         string     `gorm:"not null;default:pending" json:"status"`
+	ReviewerID     *string    `json:"reviewer_id,omitempty"`
+	ResolutionNote string     `json:"resolution_note,omitempty"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+}
+
+func (Report) TableName() string {
+	return "reports"
+}
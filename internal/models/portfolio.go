@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type PortfolioItem struct {
 	BaseModel
 	ModelID string `gorm:"not null;index" json:"model_id"`
@@ -9,7 +11,66 @@ type PortfolioItem struct {
 	Description string  `json:"description,omitempty"`
 	OrderIndex  int     `json:"order_index"`
 
+	// Альбом (nil - элемент только в общем списке) и позиция внутри альбома
+	AlbumID         *string `gorm:"type:uuid" json:"album_id,omitempty"`
+	AlbumOrderIndex int     `gorm:"default:0" json:"album_order_index"`
+
+	// Счетчики лайков и сохранений; FeaturedScore пересчитывается фоновой задачей
+	LikesCount    int     `gorm:"default:0" json:"likes_count"`
+	SavesCount    int     `gorm:"default:0" json:"saves_count"`
+	FeaturedScore float64 `gorm:"default:0" json:"-"`
+
 	// Relations
 	Upload *Upload      `gorm:"foreignKey:UploadID" json:"upload"`
 	Model  ModelProfile `gorm:"foreignKey:ModelID" json:"model"`
 }
+
+// PortfolioItemLike - лайк работы пользователем
+type PortfolioItemLike struct {
+	ItemID    string    `gorm:"type:uuid;primaryKey" json:"item_id"`
+	UserID    string    `gorm:"type:uuid;primaryKey" json:"user_id"`
+	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
+}
+
+func (PortfolioItemLike) TableName() string {
+	return "portfolio_item_likes"
+}
+
+// PortfolioItemSave - работа в "сохраненных" пользователя
+type PortfolioItemSave struct {
+	ItemID    string    `gorm:"type:uuid;primaryKey" json:"item_id"`
+	UserID    string    `gorm:"type:uuid;primaryKey" json:"user_id"`
+	CreatedAt time.Time `gorm:"default:now()" json:"created_at"`
+}
+
+func (PortfolioItemSave) TableName() string {
+	return "portfolio_item_saves"
+}
+
+// AlbumVisibility - кто видит альбом
+type AlbumVisibility string
+
+const (
+	AlbumVisibilityPublic  AlbumVisibility = "public"
+	AlbumVisibilityPrivate AlbumVisibility = "private" // только владелец и админы
+)
+
+// PortfolioAlbum - именованная подборка работ модели ("Editorial", "Polaroids")
+type PortfolioAlbum struct {
+	BaseModel
+	ModelID     string          `gorm:"type:uuid;not null;index" json:"model_id"`
+	Title       string          `gorm:"type:varchar(100);not null" json:"title"`
+	Description string          `json:"description,omitempty"`
+	Visibility  AlbumVisibility `gorm:"type:varchar(20);default:'public'" json:"visibility"`
+	OrderIndex  int             `gorm:"default:0" json:"order_index"`
+	// CoverItemID - элемент-обложка; nil - обложкой служит первый элемент альбома
+	CoverItemID *string `gorm:"type:uuid" json:"cover_item_id,omitempty"`
+}
+
+func (PortfolioAlbum) TableName() string {
+	return "portfolio_albums"
+}
+
+func (a *PortfolioAlbum) IsPublic() bool {
+	return a.Visibility != AlbumVisibilityPrivate
+}
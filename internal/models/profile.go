@@ -2,23 +2,36 @@ package models
 
 import (
 	"encoding/json"
+	"time"
 
 	"gorm.io/datatypes"
 )
 
 type ModelProfile struct {
 	BaseModel
-	UserID         string  `gorm:"uniqueIndex;not null"`
-	Name           string  `gorm:"not null"`
-	Age            int     `gorm:"not null"`
-	Height         float64 `gorm:"not null"` // Изменено с float64 на int
-	Weight         float64 `gorm:"not null"` // Изменено с float64 на int
-	Gender         string  `gorm:"not null"`
-	Experience     int     // years
-	HourlyRate     float64
-	Description    string
-	ClothingSize   string
-	ShoeSize       string
+	UserID       string  `gorm:"uniqueIndex;not null"`
+	Name         string  `gorm:"not null"`
+	Age          int     `gorm:"not null"`
+	Height       float64 `gorm:"not null"` // Изменено с float64 на int
+	Weight       float64 `gorm:"not null"` // Изменено с float64 на int
+	Gender       string  `gorm:"not null"`
+	Experience   int     // years
+	HourlyRate   float64
+	Description  string
+	ClothingSize string  // Международная буква (XXS...XXXL), см. algorithms.NormalizeClothingSize
+	ShoeSize     string  // Размер EU строкой ("38.5"), для отображения
+	ShoeSizeEU   float64 `gorm:"column:shoe_size_eu"` // Тот же размер EU числом, для фильтров
+
+	// Параметры фигуры, всегда в сантиметрах (0 - не указано)
+	Bust         float64
+	Waist        float64
+	Hips         float64
+	Inseam       float64
+	HairColor    string // см. models.HairColors
+	EyeColor     string // см. models.EyeColors
+	HasTattoos   bool   `gorm:"default:false"`
+	HasPiercings bool   `gorm:"default:false"`
+
 	City           string         `gorm:"not null"`
 	Languages      datatypes.JSON `gorm:"type:jsonb"` // ["русский", "английский"]
 	Categories     datatypes.JSON `gorm:"type:jsonb"` // ["fashion", "advertising"]
@@ -27,6 +40,19 @@ type ModelProfile struct {
 	Rating         float64        `gorm:"default:0"`
 	IsPublic       bool           `gorm:"default:true"`
 
+	// Заполненность профиля 0-100 (см. algorithms.ProfileCompleteness); пересчитывается
+	// при изменении профиля и портфолио
+	CompletenessScore    int        `gorm:"default:0"`
+	CompletenessNudgedAt *time.Time `json:"-"` // последнее напоминание заполнить профиль
+
+	Slug *string `json:"slug,omitempty"` // адрес публичной страницы /share/models/:slug
+
+	// Мультиязычное описание: Description написан на ContentLocale, остальные языки - в DescriptionI18n
+	ContentLocale   string         `gorm:"default:'ru'" json:"content_locale"`
+	DescriptionI18n datatypes.JSON `gorm:"type:jsonb" json:"description_i18n,omitempty"`
+	// Язык, на котором отдан Description (см. dto.Localize); не хранится
+	Locale string `gorm:"-" json:"locale,omitempty"`
+
 	// Relations
 	PortfolioItems []PortfolioItem `gorm:"foreignKey:ModelID"`
 	Reviews        []Review        `gorm:"foreignKey:ModelID"`
@@ -62,6 +88,16 @@ func (m *ModelProfile) SetLanguages(languages []string) {
 	m.Languages = datatypes.JSON(data)
 }
 
+// GetDescriptionI18n возвращает варианты описания по языкам
+func (m *ModelProfile) GetDescriptionI18n() map[string]string {
+	return decodeLocalized(m.DescriptionI18n)
+}
+
+// SetDescriptionI18n устанавливает варианты описания (пустые строки удаляют вариант)
+func (m *ModelProfile) SetDescriptionI18n(variants map[string]string) {
+	m.DescriptionI18n = encodeLocalized(variants)
+}
+
 type EmployerProfile struct {
 	BaseModel
 	UserID        string `gorm:"uniqueIndex;not null"`
@@ -72,6 +108,10 @@ type EmployerProfile struct {
 	City          string
 	CompanyType   string
 	Description   string
-	IsVerified    bool    `gorm:"default:false"`
-	Rating        float64 `gorm:"default:0"`
+	IsVerified    bool       `gorm:"default:false"`
+	VerifiedUntil *time.Time // срок действия верификации (см. EmployerVerification)
+	Rating        float64    `gorm:"default:0"`
+	Slug          *string    `json:"slug,omitempty"` // адрес публичной страницы /share/employers/:slug
+	// Просмотры профилей моделей без раскрытия компании (см. ProfileView)
+	AnonymousBrowsing bool `gorm:"default:false" json:"anonymous_browsing"`
 }
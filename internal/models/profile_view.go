@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// ProfileView - просмотр профиля модели авторизованным работодателем.
+// Анонимные просмотры (EmployerProfile.AnonymousBrowsing) модель видит только как счетчик;
+// ViewerUserID у них хранится лишь для защиты от накрутки.
+type ProfileView struct {
+	BaseModel
+	ModelID           string    `gorm:"not null;index" json:"model_id"`
+	ViewerUserID      string    `gorm:"not null" json:"-"`
+	EmployerProfileID *string   `json:"employer_profile_id,omitempty"`
+	IsAnonymous       bool      `gorm:"default:false" json:"is_anonymous"`
+	ViewedAt          time.Time `gorm:"not null" json:"viewed_at"`
+}
+
+func (ProfileView) TableName() string {
+	return "profile_views"
+}
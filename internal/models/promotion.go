@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// CastingPromotion - платное (или включенное в тариф) продвижение кастинга.
+// Пока промо активно, кастинг поднимается выше в выдаче и помечается флагом "promoted".
+type CastingPromotion struct {
+	BaseModel
+	CastingID   string          `gorm:"not null;index" json:"casting_id"`
+	EmployerID  string          `gorm:"not null;index" json:"employer_id"` // users.id владельца кастинга
+	PaymentID   *string         `gorm:"index" json:"payment_id,omitempty"`
+	Source      string          `gorm:"not null" json:"source"` // "payment", "plan"
+	Status      PromotionStatus `gorm:"default:'pending'" json:"status"`
+	Days        int             `gorm:"not null" json:"days"`
+	Amount      float64         `json:"amount"`
+	Currency    string          `gorm:"default:'KZT'" json:"currency"`
+	StartsAt    *time.Time      `json:"starts_at,omitempty"`
+	EndsAt      *time.Time      `gorm:"index" json:"ends_at,omitempty"`
+	Impressions int64           `gorm:"default:0" json:"impressions"`
+	Clicks      int64           `gorm:"default:0" json:"clicks"`
+
+	// Relations
+	Casting Casting `gorm:"foreignKey:CastingID" json:"-"`
+}
+
+const (
+	PromotionSourcePayment = "payment"
+	PromotionSourcePlan    = "plan"
+)
+
+// IsRunning - промо активно прямо сейчас
+func (p *CastingPromotion) IsRunning(now time.Time) bool {
+	return p.Status == PromotionStatusActive && p.EndsAt != nil && p.EndsAt.After(now)
+}
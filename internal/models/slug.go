@@ -0,0 +1,21 @@
+package models
+
+// Сущности с коротким адресом (slug) публичной страницы
+const (
+	SlugEntityModel    = "model"
+	SlugEntityEmployer = "employer"
+	SlugEntityCasting  = "casting"
+)
+
+// SlugRedirect - прежний адрес сущности. Старые ссылки перенаправляются на текущий slug,
+// а сам адрес не может занять другая сущность того же типа.
+type SlugRedirect struct {
+	BaseModel
+	EntityType string `gorm:"not null" json:"entity_type"`
+	EntityID   string `gorm:"not null;index" json:"entity_id"`
+	Slug       string `gorm:"not null" json:"slug"`
+}
+
+func (SlugRedirect) TableName() string {
+	return "slug_redirects"
+}
@@ -6,6 +6,8 @@ type CastingStatus string
 type ResponseStatus string
 type SubscriptionStatus string
 type PaymentStatus string
+type PromotionStatus string
+type BookingStatus string
 
 const (
 	UserStatusPending   UserStatus = "pending"
@@ -16,6 +18,7 @@ const (
 	UserRoleModel    UserRole = "model"
 	UserRoleEmployer UserRole = "employer"
 	UserRoleAdmin    UserRole = "admin"
+	UserRoleAgency   UserRole = "agency"
 
 	CastingStatusDraft     CastingStatus = "draft"
 	CastingStatusActive    CastingStatus = "active"
@@ -36,4 +39,15 @@ const (
 	PaymentStatusPaid     PaymentStatus = "paid"
 	PaymentStatusFailed   PaymentStatus = "failed"
 	PaymentStatusRefunded PaymentStatus = "refunded"
+
+	PromotionStatusPending   PromotionStatus = "pending"
+	PromotionStatusActive    PromotionStatus = "active"
+	PromotionStatusExpired   PromotionStatus = "expired"
+	PromotionStatusCancelled PromotionStatus = "cancelled"
+
+	BookingStatusPending   BookingStatus = "pending" // договор ждет подписей обеих сторон
+	BookingStatusConfirmed BookingStatus = "confirmed"
+	BookingStatusCompleted BookingStatus = "completed"
+	BookingStatusCancelled BookingStatus = "cancelled"
+	BookingStatusNoShow    BookingStatus = "no_show"
 )
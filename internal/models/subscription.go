@@ -38,12 +38,13 @@ type UserSubscription struct {
 
 type PaymentTransaction struct {
 	BaseModel
-	UserID         string `gorm:"not null;index"`
-	SubscriptionID string `gorm:"not null;index"`
+	UserID         string  `gorm:"not null;index"`
+	SubscriptionID *string `gorm:"index"` // nil - платеж за услугу без подписки
 	Amount         float64
 	Status         PaymentStatus `gorm:"default:'pending'"`
 	InvID          string        `gorm:"uniqueIndex"` // Просто uniqueIndex
 	PaidAt         *time.Time
+	Description    string // Назначение платежа (подписка, продвижение кастинга...)
 
 	// Relations
 	Subscription UserSubscription `gorm:"foreignKey:SubscriptionID"`
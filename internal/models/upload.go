@@ -68,6 +68,20 @@ type Upload struct {
 	// Дополнительные метаданные (JSON)
 	Metadata JSONMap `gorm:"type:jsonb" json:"metadata,omitempty"`
 
+	// Модерация фото (аватары и портфолио): другим пользователям показываются только одобренные
+	ModerationStatus ModerationStatus `gorm:"type:varchar(20);default:'approved'" json:"moderation_status"`
+	ModeratedBy      *string          `gorm:"type:uuid" json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time       `json:"moderated_at,omitempty"`
+	RejectionReason  *string          `json:"rejection_reason,omitempty"`
+
+	// Перцептивный хэш изображения (dHash) и его части для поиска похожих фото;
+	// nil - не изображение или еще не хэшировано фоновой задачей
+	PHash  *int64 `gorm:"column:phash" json:"-"`
+	PHash0 *int   `gorm:"column:phash_b0" json:"-"`
+	PHash1 *int   `gorm:"column:phash_b1" json:"-"`
+	PHash2 *int   `gorm:"column:phash_b2" json:"-"`
+	PHash3 *int   `gorm:"column:phash_b3" json:"-"`
+
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -116,3 +130,40 @@ func (u *Upload) IsVideo() bool {
 func (u *Upload) IsDocument() bool {
 	return u.FileType == "document"
 }
+
+// ModerationStatus - статус модерации загруженного фото
+type ModerationStatus string
+
+const (
+	ModerationStatusPending  ModerationStatus = "pending"
+	ModerationStatusApproved ModerationStatus = "approved"
+	ModerationStatusRejected ModerationStatus = "rejected"
+)
+
+// ModeratedUsages - назначения файлов, которые проходят модерацию перед публикацией
+var ModeratedUsages = []string{"avatar", "portfolio_photo", "portfolio_video"}
+
+// IsModeratedUsage проверяет, нужна ли файлу с этим назначением модерация
+func IsModeratedUsage(usage string) bool {
+	return containsString(ModeratedUsages, usage)
+}
+
+// IsApproved - файл можно показывать другим пользователям
+func (u *Upload) IsApproved() bool {
+	return u.ModerationStatus == ModerationStatusApproved
+}
+
+// Ключи Upload.Metadata, которые заполняет сервер при обработке изображений
+const (
+	UploadMetaWidth                = "width"
+	UploadMetaHeight               = "height"
+	UploadMetaCapturedAt           = "captured_at"
+	UploadMetaExifStripped         = "exif_stripped"
+	UploadMetaOrientationCorrected = "orientation_corrected"
+)
+
+// IsMetadataStripped - файл уже очищен от EXIF при загрузке (старые файлы очищаются при отдаче)
+func (u *Upload) IsMetadataStripped() bool {
+	stripped, _ := u.Metadata[UploadMetaExifStripped].(bool)
+	return stripped
+}
@@ -13,9 +13,11 @@ type User struct {
 	VerificationToken string
 	ResetToken        string
 	ResetTokenExp     *time.Time
+	PhotoAutoApprove  bool `gorm:"default:false"` // доверенный пользователь: фото публикуются без модерации
 
 	// Relations
 	ModelProfile    *ModelProfile     `gorm:"foreignKey:UserID"`
+	AgencyProfile   *AgencyProfile    `gorm:"foreignKey:UserID"`
 	EmployerProfile *EmployerProfile  `gorm:"foreignKey:UserID"`
 	Subscription    *UserSubscription `gorm:"foreignKey:UserID"`
 	RefreshTokens   []RefreshToken    `gorm:"foreignKey:UserID"`
@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAgencyNotFound         = errors.New("agency profile not found")
+	ErrRepresentationNotFound = errors.New("model representation not found")
+	ErrRepresentationExists   = errors.New("model representation already exists")
+)
+
+type AgencyRepository interface {
+	// Профиль агентства
+	CreateAgencyProfile(db *gorm.DB, profile *models.AgencyProfile) error
+	FindAgencyProfileByID(db *gorm.DB, id string) (*models.AgencyProfile, error)
+	FindAgencyProfileByUserID(db *gorm.DB, userID string) (*models.AgencyProfile, error)
+	UpdateAgencyProfile(db *gorm.DB, profile *models.AgencyProfile) error
+
+	// Представительство моделей
+	CreateRepresentation(db *gorm.DB, representation *models.ModelRepresentation) error
+	FindRepresentationByID(db *gorm.DB, id string) (*models.ModelRepresentation, error)
+	FindOpenRepresentation(db *gorm.DB, agencyID, modelProfileID string) (*models.ModelRepresentation, error)
+	FindActiveRepresentationByModelProfile(db *gorm.DB, modelProfileID string) (*models.ModelRepresentation, error)
+	FindActiveRepresentationByModelUser(db *gorm.DB, modelUserID string) (*models.ModelRepresentation, error)
+	FindRepresentationsByModelProfile(db *gorm.DB, modelProfileID string, statuses []string) ([]models.ModelRepresentation, error)
+	FindRepresentationsByAgency(db *gorm.DB, agencyID string, statuses []string) ([]models.ModelRepresentation, error)
+	UpdateRepresentation(db *gorm.DB, representation *models.ModelRepresentation) error
+}
+
+type AgencyRepositoryImpl struct{}
+
+func NewAgencyRepository() AgencyRepository {
+	return &AgencyRepositoryImpl{}
+}
+
+// Agency profile operations
+
+func (r *AgencyRepositoryImpl) CreateAgencyProfile(db *gorm.DB, profile *models.AgencyProfile) error {
+	return db.Create(profile).Error
+}
+
+func (r *AgencyRepositoryImpl) FindAgencyProfileByID(db *gorm.DB, id string) (*models.AgencyProfile, error) {
+	var profile models.AgencyProfile
+	if err := db.First(&profile, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAgencyNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *AgencyRepositoryImpl) FindAgencyProfileByUserID(db *gorm.DB, userID string) (*models.AgencyProfile, error) {
+	var profile models.AgencyProfile
+	if err := db.First(&profile, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAgencyNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *AgencyRepositoryImpl) UpdateAgencyProfile(db *gorm.DB, profile *models.AgencyProfile) error {
+	result := db.Model(profile).Updates(map[string]interface{}{
+		"name":        profile.Name,
+		"city":        profile.City,
+		"phone":       profile.Phone,
+		"website":     profile.Website,
+		"description": profile.Description,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAgencyNotFound
+	}
+	return nil
+}
+
+// Representation operations
+
+func (r *AgencyRepositoryImpl) CreateRepresentation(db *gorm.DB, representation *models.ModelRepresentation) error {
+	if err := db.Create(representation).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrRepresentationExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *AgencyRepositoryImpl) FindRepresentationByID(db *gorm.DB, id string) (*models.ModelRepresentation, error) {
+	var representation models.ModelRepresentation
+	err := db.Preload("Agency").Preload("ModelProfile").First(&representation, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRepresentationNotFound
+		}
+		return nil, err
+	}
+	return &representation, nil
+}
+
+// FindOpenRepresentation - приглашение или активное представительство модели этим агентством
+func (r *AgencyRepositoryImpl) FindOpenRepresentation(db *gorm.DB, agencyID, modelProfileID string) (*models.ModelRepresentation, error) {
+	var representation models.ModelRepresentation
+	err := db.Preload("Agency").Preload("ModelProfile").
+		Where("agency_id = ? AND model_profile_id = ? AND status IN ?", agencyID, modelProfileID,
+			[]string{models.RepresentationStatusPending, models.RepresentationStatusActive}).
+		First(&representation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRepresentationNotFound
+		}
+		return nil, err
+	}
+	return &representation, nil
+}
+
+func (r *AgencyRepositoryImpl) FindActiveRepresentationByModelProfile(db *gorm.DB, modelProfileID string) (*models.ModelRepresentation, error) {
+	return r.findActive(db, "model_profile_id = ?", modelProfileID)
+}
+
+func (r *AgencyRepositoryImpl) FindActiveRepresentationByModelUser(db *gorm.DB, modelUserID string) (*models.ModelRepresentation, error) {
+	return r.findActive(db, "model_user_id = ?", modelUserID)
+}
+
+func (r *AgencyRepositoryImpl) FindRepresentationsByModelProfile(db *gorm.DB, modelProfileID string, statuses []string) ([]models.ModelRepresentation, error) {
+	var representations []models.ModelRepresentation
+	query := db.Preload("Agency").Where("model_profile_id = ?", modelProfileID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	err := query.Order("created_at DESC").Find(&representations).Error
+	return representations, err
+}
+
+func (r *AgencyRepositoryImpl) FindRepresentationsByAgency(db *gorm.DB, agencyID string, statuses []string) ([]models.ModelRepresentation, error) {
+	var representations []models.ModelRepresentation
+	query := db.Preload("ModelProfile").Where("agency_id = ?", agencyID)
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+	err := query.Order("created_at DESC").Find(&representations).Error
+	return representations, err
+}
+
+func (r *AgencyRepositoryImpl) UpdateRepresentation(db *gorm.DB, representation *models.ModelRepresentation) error {
+	result := db.Model(representation).Updates(map[string]interface{}{
+		"status":     representation.Status,
+		"copy_model": representation.CopyModel,
+		"started_at": representation.StartedAt,
+		"ended_at":   representation.EndedAt,
+		"ended_by":   representation.EndedBy,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRepresentationNotFound
+	}
+	return nil
+}
+
+// Helper methods
+
+func (r *AgencyRepositoryImpl) findActive(db *gorm.DB, condition, value string) (*models.ModelRepresentation, error) {
+	var representation models.ModelRepresentation
+	err := db.Preload("Agency").Preload("ModelProfile").
+		Where(condition, value).
+		Where("status = ?", models.RepresentationStatusActive).
+		First(&representation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRepresentationNotFound
+		}
+		return nil, err
+	}
+	return &representation, nil
+}
@@ -0,0 +1,176 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAvailabilityRangeNotFound = errors.New("availability range not found")
+)
+
+type AvailabilityRepository interface {
+	// Разовые интервалы
+	CreateRange(db *gorm.DB, availabilityRange *models.ModelAvailabilityRange) error
+	FindRangeByID(db *gorm.DB, id string) (*models.ModelAvailabilityRange, error)
+	FindRanges(db *gorm.DB, profileIDs []string, from, to time.Time) ([]models.ModelAvailabilityRange, error)
+	DeleteRange(db *gorm.DB, id string) error
+
+	// Еженедельный шаблон
+	FindWeeklyRules(db *gorm.DB, profileIDs []string) ([]models.ModelAvailabilityWeekly, error)
+	ReplaceWeeklyRules(db *gorm.DB, profileID string, rules []models.ModelAvailabilityWeekly) error
+
+	// Автоматические блокировки (принятые отклики и бронирования)
+	FindBusySlots(db *gorm.DB, profileIDs []string, from, to time.Time, excludeCastingID string) ([]models.ModelBusySlot, error)
+}
+
+type AvailabilityRepositoryImpl struct{}
+
+func NewAvailabilityRepository() AvailabilityRepository {
+	return &AvailabilityRepositoryImpl{}
+}
+
+func (r *AvailabilityRepositoryImpl) CreateRange(db *gorm.DB, availabilityRange *models.ModelAvailabilityRange) error {
+	return db.Create(availabilityRange).Error
+}
+
+func (r *AvailabilityRepositoryImpl) FindRangeByID(db *gorm.DB, id string) (*models.ModelAvailabilityRange, error) {
+	var availabilityRange models.ModelAvailabilityRange
+	if err := db.First(&availabilityRange, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAvailabilityRangeNotFound
+		}
+		return nil, err
+	}
+	return &availabilityRange, nil
+}
+
+// FindRanges - интервалы моделей, пересекающиеся с [from, to)
+func (r *AvailabilityRepositoryImpl) FindRanges(db *gorm.DB, profileIDs []string, from, to time.Time) ([]models.ModelAvailabilityRange, error) {
+	var ranges []models.ModelAvailabilityRange
+	if len(profileIDs) == 0 {
+		return ranges, nil
+	}
+	err := db.Where("model_profile_id IN ? AND starts_at < ? AND ends_at > ?", profileIDs, to, from).
+		Order("starts_at ASC").
+		Find(&ranges).Error
+	return ranges, err
+}
+
+func (r *AvailabilityRepositoryImpl) DeleteRange(db *gorm.DB, id string) error {
+	result := db.Delete(&models.ModelAvailabilityRange{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAvailabilityRangeNotFound
+	}
+	return nil
+}
+
+func (r *AvailabilityRepositoryImpl) FindWeeklyRules(db *gorm.DB, profileIDs []string) ([]models.ModelAvailabilityWeekly, error) {
+	var rules []models.ModelAvailabilityWeekly
+	if len(profileIDs) == 0 {
+		return rules, nil
+	}
+	err := db.Where("model_profile_id IN ?", profileIDs).
+		Order("weekday ASC, start_minute ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// ReplaceWeeklyRules - шаблон задается целиком: старые правила удаляются, новые создаются
+func (r *AvailabilityRepositoryImpl) ReplaceWeeklyRules(db *gorm.DB, profileID string, rules []models.ModelAvailabilityWeekly) error {
+	if err := db.Where("model_profile_id = ?", profileID).Delete(&models.ModelAvailabilityWeekly{}).Error; err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	return db.Create(&rules).Error
+}
+
+// FindBusySlots - занятость из принятых откликов (день кастинга) и действующих бронирований.
+// casting_responses.model_id и bookings.model_id ссылаются на users.id, поэтому соединяемся через model_profiles.
+func (r *AvailabilityRepositoryImpl) FindBusySlots(db *gorm.DB, profileIDs []string, from, to time.Time, excludeCastingID string) ([]models.ModelBusySlot, error) {
+	var slots []models.ModelBusySlot
+	if len(profileIDs) == 0 {
+		return slots, nil
+	}
+
+	// 1. Принятые отклики: кастинг занимает весь день CastingDate
+	type castingRow struct {
+		ModelProfileID string
+		CastingID      string
+		Title          string
+		CastingDate    time.Time
+	}
+	var castingRows []castingRow
+	query := db.Table("casting_responses cr").
+		Select("mp.id AS model_profile_id, c.id AS casting_id, c.title, c.event_date AS casting_date").
+		Joins("JOIN model_profiles mp ON mp.user_id = cr.model_id").
+		Joins("JOIN castings c ON c.id = cr.casting_id").
+		Where("mp.id IN ?", profileIDs).
+		Where("cr.status IN ?", []models.ResponseStatus{models.ResponseStatusAccepted, models.ResponseStatusApproved}).
+		Where("c.event_date IS NOT NULL").
+		// Запас в сутки с каждой стороны: границы дня считаются ниже по времени календаря
+		Where("c.event_date >= ? AND c.event_date < ?", from.Add(-24*time.Hour), to.Add(24*time.Hour))
+	if excludeCastingID != "" {
+		query = query.Where("c.id <> ?", excludeCastingID)
+	}
+	if err := query.Scan(&castingRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range castingRows {
+		dayStart, dayEnd := models.CalendarDay(row.CastingDate)
+		if !dayStart.Before(to) || !dayEnd.After(from) {
+			continue
+		}
+		slots = append(slots, models.ModelBusySlot{
+			ModelProfileID: row.ModelProfileID,
+			Source:         models.ModelBusySourceCasting,
+			SourceID:       row.CastingID,
+			Title:          row.Title,
+			StartsAt:       dayStart,
+			EndsAt:         dayEnd,
+		})
+	}
+
+	// 2. Бронирования, ожидающие подписи или подтвержденные
+	type bookingRow struct {
+		ModelProfileID string
+		BookingID      string
+		Title          string
+		StartsAt       time.Time
+		EndsAt         time.Time
+	}
+	var bookingRows []bookingRow
+	query = db.Table("bookings b").
+		Select("mp.id AS model_profile_id, b.id AS booking_id, c.title, b.starts_at, b.ends_at").
+		Joins("JOIN model_profiles mp ON mp.user_id = b.model_id").
+		Joins("JOIN castings c ON c.id = b.casting_id").
+		Where("mp.id IN ?", profileIDs).
+		Where("b.status IN ?", []models.BookingStatus{models.BookingStatusPending, models.BookingStatusConfirmed}).
+		Where("b.starts_at < ? AND b.ends_at > ?", to, from)
+	if excludeCastingID != "" {
+		query = query.Where("b.casting_id <> ?", excludeCastingID)
+	}
+	if err := query.Scan(&bookingRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range bookingRows {
+		slots = append(slots, models.ModelBusySlot{
+			ModelProfileID: row.ModelProfileID,
+			Source:         models.ModelBusySourceBooking,
+			SourceID:       row.BookingID,
+			Title:          row.Title,
+			StartsAt:       row.StartsAt,
+			EndsAt:         row.EndsAt,
+		})
+	}
+
+	return slots, nil
+}
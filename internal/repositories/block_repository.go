@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBlockNotFound = errors.New("user block not found")
+	ErrBlockExists   = errors.New("user is already blocked")
+)
+
+type BlockRepository interface {
+	CreateBlock(db *gorm.DB, block *models.UserBlock) error
+	DeleteBlock(db *gorm.DB, blockerID, blockedID string) error
+	FindBlockedUsers(db *gorm.DB, blockerID string, page, pageSize int) ([]BlockedUser, int64, error)
+
+	// IsBlockedEitherWay - один из пользователей заблокировал другого
+	IsBlockedEitherWay(db *gorm.DB, userID, otherUserID string) (bool, error)
+}
+
+type BlockRepositoryImpl struct{}
+
+// BlockedUser - запись черного списка (без приватных полей пользователя)
+type BlockedUser struct {
+	UserID    string    `json:"user_id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+func NewBlockRepository() BlockRepository {
+	return &BlockRepositoryImpl{}
+}
+
+func (r *BlockRepositoryImpl) CreateBlock(db *gorm.DB, block *models.UserBlock) error {
+	if err := db.Create(block).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrBlockExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *BlockRepositoryImpl) DeleteBlock(db *gorm.DB, blockerID, blockedID string) error {
+	result := db.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&models.UserBlock{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBlockNotFound
+	}
+	return nil
+}
+
+// FindBlockedUsers - черный список пользователя, последние первыми
+func (r *BlockRepositoryImpl) FindBlockedUsers(db *gorm.DB, blockerID string, page, pageSize int) ([]BlockedUser, int64, error) {
+	base := db.Model(&models.UserBlock{}).Where("user_blocks.blocker_id = ?", blockerID)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []BlockedUser
+	err := base.Session(&gorm.Session{}).
+		Select("user_blocks.blocked_id AS user_id, users.name, users.role, user_blocks.created_at AS blocked_at").
+		Joins("JOIN users ON users.id = user_blocks.blocked_id").
+		Order("user_blocks.created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&users).Error
+	return users, total, err
+}
+
+func (r *BlockRepositoryImpl) IsBlockedEitherWay(db *gorm.DB, userID, otherUserID string) (bool, error) {
+	var count int64
+	err := db.Model(&models.UserBlock{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)",
+			userID, otherUserID, otherUserID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// blockedUsersSubquery - пользователи, которых userID заблокировал или которые заблокировали его
+// (для исключения из поиска и подбора)
+func blockedUsersSubquery(db *gorm.DB, userID string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Raw(
+		"SELECT blocked_id FROM user_blocks WHERE blocker_id = ? UNION SELECT blocker_id FROM user_blocks WHERE blocked_id = ?",
+		userID, userID)
+}
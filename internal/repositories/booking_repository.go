@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrBookingNotFound      = errors.New("booking not found")
+	ErrBookingAlreadyExists = errors.New("active booking already exists for this response")
+	ErrBookingStatusChanged = errors.New("booking status was changed concurrently")
+	ErrBookingAlreadySigned = errors.New("contract version is already signed by this party")
+)
+
+type BookingRepository interface {
+	CreateBooking(db *gorm.DB, booking *models.Booking) error
+	FindBookingByID(db *gorm.DB, id string) (*models.Booking, error)
+	FindActiveBookingByResponse(db *gorm.DB, responseID string) (*models.Booking, error)
+	FindBookingsByUser(db *gorm.DB, userID string, criteria BookingCriteria) ([]models.Booking, int64, error)
+	UpdateBookingTerms(db *gorm.DB, booking *models.Booking) error
+	TransitionBookingStatus(db *gorm.DB, bookingID string, from, to models.BookingStatus, reason *string) error
+
+	// Signatures
+	CreateSignature(db *gorm.DB, signature *models.BookingSignature) error
+}
+
+type BookingRepositoryImpl struct{}
+
+// Фильтры списка бронирований пользователя
+type BookingCriteria struct {
+	Status   models.BookingStatus
+	From     *time.Time
+	To       *time.Time
+	Page     int
+	PageSize int
+}
+
+func NewBookingRepository() BookingRepository {
+	return &BookingRepositoryImpl{}
+}
+
+func (r *BookingRepositoryImpl) CreateBooking(db *gorm.DB, booking *models.Booking) error {
+	if _, err := r.FindActiveBookingByResponse(db, booking.ResponseID); err == nil {
+		return ErrBookingAlreadyExists
+	}
+	return db.Omit(clause.Associations).Create(booking).Error
+}
+
+func (r *BookingRepositoryImpl) FindBookingByID(db *gorm.DB, id string) (*models.Booking, error) {
+	var booking models.Booking
+	err := db.Preload("Signatures", func(db *gorm.DB) *gorm.DB {
+		return db.Order("signed_at ASC")
+	}).Preload("Casting").First(&booking, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// FindActiveBookingByResponse - действующее (не отмененное) бронирование по отклику
+func (r *BookingRepositoryImpl) FindActiveBookingByResponse(db *gorm.DB, responseID string) (*models.Booking, error) {
+	var booking models.Booking
+	err := db.Where("response_id = ? AND status <> ?", responseID, models.BookingStatusCancelled).
+		First(&booking).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// FindBookingsByUser - бронирования, где пользователь - работодатель или модель
+func (r *BookingRepositoryImpl) FindBookingsByUser(db *gorm.DB, userID string, criteria BookingCriteria) ([]models.Booking, int64, error) {
+	var bookings []models.Booking
+	var total int64
+
+	query := db.Model(&models.Booking{}).Where("employer_id = ? OR model_id = ?", userID, userID)
+	if criteria.Status != "" {
+		query = query.Where("status = ?", criteria.Status)
+	}
+	if criteria.From != nil {
+		query = query.Where("ends_at >= ?", *criteria.From)
+	}
+	if criteria.To != nil {
+		query = query.Where("starts_at <= ?", *criteria.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if criteria.PageSize > 0 {
+		offset := 0
+		if criteria.Page > 1 {
+			offset = (criteria.Page - 1) * criteria.PageSize
+		}
+		query = query.Limit(criteria.PageSize).Offset(offset)
+	}
+
+	err := query.Preload("Signatures").Preload("Casting").Order("starts_at ASC").Find(&bookings).Error
+	return bookings, total, err
+}
+
+// UpdateBookingTerms - новые условия и новая версия договора (только пока бронирование ждет подписей)
+func (r *BookingRepositoryImpl) UpdateBookingTerms(db *gorm.DB, booking *models.Booking) error {
+	result := db.Model(&models.Booking{}).
+		Where("id = ? AND status = ?", booking.ID, models.BookingStatusPending).
+		Updates(map[string]interface{}{
+			"fee":               booking.Fee,
+			"currency":          booking.Currency,
+			"starts_at":         booking.StartsAt,
+			"ends_at":           booking.EndsAt,
+			"location":          booking.Location,
+			"usage_rights":      booking.UsageRights,
+			"terms":             booking.Terms,
+			"contract_template": booking.ContractTemplate,
+			"contract_version":  booking.ContractVersion,
+			"contract_body":     booking.ContractBody,
+			"contract_hash":     booking.ContractHash,
+			"updated_at":        time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookingStatusChanged
+	}
+	return nil
+}
+
+// TransitionBookingStatus - compare-and-swap смена статуса
+func (r *BookingRepositoryImpl) TransitionBookingStatus(db *gorm.DB, bookingID string, from, to models.BookingStatus, reason *string) error {
+	updates := map[string]interface{}{
+		"status":     to,
+		"updated_at": time.Now(),
+	}
+	if reason != nil {
+		updates["status_reason"] = *reason
+	}
+	switch to {
+	case models.BookingStatusConfirmed:
+		updates["confirmed_at"] = time.Now()
+	case models.BookingStatusCompleted, models.BookingStatusCancelled, models.BookingStatusNoShow:
+		updates["closed_at"] = time.Now()
+	}
+
+	result := db.Model(&models.Booking{}).
+		Where("id = ? AND status = ?", bookingID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookingStatusChanged
+	}
+	return nil
+}
+
+func (r *BookingRepositoryImpl) CreateSignature(db *gorm.DB, signature *models.BookingSignature) error {
+	var existing models.BookingSignature
+	if err := db.Where("booking_id = ? AND signer_role = ? AND contract_version = ?",
+		signature.BookingID, signature.SignerRole, signature.ContractVersion).First(&existing).Error; err == nil {
+		return ErrBookingAlreadySigned
+	}
+	return db.Create(signature).Error
+}
@@ -13,7 +13,8 @@ import (
 )
 
 var (
-	ErrCastingNotFound = errors.New("casting not found")
+	ErrCastingNotFound      = errors.New("casting not found")
+	ErrCastingStatusChanged = errors.New("casting status was changed concurrently")
 )
 
 type CastingRepository interface {
@@ -23,6 +24,7 @@ type CastingRepository interface {
 	FindCastingsByEmployer(db *gorm.DB, employerID string) ([]models.Casting, error)
 	UpdateCasting(db *gorm.DB, casting *models.Casting) error
 	UpdateCastingStatus(db *gorm.DB, castingID string, status models.CastingStatus) error
+	TransitionCastingStatus(db *gorm.DB, castingID string, from, to models.CastingStatus) error
 	DeleteCasting(db *gorm.DB, id string) error
 	IncrementCastingViews(db *gorm.DB, castingID string) error
 	SearchCastings(db *gorm.DB, criteria CastingSearchCriteria) ([]models.Casting, int64, error)
@@ -47,6 +49,10 @@ type CastingRepository interface {
 	GetPopularCategories(db *gorm.DB, limit int) ([]PopularCategoryStat, error)
 
 	GetCastingDistributionByCity(db *gorm.DB) ([]CityDistributionStat, error)
+
+	// Status transitions (audit)
+	CreateStatusTransition(db *gorm.DB, transition *models.CastingStatusTransition) error
+	FindStatusTransitions(db *gorm.DB, castingID string) ([]models.CastingStatusTransition, error)
 }
 
 type CastingRepositoryImpl struct {
@@ -55,25 +61,31 @@ type CastingRepositoryImpl struct {
 
 // Search criteria for castings
 type CastingSearchCriteria struct {
-	Query      string     `form:"query"`
-	City       string     `form:"city"`
-	Categories []string   `form:"categories[]"`
-	Gender     string     `form:"gender"`
-	MinAge     *int       `form:"min_age"`
-	MaxAge     *int       `form:"max_age"`
-	MinHeight  *int       `form:"min_height"`
-	MaxHeight  *int       `form:"max_height"`
-	MinSalary  *int       `form:"min_salary"`
-	MaxSalary  *int       `form:"max_salary"`
-	JobType    string     `form:"job_type"`
-	Status     string     `form:"status"`
-	EmployerID string     `form:"employer_id"`
-	DateFrom   *time.Time `form:"date_from"`
-	DateTo     *time.Time `form:"date_to"`
-	Page       int        `form:"page" binding:"min=1"`
-	PageSize   int        `form:"page_size" binding:"min=1,max=100"`
-	SortBy     string     `form:"sort_by"`    // created_at, salary, casting_date
-	SortOrder  string     `form:"sort_order"` // asc, desc
+	Query      string   `form:"query"`
+	City       string   `form:"city"`
+	Categories []string `form:"categories[]"`
+	Gender     string   `form:"gender"`
+	MinAge     *int     `form:"min_age"`
+	MaxAge     *int     `form:"max_age"`
+	MinHeight  *int     `form:"min_height"`
+	MaxHeight  *int     `form:"max_height"`
+	MinSalary  *int     `form:"min_salary"`
+	MaxSalary  *int     `form:"max_salary"`
+	JobType    string   `form:"job_type"`
+	Status     string   `form:"status"`
+	EmployerID string   `form:"employer_id"`
+	// VerifiedEmployer - фильтр по значку "verified" работодателя
+	VerifiedEmployer *bool      `form:"verified_employer"`
+	DateFrom         *time.Time `form:"date_from"`
+	DateTo           *time.Time `form:"date_to"`
+	Page             int        `form:"page" binding:"min=1"`
+	PageSize         int        `form:"page_size" binding:"min=1,max=100"`
+	SortBy           string     `form:"sort_by"`    // created_at, salary, casting_date
+	SortOrder        string     `form:"sort_order"` // asc, desc
+	// QueryLocales - в каких переводах (title_i18n/description_i18n) дополнительно искать Query
+	QueryLocales []string `form:"-"`
+	// Кто ищет: кастинги работодателей из его черного списка и заблокировавших его не показываются
+	HiddenForUserID string `form:"-"`
 }
 
 // Criteria for matching algorithm
@@ -151,29 +163,42 @@ func (r *CastingRepositoryImpl) FindCastingsByEmployer(db *gorm.DB, employerID s
 func (r *CastingRepositoryImpl) UpdateCasting(db *gorm.DB, casting *models.Casting) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Model(casting).Updates(map[string]interface{}{
-		"title":            casting.Title,
-		"description":      casting.Description,
-		"payment_min":      casting.PaymentMin,
-		"payment_max":      casting.PaymentMax,
-		"event_date":       casting.CastingDate,
-		"event_time":       casting.CastingTime,
-		"address":          casting.Address,
-		"city":             casting.City,
-		"categories":       casting.Categories,
-		"gender":           casting.Gender,
-		"age_min":          casting.AgeMin,
-		"age_max":          casting.AgeMax,
-		"height_min":       casting.HeightMin,
-		"height_max":       casting.HeightMax,
-		"weight_min":       casting.WeightMin,
-		"weight_max":       casting.WeightMax,
-		"clothing_size":    casting.ClothingSize,
-		"shoe_size":        casting.ShoeSize,
-		"experience_level": casting.ExperienceLevel,
-		"languages":        casting.Languages,
-		"job_type":         casting.JobType,
-		"status":           casting.Status,
-		"updated_at":       time.Now(),
+		"title":             casting.Title,
+		"description":       casting.Description,
+		"payment_min":       casting.PaymentMin,
+		"payment_max":       casting.PaymentMax,
+		"event_date":        casting.CastingDate,
+		"event_time":        casting.CastingTime,
+		"address":           casting.Address,
+		"city":              casting.City,
+		"categories":        casting.Categories,
+		"gender":            casting.Gender,
+		"age_min":           casting.AgeMin,
+		"age_max":           casting.AgeMax,
+		"height_min":        casting.HeightMin,
+		"height_max":        casting.HeightMax,
+		"weight_min":        casting.WeightMin,
+		"weight_max":        casting.WeightMax,
+		"clothing_size":     casting.ClothingSize,
+		"shoe_size":         casting.ShoeSize,
+		"bust_min":          casting.BustMin,
+		"bust_max":          casting.BustMax,
+		"waist_min":         casting.WaistMin,
+		"waist_max":         casting.WaistMax,
+		"hips_min":          casting.HipsMin,
+		"hips_max":          casting.HipsMax,
+		"hair_colors":       casting.HairColors,
+		"eye_colors":        casting.EyeColors,
+		"tattoos_allowed":   casting.TattoosAllowed,
+		"piercings_allowed": casting.PiercingsAllowed,
+		"experience_level":  casting.ExperienceLevel,
+		"languages":         casting.Languages,
+		"job_type":          casting.JobType,
+		"status":            casting.Status,
+		"content_locale":    casting.ContentLocale,
+		"title_i18n":        casting.TitleI18n,
+		"description_i18n":  casting.DescriptionI18n,
+		"updated_at":        time.Now(),
 	})
 
 	if result.Error != nil {
@@ -201,6 +226,33 @@ func (r *CastingRepositoryImpl) UpdateCastingStatus(db *gorm.DB, castingID strin
 	return nil
 }
 
+// TransitionCastingStatus - меняет статус только если кастинг все еще в статусе 'from'
+// (защита от гонок между API и воркером). Проставляет published_at / closed_at.
+func (r *CastingRepositoryImpl) TransitionCastingStatus(db *gorm.DB, castingID string, from, to models.CastingStatus) error {
+	updates := map[string]interface{}{
+		"status":     to,
+		"updated_at": time.Now(),
+	}
+	switch to {
+	case models.CastingStatusActive:
+		updates["published_at"] = gorm.Expr("COALESCE(published_at, ?)", time.Now())
+		updates["closed_at"] = nil
+	case models.CastingStatusClosed, models.CastingStatusCancelled:
+		updates["closed_at"] = time.Now()
+	}
+
+	result := db.Model(&models.Casting{}).
+		Where("id = ? AND status = ?", castingID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCastingStatusChanged
+	}
+	return nil
+}
+
 func (r *CastingRepositoryImpl) DeleteCasting(db *gorm.DB, id string) error {
 	// ✅ Вложенная транзакция удалена. Используем 'db' из параметра.
 	// Delete responses first
@@ -234,7 +286,8 @@ func (r *CastingRepositoryImpl) SearchCastings(db *gorm.DB, criteria CastingSear
 	// ... (фильтры по query, city, gender, job_type, status, employer_id, age, height, salary)
 	if criteria.Query != "" {
 		search := "%" + criteria.Query + "%"
-		query = query.Where("title ILIKE ? OR description ILIKE ?", search, search)
+		clause, args := localizedSearchClause(nil, []string{"title", "description"}, criteria.QueryLocales, search)
+		query = query.Where(clause, args...)
 	}
 	if criteria.City != "" {
 		query = query.Where("city = ?", criteria.City)
@@ -253,6 +306,14 @@ func (r *CastingRepositoryImpl) SearchCastings(db *gorm.DB, criteria CastingSear
 	if criteria.EmployerID != "" {
 		query = query.Where("employer_id = ?", criteria.EmployerID)
 	}
+	if criteria.VerifiedEmployer != nil {
+		query = query.Where("employer_id IN (?)",
+			db.Model(&models.EmployerProfile{}).Select("id").Where("is_verified = ?", *criteria.VerifiedEmployer))
+	}
+	if criteria.HiddenForUserID != "" {
+		query = query.Where("employer_id NOT IN (?)",
+			db.Model(&models.EmployerProfile{}).Select("id").Where("user_id IN (?)", blockedUsersSubquery(db, criteria.HiddenForUserID)))
+	}
 	if criteria.MinAge != nil {
 		query = query.Where("age_min >= ?", criteria.MinAge)
 	}
@@ -304,6 +365,8 @@ func (r *CastingRepositoryImpl) SearchCastings(db *gorm.DB, criteria CastingSear
 	// =======================
 	sortField := getCastingSortField(criteria.SortBy)
 	sortOrder := getSortOrder(criteria.SortOrder)
+	// Продвигаемые кастинги всегда выше остальных
+	query = query.Order(promotedCastingOrder)
 	query = query.Order(fmt.Sprintf("%s %s", sortField, sortOrder))
 
 	limit := criteria.PageSize
@@ -321,6 +384,7 @@ func (r *CastingRepositoryImpl) FindActiveCastings(db *gorm.DB, limit int) ([]mo
 	err := db.Preload("Employer").
 		Where("status = ?", models.CastingStatusActive).
 		Where("event_date IS NULL OR event_date >= ?", time.Now()). // ❌ БЫЛО: "casting_date"
+		Order(promotedCastingOrder).
 		Order("created_at DESC").
 		Limit(limit).
 		Find(&castings).Error
@@ -458,7 +522,7 @@ func (r *CastingRepositoryImpl) FindCastingsForMatching(db *gorm.DB, criteria Ma
 	}
 
 	// ✅ Используем 'db' (query)
-	err := query.Order("created_at DESC").Limit(criteria.Limit).Find(&castings).Error
+	err := query.Order(promotedCastingOrder).Order("created_at DESC").Limit(criteria.Limit).Find(&castings).Error
 	return castings, err
 }
 
@@ -565,3 +629,17 @@ func getCastingSortField(sortBy string) string {
 		return "created_at"
 	}
 }
+
+// Status transitions (audit)
+
+func (r *CastingRepositoryImpl) CreateStatusTransition(db *gorm.DB, transition *models.CastingStatusTransition) error {
+	return db.Create(transition).Error
+}
+
+func (r *CastingRepositoryImpl) FindStatusTransitions(db *gorm.DB, castingID string) ([]models.CastingStatusTransition, error) {
+	var transitions []models.CastingStatusTransition
+	err := db.Where("casting_id = ?", castingID).
+		Order("created_at ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
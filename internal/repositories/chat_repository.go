@@ -41,6 +41,10 @@ type ChatRepository interface {
 	RemoveAllParticipants(db *gorm.DB, dialogID string) error
 	IsUserInDialog(db *gorm.DB, dialogID, userID string) (bool, error)
 
+	// Агентства в диалогах представляемых моделей
+	AddAgentToModelDialogs(db *gorm.DB, agencyUserID, modelUserID string) error
+	RemoveAgentFromModelDialogs(db *gorm.DB, agencyUserID, modelUserID string) error
+
 	// (Message operations - без изменений)
 	CreateMessage(db *gorm.DB, message *chat.Message) error
 	FindMessageByID(db *gorm.DB, id string) (*chat.Message, error)
@@ -255,7 +259,14 @@ func (r *ChatRepositoryImpl) AddParticipants(db *gorm.DB, participants []*chat.D
 	if len(participants) == 0 {
 		return nil
 	}
-	return db.CreateInBatches(participants, 50).Error
+	if err := db.CreateInBatches(participants, 50).Error; err != nil {
+		return err
+	}
+	userIDs := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		userIDs = append(userIDs, participant.UserID)
+	}
+	return r.addAgencyAgents(db, participants[0].DialogID, userIDs)
 }
 func (r *ChatRepositoryImpl) FindParticipant(db *gorm.DB, dialogID, userID string) (*chat.DialogParticipant, error) {
 	var participant chat.DialogParticipant
@@ -335,6 +346,74 @@ func (r *ChatRepositoryImpl) IsUserInDialog(db *gorm.DB, dialogID, userID string
 	return count > 0, err
 }
 
+// AddAgentToModelDialogs - агентство становится участником (agent) всех текущих диалогов модели
+func (r *ChatRepositoryImpl) AddAgentToModelDialogs(db *gorm.DB, agencyUserID, modelUserID string) error {
+	var dialogIDs []string
+	err := db.Model(&chat.DialogParticipant{}).
+		Where("user_id = ? AND left_at IS NULL", modelUserID).
+		Pluck("dialog_id", &dialogIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, dialogID := range dialogIDs {
+		if err := r.joinAgent(db, dialogID, agencyUserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveAgentFromModelDialogs - агентство покидает диалоги модели, в которые было добавлено как agent
+func (r *ChatRepositoryImpl) RemoveAgentFromModelDialogs(db *gorm.DB, agencyUserID, modelUserID string) error {
+	modelDialogs := db.Model(&chat.DialogParticipant{}).
+		Select("dialog_id").
+		Where("user_id = ?", modelUserID)
+	return db.Model(&chat.DialogParticipant{}).
+		Where("user_id = ? AND role = ? AND left_at IS NULL", agencyUserID, chat.ParticipantRoleAgent).
+		Where("dialog_id IN (?)", modelDialogs).
+		Update("left_at", time.Now()).Error
+}
+
+// addAgencyAgents - в новый диалог добавляются агентства участников, у которых есть активное представительство
+func (r *ChatRepositoryImpl) addAgencyAgents(db *gorm.DB, dialogID string, userIDs []string) error {
+	var agencyUserIDs []string
+	err := db.Table("model_representations AS mr").
+		Joins("JOIN agency_profiles ap ON ap.id = mr.agency_id").
+		Where("mr.model_user_id IN ? AND mr.status = ?", userIDs, models.RepresentationStatusActive).
+		Distinct().
+		Pluck("ap.user_id", &agencyUserIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, agencyUserID := range agencyUserIDs {
+		if err := r.joinAgent(db, dialogID, agencyUserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinAgent - добавляет агентство в диалог (или возвращает ранее вышедшего agent); обычного участника не трогает
+func (r *ChatRepositoryImpl) joinAgent(db *gorm.DB, dialogID, agencyUserID string) error {
+	participant, err := r.FindParticipant(db, dialogID, agencyUserID)
+	if err == nil {
+		if participant.Role == chat.ParticipantRoleAgent && participant.LeftAt != nil {
+			participant.LeftAt = nil
+			return r.UpdateParticipant(db, participant)
+		}
+		return nil
+	}
+	if !errors.Is(err, ErrParticipantNotFound) {
+		return err
+	}
+	return db.Create(&chat.DialogParticipant{
+		DialogID: dialogID,
+		UserID:   agencyUserID,
+		Role:     chat.ParticipantRoleAgent,
+		JoinedAt: time.Now(),
+	}).Error
+}
+
 // (Message operations - в основном без изменений, кроме Preload)
 
 func (r *ChatRepositoryImpl) CreateMessage(db *gorm.DB, message *chat.Message) error {
@@ -580,6 +659,9 @@ func (r *ChatRepositoryImpl) CreateCastingDialog(db *gorm.DB, casting *models.Ca
 	if err := db.CreateInBatches(participants, 2).Error; err != nil {
 		return nil, err
 	}
+	if err := r.addAgencyAgents(db, dialog.ID, []string{modelID}); err != nil {
+		return nil, err
+	}
 	return dialog, nil
 }
 
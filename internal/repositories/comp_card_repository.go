@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCompCardNotFound = errors.New("comp card not found")
+)
+
+type CompCardRepository interface {
+	FindByModelProfileID(db *gorm.DB, modelProfileID string) (*models.CompCard, error)
+	// SaveSettings - создает или обновляет шаблон и выбор фото (одна запись на модель)
+	SaveSettings(db *gorm.DB, card *models.CompCard) error
+	UpdateCacheKey(db *gorm.DB, id, cacheKey string) error
+}
+
+type CompCardRepositoryImpl struct{}
+
+func NewCompCardRepository() CompCardRepository {
+	return &CompCardRepositoryImpl{}
+}
+
+func (r *CompCardRepositoryImpl) FindByModelProfileID(db *gorm.DB, modelProfileID string) (*models.CompCard, error) {
+	var card models.CompCard
+	if err := db.First(&card, "model_profile_id = ?", modelProfileID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCompCardNotFound
+		}
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *CompCardRepositoryImpl) SaveSettings(db *gorm.DB, card *models.CompCard) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "model_profile_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"template":           card.Template,
+			"portfolio_item_ids": card.PortfolioItemIDs,
+			"updated_at":         time.Now(),
+		}),
+	}).Create(card).Error
+	if err != nil {
+		return err
+	}
+	// При конфликте Create не возвращает id существующей записи
+	return db.First(card, "model_profile_id = ?", card.ModelProfileID).Error
+}
+
+func (r *CompCardRepositoryImpl) UpdateCacheKey(db *gorm.DB, id, cacheKey string) error {
+	result := db.Model(&models.CompCard{}).Where("id = ?", id).Update("cache_key", cacheKey)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCompCardNotFound
+	}
+	return nil
+}
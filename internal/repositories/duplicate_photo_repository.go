@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"errors"
+
+	"mwork_backend/internal/algorithms"
+	"mwork_backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrDuplicateMatchNotFound = errors.New("duplicate photo match not found")
+
+type DuplicatePhotoRepository interface {
+	// Перцептивные хэши
+	SetPerceptualHash(db *gorm.DB, uploadID string, hash uint64) error
+	MarkPerceptualHashFailed(db *gorm.DB, uploadID string) error
+	FindUnhashedImages(db *gorm.DB, limit int) ([]*models.Upload, error)
+	FindSimilarUploads(db *gorm.DB, hash uint64, maxDistance int, excludeUserID string, limit int) ([]SimilarUpload, error)
+
+	// Совпадения для проверки админом
+	CreateMatches(db *gorm.DB, matches []*models.DuplicatePhotoMatch) error
+	FindMatches(db *gorm.DB, criteria DuplicateMatchCriteria) ([]*models.DuplicatePhotoMatch, int64, error)
+	FindMatchByID(db *gorm.DB, id string) (*models.DuplicatePhotoMatch, error)
+	UpdateMatchStatus(db *gorm.DB, match *models.DuplicatePhotoMatch) error
+}
+
+type DuplicatePhotoRepositoryImpl struct{}
+
+// SimilarUpload - фото другого пользователя, близкое по перцептивному хэшу
+type SimilarUpload struct {
+	UploadID string
+	UserID   string
+	Distance int
+}
+
+// DuplicateMatchCriteria - очередь проверки совпадений
+type DuplicateMatchCriteria struct {
+	Status   models.DuplicatePhotoMatchStatus
+	UserID   string
+	Page     int
+	PageSize int
+}
+
+func NewDuplicatePhotoRepository() DuplicatePhotoRepository {
+	return &DuplicatePhotoRepositoryImpl{}
+}
+
+// SetPerceptualHash - сохраняет хэш вместе с индексируемыми частями
+func (r *DuplicatePhotoRepositoryImpl) SetPerceptualHash(db *gorm.DB, uploadID string, hash uint64) error {
+	bands := algorithms.PerceptualHashBandValues(hash)
+	return db.Model(&models.Upload{}).Where("id = ?", uploadID).Updates(map[string]interface{}{
+		"phash":    int64(hash),
+		"phash_b0": bands[0],
+		"phash_b1": bands[1],
+		"phash_b2": bands[2],
+		"phash_b3": bands[3],
+	}).Error
+}
+
+// MarkPerceptualHashFailed - изображение не удалось декодировать, фоновая задача его пропускает
+func (r *DuplicatePhotoRepositoryImpl) MarkPerceptualHashFailed(db *gorm.DB, uploadID string) error {
+	return db.Model(&models.Upload{}).Where("id = ?", uploadID).
+		Update("metadata", gorm.Expr(`COALESCE(metadata, '{}'::jsonb) || '{"phash_failed": true}'::jsonb`)).Error
+}
+
+// FindUnhashedImages - изображения без хэша (загруженные до появления проверки), старые первыми
+func (r *DuplicatePhotoRepositoryImpl) FindUnhashedImages(db *gorm.DB, limit int) ([]*models.Upload, error) {
+	var uploads []*models.Upload
+	err := db.Where("file_type = ? AND phash IS NULL", "image").
+		Where("NOT COALESCE((metadata->>'phash_failed')::boolean, false)").
+		Order("created_at ASC").Limit(limit).Find(&uploads).Error
+	return uploads, err
+}
+
+// FindSimilarUploads - модерируемые фото (аватары, портфолио) других пользователей в пределах
+// maxDistance бит от hash, ближайшие первыми. Кандидаты выбираются по индексам частей хэша,
+// точное расстояние считается в запросе до LIMIT, поэтому частые значения частей
+// (например, однотонный фон) не вытесняют настоящие совпадения.
+func (r *DuplicatePhotoRepositoryImpl) FindSimilarUploads(db *gorm.DB, hash uint64, maxDistance int, excludeUserID string, limit int) ([]SimilarUpload, error) {
+	probes := algorithms.PerceptualHashBandProbes(hash, maxDistance)
+	distance := gorm.Expr("bit_count((phash # ?)::bit(64))", int64(hash))
+
+	var similar []SimilarUpload
+	err := db.Model(&models.Upload{}).
+		Select("id AS upload_id, user_id, ? AS distance", distance).
+		Where("phash IS NOT NULL").
+		Where("phash_b0 IN ? OR phash_b1 IN ? OR phash_b2 IN ? OR phash_b3 IN ?", probes[0], probes[1], probes[2], probes[3]).
+		Where("? <= ?", distance, maxDistance).
+		Where("user_id <> ?", excludeUserID).
+		Where("usage IN ?", models.ModeratedUsages).
+		// Уже отклоненные копии не считаются оригиналами
+		Where("moderation_status <> ?", models.ModerationStatusRejected).
+		// Фото, само ожидающее проверки как копия, оригиналом не считается
+		Where("NOT EXISTS (SELECT 1 FROM duplicate_photo_matches m WHERE m.upload_id = uploads.id AND m.status = ?)", models.DuplicateMatchPending).
+		Order("distance ASC, created_at ASC").
+		Limit(limit).
+		Scan(&similar).Error
+	return similar, err
+}
+
+// CreateMatches - повторные совпадения той же пары фото игнорируются
+func (r *DuplicatePhotoRepositoryImpl) CreateMatches(db *gorm.DB, matches []*models.DuplicatePhotoMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&matches).Error
+}
+
+// FindMatches - очередь проверки, старые первыми
+func (r *DuplicatePhotoRepositoryImpl) FindMatches(db *gorm.DB, criteria DuplicateMatchCriteria) ([]*models.DuplicatePhotoMatch, int64, error) {
+	var matches []*models.DuplicatePhotoMatch
+	var total int64
+
+	query := db.Model(&models.DuplicatePhotoMatch{})
+	if criteria.Status != "" {
+		query = query.Where("status = ?", criteria.Status)
+	}
+	if criteria.UserID != "" {
+		query = query.Where("user_id = ? OR matched_user_id = ?", criteria.UserID, criteria.UserID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (criteria.Page - 1) * criteria.PageSize
+	err := query.Preload("Upload").Preload("MatchedUpload").
+		Order("created_at ASC").Offset(offset).Limit(criteria.PageSize).Find(&matches).Error
+	return matches, total, err
+}
+
+func (r *DuplicatePhotoRepositoryImpl) FindMatchByID(db *gorm.DB, id string) (*models.DuplicatePhotoMatch, error) {
+	var match models.DuplicatePhotoMatch
+	if err := db.Preload("Upload").Preload("MatchedUpload").First(&match, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDuplicateMatchNotFound
+		}
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *DuplicatePhotoRepositoryImpl) UpdateMatchStatus(db *gorm.DB, match *models.DuplicatePhotoMatch) error {
+	return db.Model(&models.DuplicatePhotoMatch{}).Where("id = ?", match.ID).Updates(map[string]interface{}{
+		"status":      match.Status,
+		"reviewed_by": match.ReviewedBy,
+		"reviewed_at": match.ReviewedAt,
+	}).Error
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"encoding/json"
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrEmployerNoteNotFound = errors.New("employer note not found")
+)
+
+type EmployerNoteRepository interface {
+	UpsertNote(db *gorm.DB, note *models.EmployerNote) error
+	FindNote(db *gorm.DB, employerID, targetType, targetID string) (*models.EmployerNote, error)
+	FindNotesByTargets(db *gorm.DB, employerID, targetType string, targetIDs []string) (map[string]*models.EmployerNote, error)
+	FindNotesByEmployer(db *gorm.DB, employerID string, criteria EmployerNoteCriteria) ([]models.EmployerNote, error)
+	DeleteNote(db *gorm.DB, employerID, targetType, targetID string) error
+}
+
+type EmployerNoteRepositoryImpl struct{}
+
+// Фильтры списка пометок
+type EmployerNoteCriteria struct {
+	TargetType string
+	Tag        string
+	MinRating  int
+}
+
+func NewEmployerNoteRepository() EmployerNoteRepository {
+	return &EmployerNoteRepositoryImpl{}
+}
+
+// UpsertNote - одна пометка на пару (работодатель, цель); повторный вызов перезаписывает поля
+func (r *EmployerNoteRepositoryImpl) UpsertNote(db *gorm.DB, note *models.EmployerNote) error {
+	return db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "employer_id"}, {Name: "target_type"}, {Name: "target_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"note":       note.Note,
+			"tags":       note.Tags,
+			"rating":     note.Rating,
+			"updated_at": time.Now(),
+		}),
+	}).Create(note).Error
+}
+
+func (r *EmployerNoteRepositoryImpl) FindNote(db *gorm.DB, employerID, targetType, targetID string) (*models.EmployerNote, error) {
+	var note models.EmployerNote
+	err := db.Where("employer_id = ? AND target_type = ? AND target_id = ?", employerID, targetType, targetID).
+		First(&note).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEmployerNoteNotFound
+		}
+		return nil, err
+	}
+	return &note, nil
+}
+
+// FindNotesByTargets - пометки работодателя по списку целей (ключ - target_id)
+func (r *EmployerNoteRepositoryImpl) FindNotesByTargets(db *gorm.DB, employerID, targetType string, targetIDs []string) (map[string]*models.EmployerNote, error) {
+	result := make(map[string]*models.EmployerNote)
+	if len(targetIDs) == 0 {
+		return result, nil
+	}
+
+	var notes []models.EmployerNote
+	err := db.Where("employer_id = ? AND target_type = ? AND target_id IN ?", employerID, targetType, targetIDs).
+		Find(&notes).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range notes {
+		result[notes[i].TargetID] = &notes[i]
+	}
+	return result, nil
+}
+
+func (r *EmployerNoteRepositoryImpl) FindNotesByEmployer(db *gorm.DB, employerID string, criteria EmployerNoteCriteria) ([]models.EmployerNote, error) {
+	var notes []models.EmployerNote
+	query := db.Where("employer_id = ?", employerID)
+	if criteria.TargetType != "" {
+		query = query.Where("target_type = ?", criteria.TargetType)
+	}
+	if criteria.Tag != "" {
+		tag, err := json.Marshal([]string{criteria.Tag})
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("tags @> ?::jsonb", string(tag))
+	}
+	if criteria.MinRating > 0 {
+		query = query.Where("rating >= ?", criteria.MinRating)
+	}
+	err := query.Order("updated_at DESC").Find(&notes).Error
+	return notes, err
+}
+
+func (r *EmployerNoteRepositoryImpl) DeleteNote(db *gorm.DB, employerID, targetType, targetID string) error {
+	result := db.Where("employer_id = ? AND target_type = ? AND target_id = ?", employerID, targetType, targetID).
+		Delete(&models.EmployerNote{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrEmployerNoteNotFound
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrInvoiceAlreadyExists = errors.New("invoice for this payment already exists")
+)
+
+type InvoiceRepository interface {
+	// NextNumber - следующий номер в последовательности вида документа за год.
+	// Вызывается в транзакции выставления: строка счетчика блокируется до коммита.
+	NextNumber(db *gorm.DB, kind string, year int) (int, error)
+	CreateInvoice(db *gorm.DB, invoice *models.Invoice) error
+	FindInvoiceByID(db *gorm.DB, id string) (*models.Invoice, error)
+	FindInvoiceByPayment(db *gorm.DB, paymentID, kind string) (*models.Invoice, error)
+	MarkEmailed(db *gorm.DB, id string, emailedAt time.Time) error
+}
+
+type InvoiceRepositoryImpl struct{}
+
+func NewInvoiceRepository() InvoiceRepository {
+	return &InvoiceRepositoryImpl{}
+}
+
+func (r *InvoiceRepositoryImpl) NextNumber(db *gorm.DB, kind string, year int) (int, error) {
+	var number int
+	err := db.Raw(`
+		INSERT INTO invoice_sequences (kind, year, last_number) VALUES (?, ?, 1)
+		ON CONFLICT (kind, year) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number`, kind, year).
+		Scan(&number).Error
+	return number, err
+}
+
+func (r *InvoiceRepositoryImpl) CreateInvoice(db *gorm.DB, invoice *models.Invoice) error {
+	var count int64
+	if err := db.Model(&models.Invoice{}).
+		Where("payment_id = ? AND kind = ?", invoice.PaymentID, invoice.Kind).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrInvoiceAlreadyExists
+	}
+	return db.Create(invoice).Error
+}
+
+func (r *InvoiceRepositoryImpl) FindInvoiceByID(db *gorm.DB, id string) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).First(&invoice, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (r *InvoiceRepositoryImpl) FindInvoiceByPayment(db *gorm.DB, paymentID, kind string) (*models.Invoice, error) {
+	var invoice models.Invoice
+	err := db.Preload("Lines", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	}).First(&invoice, "payment_id = ? AND kind = ?", paymentID, kind).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (r *InvoiceRepositoryImpl) MarkEmailed(db *gorm.DB, id string, emailedAt time.Time) error {
+	result := db.Model(&models.Invoice{}).Where("id = ?", id).Update("emailed_at", emailedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvoiceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrLedgerAccountNotFound = errors.New("ledger account not found")
+	ErrLedgerEntryNotFound   = errors.New("ledger entry not found")
+	ErrLedgerEntryExists     = errors.New("ledger entry with this reference already exists")
+	ErrLedgerEntryUnbalanced = errors.New("ledger entry is not balanced")
+)
+
+// LedgerAccountBalance - счет с суммой строк журнала (в знаке журнала: дебет > 0)
+type LedgerAccountBalance struct {
+	models.LedgerAccount
+	PostingsSum int64 `json:"-"`
+}
+
+// LedgerReconciliationRow - проводка платежа/возврата и ее строка по счету клиринга
+type LedgerReconciliationRow struct {
+	EntryID  string
+	Kind     string
+	InvID    string
+	Amount   int64 // строка по счету клиринга: платеж > 0, возврат < 0
+	PostedAt time.Time
+}
+
+type LedgerRepository interface {
+	// Счета
+	FindOrCreateAccount(db *gorm.DB, account *models.LedgerAccount) (*models.LedgerAccount, error)
+	FindAccountByID(db *gorm.DB, id string) (*models.LedgerAccount, error)
+	FindAccountByCode(db *gorm.DB, code, currency string) (*models.LedgerAccount, error)
+	ListAccountBalances(db *gorm.DB, accountType string, page, pageSize int) ([]LedgerAccountBalance, int64, error)
+	GetAccountBalance(db *gorm.DB, accountID string) (int64, error)
+	GetTrialBalance(db *gorm.DB) (map[string]int64, error)
+
+	// Журнал (только добавление)
+	CreateEntry(db *gorm.DB, entry *models.LedgerEntry) error
+	FindEntryByID(db *gorm.DB, id string) (*models.LedgerEntry, error)
+	FindEntryByReference(db *gorm.DB, reference string) (*models.LedgerEntry, error)
+	FindAccountPostings(db *gorm.DB, accountID string, page, pageSize int) ([]models.LedgerPosting, int64, error)
+
+	// Сверка
+	FindReconciliationRows(db *gorm.DB, clearingAccountID string, from, to time.Time, invIDs []string) ([]LedgerReconciliationRow, error)
+}
+
+type LedgerRepositoryImpl struct{}
+
+func NewLedgerRepository() LedgerRepository {
+	return &LedgerRepositoryImpl{}
+}
+
+// FindOrCreateAccount - счета создаются лениво при первой проводке; гонка решается уникальным индексом
+func (r *LedgerRepositoryImpl) FindOrCreateAccount(db *gorm.DB, account *models.LedgerAccount) (*models.LedgerAccount, error) {
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(account).Error; err != nil {
+		return nil, err
+	}
+	return r.FindAccountByCode(db, account.Code, account.Currency)
+}
+
+func (r *LedgerRepositoryImpl) FindAccountByID(db *gorm.DB, id string) (*models.LedgerAccount, error) {
+	var account models.LedgerAccount
+	if err := db.First(&account, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLedgerAccountNotFound
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *LedgerRepositoryImpl) FindAccountByCode(db *gorm.DB, code, currency string) (*models.LedgerAccount, error) {
+	var account models.LedgerAccount
+	if err := db.First(&account, "code = ? AND currency = ?", code, currency).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLedgerAccountNotFound
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *LedgerRepositoryImpl) ListAccountBalances(db *gorm.DB, accountType string, page, pageSize int) ([]LedgerAccountBalance, int64, error) {
+	var balances []LedgerAccountBalance
+	var total int64
+
+	query := db.Model(&models.LedgerAccount{})
+	if accountType != "" {
+		query = query.Where("type = ?", accountType)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Select("ledger_accounts.*, COALESCE((SELECT SUM(p.amount) FROM ledger_postings p WHERE p.account_id = ledger_accounts.id), 0) AS postings_sum").
+		Order("ledger_accounts.code ASC, ledger_accounts.currency ASC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&balances).Error
+	return balances, total, err
+}
+
+func (r *LedgerRepositoryImpl) GetAccountBalance(db *gorm.DB, accountID string) (int64, error) {
+	var sum int64
+	err := db.Model(&models.LedgerPosting{}).
+		Where("account_id = ?", accountID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&sum).Error
+	return sum, err
+}
+
+// GetTrialBalance - сумма всех строк по валютам; для корректного журнала везде ноль
+func (r *LedgerRepositoryImpl) GetTrialBalance(db *gorm.DB) (map[string]int64, error) {
+	var rows []struct {
+		Currency string
+		Total    int64
+	}
+	if err := db.Model(&models.LedgerPosting{}).
+		Select("currency, COALESCE(SUM(amount), 0) AS total").
+		Group("currency").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		result[row.Currency] = row.Total
+	}
+	return result, nil
+}
+
+// CreateEntry - проводка вместе со строками. Повтор по тому же reference возвращает ErrLedgerEntryExists.
+func (r *LedgerRepositoryImpl) CreateEntry(db *gorm.DB, entry *models.LedgerEntry) error {
+	if !entry.IsBalanced() {
+		return ErrLedgerEntryUnbalanced
+	}
+
+	var count int64
+	if err := db.Model(&models.LedgerEntry{}).Where("reference = ?", entry.Reference).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrLedgerEntryExists
+	}
+
+	if err := db.Create(entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrLedgerEntryExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *LedgerRepositoryImpl) FindEntryByID(db *gorm.DB, id string) (*models.LedgerEntry, error) {
+	var entry models.LedgerEntry
+	if err := db.Preload("Postings").First(&entry, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLedgerEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *LedgerRepositoryImpl) FindEntryByReference(db *gorm.DB, reference string) (*models.LedgerEntry, error) {
+	var entry models.LedgerEntry
+	if err := db.Preload("Postings").First(&entry, "reference = ?", reference).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLedgerEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindAccountPostings - выписка по счету, новые сверху
+func (r *LedgerRepositoryImpl) FindAccountPostings(db *gorm.DB, accountID string, page, pageSize int) ([]models.LedgerPosting, int64, error) {
+	var postings []models.LedgerPosting
+	var total int64
+
+	query := db.Model(&models.LedgerPosting{}).Where("account_id = ?", accountID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Entry").
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&postings).Error
+	return postings, total, err
+}
+
+// FindReconciliationRows - проводки по клирингу за период плюс проводки по платежам из выписки
+// вне периода (провайдер может включить платеж в выписку следующего дня).
+func (r *LedgerRepositoryImpl) FindReconciliationRows(db *gorm.DB, clearingAccountID string, from, to time.Time, invIDs []string) ([]LedgerReconciliationRow, error) {
+	var rows []LedgerReconciliationRow
+
+	query := db.Table("ledger_entries e").
+		Select("e.id AS entry_id, e.kind, COALESCE(pt.inv_id, '') AS inv_id, p.amount, e.posted_at").
+		Joins("JOIN ledger_postings p ON p.entry_id = e.id AND p.account_id = ?", clearingAccountID).
+		Joins("LEFT JOIN payment_transactions pt ON pt.id = e.payment_id")
+	if len(invIDs) > 0 {
+		query = query.Where("(e.posted_at >= ? AND e.posted_at < ?) OR pt.inv_id IN ?", from, to, invIDs)
+	} else {
+		query = query.Where("e.posted_at >= ? AND e.posted_at < ?", from, to)
+	}
+
+	err := query.Order("e.posted_at ASC").Scan(&rows).Error
+	return rows, err
+}
@@ -28,8 +28,27 @@ const (
 	NotificationTypeProfileView          = "profile_view"
 	NotificationTypePasswordReset        = "password_reset"
 	NotificationTypeAnnouncement         = "announcement"
+	NotificationTypeCastingStatus        = "casting_status"
+	NotificationTypeBooking              = "booking"
+	NotificationTypeRepresentation       = "representation"
+	NotificationTypeVerification         = "verification"
+	NotificationTypeProfileCompleteness  = "profile_completeness"
+	NotificationTypeReport               = "report"
+	NotificationTypePhotoModeration      = "photo_moderation"
 )
 
+// representedNotificationTypes - уведомления о работе модели, которые получает представляющее ее агентство.
+// Остальные (сброс пароля, подписка, приглашения агентств) остаются только у модели.
+var representedNotificationTypes = map[string]bool{
+	NotificationTypeNewMessage:     true,
+	NotificationTypeCastingMatch:   true,
+	NotificationTypeResponseStatus: true,
+	NotificationTypeNewCasting:     true,
+	NotificationTypeProfileView:    true,
+	NotificationTypeCastingStatus:  true,
+	NotificationTypeBooking:        true,
+}
+
 type NotificationRepository interface {
 	// Notification operations
 	CreateNotification(db *gorm.DB, notification *models.Notification) error
@@ -63,11 +82,20 @@ type NotificationRepository interface {
 	// Factory methods for common notification types
 	CreateNewResponseNotification(db *gorm.DB, employerID, castingID, responseID, modelName string) error
 	CreateResponseStatusNotification(db *gorm.DB, modelID, castingTitle string, status models.ResponseStatus) error
+	CreateResponseWithdrawnNotification(db *gorm.DB, employerID, castingID, responseID, castingTitle string, reason *string) error
+	CreateBookingNotification(db *gorm.DB, userID, bookingID, castingTitle string, status models.BookingStatus, reason *string) error
 	CreateCastingMatchNotification(db *gorm.DB, modelID string, castingTitle string, score float64) error
 	CreateNewMessageNotification(db *gorm.DB, recipientID, senderName string, dialogID string) error
 	CreateSubscriptionExpiringNotification(db *gorm.DB, userID, planName string, daysRemaining int) error
 	CreateBulkResponseNotifications(db *gorm.DB, employerID string, responses []ResponseNotificationData) error
 	CreateBulkCastingMatchNotifications(db *gorm.DB, matches []CastingMatchNotificationData) error
+	CreateCastingCancelledNotifications(db *gorm.DB, userIDs []string, castingID, castingTitle, reason string) error
+	CreateBulkResponseStatusNotifications(db *gorm.DB, items []ResponseStatusNotificationData) error
+	CreateRepresentationNotification(db *gorm.DB, userID, representationID, title, message string) error
+	CreateVerificationNotification(db *gorm.DB, userID, verificationID, status, title, message string) error
+	CreateProfileCompletenessNotification(db *gorm.DB, userID string, score int, missing []string) error
+	CreateReportNotification(db *gorm.DB, userID, reportID, status, title, message string) error
+	CreatePhotoRejectedNotification(db *gorm.DB, userID string, uploadIDs []string, reason string) error
 }
 
 type NotificationRepositoryImpl struct {
@@ -147,6 +175,24 @@ type CastingMatchNotificationData struct {
 	Score        float64
 }
 
+// representationRoute - активное представительство модели, по которому уведомление уходит агентству
+type representationRoute struct {
+	ModelUserID    string
+	ModelProfileID string
+	ModelName      string
+	AgencyUserID   string
+	CopyModel      bool
+}
+
+type ResponseStatusNotificationData struct {
+	ModelID      string
+	CastingID    string
+	ResponseID   string
+	CastingTitle string
+	Status       models.ResponseStatus
+	Message      string // Если пусто - стандартный текст по статусу
+}
+
 // ✅ Конструктор не принимает db
 func NewNotificationRepository() NotificationRepository {
 	return &NotificationRepositoryImpl{}
@@ -160,8 +206,13 @@ func (r *NotificationRepositoryImpl) CreateNotification(db *gorm.DB, notificatio
 	if err := r.validateNotification(db, notification); err != nil {
 		return err
 	}
+	// Уведомления модели с агентством уходят агентству
+	routed, err := r.routeRepresented(db, []*models.Notification{notification})
+	if err != nil {
+		return err
+	}
 	// ✅ Используем 'db' из параметра
-	return db.Create(notification).Error
+	return db.Create(&routed).Error
 }
 
 func (r *NotificationRepositoryImpl) CreateBulkNotifications(db *gorm.DB, notifications []*models.Notification) error {
@@ -176,8 +227,12 @@ func (r *NotificationRepositoryImpl) CreateBulkNotifications(db *gorm.DB, notifi
 			return err
 		}
 	}
+	routed, err := r.routeRepresented(db, notifications)
+	if err != nil {
+		return err
+	}
 	// ✅ Используем 'db' из параметра
-	return db.CreateInBatches(notifications, 100).Error
+	return db.CreateInBatches(routed, 100).Error
 }
 
 func (r *NotificationRepositoryImpl) FindNotificationByID(db *gorm.DB, id string) (*models.Notification, error) {
@@ -605,6 +660,82 @@ func (r *NotificationRepositoryImpl) CreateResponseStatusNotification(db *gorm.D
 	return r.CreateNotification(db, notification)
 }
 
+// CreateResponseWithdrawnNotification - работодателю: модель отозвала отклик (с причиной, если указана)
+func (r *NotificationRepositoryImpl) CreateResponseWithdrawnNotification(db *gorm.DB, employerID, castingID, responseID, castingTitle string, reason *string) error {
+	data := map[string]interface{}{
+		"casting_id":  castingID,
+		"response_id": responseID,
+		"status":      models.ResponseStatusWithdrawn,
+	}
+	message := fmt.Sprintf("Модель отозвала отклик на кастинг '%s'", castingTitle)
+	if reason != nil {
+		data["reason"] = *reason
+		message = fmt.Sprintf("%s. Причина: %s", message, *reason)
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:  employerID,
+		Type:    NotificationTypeResponseStatus,
+		Title:   "Отклик отозван",
+		Message: message,
+		Data:    datatypes.JSON(jsonData),
+	}
+	return r.CreateNotification(db, notification)
+}
+
+// CreateBookingNotification - участнику бронирования: договор на подпись, подтверждение или закрытие
+func (r *NotificationRepositoryImpl) CreateBookingNotification(db *gorm.DB, userID, bookingID, castingTitle string, status models.BookingStatus, reason *string) error {
+	var title, message string
+
+	switch status {
+	case models.BookingStatusPending:
+		title = "Договор на подпись"
+		message = fmt.Sprintf("Условия бронирования по кастингу '%s' ждут вашей подписи", castingTitle)
+	case models.BookingStatusConfirmed:
+		title = "Бронирование подтверждено"
+		message = fmt.Sprintf("Договор по кастингу '%s' подписан обеими сторонами", castingTitle)
+	case models.BookingStatusCompleted:
+		title = "Бронирование завершено"
+		message = fmt.Sprintf("Работа по кастингу '%s' отмечена как выполненная", castingTitle)
+	case models.BookingStatusCancelled:
+		title = "Бронирование отменено"
+		message = fmt.Sprintf("Бронирование по кастингу '%s' отменено", castingTitle)
+	case models.BookingStatusNoShow:
+		title = "Неявка"
+		message = fmt.Sprintf("По кастингу '%s' зафиксирована неявка", castingTitle)
+	default:
+		return errors.New("unsupported status for notification")
+	}
+
+	data := map[string]interface{}{
+		"booking_id": bookingID,
+		"status":     status,
+	}
+	if reason != nil {
+		data["reason"] = *reason
+		message = fmt.Sprintf("%s. Причина: %s", message, *reason)
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    NotificationTypeBooking,
+		Title:   title,
+		Message: message,
+		Data:    datatypes.JSON(jsonData),
+	}
+	return r.CreateNotification(db, notification)
+}
+
 func (r *NotificationRepositoryImpl) CreateCastingMatchNotification(db *gorm.DB, modelID string, castingTitle string, score float64) error {
 	notification := &models.Notification{
 		UserID:  modelID,
@@ -697,8 +828,249 @@ func (r *NotificationRepositoryImpl) CreateBulkCastingMatchNotifications(db *gor
 	return r.CreateBulkNotifications(db, notifications)
 }
 
+func (r *NotificationRepositoryImpl) CreateBulkResponseStatusNotifications(db *gorm.DB, items []ResponseStatusNotificationData) error {
+	var notifications []*models.Notification
+
+	for _, item := range items {
+		var title, message string
+		switch item.Status {
+		case models.ResponseStatusAccepted:
+			title = "Отклик принят"
+			message = fmt.Sprintf("Ваш отклик на кастинг '%s' был принят", item.CastingTitle)
+		case models.ResponseStatusApproved:
+			title = "Отклик одобрен"
+			message = fmt.Sprintf("Ваш отклик на кастинг '%s' был одобрен", item.CastingTitle)
+		case models.ResponseStatusRejected:
+			title = "Отклик отклонен"
+			message = fmt.Sprintf("Ваш отклик на кастинг '%s' был отклонен", item.CastingTitle)
+		default:
+			title = "Статус отклика изменен"
+			message = fmt.Sprintf("Статус вашего отклика на кастинг '%s' изменен", item.CastingTitle)
+		}
+		if item.Message != "" {
+			message = item.Message
+		}
+
+		data := map[string]interface{}{
+			"casting_id":  item.CastingID,
+			"response_id": item.ResponseID,
+			"status":      item.Status,
+		}
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		notifications = append(notifications, &models.Notification{
+			UserID:  item.ModelID,
+			Type:    NotificationTypeResponseStatus,
+			Title:   title,
+			Message: message,
+			Data:    datatypes.JSON(jsonData),
+		})
+	}
+	// ✅ Передаем db
+	return r.CreateBulkNotifications(db, notifications)
+}
+
+func (r *NotificationRepositoryImpl) CreateCastingCancelledNotifications(db *gorm.DB, userIDs []string, castingID, castingTitle, reason string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	data := map[string]interface{}{
+		"casting_id": castingID,
+		"status":     models.CastingStatusCancelled,
+		"reason":     reason,
+	}
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("Кастинг '%s', на который вы откликнулись, был отменен", castingTitle)
+	if reason != "" {
+		message = fmt.Sprintf("%s. Причина: %s", message, reason)
+	}
+
+	var notifications []*models.Notification
+	for _, userID := range userIDs {
+		notifications = append(notifications, &models.Notification{
+			UserID:  userID,
+			Type:    NotificationTypeCastingStatus,
+			Title:   "Кастинг отменен",
+			Message: message,
+			Data:    datatypes.JSON(jsonData),
+		})
+	}
+	return r.CreateBulkNotifications(db, notifications)
+}
+
+// CreateRepresentationNotification - приглашение агентства или изменение представительства
+func (r *NotificationRepositoryImpl) CreateRepresentationNotification(db *gorm.DB, userID, representationID, title, message string) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"representation_id": representationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    NotificationTypeRepresentation,
+		Title:   title,
+		Message: message,
+		Data:    datatypes.JSON(jsonData),
+	}
+	return r.CreateNotification(db, notification)
+}
+
+// CreateVerificationNotification - решение модератора по заявке на верификацию или истечение срока
+func (r *NotificationRepositoryImpl) CreateVerificationNotification(db *gorm.DB, userID, verificationID, status, title, message string) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"verification_id": verificationID,
+		"status":          status,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    NotificationTypeVerification,
+		Title:   title,
+		Message: message,
+		Data:    datatypes.JSON(jsonData),
+	}
+	return r.CreateNotification(db, notification)
+}
+
+// CreateReportNotification - решение модератора по жалобе (для автора жалобы)
+func (r *NotificationRepositoryImpl) CreateReportNotification(db *gorm.DB, userID, reportID, status, title, message string) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"report_id": reportID,
+		"status":    status,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    NotificationTypeReport,
+		Title:   title,
+		Message: message,
+		Data:    datatypes.JSON(jsonData),
+	}
+	return r.CreateNotification(db, notification)
+}
+
+// CreatePhotoRejectedNotification - фото не прошли модерацию (одно уведомление на пакет решений)
+func (r *NotificationRepositoryImpl) CreatePhotoRejectedNotification(db *gorm.DB, userID string, uploadIDs []string, reason string) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"upload_ids": uploadIDs,
+		"reason":     reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    NotificationTypePhotoModeration,
+		Title:   "Фото не прошли модерацию",
+		Message: fmt.Sprintf("Отклонено фото: %d. Причина: %s", len(uploadIDs), reason),
+		Data:    datatypes.JSON(jsonData),
+	}
+	return r.CreateNotification(db, notification)
+}
+
+// CreateProfileCompletenessNotification - напоминание дозаполнить профиль модели
+func (r *NotificationRepositoryImpl) CreateProfileCompletenessNotification(db *gorm.DB, userID string, score int, missing []string) error {
+	jsonData, err := json.Marshal(map[string]interface{}{
+		"score":   score,
+		"missing": missing,
+	})
+	if err != nil {
+		return err
+	}
+
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    NotificationTypeProfileCompleteness,
+		Title:   "Заполните профиль",
+		Message: fmt.Sprintf("Ваш профиль заполнен на %d%%. Полные профили чаще находят работодатели.", score),
+		Data:    datatypes.JSON(jsonData),
+	}
+	return r.CreateNotification(db, notification)
+}
+
 // Helper methods
 
+// routeRepresented - уведомления о работе модели с активным агентством доставляются агентству
+// (с именем модели в заголовке); сама модель получает их, только если включена копия
+func (r *NotificationRepositoryImpl) routeRepresented(db *gorm.DB, notifications []*models.Notification) ([]*models.Notification, error) {
+	var userIDs []string
+	for _, n := range notifications {
+		if representedNotificationTypes[n.Type] {
+			userIDs = append(userIDs, n.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return notifications, nil
+	}
+
+	var routes []representationRoute
+	err := db.Table("model_representations AS mr").
+		Select("mr.model_user_id, mr.model_profile_id, mp.name AS model_name, ap.user_id AS agency_user_id, mr.copy_model").
+		Joins("JOIN agency_profiles ap ON ap.id = mr.agency_id").
+		Joins("JOIN model_profiles mp ON mp.id = mr.model_profile_id").
+		Where("mr.model_user_id IN ? AND mr.status = ?", userIDs, models.RepresentationStatusActive).
+		Scan(&routes).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(routes) == 0 {
+		return notifications, nil
+	}
+	byModel := make(map[string]representationRoute, len(routes))
+	for _, route := range routes {
+		byModel[route.ModelUserID] = route
+	}
+
+	routed := make([]*models.Notification, 0, len(notifications)+len(routes))
+	for _, n := range notifications {
+		route, ok := byModel[n.UserID]
+		if !ok || !representedNotificationTypes[n.Type] {
+			routed = append(routed, n)
+			continue
+		}
+
+		data := map[string]interface{}{}
+		if len(n.Data) > 0 {
+			_ = json.Unmarshal(n.Data, &data)
+		}
+		data["represented_model_id"] = route.ModelProfileID
+		data["represented_model_user_id"] = route.ModelUserID
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		routed = append(routed, &models.Notification{
+			UserID:  route.AgencyUserID,
+			Type:    n.Type,
+			Title:   fmt.Sprintf("%s: %s", route.ModelName, n.Title),
+			Message: n.Message,
+			Data:    datatypes.JSON(jsonData),
+		})
+		if route.CopyModel {
+			routed = append(routed, n)
+		}
+	}
+	return routed, nil
+}
+
 // ✅ Метод теперь принимает 'db' (хотя и не использует), для согласованности интерфейса
 func (r *NotificationRepositoryImpl) validateNotification(db *gorm.DB, notification *models.Notification) error {
 	if notification.UserID == "" {
@@ -724,6 +1096,12 @@ func (r *NotificationRepositoryImpl) validateNotification(db *gorm.DB, notificat
 		NotificationTypeProfileView:          true,
 		NotificationTypePasswordReset:        true,
 		NotificationTypeAnnouncement:         true,
+		NotificationTypeCastingStatus:        true,
+		NotificationTypeRepresentation:       true,
+		NotificationTypeVerification:         true,
+		NotificationTypeProfileCompleteness:  true,
+		NotificationTypeReport:               true,
+		NotificationTypePhotoModeration:      true,
 	}
 
 	if !validTypes[notification.Type] {
@@ -3,14 +3,17 @@ package repositories
 import (
 	"errors"
 	"mwork_backend/internal/models"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
-	ErrPortfolioItemNotFound = errors.New("portfolio item not found")
-	ErrInvalidPortfolioOrder = errors.New("invalid portfolio order")
+	ErrPortfolioItemNotFound  = errors.New("portfolio item not found")
+	ErrInvalidPortfolioOrder  = errors.New("invalid portfolio order")
+	ErrPortfolioAlbumNotFound = errors.New("portfolio album not found")
 	// ▼▼▼ УДАЛЕНО ▼▼▼
 	// ErrUploadNotFound        = errors.New("upload not found")
 )
@@ -20,6 +23,7 @@ type PortfolioRepository interface {
 	CreatePortfolioItem(db *gorm.DB, item *models.PortfolioItem) error
 	FindPortfolioItemByID(db *gorm.DB, id string) (*models.PortfolioItem, error)
 	FindPortfolioByModel(db *gorm.DB, modelID string) ([]models.PortfolioItem, error)
+	FindPublicPortfolioByModel(db *gorm.DB, modelID string) ([]models.PortfolioItem, error)
 	FindFeaturedPortfolioItems(db *gorm.DB, limit int) ([]models.PortfolioItem, error)
 	FindRecentPortfolioItems(db *gorm.DB, limit int) ([]models.PortfolioItem, error)
 	UpdatePortfolioItem(db *gorm.DB, item *models.PortfolioItem) error
@@ -27,6 +31,7 @@ type PortfolioRepository interface {
 	DeletePortfolioItem(db *gorm.DB, id string) error
 	ReorderPortfolioItems(db *gorm.DB, modelID string, itemIDs []string) error
 	GetPortfolioStats(db *gorm.DB, modelID string) (*PortfolioStats, error)
+	CountPortfolioPhotos(db *gorm.DB, modelID string) (int64, error)
 
 	// ▼▼▼ УДАЛЕНО: Все операции Upload теперь в UploadRepository ▼▼▼
 	// CreateUpload(db *gorm.DB, upload *models.Upload) error
@@ -51,6 +56,29 @@ type PortfolioRepository interface {
 	// Additional methods
 	FindPortfolioItemsByFileType(db *gorm.DB, modelID, fileType string) ([]models.PortfolioItem, error)
 	UpdatePortfolioItemVisibility(db *gorm.DB, itemID string, isPublic bool) error
+
+	// Albums
+	CreateAlbum(db *gorm.DB, album *models.PortfolioAlbum) error
+	FindAlbumByID(db *gorm.DB, id string) (*models.PortfolioAlbum, error)
+	FindAlbumsByModel(db *gorm.DB, modelID string) ([]models.PortfolioAlbum, error)
+	UpdateAlbum(db *gorm.DB, album *models.PortfolioAlbum) error
+	DeleteAlbum(db *gorm.DB, id string) error
+	ReorderAlbums(db *gorm.DB, modelID string, albumIDs []string) error
+	FindAlbumItems(db *gorm.DB, albumID string) ([]models.PortfolioItem, error)
+	MoveItemsToAlbum(db *gorm.DB, itemIDs []string, albumID *string) error
+	ReorderAlbumItems(db *gorm.DB, albumID string, itemIDs []string) error
+
+	// Likes & saves
+	LikeItem(db *gorm.DB, itemID, userID string) (bool, error)
+	UnlikeItem(db *gorm.DB, itemID, userID string) (bool, error)
+	SaveItem(db *gorm.DB, itemID, userID string) (bool, error)
+	UnsaveItem(db *gorm.DB, itemID, userID string) (bool, error)
+	FindUserReactions(db *gorm.DB, userID string, itemIDs []string) (liked, saved map[string]bool, err error)
+	FindSavedItems(db *gorm.DB, userID string, page, pageSize int) ([]models.PortfolioItem, int64, error)
+
+	// Featured ranking
+	FindFeaturedCandidates(db *gorm.DB) ([]FeaturedCandidate, error)
+	UpdateFeaturedScores(db *gorm.DB, scores map[string]float64) error
 }
 
 type PortfolioRepositoryImpl struct {
@@ -67,6 +95,17 @@ type PortfolioStats struct {
 	LastUpdated    time.Time `json:"last_updated"`
 }
 
+// FeaturedCandidate - сигналы для рейтинга featured по одной работе
+type FeaturedCandidate struct {
+	ItemID            string
+	LikesCount        int
+	SavesCount        int
+	CreatedAt         time.Time
+	ModerationStatus  models.ModerationStatus
+	Rating            float64
+	CompletenessScore int
+}
+
 func NewPortfolioRepository() PortfolioRepository {
 	return &PortfolioRepositoryImpl{}
 }
@@ -105,13 +144,30 @@ func (r *PortfolioRepositoryImpl) FindPortfolioByModel(db *gorm.DB, modelID stri
 	return items, err
 }
 
+// FindPublicPortfolioByModel - работы модели вне закрытых альбомов (для страниц и документов,
+// которые видят другие пользователи)
+func (r *PortfolioRepositoryImpl) FindPublicPortfolioByModel(db *gorm.DB, modelID string) ([]models.PortfolioItem, error) {
+	var items []models.PortfolioItem
+	err := db.Preload("Upload").
+		Joins("LEFT JOIN portfolio_albums pa ON portfolio_items.album_id = pa.id").
+		Where("portfolio_items.model_id = ?", modelID).
+		Where("pa.id IS NULL OR pa.visibility <> ?", models.AlbumVisibilityPrivate).
+		Order("portfolio_items.order_index ASC").Find(&items).Error
+	return items, err
+}
+
+// FindFeaturedPortfolioItems - работы по рейтингу featured (см. UpdateFeaturedScores);
+// модерация и закрытые альбомы проверяются повторно, т.к. могли измениться после пересчета
 func (r *PortfolioRepositoryImpl) FindFeaturedPortfolioItems(db *gorm.DB, limit int) ([]models.PortfolioItem, error) {
-	// (Логика FindFeaturedPortfolioItems без изменений)
 	var items []models.PortfolioItem
 	err := db.Preload("Upload").Preload("Model").
-		Joins("LEFT JOIN model_profiles mp ON portfolio_items.model_id = mp.id").
-		Where("mp.rating >= ? AND mp.is_public = ?", 4.0, true).
-		Order("mp.rating DESC, portfolio_items.order_index ASC").
+		Joins("JOIN model_profiles mp ON portfolio_items.model_id = mp.id").
+		Joins("JOIN uploads ON portfolio_items.upload_id = uploads.id").
+		Joins("LEFT JOIN portfolio_albums pa ON portfolio_items.album_id = pa.id").
+		Where("portfolio_items.featured_score > 0 AND mp.is_public = ?", true).
+		Where("uploads.moderation_status = ?", models.ModerationStatusApproved).
+		Where("pa.id IS NULL OR pa.visibility <> ?", models.AlbumVisibilityPrivate).
+		Order("portfolio_items.featured_score DESC, portfolio_items.created_at DESC").
 		Limit(limit).
 		Find(&items).Error
 	return items, err
@@ -196,6 +252,17 @@ func (r *PortfolioRepositoryImpl) ReorderPortfolioItems(db *gorm.DB, modelID str
 	return nil
 }
 
+// CountPortfolioPhotos - количество одобренных модерацией фото в портфолио модели
+func (r *PortfolioRepositoryImpl) CountPortfolioPhotos(db *gorm.DB, modelID string) (int64, error) {
+	var count int64
+	err := db.Model(&models.PortfolioItem{}).
+		Joins("JOIN uploads ON portfolio_items.upload_id = uploads.id").
+		Where("portfolio_items.model_id = ? AND uploads.file_type = ?", modelID, "image").
+		Where("uploads.moderation_status = ?", models.ModerationStatusApproved).
+		Count(&count).Error
+	return count, err
+}
+
 func (r *PortfolioRepositoryImpl) GetPortfolioStats(db *gorm.DB, modelID string) (*PortfolioStats, error) {
 	// (Логика GetPortfolioStats без изменений)
 	var stats PortfolioStats
@@ -292,8 +359,300 @@ func (r *PortfolioRepositoryImpl) FindRecentPortfolioItems(db *gorm.DB, limit in
 	// (Логика FindRecentPortfolioItems без изменений)
 	var items []models.PortfolioItem
 	err := db.Preload("Upload").Preload("Model").
+		Joins("JOIN uploads ON portfolio_items.upload_id = uploads.id").
+		Joins("LEFT JOIN portfolio_albums pa ON portfolio_items.album_id = pa.id").
+		Where("uploads.moderation_status = ?", models.ModerationStatusApproved).
+		Where("pa.id IS NULL OR pa.visibility <> ?", models.AlbumVisibilityPrivate).
 		Order("portfolio_items.created_at DESC").
 		Limit(limit).
 		Find(&items).Error
 	return items, err
 }
+
+// Albums
+
+func (r *PortfolioRepositoryImpl) CreateAlbum(db *gorm.DB, album *models.PortfolioAlbum) error {
+	if album.OrderIndex == 0 {
+		var maxOrder int
+		db.Model(&models.PortfolioAlbum{}).Where("model_id = ?", album.ModelID).
+			Select("COALESCE(MAX(order_index), 0)").Scan(&maxOrder)
+		album.OrderIndex = maxOrder + 1
+	}
+	return db.Create(album).Error
+}
+
+func (r *PortfolioRepositoryImpl) FindAlbumByID(db *gorm.DB, id string) (*models.PortfolioAlbum, error) {
+	var album models.PortfolioAlbum
+	err := db.First(&album, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPortfolioAlbumNotFound
+		}
+		return nil, err
+	}
+	return &album, nil
+}
+
+func (r *PortfolioRepositoryImpl) FindAlbumsByModel(db *gorm.DB, modelID string) ([]models.PortfolioAlbum, error) {
+	var albums []models.PortfolioAlbum
+	err := db.Where("model_id = ?", modelID).
+		Order("order_index ASC, created_at ASC").Find(&albums).Error
+	return albums, err
+}
+
+func (r *PortfolioRepositoryImpl) UpdateAlbum(db *gorm.DB, album *models.PortfolioAlbum) error {
+	result := db.Model(album).Updates(map[string]interface{}{
+		"title":         album.Title,
+		"description":   album.Description,
+		"visibility":    album.Visibility,
+		"cover_item_id": album.CoverItemID,
+		"updated_at":    time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPortfolioAlbumNotFound
+	}
+	return nil
+}
+
+// DeleteAlbum - элементы альбома возвращаются в общий список (album_id обнуляется внешним ключом)
+func (r *PortfolioRepositoryImpl) DeleteAlbum(db *gorm.DB, id string) error {
+	var album models.PortfolioAlbum
+	if err := db.First(&album, "id = ?", id).Error; err != nil {
+		return ErrPortfolioAlbumNotFound
+	}
+	if err := db.Model(&models.PortfolioItem{}).Where("album_id = ?", id).
+		Updates(map[string]interface{}{"album_id": nil, "album_order_index": 0}).Error; err != nil {
+		return err
+	}
+	if err := db.Delete(&album).Error; err != nil {
+		return err
+	}
+	return db.Model(&models.PortfolioAlbum{}).
+		Where("model_id = ? AND order_index > ?", album.ModelID, album.OrderIndex).
+		Update("order_index", gorm.Expr("order_index - ?", 1)).Error
+}
+
+func (r *PortfolioRepositoryImpl) ReorderAlbums(db *gorm.DB, modelID string, albumIDs []string) error {
+	for order, albumID := range albumIDs {
+		if err := db.Model(&models.PortfolioAlbum{}).
+			Where("id = ? AND model_id = ?", albumID, modelID).
+			Update("order_index", order+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PortfolioRepositoryImpl) FindAlbumItems(db *gorm.DB, albumID string) ([]models.PortfolioItem, error) {
+	var items []models.PortfolioItem
+	err := db.Preload("Upload").Where("album_id = ?", albumID).
+		Order("album_order_index ASC, order_index ASC").Find(&items).Error
+	return items, err
+}
+
+// MoveItemsToAlbum - элементы добавляются в конец альбома в переданном порядке;
+// albumID == nil убирает их из альбомов. Обложка, ушедшая в другой альбом, сбрасывается.
+func (r *PortfolioRepositoryImpl) MoveItemsToAlbum(db *gorm.DB, itemIDs []string, albumID *string) error {
+	coverReset := db.Model(&models.PortfolioAlbum{}).Where("cover_item_id IN ?", itemIDs)
+	if albumID != nil {
+		coverReset = coverReset.Where("id <> ?", *albumID)
+	}
+	if err := coverReset.Update("cover_item_id", nil).Error; err != nil {
+		return err
+	}
+
+	if albumID == nil {
+		return db.Model(&models.PortfolioItem{}).Where("id IN ?", itemIDs).
+			Updates(map[string]interface{}{"album_id": nil, "album_order_index": 0}).Error
+	}
+
+	var maxOrder int
+	if err := db.Model(&models.PortfolioItem{}).
+		Where("album_id = ? AND id NOT IN ?", *albumID, itemIDs).
+		Select("COALESCE(MAX(album_order_index), 0)").Scan(&maxOrder).Error; err != nil {
+		return err
+	}
+	for i, itemID := range itemIDs {
+		if err := db.Model(&models.PortfolioItem{}).Where("id = ?", itemID).
+			Updates(map[string]interface{}{"album_id": *albumID, "album_order_index": maxOrder + i + 1}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PortfolioRepositoryImpl) ReorderAlbumItems(db *gorm.DB, albumID string, itemIDs []string) error {
+	for order, itemID := range itemIDs {
+		if err := db.Model(&models.PortfolioItem{}).
+			Where("id = ? AND album_id = ?", itemID, albumID).
+			Update("album_order_index", order+1).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Likes & saves
+
+// LikeItem - false, если пользователь уже лайкнул работу (счетчик не меняется)
+func (r *PortfolioRepositoryImpl) LikeItem(db *gorm.DB, itemID, userID string) (bool, error) {
+	return addReaction(db, &models.PortfolioItemLike{ItemID: itemID, UserID: userID}, itemID, "likes_count")
+}
+
+func (r *PortfolioRepositoryImpl) UnlikeItem(db *gorm.DB, itemID, userID string) (bool, error) {
+	return removeReaction(db, &models.PortfolioItemLike{}, itemID, userID, "likes_count")
+}
+
+func (r *PortfolioRepositoryImpl) SaveItem(db *gorm.DB, itemID, userID string) (bool, error) {
+	return addReaction(db, &models.PortfolioItemSave{ItemID: itemID, UserID: userID}, itemID, "saves_count")
+}
+
+func (r *PortfolioRepositoryImpl) UnsaveItem(db *gorm.DB, itemID, userID string) (bool, error) {
+	return removeReaction(db, &models.PortfolioItemSave{}, itemID, userID, "saves_count")
+}
+
+func (r *PortfolioRepositoryImpl) FindUserReactions(db *gorm.DB, userID string, itemIDs []string) (map[string]bool, map[string]bool, error) {
+	liked, saved := map[string]bool{}, map[string]bool{}
+	if userID == "" || len(itemIDs) == 0 {
+		return liked, saved, nil
+	}
+
+	var ids []string
+	if err := db.Model(&models.PortfolioItemLike{}).Where("user_id = ? AND item_id IN ?", userID, itemIDs).
+		Pluck("item_id", &ids).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, id := range ids {
+		liked[id] = true
+	}
+
+	ids = nil
+	if err := db.Model(&models.PortfolioItemSave{}).Where("user_id = ? AND item_id IN ?", userID, itemIDs).
+		Pluck("item_id", &ids).Error; err != nil {
+		return nil, nil, err
+	}
+	for _, id := range ids {
+		saved[id] = true
+	}
+	return liked, saved, nil
+}
+
+// FindSavedItems - сохраненные работы пользователя, последние сохраненные первыми
+func (r *PortfolioRepositoryImpl) FindSavedItems(db *gorm.DB, userID string, page, pageSize int) ([]models.PortfolioItem, int64, error) {
+	var items []models.PortfolioItem
+	var total int64
+
+	query := db.Model(&models.PortfolioItem{}).
+		Joins("JOIN portfolio_item_saves s ON s.item_id = portfolio_items.id").
+		Where("s.user_id = ?", userID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.Preload("Upload").
+		Order("s.created_at DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).
+		Find(&items).Error
+	return items, total, err
+}
+
+// Featured ranking
+
+// FindFeaturedCandidates - работы публичных моделей с загруженным файлом вне закрытых альбомов
+func (r *PortfolioRepositoryImpl) FindFeaturedCandidates(db *gorm.DB) ([]FeaturedCandidate, error) {
+	var candidates []FeaturedCandidate
+	err := db.Table("portfolio_items").
+		Select(`portfolio_items.id AS item_id, portfolio_items.likes_count, portfolio_items.saves_count,
+			portfolio_items.created_at, uploads.moderation_status, mp.rating, mp.completeness_score`).
+		Joins("JOIN uploads ON portfolio_items.upload_id = uploads.id").
+		Joins("JOIN model_profiles mp ON portfolio_items.model_id = mp.id").
+		Joins("LEFT JOIN portfolio_albums pa ON portfolio_items.album_id = pa.id").
+		Where("mp.is_public = ?", true).
+		Where("pa.id IS NULL OR pa.visibility <> ?", models.AlbumVisibilityPrivate).
+		Scan(&candidates).Error
+	return candidates, err
+}
+
+// featuredScoreBatchSize - строк на один INSERT во временную таблицу
+// (по 2 параметра на строку, Postgres допускает не больше 65535 параметров)
+const featuredScoreBatchSize = 5000
+
+// UpdateFeaturedScores - записывает новые рейтинги; работы, не попавшие в scores, выпадают из подборки.
+// Рейтинги сначала пишутся во временную таблицу, затем применяются двумя UPDATE с join,
+// поэтому строки портфолио блокируются только на время этих двух запросов, а неизменившиеся не трогаются.
+func (r *PortfolioRepositoryImpl) UpdateFeaturedScores(db *gorm.DB, scores map[string]float64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Внутри внешней транзакции (ON COMMIT еще не сработал) таблица может остаться от прошлого вызова
+		if err := tx.Exec("DROP TABLE IF EXISTS pg_temp.featured_scores_tmp").Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`CREATE TEMP TABLE featured_scores_tmp (
+			item_id UUID PRIMARY KEY,
+			score DOUBLE PRECISION NOT NULL
+		) ON COMMIT DROP`).Error; err != nil {
+			return err
+		}
+
+		values := make([]string, 0, featuredScoreBatchSize)
+		args := make([]interface{}, 0, 2*featuredScoreBatchSize)
+		flush := func() error {
+			if len(values) == 0 {
+				return nil
+			}
+			err := tx.Exec("INSERT INTO featured_scores_tmp (item_id, score) VALUES "+strings.Join(values, ", "), args...).Error
+			values, args = values[:0], args[:0]
+			return err
+		}
+		for id, score := range scores {
+			values = append(values, "(?, ?)")
+			args = append(args, id, score)
+			if len(values) == featuredScoreBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`UPDATE portfolio_items pi SET featured_score = fs.score
+			FROM featured_scores_tmp fs
+			WHERE pi.id = fs.item_id AND pi.featured_score IS DISTINCT FROM fs.score`).Error; err != nil {
+			return err
+		}
+		return tx.Exec(`UPDATE portfolio_items pi SET featured_score = 0
+			WHERE pi.featured_score <> 0
+			AND NOT EXISTS (SELECT 1 FROM featured_scores_tmp fs WHERE fs.item_id = pi.id)`).Error
+	})
+}
+
+// addReaction - вставка лайка/сохранения и увеличение счетчика; повторная реакция ничего не меняет
+func addReaction(db *gorm.DB, reaction interface{}, itemID, counter string) (bool, error) {
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	err := db.Model(&models.PortfolioItem{}).Where("id = ?", itemID).
+		UpdateColumn(counter, gorm.Expr(counter+" + 1")).Error
+	return err == nil, err
+}
+
+func removeReaction(db *gorm.DB, model interface{}, itemID, userID, counter string) (bool, error) {
+	result := db.Where("item_id = ? AND user_id = ?", itemID, userID).Delete(model)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	err := db.Model(&models.PortfolioItem{}).Where("id = ?", itemID).
+		UpdateColumn(counter, gorm.Expr("GREATEST("+counter+" - 1, 0)")).Error
+	return err == nil, err
+}
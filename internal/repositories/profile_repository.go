@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/models"
 	"strings"
 	"time"
@@ -31,13 +32,18 @@ type ProfileRepository interface {
 	FindFeaturedModels(db *gorm.DB, limit int) ([]models.ModelProfile, error)
 	FindModelsByCity(db *gorm.DB, city string) ([]models.ModelProfile, error)
 	GetModelStats(db *gorm.DB, modelID string) (*ModelStats, error)
+	UpdateModelCompleteness(db *gorm.DB, modelID string, score int) error
+	FindIncompleteModelProfiles(db *gorm.DB, threshold int, nudgedBefore, createdBefore time.Time, limit int) ([]models.ModelProfile, error)
+	MarkCompletenessNudged(db *gorm.DB, modelID string, at time.Time) error
+	UpdateEmployerAnonymousBrowsing(db *gorm.DB, employerID string, enabled bool) error
 
 	// EmployerProfile operations
 	CreateEmployerProfile(db *gorm.DB, profile *models.EmployerProfile) error
 	FindEmployerProfileByID(db *gorm.DB, id string) (*models.EmployerProfile, error)
 	FindEmployerProfileByUserID(db *gorm.DB, userID string) (*models.EmployerProfile, error)
 	UpdateEmployerProfile(db *gorm.DB, profile *models.EmployerProfile) error
-	VerifyEmployerProfile(db *gorm.DB, employerID string) error
+	VerifyEmployerProfile(db *gorm.DB, employerID string, verifiedUntil *time.Time) error
+	RevokeExpiredEmployerVerifications(db *gorm.DB, now time.Time) (int64, error)
 	DeleteEmployerProfile(db *gorm.DB, id string) error
 	SearchEmployerProfiles(db *gorm.DB, criteria EmployerSearchCriteria) ([]models.EmployerProfile, int64, error)
 	FindEmployersWithActiveCastings(db *gorm.DB, limit int) ([]models.EmployerProfile, error)
@@ -65,6 +71,19 @@ type ModelSearchCriteria struct {
 	MaxHeight     *int     `form:"max_height"`
 	MinWeight     *int     `form:"min_weight"`
 	MaxWeight     *int     `form:"max_weight"`
+	MinBust       *int     `form:"min_bust"`
+	MaxBust       *int     `form:"max_bust"`
+	MinWaist      *int     `form:"min_waist"`
+	MaxWaist      *int     `form:"max_waist"`
+	MinHips       *int     `form:"min_hips"`
+	MaxHips       *int     `form:"max_hips"`
+	HairColors    []string `form:"hair_colors[]"`
+	EyeColors     []string `form:"eye_colors[]"`
+	HasTattoos    *bool    `form:"has_tattoos"`
+	HasPiercings  *bool    `form:"has_piercings"`
+	ClothingSizes []string `form:"clothing_sizes[]"` // Международные буквы
+	MinShoeSize   *float64 `form:"min_shoe_size"`    // EU
+	MaxShoeSize   *float64 `form:"max_shoe_size"`
 	MinPrice      *int     `form:"min_price"`
 	MaxPrice      *int     `form:"max_price"`
 	MinExperience *int     `form:"min_experience"`
@@ -76,6 +95,12 @@ type ModelSearchCriteria struct {
 	PageSize      int      `form:"page_size" binding:"min=1,max=100"`
 	SortBy        string   `form:"sort_by"`
 	SortOrder     string   `form:"sort_order"`
+	// Незаполненные профили (ниже порога) - в конце выдачи
+	DownRankIncomplete bool `form:"down_rank_incomplete"`
+	// Кто ищет: модели из его черного списка и заблокировавшие его не показываются
+	HiddenForUserID string `form:"-"`
+	// QueryLocales - в каких переводах description_i18n дополнительно искать Query
+	QueryLocales []string `form:"-"`
 }
 
 // Добавляем EmployerSearchCriteria
@@ -86,6 +111,8 @@ type EmployerSearchCriteria struct {
 	IsVerified  *bool  `form:"is_verified"`
 	Page        int    `form:"page" binding:"min=1"`
 	PageSize    int    `form:"page_size" binding:"min=1,max=100"`
+	// Кто ищет: работодатели из его черного списка и заблокировавшие его не показываются
+	HiddenForUserID string `form:"-"`
 }
 
 // Statistics for model profile
@@ -147,22 +174,34 @@ func (r *ProfileRepositoryImpl) FindModelProfileByUserID(db *gorm.DB, userID str
 func (r *ProfileRepositoryImpl) UpdateModelProfile(db *gorm.DB, profile *models.ModelProfile) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Model(profile).Updates(map[string]interface{}{
-		"name":            profile.Name,
-		"age":             profile.Age,
-		"height":          profile.Height,
-		"weight":          profile.Weight,
-		"gender":          profile.Gender,
-		"experience":      profile.Experience,
-		"hourly_rate":     profile.HourlyRate,
-		"description":     profile.Description,
-		"clothing_size":   profile.ClothingSize,
-		"shoe_size":       profile.ShoeSize,
-		"city":            profile.City,
-		"languages":       profile.Languages,
-		"categories":      profile.Categories,
-		"barter_accepted": profile.BarterAccepted,
-		"is_public":       profile.IsPublic,
-		"updated_at":      time.Now(),
+		"name":               profile.Name,
+		"age":                profile.Age,
+		"height":             profile.Height,
+		"weight":             profile.Weight,
+		"gender":             profile.Gender,
+		"experience":         profile.Experience,
+		"hourly_rate":        profile.HourlyRate,
+		"description":        profile.Description,
+		"clothing_size":      profile.ClothingSize,
+		"shoe_size":          profile.ShoeSize,
+		"shoe_size_eu":       profile.ShoeSizeEU,
+		"bust":               profile.Bust,
+		"waist":              profile.Waist,
+		"hips":               profile.Hips,
+		"inseam":             profile.Inseam,
+		"hair_color":         profile.HairColor,
+		"eye_color":          profile.EyeColor,
+		"has_tattoos":        profile.HasTattoos,
+		"has_piercings":      profile.HasPiercings,
+		"city":               profile.City,
+		"languages":          profile.Languages,
+		"categories":         profile.Categories,
+		"barter_accepted":    profile.BarterAccepted,
+		"is_public":          profile.IsPublic,
+		"completeness_score": profile.CompletenessScore,
+		"content_locale":     profile.ContentLocale,
+		"description_i18n":   profile.DescriptionI18n,
+		"updated_at":         time.Now(),
 	})
 
 	if result.Error != nil {
@@ -174,6 +213,35 @@ func (r *ProfileRepositoryImpl) UpdateModelProfile(db *gorm.DB, profile *models.
 	return nil
 }
 
+// UpdateModelCompleteness - сохраняет пересчитанную заполненность профиля
+func (r *ProfileRepositoryImpl) UpdateModelCompleteness(db *gorm.DB, modelID string, score int) error {
+	return db.Model(&models.ModelProfile{}).Where("id = ?", modelID).
+		Update("completeness_score", score).Error
+}
+
+// FindIncompleteModelProfiles - незаполненные профили для напоминаний: не новые (createdBefore)
+// и без напоминания после nudgedBefore
+func (r *ProfileRepositoryImpl) FindIncompleteModelProfiles(db *gorm.DB, threshold int, nudgedBefore, createdBefore time.Time, limit int) ([]models.ModelProfile, error) {
+	var profiles []models.ModelProfile
+	err := db.Where("completeness_score < ? AND created_at < ?", threshold, createdBefore).
+		Where("completeness_nudged_at IS NULL OR completeness_nudged_at < ?", nudgedBefore).
+		Order("completeness_score ASC").
+		Limit(limit).
+		Find(&profiles).Error
+	return profiles, err
+}
+
+func (r *ProfileRepositoryImpl) MarkCompletenessNudged(db *gorm.DB, modelID string, at time.Time) error {
+	return db.Model(&models.ModelProfile{}).Where("id = ?", modelID).
+		Update("completeness_nudged_at", at).Error
+}
+
+// UpdateEmployerAnonymousBrowsing - анонимный просмотр профилей моделей
+func (r *ProfileRepositoryImpl) UpdateEmployerAnonymousBrowsing(db *gorm.DB, employerID string, enabled bool) error {
+	return db.Model(&models.EmployerProfile{}).Where("id = ?", employerID).
+		Update("anonymous_browsing", enabled).Error
+}
+
 func (r *ProfileRepositoryImpl) UpdateModelProfileRating(db *gorm.DB, modelID string, newRating float64) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Model(&models.ModelProfile{}).Where("id = ?", modelID).Update("rating", newRating)
@@ -215,10 +283,15 @@ func (r *ProfileRepositoryImpl) SearchModelProfiles(db *gorm.DB, criteria ModelS
 		query = query.Where("is_public = ?", true)
 	}
 
+	if criteria.HiddenForUserID != "" {
+		query = query.Where("user_id NOT IN (?)", blockedUsersSubquery(db, criteria.HiddenForUserID))
+	}
+
 	// Text search
 	if criteria.Query != "" {
 		search := "%" + criteria.Query + "%"
-		query = query.Where("name ILIKE ? OR description ILIKE ?", search, search)
+		clause, args := localizedSearchClause([]string{"name"}, []string{"description"}, criteria.QueryLocales, search)
+		query = query.Where(clause, args...)
 	}
 
 	// Basic filters
@@ -254,6 +327,48 @@ func (r *ProfileRepositoryImpl) SearchModelProfiles(db *gorm.DB, criteria ModelS
 		query = query.Where("weight <= ?", *criteria.MaxWeight)
 	}
 
+	// Параметры фигуры (см); незаполненные (0) не проходят фильтр
+	for column, bounds := range map[string][2]*int{
+		"bust":  {criteria.MinBust, criteria.MaxBust},
+		"waist": {criteria.MinWaist, criteria.MaxWaist},
+		"hips":  {criteria.MinHips, criteria.MaxHips},
+	} {
+		if bounds[0] != nil {
+			query = query.Where(column+" >= ?", *bounds[0])
+		}
+		if bounds[1] != nil {
+			query = query.Where(column+" > 0 AND "+column+" <= ?", *bounds[1])
+		}
+	}
+
+	if len(criteria.HairColors) > 0 {
+		query = query.Where("hair_color IN ?", criteria.HairColors)
+	}
+
+	if len(criteria.EyeColors) > 0 {
+		query = query.Where("eye_color IN ?", criteria.EyeColors)
+	}
+
+	if criteria.HasTattoos != nil {
+		query = query.Where("has_tattoos = ?", *criteria.HasTattoos)
+	}
+
+	if criteria.HasPiercings != nil {
+		query = query.Where("has_piercings = ?", *criteria.HasPiercings)
+	}
+
+	if len(criteria.ClothingSizes) > 0 {
+		query = query.Where("clothing_size IN ?", criteria.ClothingSizes)
+	}
+
+	if criteria.MinShoeSize != nil {
+		query = query.Where("shoe_size_eu >= ?", *criteria.MinShoeSize)
+	}
+
+	if criteria.MaxShoeSize != nil {
+		query = query.Where("shoe_size_eu > 0 AND shoe_size_eu <= ?", *criteria.MaxShoeSize)
+	}
+
 	if criteria.MinPrice != nil {
 		query = query.Where("hourly_rate >= ?", *criteria.MinPrice)
 	}
@@ -315,6 +430,10 @@ func (r *ProfileRepositoryImpl) SearchModelProfiles(db *gorm.DB, criteria ModelS
 	// Apply sorting
 	sortField := getModelSortField(criteria.SortBy)
 	sortOrder := getSortOrder(criteria.SortOrder)
+	if criteria.DownRankIncomplete {
+		query = query.Order(fmt.Sprintf("CASE WHEN completeness_score >= %d THEN 0 ELSE 1 END",
+			algorithms.CompleteProfileThreshold))
+	}
 	query = query.Order(fmt.Sprintf("%s %s", sortField, sortOrder))
 
 	// Apply pagination
@@ -543,11 +662,13 @@ func (r *ProfileRepositoryImpl) UpdateEmployerProfile(db *gorm.DB, profile *mode
 	return nil
 }
 
-func (r *ProfileRepositoryImpl) VerifyEmployerProfile(db *gorm.DB, employerID string) error {
+// VerifyEmployerProfile - значок "verified" до verifiedUntil (nil - бессрочно)
+func (r *ProfileRepositoryImpl) VerifyEmployerProfile(db *gorm.DB, employerID string, verifiedUntil *time.Time) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Model(&models.EmployerProfile{}).Where("id = ?", employerID).Updates(map[string]interface{}{
-		"is_verified": true,
-		"updated_at":  time.Now(),
+		"is_verified":    true,
+		"verified_until": verifiedUntil,
+		"updated_at":     time.Now(),
 	})
 
 	if result.Error != nil {
@@ -559,6 +680,17 @@ func (r *ProfileRepositoryImpl) VerifyEmployerProfile(db *gorm.DB, employerID st
 	return nil
 }
 
+// RevokeExpiredEmployerVerifications - снимает значок "verified" с истекшим сроком
+func (r *ProfileRepositoryImpl) RevokeExpiredEmployerVerifications(db *gorm.DB, now time.Time) (int64, error) {
+	result := db.Model(&models.EmployerProfile{}).
+		Where("is_verified = ? AND verified_until < ?", true, now).
+		Updates(map[string]interface{}{
+			"is_verified": false,
+			"updated_at":  now,
+		})
+	return result.RowsAffected, result.Error
+}
+
 func (r *ProfileRepositoryImpl) DeleteEmployerProfile(db *gorm.DB, id string) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Where("id = ?", id).Delete(&models.EmployerProfile{})
@@ -593,6 +725,10 @@ func (r *ProfileRepositoryImpl) SearchEmployerProfiles(db *gorm.DB, criteria Emp
 		query = query.Where("is_verified = ?", *criteria.IsVerified)
 	}
 
+	if criteria.HiddenForUserID != "" {
+		query = query.Where("user_id NOT IN (?)", blockedUsersSubquery(db, criteria.HiddenForUserID))
+	}
+
 	// Get total count
 	var total int64
 	// ✅ Используем 'db' (query)
@@ -649,3 +785,23 @@ func getSortOrder(sortOrder string) string {
 	}
 	return "DESC"
 }
+
+// localizedSearchClause - ILIKE по колонкам columns и переводам translatable-колонок:
+// для колонки X на каждом языке из locales добавляется условие по X_i18n ->> locale
+func localizedSearchClause(columns, translatable, locales []string, search string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	for _, column := range columns {
+		conditions = append(conditions, column+" ILIKE ?")
+		args = append(args, search)
+	}
+	for _, column := range translatable {
+		conditions = append(conditions, column+" ILIKE ?")
+		args = append(args, search)
+		for _, locale := range locales {
+			conditions = append(conditions, column+"_i18n ->> ? ILIKE ?")
+			args = append(args, locale, search)
+		}
+	}
+	return strings.Join(conditions, " OR "), args
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"database/sql"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProfileViewRepository interface {
+	CreateView(db *gorm.DB, view *models.ProfileView) error
+	HasRecentView(db *gorm.DB, modelID, viewerUserID string, since time.Time) (bool, error)
+
+	// Для модели
+	FindRecentViewers(db *gorm.DB, modelID string, since time.Time, page, pageSize int) ([]ProfileViewer, int64, error)
+	GetViewStats(db *gorm.DB, modelID string, from, to time.Time) (*ProfileViewStats, error)
+
+	// Для еженедельной сводки
+	FindViewedModelIDs(db *gorm.DB, since time.Time) ([]string, error)
+	FindLastSummaryTime(db *gorm.DB, userID string) (*time.Time, error)
+}
+
+type ProfileViewRepositoryImpl struct{}
+
+// ProfileViewer - работодатель, смотревший профиль (только неанонимные просмотры)
+type ProfileViewer struct {
+	ViewerUserID      string    `json:"viewer_user_id"`
+	EmployerProfileID *string   `json:"employer_profile_id,omitempty"`
+	CompanyName       string    `json:"company_name"`
+	City              string    `json:"city,omitempty"`
+	IsVerified        bool      `json:"is_verified"`
+	Views             int64     `json:"views"`
+	LastViewedAt      time.Time `json:"last_viewed_at"`
+}
+
+// ProfileViewStats - просмотры профиля за период
+type ProfileViewStats struct {
+	TotalViews     int64 `json:"total_views"`
+	UniqueViewers  int64 `json:"unique_viewers"`
+	AnonymousViews int64 `json:"anonymous_views"`
+}
+
+func NewProfileViewRepository() ProfileViewRepository {
+	return &ProfileViewRepositoryImpl{}
+}
+
+func (r *ProfileViewRepositoryImpl) CreateView(db *gorm.DB, view *models.ProfileView) error {
+	return db.Create(view).Error
+}
+
+// HasRecentView - просматривал ли пользователь профиль после since (повторы не записываются)
+func (r *ProfileViewRepositoryImpl) HasRecentView(db *gorm.DB, modelID, viewerUserID string, since time.Time) (bool, error) {
+	var count int64
+	err := db.Model(&models.ProfileView{}).
+		Where("model_id = ? AND viewer_user_id = ? AND viewed_at > ?", modelID, viewerUserID, since).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindRecentViewers - неанонимные зрители после since, последние первыми
+func (r *ProfileViewRepositoryImpl) FindRecentViewers(db *gorm.DB, modelID string, since time.Time, page, pageSize int) ([]ProfileViewer, int64, error) {
+	base := db.Model(&models.ProfileView{}).
+		Where("profile_views.model_id = ? AND profile_views.viewed_at > ? AND NOT profile_views.is_anonymous", modelID, since)
+
+	var total int64
+	if err := base.Session(&gorm.Session{}).Distinct("profile_views.viewer_user_id").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var viewers []ProfileViewer
+	err := base.Session(&gorm.Session{}).
+		Select(`profile_views.viewer_user_id,
+			profile_views.employer_profile_id,
+			COALESCE(employer_profiles.company_name, '') AS company_name,
+			COALESCE(employer_profiles.city, '') AS city,
+			COALESCE(employer_profiles.is_verified, FALSE) AS is_verified,
+			COUNT(*) AS views,
+			MAX(profile_views.viewed_at) AS last_viewed_at`).
+		Joins("LEFT JOIN employer_profiles ON employer_profiles.id = profile_views.employer_profile_id").
+		Group("profile_views.viewer_user_id, profile_views.employer_profile_id, employer_profiles.company_name, employer_profiles.city, employer_profiles.is_verified").
+		Order("last_viewed_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Scan(&viewers).Error
+	return viewers, total, err
+}
+
+func (r *ProfileViewRepositoryImpl) GetViewStats(db *gorm.DB, modelID string, from, to time.Time) (*ProfileViewStats, error) {
+	var stats ProfileViewStats
+	err := db.Model(&models.ProfileView{}).
+		Select(`COUNT(*) AS total_views,
+			COUNT(DISTINCT viewer_user_id) FILTER (WHERE NOT is_anonymous) AS unique_viewers,
+			COUNT(*) FILTER (WHERE is_anonymous) AS anonymous_views`).
+		Where("model_id = ? AND viewed_at > ? AND viewed_at <= ?", modelID, from, to).
+		Scan(&stats).Error
+	return &stats, err
+}
+
+func (r *ProfileViewRepositoryImpl) FindViewedModelIDs(db *gorm.DB, since time.Time) ([]string, error) {
+	var ids []string
+	err := db.Model(&models.ProfileView{}).
+		Where("viewed_at > ?", since).
+		Distinct().
+		Pluck("model_id", &ids).Error
+	return ids, err
+}
+
+// FindLastSummaryTime - конец периода последней отправленной сводки (поле "to" в данных
+// уведомления); nil - сводок еще не было. Уведомления о единичных просмотрах данных не содержат.
+func (r *ProfileViewRepositoryImpl) FindLastSummaryTime(db *gorm.DB, userID string) (*time.Time, error) {
+	var last sql.NullTime
+	err := db.Model(&models.Notification{}).
+		Select("MAX((data->>'to')::timestamptz)").
+		Where("user_id = ? AND type = ? AND data->>'to' IS NOT NULL", userID, NotificationTypeProfileView).
+		Row().Scan(&last)
+	if err != nil || !last.Valid {
+		return nil, err
+	}
+	return &last.Time, nil
+}
@@ -0,0 +1,264 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPromotionNotFound = errors.New("casting promotion not found")
+)
+
+// promotedCastingOrder - выражение для ORDER BY: продвигаемые кастинги идут первыми.
+// Используется в SearchCastings, FindActiveCastings и FindCastingsForMatching.
+// Оплаченное промо, поставленное в очередь за текущим, начинается с starts_at.
+const promotedCastingOrder = "EXISTS (SELECT 1 FROM casting_promotions cp WHERE cp.casting_id = castings.id AND cp.status = 'active' AND cp.starts_at <= NOW() AND cp.ends_at > NOW()) DESC"
+
+type PromotionRepository interface {
+	CreatePromotion(db *gorm.DB, promotion *models.CastingPromotion) error
+	FindPromotionByID(db *gorm.DB, id string) (*models.CastingPromotion, error)
+	FindPromotionByPaymentID(db *gorm.DB, paymentID string) (*models.CastingPromotion, error)
+	FindPromotionsByCasting(db *gorm.DB, castingID string) ([]models.CastingPromotion, error)
+	FindPromotionsByEmployer(db *gorm.DB, employerID string) ([]models.CastingPromotion, error)
+	FindActivePromotion(db *gorm.DB, castingID string) (*models.CastingPromotion, error)
+	FindLastScheduledPromotion(db *gorm.DB, castingID string) (*models.CastingPromotion, error)
+	FindPromotedCastingIDs(db *gorm.DB, castingIDs []string) (map[string]bool, error)
+	ActivatePromotion(db *gorm.DB, promotionID string, startsAt, endsAt time.Time) error
+	UpdatePromotionStatus(db *gorm.DB, promotionID string, status models.PromotionStatus) error
+	CancelCastingPromotions(db *gorm.DB, castingID string) error
+	ExpirePromotions(db *gorm.DB) (int64, error)
+
+	// Accounting
+	IncrementImpressions(db *gorm.DB, castingIDs []string) error
+	IncrementClicks(db *gorm.DB, castingID string) error
+	GetPromotionStats(db *gorm.DB, castingID string) (*PromotionStats, error)
+}
+
+type PromotionRepositoryImpl struct{}
+
+// Статистика продвижения по кастингу
+type PromotionStats struct {
+	TotalPromotions int64      `json:"total_promotions"`
+	IsPromoted      bool       `json:"is_promoted"`
+	PromotedUntil   *time.Time `json:"promoted_until,omitempty"`
+	Impressions     int64      `json:"impressions"`
+	Clicks          int64      `json:"clicks"`
+	CTR             float64    `json:"ctr"` // в процентах
+}
+
+func NewPromotionRepository() PromotionRepository {
+	return &PromotionRepositoryImpl{}
+}
+
+func (r *PromotionRepositoryImpl) CreatePromotion(db *gorm.DB, promotion *models.CastingPromotion) error {
+	return db.Create(promotion).Error
+}
+
+func (r *PromotionRepositoryImpl) FindPromotionByID(db *gorm.DB, id string) (*models.CastingPromotion, error) {
+	var promotion models.CastingPromotion
+	err := db.First(&promotion, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *PromotionRepositoryImpl) FindPromotionByPaymentID(db *gorm.DB, paymentID string) (*models.CastingPromotion, error) {
+	var promotion models.CastingPromotion
+	err := db.Where("payment_id = ?", paymentID).First(&promotion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *PromotionRepositoryImpl) FindPromotionsByCasting(db *gorm.DB, castingID string) ([]models.CastingPromotion, error) {
+	var promotions []models.CastingPromotion
+	err := db.Where("casting_id = ?", castingID).
+		Order("created_at DESC").
+		Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *PromotionRepositoryImpl) FindPromotionsByEmployer(db *gorm.DB, employerID string) ([]models.CastingPromotion, error) {
+	var promotions []models.CastingPromotion
+	err := db.Where("employer_id = ?", employerID).
+		Order("created_at DESC").
+		Find(&promotions).Error
+	return promotions, err
+}
+
+// FindActivePromotion - идущее сейчас промо (без стоящих в очереди)
+func (r *PromotionRepositoryImpl) FindActivePromotion(db *gorm.DB, castingID string) (*models.CastingPromotion, error) {
+	var promotion models.CastingPromotion
+	now := time.Now()
+	err := db.Where("casting_id = ? AND status = ? AND starts_at <= ? AND ends_at > ?",
+		castingID, models.PromotionStatusActive, now, now).
+		Order("ends_at DESC").
+		First(&promotion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// FindLastScheduledPromotion - активное промо с самой поздней датой окончания, включая
+// стоящие в очереди; новое промо встает в очередь после него
+func (r *PromotionRepositoryImpl) FindLastScheduledPromotion(db *gorm.DB, castingID string) (*models.CastingPromotion, error) {
+	var promotion models.CastingPromotion
+	err := db.Where("casting_id = ? AND status = ? AND ends_at > ?",
+		castingID, models.PromotionStatusActive, time.Now()).
+		Order("ends_at DESC").
+		First(&promotion).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// FindPromotedCastingIDs - какие из переданных кастингов сейчас продвигаются
+func (r *PromotionRepositoryImpl) FindPromotedCastingIDs(db *gorm.DB, castingIDs []string) (map[string]bool, error) {
+	result := make(map[string]bool)
+	if len(castingIDs) == 0 {
+		return result, nil
+	}
+
+	now := time.Now()
+	var ids []string
+	err := db.Model(&models.CastingPromotion{}).
+		Distinct("casting_id").
+		Where("casting_id IN ? AND status = ? AND starts_at <= ? AND ends_at > ?",
+			castingIDs, models.PromotionStatusActive, now, now).
+		Pluck("casting_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		result[id] = true
+	}
+	return result, nil
+}
+
+func (r *PromotionRepositoryImpl) ActivatePromotion(db *gorm.DB, promotionID string, startsAt, endsAt time.Time) error {
+	result := db.Model(&models.CastingPromotion{}).
+		Where("id = ?", promotionID).
+		Updates(map[string]interface{}{
+			"status":    models.PromotionStatusActive,
+			"starts_at": startsAt,
+			"ends_at":   endsAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (r *PromotionRepositoryImpl) UpdatePromotionStatus(db *gorm.DB, promotionID string, status models.PromotionStatus) error {
+	result := db.Model(&models.CastingPromotion{}).
+		Where("id = ?", promotionID).
+		Update("status", status)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+// CancelCastingPromotions - отменяет все активные и ожидающие оплаты промо кастинга
+func (r *PromotionRepositoryImpl) CancelCastingPromotions(db *gorm.DB, castingID string) error {
+	return db.Model(&models.CastingPromotion{}).
+		Where("casting_id = ? AND status IN ?", castingID,
+			[]models.PromotionStatus{models.PromotionStatusActive, models.PromotionStatusPending}).
+		Update("status", models.PromotionStatusCancelled).Error
+}
+
+// ExpirePromotions - переводит истекшие промо в статус expired, возвращает их количество
+func (r *PromotionRepositoryImpl) ExpirePromotions(db *gorm.DB) (int64, error) {
+	result := db.Model(&models.CastingPromotion{}).
+		Where("status = ? AND ends_at <= ?", models.PromotionStatusActive, time.Now()).
+		Update("status", models.PromotionStatusExpired)
+	return result.RowsAffected, result.Error
+}
+
+// Accounting
+
+// IncrementImpressions - один показ в выдаче для каждого продвигаемого кастинга из списка.
+// Засчитывается только идущему промо, не стоящему в очереди.
+func (r *PromotionRepositoryImpl) IncrementImpressions(db *gorm.DB, castingIDs []string) error {
+	if len(castingIDs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	return db.Model(&models.CastingPromotion{}).
+		Where("casting_id IN ? AND status = ? AND starts_at <= ? AND ends_at > ?",
+			castingIDs, models.PromotionStatusActive, now, now).
+		UpdateColumn("impressions", gorm.Expr("impressions + ?", 1)).Error
+}
+
+func (r *PromotionRepositoryImpl) IncrementClicks(db *gorm.DB, castingID string) error {
+	now := time.Now()
+	return db.Model(&models.CastingPromotion{}).
+		Where("casting_id = ? AND status = ? AND starts_at <= ? AND ends_at > ?",
+			castingID, models.PromotionStatusActive, now, now).
+		UpdateColumn("clicks", gorm.Expr("clicks + ?", 1)).Error
+}
+
+func (r *PromotionRepositoryImpl) GetPromotionStats(db *gorm.DB, castingID string) (*PromotionStats, error) {
+	var stats PromotionStats
+
+	var totals struct {
+		Total       int64
+		Impressions int64
+		Clicks      int64
+	}
+	err := db.Model(&models.CastingPromotion{}).
+		Select("COUNT(*) AS total, COALESCE(SUM(impressions), 0) AS impressions, COALESCE(SUM(clicks), 0) AS clicks").
+		Where("casting_id = ? AND status <> ?", castingID, models.PromotionStatusPending).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats.TotalPromotions = totals.Total
+	stats.Impressions = totals.Impressions
+	stats.Clicks = totals.Clicks
+	if stats.Impressions > 0 {
+		stats.CTR = float64(stats.Clicks) / float64(stats.Impressions) * 100
+	}
+
+	active, err := r.FindActivePromotion(db, castingID)
+	if err != nil && !errors.Is(err, ErrPromotionNotFound) {
+		return nil, err
+	}
+	if active != nil {
+		stats.IsPromoted = true
+		stats.PromotedUntil = active.EndsAt
+		// Очередь промо идет встык, поэтому продвижение длится до конца последнего
+		if last, err := r.FindLastScheduledPromotion(db, castingID); err == nil {
+			stats.PromotedUntil = last.EndsAt
+		}
+	}
+
+	return &stats, nil
+}
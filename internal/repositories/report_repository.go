@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReportNotFound = errors.New("report not found")
+	ErrReportExists   = errors.New("report is already pending review")
+)
+
+type ReportRepository interface {
+	CreateReport(db *gorm.DB, report *models.Report) error
+	FindReportByID(db *gorm.DB, id string) (*models.Report, error)
+	FindReports(db *gorm.DB, criteria ReportCriteria) ([]models.Report, int64, error)
+	UpdateReport(db *gorm.DB, report *models.Report) error
+
+	// CountPendingReports - открытые жалобы на объект (для приоритета в очереди)
+	CountPendingReports(db *gorm.DB, targetType, targetID string) (int64, error)
+}
+
+type ReportRepositoryImpl struct{}
+
+// ReportCriteria - очередь модерации
+type ReportCriteria struct {
+	Status       string
+	TargetType   string
+	Reason       string
+	TargetUserID string
+	Page         int
+	PageSize     int
+}
+
+func NewReportRepository() ReportRepository {
+	return &ReportRepositoryImpl{}
+}
+
+func (r *ReportRepositoryImpl) CreateReport(db *gorm.DB, report *models.Report) error {
+	if err := db.Create(report).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrReportExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *ReportRepositoryImpl) FindReportByID(db *gorm.DB, id string) (*models.Report, error) {
+	var report models.Report
+	if err := db.First(&report, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+	return &report, nil
+}
+
+// FindReports - жалобы по фильтрам, старые первыми (порядок очереди)
+func (r *ReportRepositoryImpl) FindReports(db *gorm.DB, criteria ReportCriteria) ([]models.Report, int64, error) {
+	var reports []models.Report
+	var total int64
+
+	query := db.Model(&models.Report{})
+	if criteria.Status != "" {
+		query = query.Where("status = ?", criteria.Status)
+	}
+	if criteria.TargetType != "" {
+		query = query.Where("target_type = ?", criteria.TargetType)
+	}
+	if criteria.Reason != "" {
+		query = query.Where("reason = ?", criteria.Reason)
+	}
+	if criteria.TargetUserID != "" {
+		query = query.Where("target_user_id = ?", criteria.TargetUserID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if criteria.Page < 1 {
+		criteria.Page = 1
+	}
+	if criteria.PageSize < 1 {
+		criteria.PageSize = 20
+	}
+	err := query.Order("created_at ASC").
+		Offset((criteria.Page - 1) * criteria.PageSize).
+		Limit(criteria.PageSize).
+		Find(&reports).Error
+	return reports, total, err
+}
+
+func (r *ReportRepositoryImpl) UpdateReport(db *gorm.DB, report *models.Report) error {
+	result := db.Model(report).Updates(map[string]interface{}{
+		"status":          report.Status,
+		"reviewer_id":     report.ReviewerID,
+		"resolution_note": report.ResolutionNote,
+		"reviewed_at":     report.ReviewedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrReportNotFound
+	}
+	return nil
+}
+
+func (r *ReportRepositoryImpl) CountPendingReports(db *gorm.DB, targetType, targetID string) (int64, error) {
+	var count int64
+	err := db.Model(&models.Report{}).
+		Where("target_type = ? AND target_id = ? AND status = ?", targetType, targetID, models.ReportStatusPending).
+		Count(&count).Error
+	return count, err
+}
@@ -11,6 +11,7 @@ import (
 var (
 	ErrResponseNotFound      = errors.New("response not found")
 	ErrResponseAlreadyExists = errors.New("response already exists for this casting and model")
+	ErrResponseStatusChanged = errors.New("response status was changed concurrently")
 )
 
 type ResponseRepository interface {
@@ -20,10 +21,16 @@ type ResponseRepository interface {
 	FindResponsesByCasting(db *gorm.DB, castingID string) ([]models.CastingResponse, error)
 	FindResponsesByModel(db *gorm.DB, modelID string) ([]models.CastingResponse, error)
 	UpdateResponseStatus(db *gorm.DB, responseID string, status models.ResponseStatus) error
+	FindResponsesByIDs(db *gorm.DB, castingID string, responseIDs []string) ([]models.CastingResponse, error)
+	FindResponsesByStatus(db *gorm.DB, castingID string, status models.ResponseStatus) ([]models.CastingResponse, error)
+	BulkUpdateResponseStatus(db *gorm.DB, responseIDs []string, status models.ResponseStatus) (int64, error)
 	MarkResponseAsViewed(db *gorm.DB, responseID string) error
 	DeleteResponse(db *gorm.DB, responseID string) error
+	WithdrawResponse(db *gorm.DB, responseID string, from []models.ResponseStatus, reason *string, quotaCharged bool) error
+	ReapplyResponse(db *gorm.DB, responseID string, message *string, quotaCharged bool) error
 	GetResponseStats(db *gorm.DB, castingID string) (*ResponseStats, error)
 	UpdateResponseViewedByEmployer(db *gorm.DB, responseID string, viewed bool) error
+	HasAcceptedResponse(db *gorm.DB, employerUserID, modelUserID string) (bool, error)
 }
 
 type ResponseRepositoryImpl struct {
@@ -32,10 +39,11 @@ type ResponseRepositoryImpl struct {
 
 // Statistics for responses
 type ResponseStats struct {
-	TotalResponses    int64 `json:"total_responses"`
-	PendingResponses  int64 `json:"pending_responses"`
-	AcceptedResponses int64 `json:"accepted_responses"`
-	RejectedResponses int64 `json:"rejected_responses"`
+	TotalResponses     int64 `json:"total_responses"`
+	PendingResponses   int64 `json:"pending_responses"`
+	AcceptedResponses  int64 `json:"accepted_responses"`
+	RejectedResponses  int64 `json:"rejected_responses"`
+	WithdrawnResponses int64 `json:"withdrawn_responses"`
 }
 
 // ✅ Конструктор не принимает db
@@ -123,6 +131,39 @@ func (r *ResponseRepositoryImpl) UpdateResponseStatus(db *gorm.DB, id string, st
 	return nil
 }
 
+// FindResponsesByIDs - отклики кастинга из списка (чужие ID просто не попадут в результат)
+func (r *ResponseRepositoryImpl) FindResponsesByIDs(db *gorm.DB, castingID string, responseIDs []string) ([]models.CastingResponse, error) {
+	var responses []models.CastingResponse
+	if len(responseIDs) == 0 {
+		return responses, nil
+	}
+	err := db.Where("casting_id = ? AND id IN ?", castingID, responseIDs).
+		Find(&responses).Error
+	return responses, err
+}
+
+func (r *ResponseRepositoryImpl) FindResponsesByStatus(db *gorm.DB, castingID string, status models.ResponseStatus) ([]models.CastingResponse, error) {
+	var responses []models.CastingResponse
+	err := db.Where("casting_id = ? AND status = ?", castingID, status).
+		Order("created_at ASC").
+		Find(&responses).Error
+	return responses, err
+}
+
+// BulkUpdateResponseStatus - одним UPDATE для массовых операций работодателя
+func (r *ResponseRepositoryImpl) BulkUpdateResponseStatus(db *gorm.DB, responseIDs []string, status models.ResponseStatus) (int64, error) {
+	if len(responseIDs) == 0 {
+		return 0, nil
+	}
+	result := db.Model(&models.CastingResponse{}).
+		Where("id IN ?", responseIDs).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
 func (r *ResponseRepositoryImpl) MarkResponseAsViewed(db *gorm.DB, responseID string) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Model(&models.CastingResponse{}).Where("id = ?", responseID).Updates(map[string]interface{}{
@@ -163,6 +204,48 @@ func (r *ResponseRepositoryImpl) DeleteResponse(db *gorm.DB, id string) error {
 	return nil
 }
 
+// WithdrawResponse - переводит отклик в withdrawn, только если он все еще в одном из статусов from
+func (r *ResponseRepositoryImpl) WithdrawResponse(db *gorm.DB, responseID string, from []models.ResponseStatus, reason *string, quotaCharged bool) error {
+	result := db.Model(&models.CastingResponse{}).
+		Where("id = ? AND status IN ?", responseID, from).
+		Updates(map[string]interface{}{
+			"status":            models.ResponseStatusWithdrawn,
+			"withdrawn_at":      time.Now(),
+			"withdrawal_reason": reason,
+			"withdraw_count":    gorm.Expr("withdraw_count + 1"),
+			"quota_charged":     quotaCharged,
+			"updated_at":        time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrResponseStatusChanged
+	}
+	return nil
+}
+
+// ReapplyResponse - повторный отклик: переиспользует отозванную запись (история отзывов сохраняется)
+func (r *ResponseRepositoryImpl) ReapplyResponse(db *gorm.DB, responseID string, message *string, quotaCharged bool) error {
+	result := db.Model(&models.CastingResponse{}).
+		Where("id = ? AND status = ?", responseID, models.ResponseStatusWithdrawn).
+		Updates(map[string]interface{}{
+			"status":            models.ResponseStatusPending,
+			"message":           message,
+			"withdrawn_at":      nil,
+			"withdrawal_reason": nil,
+			"quota_charged":     quotaCharged,
+			"updated_at":        time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrResponseStatusChanged
+	}
+	return nil
+}
+
 func (r *ResponseRepositoryImpl) GetResponseStats(db *gorm.DB, castingID string) (*ResponseStats, error) {
 	var stats ResponseStats
 
@@ -194,5 +277,24 @@ func (r *ResponseRepositoryImpl) GetResponseStats(db *gorm.DB, castingID string)
 		return nil, err
 	}
 
+	// Withdrawn responses
+	if err := db.Model(&models.CastingResponse{}).Where("casting_id = ? AND status = ?",
+		castingID, models.ResponseStatusWithdrawn).Count(&stats.WithdrawnResponses).Error; err != nil {
+		return nil, err
+	}
+
 	return &stats, nil
 }
+
+// HasAcceptedResponse - работодатель принял отклик модели хотя бы на один свой кастинг
+func (r *ResponseRepositoryImpl) HasAcceptedResponse(db *gorm.DB, employerUserID, modelUserID string) (bool, error) {
+	var count int64
+	err := db.Table("casting_responses cr").
+		Joins("JOIN castings c ON c.id = cr.casting_id").
+		Joins("JOIN employer_profiles ep ON ep.id = c.employer_id").
+		Where("cr.model_id = ? AND ep.user_id = ?", modelUserID, employerUserID).
+		Where("cr.status IN ?", []models.ResponseStatus{models.ResponseStatusAccepted, models.ResponseStatusApproved}).
+		Where("cr.deleted_at IS NULL").
+		Count(&count).Error
+	return count > 0, err
+}
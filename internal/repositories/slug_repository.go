@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"mwork_backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSlugNotFound = errors.New("slug not found")
+	ErrSlugTaken    = errors.New("slug is already taken")
+)
+
+// slugTables - таблицы сущностей с колонкой slug
+var slugTables = map[string]string{
+	models.SlugEntityModel:    "model_profiles",
+	models.SlugEntityEmployer: "employer_profiles",
+	models.SlugEntityCasting:  "castings",
+}
+
+type SlugRepository interface {
+	// Текущие адреса (колонка slug в таблице сущности)
+	FindEntityIDBySlug(db *gorm.DB, entityType, slug string) (string, error)
+	UpdateEntitySlug(db *gorm.DB, entityType, entityID, slug string) error
+
+	// Прежние адреса
+	CreateRedirect(db *gorm.DB, redirect *models.SlugRedirect) error
+	FindRedirect(db *gorm.DB, entityType, slug string) (*models.SlugRedirect, error)
+	DeleteRedirect(db *gorm.DB, entityType, slug string) error
+}
+
+type SlugRepositoryImpl struct{}
+
+func NewSlugRepository() SlugRepository {
+	return &SlugRepositoryImpl{}
+}
+
+func (r *SlugRepositoryImpl) FindEntityIDBySlug(db *gorm.DB, entityType, slug string) (string, error) {
+	table, err := slugTable(entityType)
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	if err := db.Table(table).Where("slug = ?", slug).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", ErrSlugNotFound
+	}
+	return ids[0], nil
+}
+
+func (r *SlugRepositoryImpl) UpdateEntitySlug(db *gorm.DB, entityType, entityID, slug string) error {
+	table, err := slugTable(entityType)
+	if err != nil {
+		return err
+	}
+
+	result := db.Table(table).Where("id = ?", entityID).Update("slug", slug)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return ErrSlugTaken
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSlugNotFound
+	}
+	return nil
+}
+
+func (r *SlugRepositoryImpl) CreateRedirect(db *gorm.DB, redirect *models.SlugRedirect) error {
+	if err := db.Create(redirect).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrSlugTaken
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *SlugRepositoryImpl) FindRedirect(db *gorm.DB, entityType, slug string) (*models.SlugRedirect, error) {
+	var redirect models.SlugRedirect
+	err := db.Where("entity_type = ? AND slug = ?", entityType, slug).First(&redirect).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSlugNotFound
+		}
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+func (r *SlugRepositoryImpl) DeleteRedirect(db *gorm.DB, entityType, slug string) error {
+	return db.Where("entity_type = ? AND slug = ?", entityType, slug).
+		Delete(&models.SlugRedirect{}).Error
+}
+
+// Helper methods
+
+func slugTable(entityType string) (string, error) {
+	table, ok := slugTables[entityType]
+	if !ok {
+		return "", fmt.Errorf("unsupported slug entity type: %s", entityType)
+	}
+	return table, nil
+}
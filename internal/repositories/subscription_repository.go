@@ -15,6 +15,7 @@ var (
 	ErrSubscriptionNotFound     = errors.New("subscription not found")
 	ErrSubscriptionPlanNotFound = errors.New("subscription plan not found")
 	ErrPaymentNotFound          = errors.New("payment transaction not found")
+	ErrPaymentStatusChanged     = errors.New("payment status was changed concurrently")
 	ErrSubscriptionLimit        = errors.New("subscription limit reached")
 )
 
@@ -48,6 +49,7 @@ type SubscriptionRepository interface {
 	FindPaymentByInvID(db *gorm.DB, invID string) (*models.PaymentTransaction, error)
 	FindPaymentsByUser(db *gorm.DB, userID string) ([]models.PaymentTransaction, error)
 	UpdatePaymentStatus(db *gorm.DB, invID string, status models.PaymentStatus, paidAt *time.Time) error
+	TransitionPaymentStatus(db *gorm.DB, id string, from, to models.PaymentStatus) error
 	DeletePayment(db *gorm.DB, id string) error
 
 	// Usage and limits operations
@@ -493,6 +495,29 @@ func (r *SubscriptionRepositoryImpl) UpdatePaymentStatus(db *gorm.DB, invID stri
 	return nil
 }
 
+// TransitionPaymentStatus - смена статуса только из ожидаемого (защита от двойного возврата
+// и повторной оплаты); при переходе в paid фиксируется время оплаты
+func (r *SubscriptionRepositoryImpl) TransitionPaymentStatus(db *gorm.DB, id string, from, to models.PaymentStatus) error {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     to,
+		"updated_at": now,
+	}
+	if to == models.PaymentStatusPaid {
+		updates["paid_at"] = now
+	}
+	result := db.Model(&models.PaymentTransaction{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPaymentStatusChanged
+	}
+	return nil
+}
+
 func (r *SubscriptionRepositoryImpl) DeletePayment(db *gorm.DB, id string) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Where("id = ?", id).Delete(&models.PaymentTransaction{})
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/types"
 
@@ -32,6 +34,20 @@ type UploadRepository interface {
 	// CleanOrphaned(db *gorm.DB) error // Удалено
 	DeleteOlderThan(db *gorm.DB, days int) error
 	// ▲▲▲ ИЗМЕНЕНО (Проблема 2) ▲▲▲
+
+	// Модерация фото
+	FindModerationQueue(db *gorm.DB, criteria PhotoModerationCriteria) ([]*models.Upload, int64, error)
+	FindByIDs(db *gorm.DB, uploadIDs []string) ([]*models.Upload, error)
+	SetModerationStatus(db *gorm.DB, uploadIDs []string, status models.ModerationStatus, moderatorID string, reason *string) error
+}
+
+// PhotoModerationCriteria - очередь модерации фото (аватары и портфолио)
+type PhotoModerationCriteria struct {
+	Status   models.ModerationStatus
+	Usage    string
+	UserID   string
+	Page     int
+	PageSize int
 }
 
 // ============================================
@@ -229,3 +245,45 @@ func (r *uploadRepository) applyFilters(query *gorm.DB, filters *types.UploadFil
 
 	return query
 }
+
+// FindModerationQueue - фото с модерируемыми назначениями, старые первыми (порядок очереди)
+func (r *uploadRepository) FindModerationQueue(db *gorm.DB, criteria PhotoModerationCriteria) ([]*models.Upload, int64, error) {
+	var uploads []*models.Upload
+	var total int64
+
+	query := db.Model(&models.Upload{}).Where("usage IN ?", models.ModeratedUsages)
+	if criteria.Status != "" {
+		query = query.Where("moderation_status = ?", criteria.Status)
+	}
+	if criteria.Usage != "" {
+		query = query.Where("usage = ?", criteria.Usage)
+	}
+	if criteria.UserID != "" {
+		query = query.Where("user_id = ?", criteria.UserID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (criteria.Page - 1) * criteria.PageSize
+	err := query.Order("created_at ASC").Offset(offset).Limit(criteria.PageSize).Find(&uploads).Error
+	return uploads, total, err
+}
+
+// FindByIDs - файлы по списку ID (отсутствующие пропускаются)
+func (r *uploadRepository) FindByIDs(db *gorm.DB, uploadIDs []string) ([]*models.Upload, error) {
+	var uploads []*models.Upload
+	err := db.Where("id IN ?", uploadIDs).Find(&uploads).Error
+	return uploads, err
+}
+
+// SetModerationStatus - решение модератора по пакету фото
+func (r *uploadRepository) SetModerationStatus(db *gorm.DB, uploadIDs []string, status models.ModerationStatus, moderatorID string, reason *string) error {
+	return db.Model(&models.Upload{}).Where("id IN ?", uploadIDs).Updates(map[string]interface{}{
+		"moderation_status": status,
+		"moderated_by":      moderatorID,
+		"moderated_at":      time.Now(),
+		"rejection_reason":  reason,
+		"updated_at":        time.Now(),
+	}).Error
+}
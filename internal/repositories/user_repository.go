@@ -20,6 +20,7 @@ type UserRepository interface {
 	Create(db *gorm.DB, user *models.User) error
 	Update(db *gorm.DB, user *models.User) error
 	UpdateStatus(db *gorm.DB, userID string, status models.UserStatus) error
+	SetPhotoAutoApprove(db *gorm.DB, userID string, enabled bool) error
 	VerifyUser(db *gorm.DB, userID string) error
 	Delete(db *gorm.DB, userID string) error
 	FindByRole(db *gorm.DB, role models.UserRole, limit, offset int) ([]models.User, error)
@@ -121,6 +122,21 @@ func (r *UserRepositoryImpl) Update(db *gorm.DB, user *models.User) error {
 	return nil
 }
 
+// SetPhotoAutoApprove - доверенный пользователь публикует фото без модерации
+func (r *UserRepositoryImpl) SetPhotoAutoApprove(db *gorm.DB, userID string, enabled bool) error {
+	result := db.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+		"photo_auto_approve": enabled,
+		"updated_at":         time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
 func (r *UserRepositoryImpl) UpdateStatus(db *gorm.DB, userID string, status models.UserStatus) error {
 	// ✅ Используем 'db' из параметра
 	result := db.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"errors"
+	"mwork_backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVerificationNotFound = errors.New("employer verification not found")
+	ErrVerificationExists   = errors.New("employer verification is already under review")
+)
+
+type VerificationRepository interface {
+	CreateVerification(db *gorm.DB, verification *models.EmployerVerification) error
+	FindVerificationByID(db *gorm.DB, id string) (*models.EmployerVerification, error)
+	FindLatestVerification(db *gorm.DB, employerProfileID string) (*models.EmployerVerification, error)
+	FindVerifications(db *gorm.DB, criteria VerificationCriteria) ([]models.EmployerVerification, int64, error)
+	FindExpiredVerifications(db *gorm.DB, now time.Time) ([]models.EmployerVerification, error)
+	UpdateVerification(db *gorm.DB, verification *models.EmployerVerification) error
+
+	// История статусов
+	CreateTransition(db *gorm.DB, transition *models.EmployerVerificationTransition) error
+}
+
+type VerificationRepositoryImpl struct{}
+
+// VerificationCriteria - очередь модерации
+type VerificationCriteria struct {
+	Status   string
+	Page     int
+	PageSize int
+}
+
+func NewVerificationRepository() VerificationRepository {
+	return &VerificationRepositoryImpl{}
+}
+
+func (r *VerificationRepositoryImpl) CreateVerification(db *gorm.DB, verification *models.EmployerVerification) error {
+	if err := db.Create(verification).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrVerificationExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *VerificationRepositoryImpl) FindVerificationByID(db *gorm.DB, id string) (*models.EmployerVerification, error) {
+	var verification models.EmployerVerification
+	err := r.withRelations(db).First(&verification, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVerificationNotFound
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// FindLatestVerification - последняя заявка работодателя (текущая или последняя рассмотренная)
+func (r *VerificationRepositoryImpl) FindLatestVerification(db *gorm.DB, employerProfileID string) (*models.EmployerVerification, error) {
+	var verification models.EmployerVerification
+	err := r.withRelations(db).Where("employer_profile_id = ?", employerProfileID).
+		Order("submitted_at DESC").First(&verification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVerificationNotFound
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// FindVerifications - заявки по статусу, старые первыми (порядок очереди)
+func (r *VerificationRepositoryImpl) FindVerifications(db *gorm.DB, criteria VerificationCriteria) ([]models.EmployerVerification, int64, error) {
+	var verifications []models.EmployerVerification
+	var total int64
+
+	query := db.Model(&models.EmployerVerification{})
+	if criteria.Status != "" {
+		query = query.Where("status = ?", criteria.Status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if criteria.Page < 1 {
+		criteria.Page = 1
+	}
+	if criteria.PageSize < 1 {
+		criteria.PageSize = 20
+	}
+	err := query.Preload("EmployerProfile").
+		Order("submitted_at ASC").
+		Offset((criteria.Page - 1) * criteria.PageSize).
+		Limit(criteria.PageSize).
+		Find(&verifications).Error
+	return verifications, total, err
+}
+
+// FindExpiredVerifications - одобренные заявки с истекшим сроком действия
+func (r *VerificationRepositoryImpl) FindExpiredVerifications(db *gorm.DB, now time.Time) ([]models.EmployerVerification, error) {
+	var verifications []models.EmployerVerification
+	err := db.Where("status = ? AND expires_at < ?", models.VerificationStatusApproved, now).
+		Find(&verifications).Error
+	return verifications, err
+}
+
+func (r *VerificationRepositoryImpl) UpdateVerification(db *gorm.DB, verification *models.EmployerVerification) error {
+	result := db.Model(verification).Updates(map[string]interface{}{
+		"status":       verification.Status,
+		"bin":          verification.BIN,
+		"legal_name":   verification.LegalName,
+		"comment":      verification.Comment,
+		"reviewer_id":  verification.ReviewerID,
+		"review_note":  verification.ReviewNote,
+		"submitted_at": verification.SubmittedAt,
+		"reviewed_at":  verification.ReviewedAt,
+		"expires_at":   verification.ExpiresAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVerificationNotFound
+	}
+	return nil
+}
+
+func (r *VerificationRepositoryImpl) CreateTransition(db *gorm.DB, transition *models.EmployerVerificationTransition) error {
+	return db.Create(transition).Error
+}
+
+// Helper methods
+
+func (r *VerificationRepositoryImpl) withRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("EmployerProfile").Preload("Transitions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	})
+}
@@ -33,8 +33,24 @@ func RegisterRoutes(
 		appHandlers.AnalyticsHandler.RegisterRoutes(api)
 		appHandlers.ChatHandler.RegisterRoutes(api)
 		appHandlers.UploadHandler.RegisterRoutes(api)
+		appHandlers.PromotionHandler.RegisterRoutes(api)
+		appHandlers.EmployerNoteHandler.RegisterRoutes(api)
+		appHandlers.ShortlistHandler.RegisterRoutes(api)
+		appHandlers.BookingHandler.RegisterRoutes(api)
+		appHandlers.AvailabilityHandler.RegisterRoutes(api)
+		appHandlers.LedgerHandler.RegisterRoutes(api)
+		appHandlers.InvoiceHandler.RegisterRoutes(api)
+		appHandlers.CompCardHandler.RegisterRoutes(api)
+		appHandlers.AgencyHandler.RegisterRoutes(api)
+		appHandlers.VerificationHandler.RegisterRoutes(api)
+		appHandlers.ShareHandler.RegisterRoutes(api)
+		appHandlers.ProfileViewHandler.RegisterRoutes(api)
+		appHandlers.ModerationHandler.RegisterRoutes(api)
 	}
 
+	// Публичные HTML-страницы для шеринга (OpenGraph/Twitter превью)
+	appHandlers.ShareHandler.RegisterPageRoutes(ginRouter.Group(""))
+
 	// Регистрация WebSocket
 	wsGroup := ginRouter.Group("/ws")
 	wsGroup.Use(middleware.AuthMiddleware()) // <-- AuthMiddleware должно быть в пакете middleware
@@ -0,0 +1,556 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/algorithms"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type AgencyService interface {
+	// Профиль агентства (userID = users.id агентства)
+	GetMyAgency(db *gorm.DB, userID string) (*models.AgencyProfile, error)
+	UpdateMyAgency(db *gorm.DB, userID string, req *dto.UpdateAgencyProfileRequest) (*models.AgencyProfile, error)
+
+	// Модели агентства (modelProfileID = model_profiles.id)
+	ListModels(db *gorm.DB, userID string, query *dto.AgencyModelsQuery) ([]models.ModelRepresentation, error)
+	CreateManagedModel(db *gorm.DB, userID string, req *dto.CreateManagedModelRequest) (*models.ModelRepresentation, error)
+	InviteModel(db *gorm.DB, userID string, req *dto.InviteModelRequest) (*models.ModelRepresentation, error)
+	UpdateModelProfile(db *gorm.DB, userID, modelProfileID string, req *dto.UpdateProfileRequest) error
+	RespondToCasting(db *gorm.DB, userID, modelProfileID string, req *dto.AgencyResponseRequest) (*models.CastingResponse, error)
+	EndRepresentation(db *gorm.DB, userID, modelProfileID string) error
+
+	// Представительство со стороны модели (userID = users.id модели)
+	GetMyRepresentation(db *gorm.DB, userID string) (*dto.MyRepresentationResponse, error)
+	AcceptInvitation(db *gorm.DB, userID, representationID string) (*models.ModelRepresentation, error)
+	DeclineInvitation(db *gorm.DB, userID, representationID string) error
+	UpdateMyRepresentation(db *gorm.DB, userID string, req *dto.UpdateRepresentationRequest) (*models.ModelRepresentation, error)
+	LeaveAgency(db *gorm.DB, userID string) error
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type agencyService struct {
+	agencyRepo       repositories.AgencyRepository
+	userRepo         repositories.UserRepository
+	profileRepo      repositories.ProfileRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	chatRepo         repositories.ChatRepository
+	notificationRepo repositories.NotificationRepository
+	blockRepo        repositories.BlockRepository
+	profileService   ProfileService
+	responseService  ResponseService
+}
+
+func NewAgencyService(
+	agencyRepo repositories.AgencyRepository,
+	userRepo repositories.UserRepository,
+	profileRepo repositories.ProfileRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	chatRepo repositories.ChatRepository,
+	notificationRepo repositories.NotificationRepository,
+	blockRepo repositories.BlockRepository,
+	profileService ProfileService,
+	responseService ResponseService,
+) AgencyService {
+	return &agencyService{
+		agencyRepo:       agencyRepo,
+		userRepo:         userRepo,
+		profileRepo:      profileRepo,
+		subscriptionRepo: subscriptionRepo,
+		chatRepo:         chatRepo,
+		notificationRepo: notificationRepo,
+		blockRepo:        blockRepo,
+		profileService:   profileService,
+		responseService:  responseService,
+	}
+}
+
+// --- Профиль агентства ---
+
+func (s *agencyService) GetMyAgency(db *gorm.DB, userID string) (*models.AgencyProfile, error) {
+	agency, err := s.agencyRepo.FindAgencyProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+	return agency, nil
+}
+
+func (s *agencyService) UpdateMyAgency(db *gorm.DB, userID string, req *dto.UpdateAgencyProfileRequest) (*models.AgencyProfile, error) {
+	agency, err := s.agencyRepo.FindAgencyProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+
+	if req.Name != nil {
+		agency.Name = *req.Name
+	}
+	if req.City != nil {
+		agency.City = *req.City
+	}
+	if req.Phone != nil {
+		agency.Phone = *req.Phone
+	}
+	if req.Website != nil {
+		agency.Website = *req.Website
+	}
+	if req.Description != nil {
+		agency.Description = *req.Description
+	}
+
+	if err := s.agencyRepo.UpdateAgencyProfile(db, agency); err != nil {
+		return nil, handleAgencyError(err)
+	}
+	return agency, nil
+}
+
+// --- Модели агентства ---
+
+func (s *agencyService) ListModels(db *gorm.DB, userID string, query *dto.AgencyModelsQuery) ([]models.ModelRepresentation, error) {
+	agency, err := s.agencyRepo.FindAgencyProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+
+	statuses := []string{models.RepresentationStatusPending, models.RepresentationStatusActive}
+	if query.Status != "" {
+		statuses = []string{query.Status}
+	}
+	representations, err := s.agencyRepo.FindRepresentationsByAgency(db, agency.ID, statuses)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return representations, nil
+}
+
+// CreateManagedModel - аккаунт и профиль модели, сразу с активным представительством.
+// Пароль случайный: модель входит в аккаунт через сброс пароля на свой email.
+func (s *agencyService) CreateManagedModel(db *gorm.DB, userID string, req *dto.CreateManagedModelRequest) (*models.ModelRepresentation, error) {
+	agency, err := s.agencyRepo.FindAgencyProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+
+	passwordHash, err := randomPasswordHash()
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	profileReq := &req.CreateModelProfileRequest
+	languagesJSON, err := json.Marshal(profileReq.Languages)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	categoriesJSON, err := json.Marshal(profileReq.Categories)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	profile := &models.ModelProfile{
+		Name:           profileReq.Name,
+		Age:            profileReq.Age,
+		Gender:         profileReq.Gender,
+		Experience:     profileReq.Experience,
+		HourlyRate:     profileReq.HourlyRate,
+		Description:    profileReq.Description,
+		City:           profileReq.City,
+		Languages:      datatypes.JSON(languagesJSON),
+		Categories:     datatypes.JSON(categoriesJSON),
+		BarterAccepted: profileReq.BarterAccepted,
+		IsPublic:       profileReq.IsPublic,
+	}
+	// Рост, вес и размеры - в см/кг и каноническом виде
+	if err := applyModelMeasurements(profile, &profileReq.Height, &profileReq.Weight,
+		&profileReq.ClothingSize, &profileReq.ShoeSize, &profileReq.MeasurementsInput); err != nil {
+		return nil, err
+	}
+	profile.CompletenessScore, _ = algorithms.ProfileCompleteness(profile, 0)
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	user := &models.User{
+		Name:         profileReq.Name,
+		Email:        req.Email,
+		PasswordHash: passwordHash,
+		Role:         models.UserRoleModel,
+		Status:       models.UserStatusActive,
+	}
+	if err := s.userRepo.Create(tx, user); err != nil {
+		if errors.Is(err, repositories.ErrUserAlreadyExists) {
+			return nil, apperrors.ErrEmailAlreadyExists
+		}
+		return nil, apperrors.InternalError(err)
+	}
+
+	profile.UserID = user.ID
+	if err := s.profileRepo.CreateModelProfile(tx, profile); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	// Отклики модели расходуют ее собственный тариф
+	if err := assignFreeSubscription(tx, s.subscriptionRepo, user.ID); err != nil {
+		fmt.Printf("CreateManagedModel: failed to create free subscription: %v\n", err)
+	}
+
+	now := time.Now()
+	representation := &models.ModelRepresentation{
+		AgencyID:       agency.ID,
+		ModelProfileID: profile.ID,
+		ModelUserID:    user.ID,
+		Status:         models.RepresentationStatusActive,
+		CopyModel:      req.CopyModel,
+		IsManaged:      true,
+		StartedAt:      &now,
+	}
+	if err := s.agencyRepo.CreateRepresentation(tx, representation); err != nil {
+		return nil, handleAgencyError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	representation.Agency = agency
+	representation.ModelProfile = profile
+	return representation, nil
+}
+
+// InviteModel - приглашение существующей модели; представительство начнется, когда модель его примет
+func (s *agencyService) InviteModel(db *gorm.DB, userID string, req *dto.InviteModelRequest) (*models.ModelRepresentation, error) {
+	agency, err := s.agencyRepo.FindAgencyProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+	profile, err := s.profileRepo.FindModelProfileByID(db, req.ModelProfileID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+	if err := ensureNotBlocked(db, s.blockRepo, userID, profile.UserID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.agencyRepo.FindOpenRepresentation(db, agency.ID, profile.ID); err == nil {
+		return nil, apperrors.ErrRepresentationExists
+	} else if !errors.Is(err, repositories.ErrRepresentationNotFound) {
+		return nil, apperrors.InternalError(err)
+	}
+
+	copyModel := true
+	if req.CopyModel != nil {
+		copyModel = *req.CopyModel
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	representation := &models.ModelRepresentation{
+		AgencyID:       agency.ID,
+		ModelProfileID: profile.ID,
+		ModelUserID:    profile.UserID,
+		Status:         models.RepresentationStatusPending,
+		CopyModel:      copyModel,
+	}
+	if err := s.agencyRepo.CreateRepresentation(tx, representation); err != nil {
+		return nil, handleAgencyError(err)
+	}
+
+	if err := s.notificationRepo.CreateRepresentationNotification(tx, profile.UserID, representation.ID,
+		"Приглашение от агентства",
+		fmt.Sprintf("Агентство '%s' предлагает вам представительство", agency.Name)); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	representation.Agency = agency
+	representation.ModelProfile = profile
+	return representation, nil
+}
+
+// UpdateModelProfile - агентство редактирует профиль модели так же, как сама модель
+func (s *agencyService) UpdateModelProfile(db *gorm.DB, userID, modelProfileID string, req *dto.UpdateProfileRequest) error {
+	representation, err := s.findRepresented(db, userID, modelProfileID)
+	if err != nil {
+		return err
+	}
+	return s.profileService.UpdateProfile(db, representation.ModelUserID, req)
+}
+
+// RespondToCasting - отклик от имени модели: те же проверки и лимиты тарифа модели, что и при ее собственном отклике
+func (s *agencyService) RespondToCasting(db *gorm.DB, userID, modelProfileID string, req *dto.AgencyResponseRequest) (*models.CastingResponse, error) {
+	representation, err := s.findRepresented(db, userID, modelProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	responseReq := &dto.CreateResponseRequest{
+		ModelID:   representation.ModelUserID,
+		CastingID: req.CastingID,
+		Message:   req.Message,
+	}
+	return s.responseService.CreateResponse(db, representation.ModelUserID, req.CastingID, responseReq)
+}
+
+// EndRepresentation - агентство прекращает представлять модель (или отзывает приглашение)
+func (s *agencyService) EndRepresentation(db *gorm.DB, userID, modelProfileID string) error {
+	agency, err := s.agencyRepo.FindAgencyProfileByUserID(db, userID)
+	if err != nil {
+		return handleAgencyError(err)
+	}
+	representation, err := s.agencyRepo.FindOpenRepresentation(db, agency.ID, modelProfileID)
+	if err != nil {
+		return handleAgencyError(err)
+	}
+
+	return s.end(db, representation, models.RepresentationEndedByAgency, representation.ModelUserID,
+		"Представительство прекращено",
+		fmt.Sprintf("Агентство '%s' больше не представляет вас", agency.Name))
+}
+
+// --- Сторона модели ---
+
+func (s *agencyService) GetMyRepresentation(db *gorm.DB, userID string) (*dto.MyRepresentationResponse, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+
+	representations, err := s.agencyRepo.FindRepresentationsByModelProfile(db, profile.ID,
+		[]string{models.RepresentationStatusPending, models.RepresentationStatusActive})
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	response := &dto.MyRepresentationResponse{Invitations: []models.ModelRepresentation{}}
+	for i := range representations {
+		if representations[i].Status == models.RepresentationStatusActive {
+			response.Current = &representations[i]
+		} else {
+			response.Invitations = append(response.Invitations, representations[i])
+		}
+	}
+	return response, nil
+}
+
+// AcceptInvitation - модель принимает приглашение; агентство подключается к ее диалогам
+func (s *agencyService) AcceptInvitation(db *gorm.DB, userID, representationID string) (*models.ModelRepresentation, error) {
+	representation, err := s.findInvitation(db, userID, representationID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.agencyRepo.FindActiveRepresentationByModelUser(db, userID); err == nil {
+		return nil, apperrors.ErrAlreadyRepresented
+	} else if !errors.Is(err, repositories.ErrRepresentationNotFound) {
+		return nil, apperrors.InternalError(err)
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	representation.Status = models.RepresentationStatusActive
+	representation.StartedAt = &now
+	if err := s.agencyRepo.UpdateRepresentation(tx, representation); err != nil {
+		return nil, handleAgencyError(err)
+	}
+	if err := s.chatRepo.AddAgentToModelDialogs(tx, representation.Agency.UserID, userID); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	if err := s.notificationRepo.CreateRepresentationNotification(tx, representation.Agency.UserID, representation.ID,
+		"Приглашение принято",
+		fmt.Sprintf("%s теперь представлена вашим агентством", representation.ModelProfile.Name)); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return representation, nil
+}
+
+func (s *agencyService) DeclineInvitation(db *gorm.DB, userID, representationID string) error {
+	representation, err := s.findInvitation(db, userID, representationID)
+	if err != nil {
+		return err
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	representation.Status = models.RepresentationStatusDeclined
+	representation.EndedAt = &now
+	representation.EndedBy = models.RepresentationEndedByModel
+	if err := s.agencyRepo.UpdateRepresentation(tx, representation); err != nil {
+		return handleAgencyError(err)
+	}
+	if err := s.notificationRepo.CreateRepresentationNotification(tx, representation.Agency.UserID, representation.ID,
+		"Приглашение отклонено",
+		fmt.Sprintf("%s отклонила приглашение агентства", representation.ModelProfile.Name)); err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	return tx.Commit().Error
+}
+
+// UpdateMyRepresentation - модель включает или выключает копии уведомлений, которые получает агентство
+func (s *agencyService) UpdateMyRepresentation(db *gorm.DB, userID string, req *dto.UpdateRepresentationRequest) (*models.ModelRepresentation, error) {
+	representation, err := s.agencyRepo.FindActiveRepresentationByModelUser(db, userID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+
+	representation.CopyModel = req.CopyModel
+	if err := s.agencyRepo.UpdateRepresentation(db, representation); err != nil {
+		return nil, handleAgencyError(err)
+	}
+	return representation, nil
+}
+
+// LeaveAgency - модель уходит из агентства; уведомления и сообщения снова приходят только ей
+func (s *agencyService) LeaveAgency(db *gorm.DB, userID string) error {
+	representation, err := s.agencyRepo.FindActiveRepresentationByModelUser(db, userID)
+	if err != nil {
+		return handleAgencyError(err)
+	}
+
+	return s.end(db, representation, models.RepresentationEndedByModel, representation.Agency.UserID,
+		"Модель покинула агентство",
+		fmt.Sprintf("%s больше не представлена вашим агентством", representation.ModelProfile.Name))
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// findRepresented - активное представительство модели агентством текущего пользователя
+func (s *agencyService) findRepresented(db *gorm.DB, userID, modelProfileID string) (*models.ModelRepresentation, error) {
+	representation, err := s.agencyRepo.FindActiveRepresentationByModelProfile(db, modelProfileID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrRepresentationNotFound) {
+			return nil, apperrors.ErrNotRepresented
+		}
+		return nil, apperrors.InternalError(err)
+	}
+	if representation.Agency == nil || representation.Agency.UserID != userID {
+		return nil, apperrors.ErrNotRepresented
+	}
+	return representation, nil
+}
+
+// findInvitation - приглашение, адресованное текущей модели и еще ждущее ответа
+func (s *agencyService) findInvitation(db *gorm.DB, userID, representationID string) (*models.ModelRepresentation, error) {
+	representation, err := s.agencyRepo.FindRepresentationByID(db, representationID)
+	if err != nil {
+		return nil, handleAgencyError(err)
+	}
+	if representation.ModelUserID != userID {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+	if representation.Status != models.RepresentationStatusPending {
+		return nil, apperrors.ErrInvalidStatus("agency", "Invitation has already been answered")
+	}
+	return representation, nil
+}
+
+// end - закрывает приглашение или представительство и убирает агентство из диалогов модели
+func (s *agencyService) end(db *gorm.DB, representation *models.ModelRepresentation, endedBy, notifyUserID, title, message string) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	wasActive := representation.Status == models.RepresentationStatusActive
+	now := time.Now()
+	representation.Status = models.RepresentationStatusEnded
+	representation.EndedAt = &now
+	representation.EndedBy = endedBy
+	if err := s.agencyRepo.UpdateRepresentation(tx, representation); err != nil {
+		return handleAgencyError(err)
+	}
+
+	if wasActive {
+		if err := s.chatRepo.RemoveAgentFromModelDialogs(tx, representation.Agency.UserID, representation.ModelUserID); err != nil {
+			return apperrors.InternalError(err)
+		}
+	}
+	if err := s.notificationRepo.CreateRepresentationNotification(tx, notifyUserID, representation.ID, title, message); err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	return tx.Commit().Error
+}
+
+// representedBy - "represented by" для публичного профиля модели (nil - модель без агентства)
+func representedBy(db *gorm.DB, agencyRepo repositories.AgencyRepository, modelProfileID string) *dto.RepresentedBy {
+	representation, err := agencyRepo.FindActiveRepresentationByModelProfile(db, modelProfileID)
+	if err != nil || representation.Agency == nil {
+		return nil
+	}
+	return &dto.RepresentedBy{
+		AgencyID:   representation.Agency.ID,
+		UserID:     representation.Agency.UserID,
+		Name:       representation.Agency.Name,
+		City:       representation.Agency.City,
+		Website:    representation.Agency.Website,
+		IsVerified: representation.Agency.IsVerified,
+		Since:      representation.StartedAt,
+	}
+}
+
+// randomPasswordHash - пароль аккаунта, созданного агентством (модель задает свой через сброс пароля)
+func randomPasswordHash() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(hex.EncodeToString(buf)), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func handleAgencyError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrAgencyNotFound),
+		errors.Is(err, repositories.ErrRepresentationNotFound),
+		errors.Is(err, repositories.ErrProfileNotFound),
+		errors.Is(err, repositories.ErrUserNotFound):
+		return apperrors.ErrNotFound(err)
+	case errors.Is(err, repositories.ErrRepresentationExists):
+		return apperrors.ErrRepresentationExists
+	}
+	return apperrors.InternalError(err)
+}
@@ -8,6 +8,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/auth"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
@@ -41,6 +42,7 @@ type AuthServiceImpl struct {
 	subscriptionRepo repositories.SubscriptionRepository
 	emailProvider    email.Provider
 	refreshTokenRepo repositories.RefreshTokenRepository
+	agencyRepo       repositories.AgencyRepository
 }
 
 // ✅ Конструктор (без изменений)
@@ -50,6 +52,7 @@ func NewAuthService(
 	subscriptionRepo repositories.SubscriptionRepository,
 	emailProvider email.Provider,
 	refreshTokenRepo repositories.RefreshTokenRepository,
+	agencyRepo repositories.AgencyRepository,
 ) AuthService {
 	return &AuthServiceImpl{
 		userRepo:         userRepo,
@@ -57,6 +60,7 @@ func NewAuthService(
 		subscriptionRepo: subscriptionRepo,
 		emailProvider:    emailProvider,
 		refreshTokenRepo: refreshTokenRepo,
+		agencyRepo:       agencyRepo,
 	}
 }
 
@@ -66,7 +70,7 @@ func (s *AuthServiceImpl) Register(db *gorm.DB, req *dto.RegisterRequest) error
 	if len(req.Password) < 6 {
 		return apperrors.ErrWeakPassword
 	}
-	if req.Role != models.UserRoleModel && req.Role != models.UserRoleEmployer {
+	if req.Role != models.UserRoleModel && req.Role != models.UserRoleEmployer && req.Role != models.UserRoleAgency {
 		return apperrors.ErrInvalidUserRole
 	}
 	if err := s.validateRegisterRequest(req); err != nil {
@@ -385,7 +389,8 @@ func (s *AuthServiceImpl) ChangePassword(db *gorm.DB, userID, currentPassword, n
 func (s *AuthServiceImpl) AdminCreateUser(db *gorm.DB, req *dto.AdminCreateUserRequest) (*models.User, error) {
 
 	// 1. Проверяем, что роль валидна (но теперь допускаем и Admin)
-	if req.Role != models.UserRoleModel && req.Role != models.UserRoleEmployer && req.Role != models.UserRoleAdmin {
+	if req.Role != models.UserRoleModel && req.Role != models.UserRoleEmployer &&
+		req.Role != models.UserRoleAgency && req.Role != models.UserRoleAdmin {
 		return nil, apperrors.ErrInvalidUserRole
 	}
 
@@ -396,9 +401,9 @@ func (s *AuthServiceImpl) AdminCreateUser(db *gorm.DB, req *dto.AdminCreateUserR
 		if req.Name == "" || req.City == "" {
 			return nil, apperrors.ValidationError("name and city are required for model role")
 		}
-	} else if req.Role == models.UserRoleEmployer {
+	} else if req.Role == models.UserRoleEmployer || req.Role == models.UserRoleAgency {
 		if req.CompanyName == "" || req.City == "" {
-			return nil, apperrors.ValidationError("company_name and city are required for employer role")
+			return nil, apperrors.ValidationError(fmt.Sprintf("company_name and city are required for %s role", req.Role))
 		}
 	}
 
@@ -481,6 +486,7 @@ func (s *AuthServiceImpl) createUserProfile(db *gorm.DB, user *models.User, req
 			Height:   170, // Поставь валидное значение (не 0)
 			Weight:   55,  // Поставь валидное значение (не 0)
 		}
+		profile.CompletenessScore, _ = algorithms.ProfileCompleteness(profile, 0)
 		// ✅ Передаем db (БЕЗ 'ctx')
 		return s.profileRepo.CreateModelProfile(db, profile)
 	} else if user.Role == models.UserRoleEmployer {
@@ -492,13 +498,25 @@ func (s *AuthServiceImpl) createUserProfile(db *gorm.DB, user *models.User, req
 		}
 		// ✅ Передаем db (БЕЗ 'ctx')
 		return s.profileRepo.CreateEmployerProfile(db, profile)
+	} else if user.Role == models.UserRoleAgency {
+		profile := &models.AgencyProfile{
+			UserID: user.ID,
+			Name:   req.CompanyName,
+			City:   req.City,
+		}
+		return s.agencyRepo.CreateAgencyProfile(db, profile)
 	}
 	return nil
 }
 
 func (s *AuthServiceImpl) assignFreeSubscription(db *gorm.DB, userID string) error {
+	return assignFreeSubscription(db, s.subscriptionRepo, userID)
+}
+
+// assignFreeSubscription - бесплатный тариф новому пользователю (регистрация, админ, модель агентства)
+func assignFreeSubscription(db *gorm.DB, subscriptionRepo repositories.SubscriptionRepository, userID string) error {
 	// ✅ Передаем db (БЕЗ 'ctx')
-	freePlan, err := s.subscriptionRepo.FindPlanByName(db, "Free")
+	freePlan, err := subscriptionRepo.FindPlanByName(db, "Free")
 	if err != nil || freePlan == nil {
 		return fmt.Errorf("free plan not found: %w", err)
 	}
@@ -514,7 +532,7 @@ func (s *AuthServiceImpl) assignFreeSubscription(db *gorm.DB, userID string) err
 	}
 
 	// ✅ Передаем db (БЕЗ 'ctx')
-	return s.subscriptionRepo.CreateUserSubscription(db, subscription)
+	return subscriptionRepo.CreateUserSubscription(db, subscription)
 }
 
 func (s *AuthServiceImpl) createRefreshToken(db *gorm.DB, userID string) (string, error) {
@@ -596,12 +614,12 @@ func (s *AuthServiceImpl) validateRegisterRequest(req *dto.RegisterRequest) erro
 		if req.City == "" {
 			return apperrors.ValidationError("city is required for model role")
 		}
-	} else if req.Role == models.UserRoleEmployer {
+	} else if req.Role == models.UserRoleEmployer || req.Role == models.UserRoleAgency {
 		if req.CompanyName == "" {
-			return apperrors.ValidationError("company_name is required for employer role")
+			return apperrors.ValidationError(fmt.Sprintf("company_name is required for %s role", req.Role))
 		}
 		if req.City == "" {
-			return apperrors.ValidationError("city is required for employer role")
+			return apperrors.ValidationError(fmt.Sprintf("city is required for %s role", req.Role))
 		}
 	}
 	return nil
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type AvailabilityService interface {
+	// Календарь текущей модели (userID = users.id)
+	GetMyCalendar(db *gorm.DB, userID string, query *dto.AvailabilityPeriodQuery) (*dto.AvailabilityCalendar, error)
+	CreateRange(db *gorm.DB, userID string, req *dto.CreateAvailabilityRangeRequest) (*models.ModelAvailabilityRange, error)
+	DeleteRange(db *gorm.DB, userID, rangeID string) error
+	ReplaceWeekly(db *gorm.DB, userID string, req *dto.ReplaceWeeklyAvailabilityRequest) ([]models.ModelAvailabilityWeekly, error)
+
+	// Календарь модели для работодателей (без личных заметок)
+	GetModelCalendar(db *gorm.DB, modelProfileID string, query *dto.AvailabilityPeriodQuery) (*dto.AvailabilityCalendar, error)
+
+	// CheckAvailability - доступность моделей (model_profiles.id) в интервал [from, to).
+	// Блокировки по кастингу excludeCastingID не учитываются (подбор на сам этот кастинг).
+	CheckAvailability(db *gorm.DB, profileIDs []string, from, to time.Time, excludeCastingID string) (map[string]*dto.AvailabilityCheck, error)
+}
+
+// defaultCalendarPeriod - период календаря, если он не указан в запросе
+const defaultCalendarPeriod = 60 * 24 * time.Hour
+
+// maxCalendarPeriod - максимальный запрашиваемый период
+const maxCalendarPeriod = 366 * 24 * time.Hour
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type availabilityService struct {
+	availabilityRepo repositories.AvailabilityRepository
+	profileRepo      repositories.ProfileRepository
+}
+
+func NewAvailabilityService(
+	availabilityRepo repositories.AvailabilityRepository,
+	profileRepo repositories.ProfileRepository,
+) AvailabilityService {
+	return &availabilityService{
+		availabilityRepo: availabilityRepo,
+		profileRepo:      profileRepo,
+	}
+}
+
+func (s *availabilityService) GetMyCalendar(db *gorm.DB, userID string, query *dto.AvailabilityPeriodQuery) (*dto.AvailabilityCalendar, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAvailabilityError(err)
+	}
+	return s.buildCalendar(db, profile.ID, query, true)
+}
+
+func (s *availabilityService) CreateRange(db *gorm.DB, userID string, req *dto.CreateAvailabilityRangeRequest) (*models.ModelAvailabilityRange, error) {
+	if !req.EndsAt.After(req.StartsAt) {
+		return nil, apperrors.ErrInvalidOperation("availability", "ends_at must be after starts_at")
+	}
+	if req.EndsAt.Sub(req.StartsAt) > maxCalendarPeriod {
+		return nil, apperrors.ErrInvalidOperation("availability", "Range cannot be longer than one year")
+	}
+
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAvailabilityError(err)
+	}
+
+	availabilityRange := &models.ModelAvailabilityRange{
+		ModelProfileID: profile.ID,
+		Status:         req.Status,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		Note:           req.Note,
+	}
+	if err := s.availabilityRepo.CreateRange(db, availabilityRange); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return availabilityRange, nil
+}
+
+func (s *availabilityService) DeleteRange(db *gorm.DB, userID, rangeID string) error {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return handleAvailabilityError(err)
+	}
+
+	availabilityRange, err := s.availabilityRepo.FindRangeByID(db, rangeID)
+	if err != nil {
+		return handleAvailabilityError(err)
+	}
+	if availabilityRange.ModelProfileID != profile.ID {
+		return apperrors.ErrInsufficientPermissions
+	}
+
+	if err := s.availabilityRepo.DeleteRange(db, rangeID); err != nil {
+		return handleAvailabilityError(err)
+	}
+	return nil
+}
+
+func (s *availabilityService) ReplaceWeekly(db *gorm.DB, userID string, req *dto.ReplaceWeeklyAvailabilityRequest) ([]models.ModelAvailabilityWeekly, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleAvailabilityError(err)
+	}
+
+	rules := make([]models.ModelAvailabilityWeekly, 0, len(req.Rules))
+	for i, rule := range req.Rules {
+		startMinute, err := parseDayMinute(rule.Start, 0)
+		if err != nil {
+			return nil, apperrors.ErrInvalidOperation("availability", fmt.Sprintf("rules[%d].start: %v", i, err))
+		}
+		endMinute, err := parseDayMinute(rule.End, 24*60)
+		if err != nil {
+			return nil, apperrors.ErrInvalidOperation("availability", fmt.Sprintf("rules[%d].end: %v", i, err))
+		}
+		if endMinute <= startMinute {
+			return nil, apperrors.ErrInvalidOperation("availability", fmt.Sprintf("rules[%d]: end must be after start", i))
+		}
+		rules = append(rules, models.ModelAvailabilityWeekly{
+			ModelProfileID: profile.ID,
+			Status:         rule.Status,
+			Weekday:        rule.Weekday,
+			StartMinute:    startMinute,
+			EndMinute:      endMinute,
+		})
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	if err := s.availabilityRepo.ReplaceWeeklyRules(tx, profile.ID, rules); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return rules, nil
+}
+
+func (s *availabilityService) GetModelCalendar(db *gorm.DB, modelProfileID string, query *dto.AvailabilityPeriodQuery) (*dto.AvailabilityCalendar, error) {
+	profile, err := s.profileRepo.FindModelProfileByID(db, modelProfileID)
+	if err != nil {
+		return nil, handleAvailabilityError(err)
+	}
+	if !profile.IsPublic {
+		return nil, apperrors.ErrProfileNotPublic
+	}
+	return s.buildCalendar(db, profile.ID, query, false)
+}
+
+func (s *availabilityService) CheckAvailability(db *gorm.DB, profileIDs []string, from, to time.Time, excludeCastingID string) (map[string]*dto.AvailabilityCheck, error) {
+	result := make(map[string]*dto.AvailabilityCheck, len(profileIDs))
+	if len(profileIDs) == 0 {
+		return result, nil
+	}
+
+	ranges, err := s.availabilityRepo.FindRanges(db, profileIDs, from, to)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	rules, err := s.availabilityRepo.FindWeeklyRules(db, profileIDs)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	busy, err := s.availabilityRepo.FindBusySlots(db, profileIDs, from, to, excludeCastingID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	rangesByProfile := make(map[string][]models.ModelAvailabilityRange)
+	for _, r := range ranges {
+		rangesByProfile[r.ModelProfileID] = append(rangesByProfile[r.ModelProfileID], r)
+	}
+	rulesByProfile := make(map[string][]models.ModelAvailabilityWeekly)
+	for _, rule := range rules {
+		rulesByProfile[rule.ModelProfileID] = append(rulesByProfile[rule.ModelProfileID], rule)
+	}
+	busyByProfile := make(map[string][]models.ModelBusySlot)
+	for _, slot := range busy {
+		busyByProfile[slot.ModelProfileID] = append(busyByProfile[slot.ModelProfileID], slot)
+	}
+
+	for _, profileID := range profileIDs {
+		result[profileID] = evaluateAvailability(from, to, rangesByProfile[profileID], rulesByProfile[profileID], busyByProfile[profileID])
+	}
+	return result, nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+func (s *availabilityService) buildCalendar(db *gorm.DB, profileID string, query *dto.AvailabilityPeriodQuery, withNotes bool) (*dto.AvailabilityCalendar, error) {
+	from, to, err := calendarPeriod(query)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := s.availabilityRepo.FindRanges(db, []string{profileID}, from, to)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	rules, err := s.availabilityRepo.FindWeeklyRules(db, []string{profileID})
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	busy, err := s.availabilityRepo.FindBusySlots(db, []string{profileID}, from, to, "")
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	if !withNotes {
+		// Работодателю - только факт занятости, без заметок и названий чужих проектов
+		for i := range ranges {
+			ranges[i].Note = ""
+		}
+		for i := range busy {
+			busy[i].Title = ""
+			busy[i].SourceID = ""
+		}
+	}
+
+	return &dto.AvailabilityCalendar{
+		ModelProfileID: profileID,
+		From:           from,
+		To:             to,
+		Ranges:         ranges,
+		Weekly:         rules,
+		Busy:           busy,
+	}, nil
+}
+
+// calendarPeriod - [from, to) по дням календаря; to включает указанный день
+func calendarPeriod(query *dto.AvailabilityPeriodQuery) (time.Time, time.Time, error) {
+	from, _ := models.CalendarDay(time.Now())
+	if query != nil && query.From != nil {
+		from, _ = models.CalendarDay(*query.From)
+	}
+	to := from.Add(defaultCalendarPeriod)
+	if query != nil && query.To != nil {
+		_, to = models.CalendarDay(*query.To)
+	}
+
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, apperrors.ErrInvalidOperation("availability", "to cannot be before from")
+	}
+	if to.Sub(from) > maxCalendarPeriod {
+		return time.Time{}, time.Time{}, apperrors.ErrInvalidOperation("availability", "Period cannot be longer than one year")
+	}
+	return from, to, nil
+}
+
+// evaluateAvailability - приоритет: занятость (отклики, бронирования) > явная недоступность >
+// явная доступность > еженедельный шаблон > неизвестно
+func evaluateAvailability(from, to time.Time, ranges []models.ModelAvailabilityRange, rules []models.ModelAvailabilityWeekly, busy []models.ModelBusySlot) *dto.AvailabilityCheck {
+	for _, slot := range busy {
+		if slot.StartsAt.Before(to) && slot.EndsAt.After(from) {
+			reason := "Занята на другом проекте"
+			if slot.Source == models.ModelBusySourceBooking {
+				reason = "Есть бронирование на эти даты"
+			}
+			return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusUnavailable, Reason: reason}
+		}
+	}
+
+	explicitlyAvailable := false
+	for i := range ranges {
+		if !ranges[i].Overlaps(from, to) {
+			continue
+		}
+		if ranges[i].Status == models.AvailabilityUnavailable {
+			return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusUnavailable, Reason: "Отмечена недоступной в календаре"}
+		}
+		explicitlyAvailable = true
+	}
+	if explicitlyAvailable {
+		return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusAvailable}
+	}
+
+	if len(rules) == 0 {
+		return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusUnknown}
+	}
+
+	hasAvailableRules := false
+	matchedAvailable := false
+	for _, rule := range rules {
+		if rule.Status == models.AvailabilityAvailable {
+			hasAvailableRules = true
+		}
+		if !weeklyRuleOverlaps(rule, from, to) {
+			continue
+		}
+		if rule.Status == models.AvailabilityUnavailable {
+			return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusUsuallyUnavailable, Reason: "Обычно недоступна в это время"}
+		}
+		matchedAvailable = true
+	}
+	if matchedAvailable {
+		return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusAvailable}
+	}
+	// Шаблон задан рабочими часами, и интервал в них не попадает
+	if hasAvailableRules {
+		return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusUsuallyUnavailable, Reason: "Вне обычного графика"}
+	}
+	return &dto.AvailabilityCheck{Status: dto.AvailabilityStatusUnknown}
+}
+
+// weeklyRuleOverlaps - пересекается ли правило с [from, to) хотя бы в один из затронутых дней
+func weeklyRuleOverlaps(rule models.ModelAvailabilityWeekly, from, to time.Time) bool {
+	day, _ := models.CalendarDay(from)
+	for day.Before(to) {
+		if int(day.Weekday()) == rule.Weekday {
+			ruleStart := day.Add(time.Duration(rule.StartMinute) * time.Minute)
+			ruleEnd := day.Add(time.Duration(rule.EndMinute) * time.Minute)
+			if ruleStart.Before(to) && ruleEnd.After(from) {
+				return true
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return false
+}
+
+// parseDayMinute - "HH:MM" в минуты от начала дня ("24:00" допустимо как конец дня)
+func parseDayMinute(value string, defaultMinute int) (int, error) {
+	if value == "" {
+		return defaultMinute, nil
+	}
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, errors.New("time must be in HH:MM format")
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func handleAvailabilityError(err error) error {
+	if errors.Is(err, repositories.ErrAvailabilityRangeNotFound) ||
+		errors.Is(err, repositories.ErrProfileNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	return apperrors.InternalError(err)
+}
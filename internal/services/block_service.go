@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type BlockService interface {
+	BlockUser(db *gorm.DB, userID, targetUserID string) (*models.UserBlock, error)
+	UnblockUser(db *gorm.DB, userID, targetUserID string) error
+	GetBlockedUsers(db *gorm.DB, userID string, page, pageSize int) ([]repositories.BlockedUser, int64, error)
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type blockService struct {
+	blockRepo repositories.BlockRepository
+	userRepo  repositories.UserRepository
+}
+
+func NewBlockService(blockRepo repositories.BlockRepository, userRepo repositories.UserRepository) BlockService {
+	return &blockService{
+		blockRepo: blockRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// BlockUser - добавить пользователя в черный список. Администраторов заблокировать нельзя:
+// модерация должна оставаться доступной.
+func (s *blockService) BlockUser(db *gorm.DB, userID, targetUserID string) (*models.UserBlock, error) {
+	if userID == targetUserID {
+		return nil, apperrors.ErrInvalidOperation("block", "You cannot block yourself")
+	}
+
+	target, err := s.userRepo.FindByID(db, targetUserID)
+	if err != nil {
+		return nil, handleBlockError(err)
+	}
+	if target.Role == models.UserRoleAdmin {
+		return nil, apperrors.ErrInvalidOperation("block", "Administrators cannot be blocked")
+	}
+
+	block := &models.UserBlock{
+		BlockerID: userID,
+		BlockedID: targetUserID,
+	}
+	if err := s.blockRepo.CreateBlock(db, block); err != nil {
+		return nil, handleBlockError(err)
+	}
+	return block, nil
+}
+
+func (s *blockService) UnblockUser(db *gorm.DB, userID, targetUserID string) error {
+	if err := s.blockRepo.DeleteBlock(db, userID, targetUserID); err != nil {
+		return handleBlockError(err)
+	}
+	return nil
+}
+
+func (s *blockService) GetBlockedUsers(db *gorm.DB, userID string, page, pageSize int) ([]repositories.BlockedUser, int64, error) {
+	users, total, err := s.blockRepo.FindBlockedUsers(db, userID, page, pageSize)
+	if err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+	return users, total, nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// ensureNotBlocked - ErrUserBlocked, если один из пользователей заблокировал другого.
+// Используется перед личными действиями: сообщения, диалоги, отклики, приглашения.
+func ensureNotBlocked(db *gorm.DB, blockRepo repositories.BlockRepository, userID, otherUserID string) error {
+	if userID == "" || otherUserID == "" || userID == otherUserID {
+		return nil
+	}
+	blocked, err := blockRepo.IsBlockedEitherWay(db, userID, otherUserID)
+	if err != nil {
+		return apperrors.InternalError(err)
+	}
+	if blocked {
+		return apperrors.ErrUserBlocked
+	}
+	return nil
+}
+
+func handleBlockError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrBlockNotFound),
+		errors.Is(err, repositories.ErrUserNotFound):
+		return apperrors.ErrNotFound(err)
+	case errors.Is(err, repositories.ErrBlockExists):
+		return apperrors.ErrAlreadyBlocked
+	}
+	return apperrors.InternalError(err)
+}
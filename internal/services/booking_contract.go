@@ -0,0 +1,99 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Шаблоны договоров бронирования. Ключ сохраняется в Booking.ContractTemplate,
+// сам текст - в Booking.ContractBody, поэтому правка шаблона не меняет уже подписанные договоры.
+const (
+	ContractTemplateStandard   = "standard"
+	ContractTemplateCommercial = "commercial"
+)
+
+// bookingContractData - подстановки для шаблона договора
+type bookingContractData struct {
+	Number       string
+	Date         string
+	CastingTitle string
+	EmployerName string
+	ModelName    string
+	Fee          string
+	Currency     string
+	StartsAt     string
+	EndsAt       string
+	Location     string
+	UsageRights  string
+	Terms        string
+}
+
+const contractHeader = `Договор № {{.Number}} от {{.Date}}
+
+{{.EmployerName}} (далее - Заказчик) и {{.ModelName}} (далее - Модель) заключили настоящий договор о нижеследующем.
+
+1. Предмет договора
+Модель оказывает Заказчику услуги по участию в проекте «{{.CastingTitle}}».
+Место оказания услуг: {{.Location}}.
+Период: с {{.StartsAt}} по {{.EndsAt}}.
+
+2. Вознаграждение
+Заказчик выплачивает Модели вознаграждение в размере {{.Fee}} {{.Currency}}.
+`
+
+const contractFooter = `
+{{if .Terms}}4. Дополнительные условия
+{{.Terms}}
+
+{{end}}{{if .Terms}}5{{else}}4{{end}}. Подписание
+Договор подписывается сторонами электронно путем ввода полного имени. Подпись относится к конкретной версии текста договора; при изменении условий договор подписывается заново.
+`
+
+var bookingContractTemplates = map[string]*template.Template{
+	ContractTemplateStandard: template.Must(template.New(ContractTemplateStandard).Parse(contractHeader + `
+3. Использование материалов
+{{if .UsageRights}}{{.UsageRights}}{{else}}Материалы, полученные в ходе работы, используются Заказчиком только в портфолио и внутренних целях. Коммерческое использование требует отдельного согласия Модели.{{end}}
+` + contractFooter)),
+
+	ContractTemplateCommercial: template.Must(template.New(ContractTemplateCommercial).Parse(contractHeader + `
+3. Использование материалов
+Модель передает Заказчику право на коммерческое использование изображений на следующих условиях: {{if .UsageRights}}{{.UsageRights}}{{else}}территория - Республика Казахстан, срок - 1 год, носители - digital и печать{{end}}.
+Использование за пределами указанных условий требует отдельного договора.
+` + contractFooter)),
+}
+
+// isKnownContractTemplate - проверка ключа шаблона из запроса
+func isKnownContractTemplate(name string) bool {
+	_, ok := bookingContractTemplates[name]
+	return ok
+}
+
+// renderBookingContract - текст договора и его SHA-256 (подписи ссылаются на хэш)
+func renderBookingContract(templateName string, data *bookingContractData) (string, string, error) {
+	tmpl, ok := bookingContractTemplates[templateName]
+	if !ok {
+		return "", "", fmt.Errorf("unknown contract template: %s", templateName)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render contract: %w", err)
+	}
+
+	body := strings.TrimSpace(buf.String())
+	sum := sha256.Sum256([]byte(body))
+	return body, hex.EncodeToString(sum[:]), nil
+}
+
+// formatContractTime - даты в договоре указываются по времени Алматы
+func formatContractTime(t time.Time) string {
+	if loc, err := time.LoadLocation("Asia/Almaty"); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format("02.01.2006 15:04")
+}
@@ -0,0 +1,632 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/document"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type BookingService interface {
+	CreateBooking(db *gorm.DB, employerID string, req *dto.CreateBookingRequest) (*dto.BookingResponse, error)
+	GetBooking(db *gorm.DB, bookingID, userID string) (*dto.BookingResponse, error)
+	GetMyBookings(db *gorm.DB, userID string, filter *dto.BookingListFilter) (*dto.BookingListResponse, error)
+	UpdateBookingTerms(db *gorm.DB, bookingID, employerID string, req *dto.UpdateBookingTermsRequest) (*dto.BookingResponse, error)
+	UpdateBookingStatus(db *gorm.DB, bookingID, userID string, req *dto.UpdateBookingStatusRequest) (*dto.BookingResponse, error)
+
+	// Договор и подписи
+	GetContract(db *gorm.DB, bookingID, userID string) (*dto.BookingContractResponse, error)
+	ExportContractPDF(db *gorm.DB, bookingID, userID string) (*dto.ExportFile, error)
+	SignContract(db *gorm.DB, bookingID, userID string, req *dto.SignBookingRequest, sigCtx dto.SignatureContext) (*dto.BookingResponse, error)
+}
+
+// bookableResponseStatuses - из каких статусов отклика можно оформить бронирование
+var bookableResponseStatuses = []models.ResponseStatus{
+	models.ResponseStatusAccepted,
+	models.ResponseStatusApproved,
+}
+
+// bookingTransition - кто и когда может перевести бронирование в статус
+type bookingTransition struct {
+	from          []models.BookingStatus
+	employerOnly  bool
+	afterStartsAt bool // только после начала работ (завершение, неявка)
+}
+
+// bookingTransitions - ручные переходы; pending -> confirmed происходит только при второй подписи
+var bookingTransitions = map[models.BookingStatus]bookingTransition{
+	models.BookingStatusCompleted: {
+		from:          []models.BookingStatus{models.BookingStatusConfirmed},
+		employerOnly:  true,
+		afterStartsAt: true,
+	},
+	models.BookingStatusNoShow: {
+		from:          []models.BookingStatus{models.BookingStatusConfirmed},
+		employerOnly:  true,
+		afterStartsAt: true,
+	},
+	models.BookingStatusCancelled: {
+		from: []models.BookingStatus{models.BookingStatusPending, models.BookingStatusConfirmed},
+	},
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type bookingService struct {
+	bookingRepo      repositories.BookingRepository
+	responseRepo     repositories.ResponseRepository
+	castingRepo      repositories.CastingRepository
+	userRepo         repositories.UserRepository
+	profileRepo      repositories.ProfileRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewBookingService(
+	bookingRepo repositories.BookingRepository,
+	responseRepo repositories.ResponseRepository,
+	castingRepo repositories.CastingRepository,
+	userRepo repositories.UserRepository,
+	profileRepo repositories.ProfileRepository,
+	notificationRepo repositories.NotificationRepository,
+) BookingService {
+	return &bookingService{
+		bookingRepo:      bookingRepo,
+		responseRepo:     responseRepo,
+		castingRepo:      castingRepo,
+		userRepo:         userRepo,
+		profileRepo:      profileRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// CreateBooking - работодатель фиксирует условия по принятому отклику; договор ждет подписей обеих сторон
+func (s *bookingService) CreateBooking(db *gorm.DB, employerID string, req *dto.CreateBookingRequest) (*dto.BookingResponse, error) {
+	if req.EndsAt.Before(req.StartsAt) {
+		return nil, apperrors.ErrInvalidOperation("booking", "ends_at must not be before starts_at")
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	response, err := s.responseRepo.FindResponseByID(tx, req.ResponseID)
+	if err != nil {
+		return nil, handleBookingError(err)
+	}
+	if !isBookableResponseStatus(response.Status) {
+		return nil, apperrors.ErrInvalidStatus("booking", fmt.Sprintf("Cannot book response in status '%s'", response.Status))
+	}
+
+	casting, err := s.castingRepo.FindCastingByID(tx, response.CastingID)
+	if err != nil {
+		return nil, handleBookingError(err)
+	}
+	if !s.isCastingOwner(tx, casting, employerID) {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+
+	booking := &models.Booking{
+		ResponseID:       response.ID,
+		CastingID:        casting.ID,
+		EmployerID:       employerID,
+		ModelID:          response.ModelID,
+		Status:           models.BookingStatusPending,
+		Fee:              req.Fee,
+		Currency:         req.Currency,
+		StartsAt:         req.StartsAt,
+		EndsAt:           req.EndsAt,
+		Location:         req.Location,
+		UsageRights:      req.UsageRights,
+		Terms:            req.Terms,
+		ContractTemplate: req.ContractTemplate,
+		ContractVersion:  1,
+	}
+	if booking.Currency == "" {
+		booking.Currency = "KZT"
+	}
+	if booking.ContractTemplate == "" {
+		booking.ContractTemplate = ContractTemplateStandard
+	}
+	booking.Casting = *casting
+
+	if err := s.renderContract(tx, booking); err != nil {
+		return nil, err
+	}
+
+	if err := s.bookingRepo.CreateBooking(tx, booking); err != nil {
+		return nil, handleBookingError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	// Модели - договор на подпись
+	go func() {
+		if err := s.notificationRepo.CreateBookingNotification(db, booking.ModelID, booking.ID, casting.Title, models.BookingStatusPending, nil); err != nil {
+			log.Printf("Failed to create booking notification: %v", err)
+		}
+	}()
+
+	return toBookingResponse(booking, employerID), nil
+}
+
+func (s *bookingService) GetBooking(db *gorm.DB, bookingID, userID string) (*dto.BookingResponse, error) {
+	booking, err := s.findParticipantBooking(db, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toBookingResponse(booking, userID), nil
+}
+
+// GetMyBookings - бронирования, где пользователь работодатель или модель
+func (s *bookingService) GetMyBookings(db *gorm.DB, userID string, filter *dto.BookingListFilter) (*dto.BookingListResponse, error) {
+	if filter == nil {
+		filter = &dto.BookingListFilter{}
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 20
+	}
+
+	bookings, total, err := s.bookingRepo.FindBookingsByUser(db, userID, repositories.BookingCriteria{
+		Status:   models.BookingStatus(filter.Status),
+		From:     filter.From,
+		To:       filter.To,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	})
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	result := make([]dto.BookingResponse, 0, len(bookings))
+	for i := range bookings {
+		result = append(result, *toBookingResponse(&bookings[i], userID))
+	}
+
+	return &dto.BookingListResponse{
+		Bookings: result,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+// UpdateBookingTerms - изменение условий до подтверждения. Договор перегенерируется с новой версией,
+// подписи предыдущей версии перестают действовать.
+func (s *bookingService) UpdateBookingTerms(db *gorm.DB, bookingID, employerID string, req *dto.UpdateBookingTermsRequest) (*dto.BookingResponse, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	booking, err := s.findParticipantBooking(tx, bookingID, employerID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.PartyOf(employerID) != models.BookingPartyEmployer {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+	if booking.Status != models.BookingStatusPending {
+		return nil, apperrors.ErrInvalidStatus("booking", "Terms can only be changed before the contract is signed by both parties")
+	}
+
+	if req.Fee != nil {
+		booking.Fee = *req.Fee
+	}
+	if req.Currency != nil {
+		booking.Currency = *req.Currency
+	}
+	if req.StartsAt != nil {
+		booking.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		booking.EndsAt = *req.EndsAt
+	}
+	if req.Location != nil {
+		booking.Location = *req.Location
+	}
+	if req.UsageRights != nil {
+		booking.UsageRights = *req.UsageRights
+	}
+	if req.Terms != nil {
+		booking.Terms = *req.Terms
+	}
+	if req.ContractTemplate != nil {
+		booking.ContractTemplate = *req.ContractTemplate
+	}
+	if booking.EndsAt.Before(booking.StartsAt) {
+		return nil, apperrors.ErrInvalidOperation("booking", "ends_at must not be before starts_at")
+	}
+
+	previousHash := booking.ContractHash
+	if err := s.renderContract(tx, booking); err != nil {
+		return nil, err
+	}
+	if booking.ContractHash == previousHash {
+		// Условия не изменились - версия договора и подписи остаются в силе
+		return toBookingResponse(booking, employerID), nil
+	}
+	booking.ContractVersion++
+
+	if err := s.bookingRepo.UpdateBookingTerms(tx, booking); err != nil {
+		return nil, handleBookingError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	// Модели - новая версия на подпись
+	go func() {
+		if err := s.notificationRepo.CreateBookingNotification(db, booking.ModelID, booking.ID, booking.Casting.Title, models.BookingStatusPending, nil); err != nil {
+			log.Printf("Failed to create booking notification: %v", err)
+		}
+	}()
+
+	return toBookingResponse(booking, employerID), nil
+}
+
+// UpdateBookingStatus - завершение, неявка (работодатель, после начала работ) или отмена (любая сторона)
+func (s *bookingService) UpdateBookingStatus(db *gorm.DB, bookingID, userID string, req *dto.UpdateBookingStatusRequest) (*dto.BookingResponse, error) {
+	transition, ok := bookingTransitions[req.Status]
+	if !ok {
+		return nil, apperrors.ErrInvalidStatus("booking", fmt.Sprintf("Status '%s' cannot be set manually", req.Status))
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	booking, err := s.findParticipantBooking(tx, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	party := booking.PartyOf(userID)
+
+	if transition.employerOnly && party != models.BookingPartyEmployer {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+	if !containsBookingStatus(transition.from, booking.Status) {
+		return nil, apperrors.ErrInvalidStatus("booking", fmt.Sprintf("Cannot change booking status from '%s' to '%s'", booking.Status, req.Status))
+	}
+	if transition.afterStartsAt && time.Now().Before(booking.StartsAt) {
+		return nil, apperrors.ErrInvalidOperation("booking", "Booking has not started yet")
+	}
+
+	var reason *string
+	if req.Reason != nil && strings.TrimSpace(*req.Reason) != "" {
+		reason = req.Reason
+	}
+
+	if err := s.bookingRepo.TransitionBookingStatus(tx, booking.ID, booking.Status, req.Status, reason); err != nil {
+		return nil, handleBookingError(err)
+	}
+
+	updated, err := s.bookingRepo.FindBookingByID(tx, booking.ID)
+	if err != nil {
+		return nil, handleBookingError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	// Уведомляем вторую сторону
+	recipientID := updated.ModelID
+	if party == models.BookingPartyModel {
+		recipientID = updated.EmployerID
+	}
+	go func() {
+		if err := s.notificationRepo.CreateBookingNotification(db, recipientID, updated.ID, updated.Casting.Title, updated.Status, reason); err != nil {
+			log.Printf("Failed to create booking notification: %v", err)
+		}
+	}()
+
+	return toBookingResponse(updated, userID), nil
+}
+
+// GetContract - текущая версия договора и подписи к ней
+func (s *bookingService) GetContract(db *gorm.DB, bookingID, userID string) (*dto.BookingContractResponse, error) {
+	booking, err := s.findParticipantBooking(db, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]models.BookingSignature, 0, 2)
+	for _, party := range []string{models.BookingPartyEmployer, models.BookingPartyModel} {
+		if signature := booking.SignatureOf(party); signature != nil {
+			signatures = append(signatures, *signature)
+		}
+	}
+
+	return &dto.BookingContractResponse{
+		BookingID:  booking.ID,
+		Template:   booking.ContractTemplate,
+		Version:    booking.ContractVersion,
+		Hash:       booking.ContractHash,
+		Body:       booking.ContractBody,
+		Signatures: signatures,
+	}, nil
+}
+
+// ExportContractPDF - договор с блоком подписей в PDF
+func (s *bookingService) ExportContractPDF(db *gorm.DB, bookingID, userID string) (*dto.ExportFile, error) {
+	booking, err := s.findParticipantBooking(db, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &document.Contract{
+		Title:    "Договор об оказании услуг модели",
+		Subtitle: fmt.Sprintf("Версия %d", booking.ContractVersion),
+		Body:     booking.ContractBody,
+		Footer:   "SHA-256: " + booking.ContractHash,
+	}
+	for _, party := range []struct{ role, label string }{
+		{models.BookingPartyEmployer, "Заказчик"},
+		{models.BookingPartyModel, "Модель"},
+	} {
+		line := document.ContractSignature{Party: party.label}
+		if signature := booking.SignatureOf(party.role); signature != nil {
+			line.Name = signature.TypedName
+			line.Details = []string{
+				"Подписано: " + formatContractTime(signature.SignedAt),
+				"IP: " + signature.IPAddress,
+			}
+		}
+		contract.Signatures = append(contract.Signatures, line)
+	}
+
+	pages, err := document.RenderContract(contract)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	var buf bytes.Buffer
+	if err := document.EncodePDF(&buf, pages, 85); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	return &dto.ExportFile{
+		FileName:    fmt.Sprintf("contract_%s_v%d.pdf", booking.ID, booking.ContractVersion),
+		ContentType: "application/pdf",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+// SignContract - typed-name подпись текущей версии договора. Вторая подпись подтверждает бронирование.
+func (s *bookingService) SignContract(db *gorm.DB, bookingID, userID string, req *dto.SignBookingRequest, sigCtx dto.SignatureContext) (*dto.BookingResponse, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	booking, err := s.findParticipantBooking(tx, bookingID, userID)
+	if err != nil {
+		return nil, err
+	}
+	party := booking.PartyOf(userID)
+
+	if booking.Status != models.BookingStatusPending {
+		return nil, apperrors.ErrInvalidStatus("booking", fmt.Sprintf("Cannot sign contract of booking in status '%s'", booking.Status))
+	}
+	if !strings.EqualFold(req.ContractHash, booking.ContractHash) {
+		return nil, apperrors.ErrContractVersionMismatch
+	}
+
+	signer, err := s.userRepo.FindByID(tx, userID)
+	if err != nil {
+		return nil, handleBookingError(err)
+	}
+	if normalizeSignatureName(req.TypedName) != normalizeSignatureName(signer.Name) {
+		return nil, apperrors.ErrSignatureNameMismatch
+	}
+
+	signature := &models.BookingSignature{
+		BookingID:       booking.ID,
+		SignerID:        userID,
+		SignerRole:      party,
+		TypedName:       strings.TrimSpace(req.TypedName),
+		ContractVersion: booking.ContractVersion,
+		ContractHash:    booking.ContractHash,
+		SignedAt:        time.Now(),
+		IPAddress:       sigCtx.IPAddress,
+		UserAgent:       sigCtx.UserAgent,
+	}
+	if err := s.bookingRepo.CreateSignature(tx, signature); err != nil {
+		return nil, handleBookingError(err)
+	}
+
+	otherParty := models.BookingPartyModel
+	if party == models.BookingPartyModel {
+		otherParty = models.BookingPartyEmployer
+	}
+	confirmed := booking.SignatureOf(otherParty) != nil
+	if confirmed {
+		if err := s.bookingRepo.TransitionBookingStatus(tx, booking.ID, models.BookingStatusPending, models.BookingStatusConfirmed, nil); err != nil {
+			return nil, handleBookingError(err)
+		}
+	}
+
+	updated, err := s.bookingRepo.FindBookingByID(tx, booking.ID)
+	if err != nil {
+		return nil, handleBookingError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	// Подтверждение - обеим сторонам, иначе - второй стороне договор на подпись
+	go func() {
+		recipients := []string{updated.ModelID}
+		status := models.BookingStatusPending
+		if party == models.BookingPartyModel {
+			recipients = []string{updated.EmployerID}
+		}
+		if confirmed {
+			recipients = []string{updated.EmployerID, updated.ModelID}
+			status = models.BookingStatusConfirmed
+		}
+		for _, recipientID := range recipients {
+			if err := s.notificationRepo.CreateBookingNotification(db, recipientID, updated.ID, updated.Casting.Title, status, nil); err != nil {
+				log.Printf("Failed to create booking notification: %v", err)
+			}
+		}
+	}()
+
+	return toBookingResponse(updated, userID), nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// findParticipantBooking - бронирование доступно только его сторонам
+func (s *bookingService) findParticipantBooking(db *gorm.DB, bookingID, userID string) (*models.Booking, error) {
+	booking, err := s.bookingRepo.FindBookingByID(db, bookingID)
+	if err != nil {
+		return nil, handleBookingError(err)
+	}
+	if booking.PartyOf(userID) == "" {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+	return booking, nil
+}
+
+// renderContract - генерирует текст договора из шаблона по текущим условиям бронирования
+func (s *bookingService) renderContract(db *gorm.DB, booking *models.Booking) error {
+	if !isKnownContractTemplate(booking.ContractTemplate) {
+		return apperrors.ErrInvalidOperation("booking", fmt.Sprintf("Unknown contract template '%s'", booking.ContractTemplate))
+	}
+
+	employerName := ""
+	if employer, err := s.userRepo.FindByID(db, booking.EmployerID); err == nil {
+		employerName = employer.Name
+	}
+	if profile, err := s.profileRepo.FindEmployerProfileByUserID(db, booking.EmployerID); err == nil && profile.CompanyName != "" {
+		employerName = profile.CompanyName
+	}
+	model, err := s.userRepo.FindByID(db, booking.ModelID)
+	if err != nil {
+		return handleBookingError(err)
+	}
+
+	number := booking.ResponseID
+	if len(number) > 8 {
+		number = number[:8]
+	}
+	// Дата договора - дата создания бронирования, чтобы перегенерация без изменений давала тот же хэш
+	date := booking.CreatedAt
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	body, hash, err := renderBookingContract(booking.ContractTemplate, &bookingContractData{
+		Number:       strings.ToUpper(number),
+		Date:         date.Format("02.01.2006"),
+		CastingTitle: booking.Casting.Title,
+		EmployerName: employerName,
+		ModelName:    model.Name,
+		Fee:          fmt.Sprintf("%.2f", booking.Fee),
+		Currency:     booking.Currency,
+		StartsAt:     formatContractTime(booking.StartsAt),
+		EndsAt:       formatContractTime(booking.EndsAt),
+		Location:     booking.Location,
+		UsageRights:  booking.UsageRights,
+		Terms:        booking.Terms,
+	})
+	if err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	booking.ContractBody = body
+	booking.ContractHash = hash
+	return nil
+}
+
+// isCastingOwner - casting.EmployerID хранит employer_profiles.id (см. ResponseServiceImpl.isCastingOwner)
+func (s *bookingService) isCastingOwner(db *gorm.DB, casting *models.Casting, userID string) bool {
+	if casting.EmployerID == userID {
+		return true
+	}
+	employerUser, err := s.userRepo.FindByProfileID(db, casting.EmployerID)
+	return err == nil && employerUser.ID == userID
+}
+
+func toBookingResponse(booking *models.Booking, userID string) *dto.BookingResponse {
+	party := booking.PartyOf(userID)
+	signedByMe := party != "" && booking.SignatureOf(party) != nil
+	return &dto.BookingResponse{
+		Booking:      booking,
+		CastingTitle: booking.Casting.Title,
+		MyRole:       party,
+		SignedByMe:   signedByMe,
+		AwaitingSign: booking.Status == models.BookingStatusPending && !signedByMe,
+	}
+}
+
+// normalizeSignatureName - сравнение имен без учета регистра и лишних пробелов
+func normalizeSignatureName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+func isBookableResponseStatus(status models.ResponseStatus) bool {
+	for _, allowed := range bookableResponseStatuses {
+		if status == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func containsBookingStatus(statuses []models.BookingStatus, status models.BookingStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func handleBookingError(err error) error {
+	if errors.Is(err, repositories.ErrBookingNotFound) ||
+		errors.Is(err, repositories.ErrResponseNotFound) ||
+		errors.Is(err, repositories.ErrCastingNotFound) ||
+		errors.Is(err, repositories.ErrUserNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	if errors.Is(err, repositories.ErrBookingAlreadyExists) {
+		return apperrors.ErrBookingAlreadyExists
+	}
+	if errors.Is(err, repositories.ErrBookingAlreadySigned) {
+		return apperrors.ErrConflict(err, "booking", "You have already signed this version of the contract")
+	}
+	if errors.Is(err, repositories.ErrBookingStatusChanged) {
+		return apperrors.ErrBookingStatusChanged
+	}
+	return apperrors.InternalError(err)
+}
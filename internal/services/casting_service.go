@@ -8,6 +8,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
 	"mwork_backend/internal/services/dto"
@@ -34,7 +35,8 @@ type CastingService interface {
 	GetCastingsByCity(db *gorm.DB, city string, limit int) ([]*dto.CastingResponse, error)
 	GetCastingStats(db *gorm.DB, employerID string, requesterID string) (*repositories.CastingStats, error)
 	FindMatchingCastings(db *gorm.DB, modelID string, limit int) ([]*dto.CastingResponse, error)
-	UpdateCastingStatus(db *gorm.DB, castingID string, requesterID string, status models.CastingStatus) error
+	UpdateCastingStatus(db *gorm.DB, castingID string, requesterID string, req *dto.UpdateCastingStatusRequest) (*dto.CastingStatusChangeResponse, error)
+	GetCastingStatusHistory(db *gorm.DB, castingID string, requesterID string) ([]models.CastingStatusTransition, error)
 	GetCastingStatsForCasting(db *gorm.DB, castingID string, requesterID string) (*dto.CastingStatsResponse, error)
 	CloseExpiredCastings(db *gorm.DB) error
 	// ▼▼▼ ДОБАВЛЕНЫ НЕДОСТАЮЩИЕ МЕТОДЫ (ADMIN) ▼▼▼
@@ -58,6 +60,9 @@ type CastingServiceImpl struct {
 	notificationRepo repositories.NotificationRepository
 	reviewRepo       repositories.ReviewRepository
 	responseRepo     repositories.ResponseRepository
+	promotionRepo    repositories.PromotionRepository
+	stateMachine     *castingStateMachine
+	bulkUpdater      *responseBulkUpdater
 }
 
 // ✅ Конструктор обновлен (db убран)
@@ -70,6 +75,8 @@ func NewCastingService(
 	notificationRepo repositories.NotificationRepository,
 	reviewRepo repositories.ReviewRepository,
 	responseRepo repositories.ResponseRepository,
+	promotionRepo repositories.PromotionRepository,
+	chatRepo repositories.ChatRepository,
 ) CastingService {
 	return &CastingServiceImpl{
 		// ❌ 'db: db,' УДАЛЕНО
@@ -80,6 +87,9 @@ func NewCastingService(
 		notificationRepo: notificationRepo,
 		reviewRepo:       reviewRepo,
 		responseRepo:     responseRepo,
+		promotionRepo:    promotionRepo,
+		stateMachine:     newCastingStateMachine(castingRepo, responseRepo, promotionRepo, notificationRepo),
+		bulkUpdater:      newResponseBulkUpdater(responseRepo, reviewRepo, chatRepo, notificationRepo),
 	}
 }
 
@@ -162,16 +172,25 @@ func (s *CastingServiceImpl) CreateCasting(db *gorm.DB, req *dto.CreateCastingRe
 		Gender:          req.Gender,
 		AgeMin:          req.AgeMin,
 		AgeMax:          req.AgeMax,
-		HeightMin:       req.HeightMin,
-		HeightMax:       req.HeightMax,
-		WeightMin:       req.WeightMin,
-		WeightMax:       req.WeightMax,
-		ClothingSize:    &req.ClothingSize,
-		ShoeSize:        &req.ShoeSize,
 		ExperienceLevel: &req.ExperienceLevel,
 		Languages:       datatypes.JSON(languagesJSON),
 		JobType:         req.JobType,
 		Status:          models.CastingStatusDraft,
+		ContentLocale:   models.DefaultLocale,
+	}
+	if req.ContentLocale != "" {
+		casting.ContentLocale = req.ContentLocale
+	}
+	casting.SetTitleI18n(mergeLocalized(nil, req.TitleI18n, casting.ContentLocale))
+	casting.SetDescriptionI18n(mergeLocalized(nil, req.DescriptionI18n, casting.ContentLocale))
+	// Рост, вес, размеры и параметры фигуры (с переводом единиц)
+	requirements := castingRequirementsInput{
+		HeightMin: req.HeightMin, HeightMax: req.HeightMax,
+		WeightMin: req.WeightMin, WeightMax: req.WeightMax,
+		ClothingSize: &req.ClothingSize, ShoeSize: &req.ShoeSize,
+	}
+	if err := applyCastingRequirements(casting, requirements, &req.CastingMeasurementRequirements); err != nil {
+		return err
 	}
 
 	// ✅ Передаем tx
@@ -204,14 +223,22 @@ func (s *CastingServiceImpl) GetCasting(db *gorm.DB, castingID string, requester
 			return nil, apperrors.InternalError(err) // Не смогли найти юзера по профилю, это странно
 		}
 
+		s.markCastingPromoted(db, casting)
 		if requesterID != employerUser.ID {
 			go s.castingRepo.IncrementCastingViews(db, castingID)
+			if casting.IsPromoted {
+				go s.promotionRepo.IncrementClicks(db, castingID)
+			}
 		}
 		// Передаем true, если ID реквестера совпадает с ID юзера-работодателя
 		return s.buildCastingResponse(db, casting, requesterID == employerUser.ID)
 	}
 	// Если юзер неавторизован, просмотр засчитывается
+	s.markCastingPromoted(db, casting)
 	go s.castingRepo.IncrementCastingViews(db, castingID)
+	if casting.IsPromoted {
+		go s.promotionRepo.IncrementClicks(db, castingID)
+	}
 	return s.buildCastingResponse(db, casting, false)
 }
 
@@ -240,6 +267,18 @@ func (s *CastingServiceImpl) UpdateCasting(db *gorm.DB, castingID string, reques
 	if req.Title != nil {
 		casting.Title = *req.Title
 	}
+	if req.Description != nil {
+		casting.Description = *req.Description
+	}
+	if req.ContentLocale != nil {
+		casting.ContentLocale = *req.ContentLocale
+	}
+	if req.TitleI18n != nil || req.ContentLocale != nil {
+		casting.SetTitleI18n(mergeLocalized(casting.GetTitleI18n(), req.TitleI18n, casting.ContentLocale))
+	}
+	if req.DescriptionI18n != nil || req.ContentLocale != nil {
+		casting.SetDescriptionI18n(mergeLocalized(casting.GetDescriptionI18n(), req.DescriptionI18n, casting.ContentLocale))
+	}
 	// ... (другие поля)
 	if req.Categories != nil {
 		categoriesJSON, err := json.Marshal(req.Categories)
@@ -255,6 +294,18 @@ func (s *CastingServiceImpl) UpdateCasting(db *gorm.DB, castingID string, reques
 		}
 		casting.Languages = datatypes.JSON(languagesJSON)
 	}
+	if req.Gender != nil {
+		casting.Gender = *req.Gender
+	}
+	// Рост, вес, размеры и параметры фигуры (с переводом единиц)
+	requirements := castingRequirementsInput{
+		HeightMin: req.HeightMin, HeightMax: req.HeightMax,
+		WeightMin: req.WeightMin, WeightMax: req.WeightMax,
+		ClothingSize: req.ClothingSize, ShoeSize: req.ShoeSize,
+	}
+	if err := applyCastingRequirements(casting, requirements, &req.CastingMeasurementRequirements); err != nil {
+		return err
+	}
 	// ... (другие поля)
 
 	// ✅ Передаем tx
@@ -264,68 +315,16 @@ func (s *CastingServiceImpl) UpdateCasting(db *gorm.DB, castingID string, reques
 	return tx.Commit().Error
 }
 
-// PublishCasting - 'db' добавлен
+// PublishCasting - draft -> active через state machine
 func (s *CastingServiceImpl) PublishCasting(db *gorm.DB, castingID string, requesterID string) error {
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperrors.InternalError(tx.Error)
-	}
-	defer tx.Rollback()
-	casting, err := s.castingRepo.FindCastingByID(tx, castingID)
-	if err != nil {
-		return handleCastingError(err)
-	}
-
-	// ✅ ИСПРАВЛЕНА ПРОВЕРКА ПРАВ
-	employerUser, err := s.userRepo.FindByProfileID(tx, casting.EmployerID)
-	if err != nil {
-		return handleCastingError(err)
-	}
-	if employerUser.ID != requesterID {
-		return apperrors.ErrInsufficientPermissions
-	}
-	// КОНЕЦ ПРОВЕРКИ ПРАВ
-
-	if casting.Status != models.CastingStatusDraft {
-		return apperrors.ErrInvalidCastingStatus
-	}
-	casting.Status = models.CastingStatusActive
-	if err := s.castingRepo.UpdateCasting(tx, casting); err != nil {
-		return apperrors.InternalError(err)
-	}
-	return tx.Commit().Error
+	_, err := s.changeCastingStatus(db, castingID, requesterID, &dto.UpdateCastingStatusRequest{Status: models.CastingStatusActive})
+	return err
 }
 
-// CloseCasting - 'db' добавлен
+// CloseCasting - active -> closed через state machine
 func (s *CastingServiceImpl) CloseCasting(db *gorm.DB, castingID string, requesterID string) error {
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperrors.InternalError(tx.Error)
-	}
-	defer tx.Rollback()
-	casting, err := s.castingRepo.FindCastingByID(tx, castingID)
-	if err != nil {
-		return handleCastingError(err)
-	}
-
-	// ✅ ИСПРАВЛЕНА ПРОВЕРКА ПРАВ
-	employerUser, err := s.userRepo.FindByProfileID(tx, casting.EmployerID)
-	if err != nil {
-		return handleCastingError(err)
-	}
-	if employerUser.ID != requesterID {
-		return apperrors.ErrInsufficientPermissions
-	}
-	// КОНЕЦ ПРОВЕРКИ ПРАВ
-
-	if casting.Status != models.CastingStatusActive {
-		return apperrors.ErrInvalidCastingStatus
-	}
-	casting.Status = models.CastingStatusClosed
-	if err := s.castingRepo.UpdateCasting(tx, casting); err != nil {
-		return apperrors.InternalError(err)
-	}
-	return tx.Commit().Error
+	_, err := s.changeCastingStatus(db, castingID, requesterID, &dto.UpdateCastingStatusRequest{Status: models.CastingStatusClosed})
+	return err
 }
 
 // DeleteCasting - 'db' добавлен
@@ -364,21 +363,24 @@ func (s *CastingServiceImpl) DeleteCasting(db *gorm.DB, castingID string, reques
 // SearchCastings - 'db' добавлен
 func (s *CastingServiceImpl) SearchCastings(db *gorm.DB, criteria dto.SearchCastingsRequest) ([]*dto.CastingResponse, int64, error) {
 	searchCriteria := repositories.CastingSearchCriteria{
-		Query:      criteria.Query,
-		City:       criteria.City,
-		Categories: criteria.Categories,
-		Gender:     criteria.Gender,
-		MinAge:     criteria.MinAge,
-		MaxAge:     criteria.MaxAge,
-		MinSalary:  criteria.MinSalary,
-		MaxSalary:  criteria.MaxSalary,
-		JobType:    criteria.JobType,
-		Status:     criteria.Status,
-		EmployerID: criteria.EmployerID,
-		Page:       criteria.Page,
-		PageSize:   criteria.PageSize,
-		SortBy:     criteria.SortBy,
-		SortOrder:  criteria.SortOrder,
+		Query:            criteria.Query,
+		City:             criteria.City,
+		Categories:       criteria.Categories,
+		Gender:           criteria.Gender,
+		MinAge:           criteria.MinAge,
+		MaxAge:           criteria.MaxAge,
+		MinSalary:        criteria.MinSalary,
+		MaxSalary:        criteria.MaxSalary,
+		JobType:          criteria.JobType,
+		Status:           criteria.Status,
+		EmployerID:       criteria.EmployerID,
+		VerifiedEmployer: criteria.VerifiedEmployer,
+		Page:             criteria.Page,
+		PageSize:         criteria.PageSize,
+		SortBy:           criteria.SortBy,
+		SortOrder:        criteria.SortOrder,
+		QueryLocales:     algorithms.SearchLocales(criteria.Query, criteria.Locale),
+		HiddenForUserID:  criteria.RequesterID,
 	}
 
 	// ✅ Используем 'db' из параметра
@@ -436,6 +438,7 @@ func (s *CastingServiceImpl) GetActiveCastings(db *gorm.DB, limit int) ([]*dto.C
 	if err != nil {
 		return nil, apperrors.InternalError(err)
 	}
+	applyPromotionFlags(db, s.promotionRepo, castings)
 
 	var responses []*dto.CastingResponse
 	for _, casting := range castings {
@@ -532,6 +535,7 @@ func (s *CastingServiceImpl) FindMatchingCastings(db *gorm.DB, modelID string, l
 			}
 		}
 	}
+	applyPromotionFlags(db, s.promotionRepo, matchingCastings)
 	var responses []*dto.CastingResponse
 	for _, casting := range matchingCastings {
 		response, err := s.buildCastingResponse(db, &casting, false)
@@ -558,34 +562,49 @@ func (s *CastingServiceImpl) buildCastingResponse(db *gorm.DB, casting *models.C
 	}
 
 	response := &dto.CastingResponse{
-		ID:              casting.ID,
-		EmployerID:      casting.EmployerID,
-		Title:           casting.Title,
-		Description:     casting.Description,
-		PaymentMin:      casting.PaymentMin,
-		PaymentMax:      casting.PaymentMax,
-		CastingDate:     casting.CastingDate,
-		CastingTime:     casting.CastingTime,
-		Address:         casting.Address,
-		City:            casting.City,
-		Categories:      categories,
-		Gender:          casting.Gender,
-		AgeMin:          casting.AgeMin,
-		AgeMax:          casting.AgeMax,
-		HeightMin:       casting.HeightMin,
-		HeightMax:       casting.HeightMax,
-		WeightMin:       casting.WeightMin,
-		WeightMax:       casting.WeightMax,
-		ClothingSize:    casting.ClothingSize,
-		ShoeSize:        casting.ShoeSize,
-		ExperienceLevel: casting.ExperienceLevel,
-		Languages:       languages,
-		JobType:         casting.JobType,
-		Status:          casting.Status,
-		Views:           casting.Views,
-		Employer:        casting.Employer,
-		CreatedAt:       casting.CreatedAt,
-		UpdatedAt:       casting.UpdatedAt,
+		ID:               casting.ID,
+		EmployerID:       casting.EmployerID,
+		Title:            casting.Title,
+		Description:      casting.Description,
+		ContentLocale:    casting.ContentLocale,
+		TitleI18n:        casting.GetTitleI18n(),
+		DescriptionI18n:  casting.GetDescriptionI18n(),
+		PaymentMin:       casting.PaymentMin,
+		PaymentMax:       casting.PaymentMax,
+		CastingDate:      casting.CastingDate,
+		CastingTime:      casting.CastingTime,
+		Address:          casting.Address,
+		City:             casting.City,
+		Categories:       categories,
+		Gender:           casting.Gender,
+		AgeMin:           casting.AgeMin,
+		AgeMax:           casting.AgeMax,
+		HeightMin:        casting.HeightMin,
+		HeightMax:        casting.HeightMax,
+		WeightMin:        casting.WeightMin,
+		WeightMax:        casting.WeightMax,
+		ClothingSize:     casting.ClothingSize,
+		ShoeSize:         casting.ShoeSize,
+		BustMin:          casting.BustMin,
+		BustMax:          casting.BustMax,
+		WaistMin:         casting.WaistMin,
+		WaistMax:         casting.WaistMax,
+		HipsMin:          casting.HipsMin,
+		HipsMax:          casting.HipsMax,
+		HairColors:       casting.GetHairColors(),
+		EyeColors:        casting.GetEyeColors(),
+		TattoosAllowed:   casting.TattoosAllowed,
+		PiercingsAllowed: casting.PiercingsAllowed,
+		ExperienceLevel:  casting.ExperienceLevel,
+		Languages:        languages,
+		JobType:          casting.JobType,
+		Status:           casting.Status,
+		Views:            casting.Views,
+		IsPromoted:       casting.IsPromoted,
+		Employer:         casting.Employer,
+		EmployerVerified: casting.Employer.IsVerified,
+		CreatedAt:        casting.CreatedAt,
+		UpdatedAt:        casting.UpdatedAt,
 	}
 
 	if includeResponses {
@@ -601,6 +620,9 @@ func (s *CastingServiceImpl) buildCastingResponse(db *gorm.DB, casting *models.C
 					Message:   resp.Message,
 					Status:    resp.Status,
 					CreatedAt: resp.CreatedAt,
+
+					WithdrawnAt:      resp.WithdrawnAt,
+					WithdrawalReason: resp.WithdrawalReason,
 				}
 				responseSummaries = append(responseSummaries, summary)
 			}
@@ -611,10 +633,11 @@ func (s *CastingServiceImpl) buildCastingResponse(db *gorm.DB, casting *models.C
 		stats, err := s.responseRepo.GetResponseStats(db, casting.ID)
 		if err == nil {
 			response.Stats = &dto.CastingStatsResponse{
-				TotalResponses:    stats.TotalResponses,
-				PendingResponses:  stats.PendingResponses,
-				AcceptedResponses: stats.AcceptedResponses,
-				RejectedResponses: stats.RejectedResponses,
+				TotalResponses:     stats.TotalResponses,
+				PendingResponses:   stats.PendingResponses,
+				AcceptedResponses:  stats.AcceptedResponses,
+				RejectedResponses:  stats.RejectedResponses,
+				WithdrawnResponses: stats.WithdrawnResponses,
 			}
 		}
 	}
@@ -622,35 +645,103 @@ func (s *CastingServiceImpl) buildCastingResponse(db *gorm.DB, casting *models.C
 	return response, nil
 }
 
-// UpdateCastingStatus - 'db' добавлен
-func (s *CastingServiceImpl) UpdateCastingStatus(db *gorm.DB, castingID string, requesterID string, status models.CastingStatus) error {
+// markCastingPromoted - проставляет флаг продвижения для одного кастинга (без учета показа)
+func (s *CastingServiceImpl) markCastingPromoted(db *gorm.DB, casting *models.Casting) {
+	promoted, err := s.promotionRepo.FindPromotedCastingIDs(db, []string{casting.ID})
+	if err != nil {
+		return
+	}
+	casting.IsPromoted = promoted[casting.ID]
+}
+
+// UpdateCastingStatus - любая смена статуса идет через state machine.
+// При закрытии с reject_pending оставшиеся отклики отклоняются в той же транзакции.
+func (s *CastingServiceImpl) UpdateCastingStatus(db *gorm.DB, castingID string, requesterID string, req *dto.UpdateCastingStatusRequest) (*dto.CastingStatusChangeResponse, error) {
+	return s.changeCastingStatus(db, castingID, requesterID, req)
+}
+
+// GetCastingStatusHistory - журнал переходов статуса (владелец или админ)
+func (s *CastingServiceImpl) GetCastingStatusHistory(db *gorm.DB, castingID string, requesterID string) ([]models.CastingStatusTransition, error) {
+	casting, err := s.castingRepo.FindCastingByID(db, castingID)
+	if err != nil {
+		return nil, handleCastingError(err)
+	}
+	if _, err := s.resolveCastingActor(db, casting, requesterID); err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.castingRepo.FindStatusTransitions(db, castingID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return transitions, nil
+}
+
+// changeCastingStatus - общий путь для Publish/Close/UpdateCastingStatus
+func (s *CastingServiceImpl) changeCastingStatus(db *gorm.DB, castingID string, requesterID string, req *dto.UpdateCastingStatusRequest) (*dto.CastingStatusChangeResponse, error) {
 	tx := db.Begin()
 	if tx.Error != nil {
-		return apperrors.InternalError(tx.Error)
+		return nil, apperrors.InternalError(tx.Error)
 	}
 	defer tx.Rollback()
+
 	casting, err := s.castingRepo.FindCastingByID(tx, castingID)
 	if err != nil {
-		return handleCastingError(err)
+		return nil, handleCastingError(err)
+	}
+	actor, err := s.resolveCastingActor(tx, casting, requesterID)
+	if err != nil {
+		return nil, err
 	}
 
-	// ✅ ИСПРАВЛЕНА ПРОВЕРКА ПРАВ
-	employerUser, err := s.userRepo.FindByProfileID(tx, casting.EmployerID)
+	// Автоотклонение оставшихся откликов снимает guard "pending responses" при закрытии
+	var rejected *bulkOutcome
+	if req.RejectPending && req.Status == models.CastingStatusClosed && CanTransitionCasting(casting.Status, req.Status) {
+		rejected, err = s.bulkUpdater.RejectPending(tx, casting, requesterID, req.Message)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transition, err := s.stateMachine.Transition(tx, casting, req.Status, actor, req.Reason)
 	if err != nil {
-		return handleCastingError(err)
+		return nil, err
 	}
-	if employerUser.ID != requesterID {
-		return apperrors.ErrInsufficientPermissions
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
 	}
-	// КОНЕЦ ПРОВЕРКИ ПРАВ
 
-	if !isValidStatusTransition(casting.Status, status) {
-		return apperrors.ErrInvalidCastingStatus
+	s.stateMachine.afterCommit(db, casting, transition)
+	s.bulkUpdater.afterCommit(db, casting, rejected)
+
+	response := &dto.CastingStatusChangeResponse{
+		CastingID: casting.ID,
+		Status:    casting.Status,
 	}
-	if err := s.castingRepo.UpdateCastingStatus(tx, castingID, status); err != nil {
-		return apperrors.InternalError(err)
+	if rejected != nil {
+		response.Rejected = rejected.Result
 	}
-	return tx.Commit().Error
+	return response, nil
+}
+
+// resolveCastingActor - владелец кастинга действует как работодатель, админ - как админ
+func (s *CastingServiceImpl) resolveCastingActor(db *gorm.DB, casting *models.Casting, requesterID string) (CastingActor, error) {
+	employerUser, err := s.userRepo.FindByProfileID(db, casting.EmployerID)
+	if err != nil {
+		return CastingActor{}, handleCastingError(err)
+	}
+	if employerUser.ID == requesterID {
+		return CastingActor{UserID: requesterID, Type: models.CastingActorEmployer}, nil
+	}
+
+	requester, err := s.userRepo.FindByID(db, requesterID)
+	if err != nil {
+		return CastingActor{}, handleCastingError(err)
+	}
+	if requester.Role == models.UserRoleAdmin {
+		return CastingActor{UserID: requesterID, Type: models.CastingActorAdmin}, nil
+	}
+	return CastingActor{}, apperrors.ErrInsufficientPermissions
 }
 
 // GetCastingStatsForCasting - 'db' добавлен
@@ -675,61 +766,47 @@ func (s *CastingServiceImpl) GetCastingStatsForCasting(db *gorm.DB, castingID st
 		return nil, apperrors.InternalError(err)
 	}
 	return &dto.CastingStatsResponse{
-		TotalResponses:    stats.TotalResponses,
-		PendingResponses:  stats.PendingResponses,
-		AcceptedResponses: stats.AcceptedResponses,
-		RejectedResponses: stats.RejectedResponses,
+		TotalResponses:     stats.TotalResponses,
+		PendingResponses:   stats.PendingResponses,
+		AcceptedResponses:  stats.AcceptedResponses,
+		RejectedResponses:  stats.RejectedResponses,
+		WithdrawnResponses: stats.WithdrawnResponses,
 	}, nil
 }
 
-// CloseExpiredCastings - 'db' добавлен
+// CloseExpiredCastings - системное закрытие кастингов с прошедшей датой (админка и CastingWorker).
+// Каждый кастинг закрывается в своей транзакции, чтобы одна ошибка не блокировала остальные.
 func (s *CastingServiceImpl) CloseExpiredCastings(db *gorm.DB) error {
-	// ✅ Начинаем транзакцию из переданного 'db'
-	tx := db.Begin()
-	if tx.Error != nil {
-		return apperrors.InternalError(tx.Error)
-	}
-	defer tx.Rollback()
-
-	// ✅ Передаем tx
-	castings, err := s.castingRepo.FindExpiredCastings(tx)
+	castings, err := s.castingRepo.FindExpiredCastings(db)
 	if err != nil {
 		return apperrors.InternalError(err)
 	}
 
-	for _, casting := range castings {
-		// ✅ Передаем tx
-		if err := s.castingRepo.UpdateCastingStatus(tx, casting.ID, models.CastingStatusClosed); err != nil {
+	for i := range castings {
+		casting := &castings[i]
+		if err := s.closeExpiredCasting(db, casting); err != nil {
 			fmt.Printf("Failed to close casting %s: %v\n", casting.ID, err)
-			return apperrors.InternalError(err)
 		}
 	}
-	return tx.Commit().Error
+	return nil
 }
 
-// (isValidStatusTransition - чистая функция, без изменений)
-func isValidStatusTransition(currentStatus, newStatus models.CastingStatus) bool {
-	validTransitions := map[models.CastingStatus][]models.CastingStatus{
-		models.CastingStatusDraft: {
-			models.CastingStatusActive,
-		},
-		models.CastingStatusActive: {
-			models.CastingStatusClosed,
-		},
-		models.CastingStatusClosed: {
-			models.CastingStatusActive,
-		},
-	}
-	allowedStatuses, exists := validTransitions[currentStatus]
-	if !exists {
-		return false
+func (s *CastingServiceImpl) closeExpiredCasting(db *gorm.DB, casting *models.Casting) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
 	}
-	for _, allowed := range allowedStatuses {
-		if allowed == newStatus {
-			return true
-		}
+	defer tx.Rollback()
+
+	transition, err := s.stateMachine.Transition(tx, casting, models.CastingStatusClosed, SystemCastingActor(), "Дата кастинга прошла")
+	if err != nil {
+		return err
 	}
-	return false
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	s.stateMachine.afterCommit(db, casting, transition)
+	return nil
 }
 
 // (isModelMatchesCasting - чистая функция, без изменений)
@@ -761,6 +838,9 @@ func (s *CastingServiceImpl) isModelMatchesCasting(profile *models.ModelProfile,
 			return false
 		}
 	}
+	if algorithms.CastingMeasurementRequirements(casting).Excludes(profile) {
+		return false
+	}
 	if len(casting.Categories) > 0 && len(profile.Categories) > 0 {
 		var castingCategories []string
 		var profileCategories []string
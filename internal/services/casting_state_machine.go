@@ -0,0 +1,191 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНИЦИАТОР ПЕРЕХОДА
+// =======================
+
+// CastingActor - кто меняет статус кастинга (работодатель, админ или система)
+type CastingActor struct {
+	UserID string // users.id; пусто для системных переходов
+	Type   string // models.CastingActorEmployer / CastingActorAdmin / CastingActorSystem
+}
+
+// SystemCastingActor - инициатор для фоновых задач (автозакрытие и т.п.)
+func SystemCastingActor() CastingActor {
+	return CastingActor{Type: models.CastingActorSystem}
+}
+
+func (a CastingActor) isSystem() bool {
+	return a.Type == models.CastingActorSystem
+}
+
+// =======================
+// 2. ТАБЛИЦА ПЕРЕХОДОВ
+// =======================
+
+// castingTransitions - единственный источник правды о допустимых переходах.
+// 'cancelled' - терминальный статус.
+var castingTransitions = map[models.CastingStatus][]models.CastingStatus{
+	models.CastingStatusDraft: {
+		models.CastingStatusActive,
+		models.CastingStatusCancelled,
+	},
+	models.CastingStatusActive: {
+		models.CastingStatusClosed,
+		models.CastingStatusCancelled,
+	},
+	models.CastingStatusClosed: {
+		models.CastingStatusActive,
+		models.CastingStatusCancelled,
+	},
+}
+
+// CanTransitionCasting - разрешен ли переход from -> to
+func CanTransitionCasting(from, to models.CastingStatus) bool {
+	for _, allowed := range castingTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// =======================
+// 3. STATE MACHINE
+// =======================
+
+// castingStateMachine - все смены статуса кастинга (API, админка, воркер) идут через Transition
+type castingStateMachine struct {
+	castingRepo      repositories.CastingRepository
+	responseRepo     repositories.ResponseRepository
+	promotionRepo    repositories.PromotionRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+func newCastingStateMachine(
+	castingRepo repositories.CastingRepository,
+	responseRepo repositories.ResponseRepository,
+	promotionRepo repositories.PromotionRepository,
+	notificationRepo repositories.NotificationRepository,
+) *castingStateMachine {
+	return &castingStateMachine{
+		castingRepo:      castingRepo,
+		responseRepo:     responseRepo,
+		promotionRepo:    promotionRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// Transition - проверяет переход и guards, меняет статус и пишет запись в журнал.
+// Работает внутри транзакции вызывающего; уведомления отправляются отдельно через afterCommit.
+func (m *castingStateMachine) Transition(tx *gorm.DB, casting *models.Casting, to models.CastingStatus, actor CastingActor, reason string) (*models.CastingStatusTransition, error) {
+	from := casting.Status
+	if !CanTransitionCasting(from, to) {
+		return nil, apperrors.ErrInvalidCastingStatus
+	}
+	if err := m.checkGuards(tx, casting, to, actor, reason); err != nil {
+		return nil, err
+	}
+
+	if err := m.castingRepo.TransitionCastingStatus(tx, casting.ID, from, to); err != nil {
+		if errors.Is(err, repositories.ErrCastingStatusChanged) {
+			return nil, apperrors.ErrCastingStatusChanged
+		}
+		return nil, apperrors.InternalError(err)
+	}
+
+	transition := &models.CastingStatusTransition{
+		CastingID:  casting.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorType:  actor.Type,
+		Reason:     reason,
+	}
+	if actor.UserID != "" {
+		transition.ActorID = &actor.UserID
+	}
+	if err := m.castingRepo.CreateStatusTransition(tx, transition); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	// Побочные эффекты внутри транзакции
+	if to == models.CastingStatusCancelled {
+		if err := m.promotionRepo.CancelCastingPromotions(tx, casting.ID); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+	}
+
+	casting.Status = to
+	return transition, nil
+}
+
+// checkGuards - бизнес-условия конкретных переходов
+func (m *castingStateMachine) checkGuards(tx *gorm.DB, casting *models.Casting, to models.CastingStatus, actor CastingActor, reason string) error {
+	switch to {
+	case models.CastingStatusActive:
+		// Публикация / переоткрытие: дата кастинга не должна быть в прошлом
+		if casting.CastingDate != nil && casting.CastingDate.Before(time.Now()) {
+			return apperrors.ErrInvalidOperation("casting", "Cannot activate a casting whose date has already passed")
+		}
+	case models.CastingStatusClosed:
+		// Автозакрытие по дате не блокируется необработанными откликами
+		if actor.isSystem() {
+			return nil
+		}
+		stats, err := m.responseRepo.GetResponseStats(tx, casting.ID)
+		if err != nil {
+			return apperrors.InternalError(err)
+		}
+		if stats.PendingResponses > 0 {
+			return apperrors.ErrCastingHasPendingResponses
+		}
+	case models.CastingStatusCancelled:
+		if !actor.isSystem() && reason == "" {
+			return apperrors.ErrInvalidOperation("casting", "Reason is required to cancel a casting")
+		}
+	}
+	return nil
+}
+
+// afterCommit - побочные эффекты после успешного коммита (уведомления)
+func (m *castingStateMachine) afterCommit(db *gorm.DB, casting *models.Casting, transition *models.CastingStatusTransition) {
+	if transition == nil {
+		return
+	}
+	if transition.ToStatus == models.CastingStatusCancelled {
+		go m.notifyApplicantsCancelled(db, casting, transition.Reason)
+	}
+}
+
+// notifyApplicantsCancelled - уведомляет откликнувшихся моделей об отмене кастинга
+func (m *castingStateMachine) notifyApplicantsCancelled(db *gorm.DB, casting *models.Casting, reason string) {
+	responses, err := m.responseRepo.FindResponsesByCasting(db, casting.ID)
+	if err != nil {
+		fmt.Printf("Failed to load responses for cancelled casting %s: %v\n", casting.ID, err)
+		return
+	}
+
+	var modelIDs []string
+	for _, response := range responses {
+		switch response.Status {
+		case models.ResponseStatusPending, models.ResponseStatusAccepted, models.ResponseStatusApproved:
+			modelIDs = append(modelIDs, response.ModelID)
+		}
+	}
+
+	if err := m.notificationRepo.CreateCastingCancelledNotifications(db, modelIDs, casting.ID, casting.Title, reason); err != nil {
+		fmt.Printf("Failed to notify applicants of cancelled casting %s: %v\n", casting.ID, err)
+	}
+}
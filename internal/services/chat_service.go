@@ -90,6 +90,8 @@ type chatService struct {
 	responseRepo     repositories.ResponseRepository
 	profileRepo      repositories.ProfileRepository
 	notificationRepo repositories.NotificationRepository
+	agencyRepo       repositories.AgencyRepository
+	blockRepo        repositories.BlockRepository
 	uploadService    UploadService // <-- ВНЕДРЕН УНИВЕРСАЛЬНЫЙ СЕРВИС
 }
 
@@ -101,6 +103,8 @@ func NewChatService(
 	profileRepo repositories.ProfileRepository,
 	notificationRepo repositories.NotificationRepository,
 	responseRepo repositories.ResponseRepository,
+	agencyRepo repositories.AgencyRepository,
+	blockRepo repositories.BlockRepository,
 	uploadService UploadService, // <-- ПРИНИМАЕМ УНИВЕРСАЛЬНЫЙ СЕРВИС
 ) ChatService {
 	return &chatService{
@@ -110,6 +114,8 @@ func NewChatService(
 		profileRepo:      profileRepo,
 		notificationRepo: notificationRepo,
 		responseRepo:     responseRepo,
+		agencyRepo:       agencyRepo,
+		blockRepo:        blockRepo,
 		uploadService:    uploadService, // <-- СОХРАНЯЕМ УНИВЕРСАЛЬНЫЙ СЕРВИС
 	}
 }
@@ -131,6 +137,9 @@ func (s *chatService) CreateDialog(ctx context.Context, db *gorm.DB, userID stri
 		if _, err := s.userRepo.FindByID(tx, participantID); err != nil {
 			return nil, fmt.Errorf("user not found: %s", participantID)
 		}
+		if err := ensureNotBlocked(tx, s.blockRepo, userID, participantID); err != nil {
+			return nil, err
+		}
 	}
 	// (Остальная логика без изменений, кроме передачи 'tx')
 	participants := append([]string{userID}, req.UserIDs...)
@@ -179,6 +188,9 @@ func (s *chatService) CreateCastingDialog(ctx context.Context, db *gorm.DB, cast
 	if err != nil {
 		return nil, apperrors.ErrDialogNotFound
 	}
+	if err := ensureNotBlocked(tx, s.blockRepo, employerID, modelID); err != nil {
+		return nil, err
+	}
 	dialog, err := s.chatRepo.CreateCastingDialog(tx, casting, employerID, modelID)
 	if err != nil {
 		return nil, apperrors.InternalError(err)
@@ -328,6 +340,9 @@ func (s *chatService) AddParticipants(ctx context.Context, db *gorm.DB, userID,
 		if _, err := s.userRepo.FindByID(tx, participantID); err != nil {
 			return fmt.Errorf("user not found: %s", participantID)
 		}
+		if err := ensureNotBlocked(tx, s.blockRepo, userID, participantID); err != nil {
+			return err
+		}
 	}
 	var participants []*chat.DialogParticipant
 	for _, participantID := range participantIDs {
@@ -466,6 +481,9 @@ func (s *chatService) SendMessage(ctx context.Context, db *gorm.DB, userID strin
 		if !hasAccess {
 			return nil, apperrors.ErrDialogAccessDenied // 403 для не-админов
 		}
+		if err := s.ensureDialogNotBlocked(tx, req.DialogID, userID); err != nil {
+			return nil, err
+		}
 	}
 	// ⭐️⭐️⭐️ Админ пропускает проверку hasAccess ⭐️⭐️⭐️
 
@@ -507,6 +525,9 @@ func (s *chatService) SendMessageWithAttachments(ctx context.Context, db *gorm.D
 	if !hasAccess {
 		return nil, apperrors.ErrDialogAccessDenied
 	}
+	if err := s.ensureDialogNotBlocked(tx, req.DialogID, userID); err != nil {
+		return nil, err
+	}
 	if !isValidMessageType(req.Type) {
 		return nil, apperrors.ErrInvalidMessageType
 	}
@@ -1006,6 +1027,24 @@ func (s *chatService) DeleteUserMessages(ctx context.Context, db *gorm.DB, admin
 
 // Helper methods
 
+// ensureDialogNotBlocked - в личном диалоге нельзя писать, если собеседник в черном списке (в любую сторону).
+// В групповых диалогах блокировка не мешает переписке остальных участников.
+func (s *chatService) ensureDialogNotBlocked(db *gorm.DB, dialogID, userID string) error {
+	dialog, err := s.chatRepo.GetDialogWithParticipants(db, dialogID)
+	if err != nil {
+		return handleChatError(err)
+	}
+	if dialog.IsGroup {
+		return nil
+	}
+	for _, participant := range dialog.Participants {
+		if err := ensureNotBlocked(db, s.blockRepo, userID, participant.UserID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *chatService) buildDialogResponse(ctx context.Context, db *gorm.DB, dialog *chat.Dialog, userID string) (*dto.DialogResponse, error) {
 	// (Логика без изменений, кроме 'ctx'/'db' в хелперах)
 	response := &dto.DialogResponse{
@@ -1150,7 +1189,19 @@ func (s *chatService) notifyNewMessage(ctx context.Context, db *gorm.DB, dialogI
 		log.Printf("notifyNewMessage: failed to find participants: %v", err)
 		return
 	}
+	senderIsAgent := false
+	for _, participant := range participants {
+		if participant.UserID == senderID && participant.Role == chat.ParticipantRoleAgent {
+			senderIsAgent = true
+		}
+	}
 	for _, participant := range participants {
+		// Агентство получает уведомление вместо представляемой модели (см. NotificationRepository),
+		// а о своих сообщениях от имени модели - не получает
+		if participant.Role == chat.ParticipantRoleAgent ||
+			(senderIsAgent && s.isAgencyOfModel(db, senderID, participant.UserID)) {
+			continue
+		}
 		if participant.UserID != senderID && !participant.IsMuted {
 			sender, err := s.userRepo.FindByID(db, senderID)
 			if err != nil {
@@ -1167,6 +1218,12 @@ func (s *chatService) notifyNewMessage(ctx context.Context, db *gorm.DB, dialogI
 	}
 }
 
+// isAgencyOfModel - пользователь агентства, которое сейчас представляет модель
+func (s *chatService) isAgencyOfModel(db *gorm.DB, userID, modelUserID string) bool {
+	representation, err := s.agencyRepo.FindActiveRepresentationByModelUser(db, modelUserID)
+	return err == nil && representation.Agency != nil && representation.Agency.UserID == userID
+}
+
 // (Вспомогательные хелперы без изменений)
 func isValidMessageType(messageType string) bool {
 	validTypes := map[string]bool{
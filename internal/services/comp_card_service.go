@@ -0,0 +1,439 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/document"
+	"mwork_backend/internal/imageprocessor"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/internal/storage"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type CompCardService interface {
+	GetMyCompCard(db *gorm.DB, userID string) (*dto.CompCardResponse, error)
+	UpdateMyCompCard(db *gorm.DB, userID string, req *dto.UpdateCompCardRequest) (*dto.CompCardResponse, error)
+	// RenderMyCompCard - композитка текущей модели (PDF для печати или PNG высокого разрешения)
+	RenderMyCompCard(db *gorm.DB, ctx context.Context, userID, format string) (*dto.ExportFile, error)
+	// RenderModelCompCard - композитка публичного профиля для работодателей
+	RenderModelCompCard(db *gorm.DB, ctx context.Context, modelProfileID, format string) (*dto.ExportFile, error)
+}
+
+// =======================
+// 2. КОНФИГУРАЦИЯ
+// =======================
+
+// CompCardConfig - параметры отрисовки композитки
+type CompCardConfig struct {
+	PNGScale   int    // множитель DPI для PNG (2 = 300 DPI, пригодно для печати)
+	PDFQuality int    // качество JPEG страниц PDF
+	Brand      string // подпись в нижней строке карточки
+	// Watermark - знак на фото композитки, скачанной не самой моделью (nil - без знака)
+	Watermark *imageprocessor.Watermark
+}
+
+func GetDefaultCompCardConfig() CompCardConfig {
+	return CompCardConfig{
+		PNGScale:   2,
+		PDFQuality: 90,
+		Brand:      "MWork",
+	}
+}
+
+// compCardLayoutVersion входит в ключ кэша: при изменении верстки старые файлы не используются
+const compCardLayoutVersion = 1
+
+// =======================
+// 3. РЕАЛИЗАЦИЯ
+// =======================
+type compCardService struct {
+	compCardRepo  repositories.CompCardRepository
+	profileRepo   repositories.ProfileRepository
+	portfolioRepo repositories.PortfolioRepository
+	storage       storage.Storage
+	config        CompCardConfig
+}
+
+func NewCompCardService(
+	compCardRepo repositories.CompCardRepository,
+	profileRepo repositories.ProfileRepository,
+	portfolioRepo repositories.PortfolioRepository,
+	storage storage.Storage,
+	config CompCardConfig,
+) CompCardService {
+	return &compCardService{
+		compCardRepo:  compCardRepo,
+		profileRepo:   profileRepo,
+		portfolioRepo: portfolioRepo,
+		storage:       storage,
+		config:        config,
+	}
+}
+
+func (s *compCardService) GetMyCompCard(db *gorm.DB, userID string) (*dto.CompCardResponse, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleCompCardError(err)
+	}
+	card, err := s.findSettings(db, profile.ID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.buildResponse(db, card)
+}
+
+func (s *compCardService) UpdateMyCompCard(db *gorm.DB, userID string, req *dto.UpdateCompCardRequest) (*dto.CompCardResponse, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleCompCardError(err)
+	}
+
+	// Выбирать можно только фото из своего портфолио вне закрытых альбомов, без повторов
+	items, err := s.portfolioRepo.FindPublicPortfolioByModel(db, profile.ID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	photos := make(map[string]bool, len(items))
+	for _, item := range items {
+		if isCompCardPhoto(item) {
+			photos[item.ID] = true
+		}
+	}
+	seen := make(map[string]bool, len(req.PortfolioItemIDs))
+	for _, id := range req.PortfolioItemIDs {
+		if !photos[id] {
+			return nil, apperrors.ErrInvalidOperation("comp_card", "Only photos from your own portfolio can be placed on the comp card")
+		}
+		if seen[id] {
+			return nil, apperrors.ErrInvalidOperation("comp_card", "Each photo can be placed on the comp card only once")
+		}
+		seen[id] = true
+	}
+
+	card := &models.CompCard{ModelProfileID: profile.ID, Template: req.Template}
+	card.SetPortfolioItemIDs(req.PortfolioItemIDs)
+	if err := s.compCardRepo.SaveSettings(db, card); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.buildResponse(db, card)
+}
+
+func (s *compCardService) RenderMyCompCard(db *gorm.DB, ctx context.Context, userID, format string) (*dto.ExportFile, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleCompCardError(err)
+	}
+	return s.render(db, ctx, profile, format, false)
+}
+
+func (s *compCardService) RenderModelCompCard(db *gorm.DB, ctx context.Context, modelProfileID, format string) (*dto.ExportFile, error) {
+	profile, err := s.profileRepo.FindModelProfileByID(db, modelProfileID)
+	if err != nil {
+		return nil, handleCompCardError(err)
+	}
+	if !profile.IsPublic {
+		return nil, apperrors.ErrProfileNotPublic
+	}
+	// Чужим пользователям фото портфолио отдаются только с водяным знаком
+	return s.render(db, ctx, profile, format, s.config.Watermark != nil)
+}
+
+// =======================
+// 4. ХЕЛПЕРЫ
+// =======================
+
+// findSettings - сохраненные настройки или настройки по умолчанию (без записи в БД)
+func (s *compCardService) findSettings(db *gorm.DB, profileID string) (*models.CompCard, error) {
+	card, err := s.compCardRepo.FindByModelProfileID(db, profileID)
+	if errors.Is(err, repositories.ErrCompCardNotFound) {
+		card = &models.CompCard{ModelProfileID: profileID, Template: document.CompCardTemplates[0]}
+		card.SetPortfolioItemIDs(nil)
+		return card, nil
+	}
+	return card, err
+}
+
+// selectPhotos - выбранные фото в порядке модели; удаленные из портфолио и перенесенные
+// в закрытые альбомы пропускаются.
+// Без выбора (или если все выбранные удалены) - первые фото портфолио.
+func (s *compCardService) selectPhotos(db *gorm.DB, card *models.CompCard) ([]models.PortfolioItem, error) {
+	items, err := s.portfolioRepo.FindPublicPortfolioByModel(db, card.ModelProfileID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.PortfolioItem, len(items))
+	for _, item := range items {
+		if isCompCardPhoto(item) {
+			byID[item.ID] = item
+		}
+	}
+
+	var selected []models.PortfolioItem
+	for _, id := range card.GetPortfolioItemIDs() {
+		if item, ok := byID[id]; ok {
+			selected = append(selected, item)
+		}
+	}
+	if len(selected) == 0 {
+		for _, item := range items {
+			if isCompCardPhoto(item) {
+				selected = append(selected, item)
+			}
+		}
+	}
+	if len(selected) > document.CompCardMaxPhotos {
+		selected = selected[:document.CompCardMaxPhotos]
+	}
+	return selected, nil
+}
+
+func (s *compCardService) buildResponse(db *gorm.DB, card *models.CompCard) (*dto.CompCardResponse, error) {
+	photos, err := s.selectPhotos(db, card)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	response := &dto.CompCardResponse{
+		Template:         card.Template,
+		Templates:        document.CompCardTemplates,
+		PortfolioItemIDs: card.GetPortfolioItemIDs(),
+		Photos:           make([]dto.CompCardPhoto, 0, len(photos)),
+		MaxPhotos:        document.CompCardMaxPhotos,
+	}
+	for _, item := range photos {
+		response.Photos = append(response.Photos, dto.CompCardPhoto{
+			PortfolioItemID: item.ID,
+			UploadID:        item.Upload.ID,
+			Title:           item.Title,
+		})
+	}
+	return response, nil
+}
+
+// render отдает карточку из кэша хранилища или рисует и кэширует ее.
+// Ключ кэша - отпечаток всех данных карточки (шаблон, профиль, фото), поэтому любое
+// изменение профиля или портфолио дает новый ключ; файлы прежнего ключа удаляются.
+// Вариант с водяным знаком кэшируется рядом с чистым под тем же ключом.
+func (s *compCardService) render(db *gorm.DB, ctx context.Context, profile *models.ModelProfile, format string, watermarked bool) (*dto.ExportFile, error) {
+	if format == "" {
+		format = dto.ExportFormatPDF
+	}
+	card, err := s.findSettings(db, profile.ID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	photos, err := s.selectPhotos(db, card)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	data := s.buildCardData(profile, card.Template)
+	cacheKey := compCardCacheKey(data, photos)
+	file := &dto.ExportFile{
+		FileName:    fmt.Sprintf("comp-card-%s.%s", shortID(profile.ID), format),
+		ContentType: compCardContentType(format),
+	}
+
+	path := compCardPath(profile.ID, cacheKey, format, watermarked)
+	if cached, ok := s.readCached(ctx, path); ok {
+		file.Data = cached
+		return file, nil
+	}
+
+	for _, item := range photos {
+		photo := s.loadPhoto(ctx, item.Upload.Path)
+		if photo != nil && watermarked {
+			photo = s.config.Watermark.Apply(photo)
+		}
+		data.Photos = append(data.Photos, photo)
+	}
+	file.Data, err = s.renderFile(data, format)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	if err := s.storage.Save(ctx, path, bytes.NewReader(file.Data), file.ContentType); err != nil {
+		log.Printf("Failed to cache comp card %s: %v", path, err)
+		return file, nil
+	}
+	s.rotateCache(db, ctx, card, cacheKey)
+	return file, nil
+}
+
+// readCached - готовый файл из хранилища; любая ошибка означает промах кэша
+func (s *compCardService) readCached(ctx context.Context, path string) ([]byte, bool) {
+	if exists, err := s.storage.Exists(ctx, path); err != nil || !exists {
+		return nil, false
+	}
+	reader, err := s.storage.Get(ctx, path)
+	if err != nil {
+		return nil, false
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// rotateCache запоминает новый ключ и удаляет файлы прежнего (ошибки не прерывают отдачу файла)
+func (s *compCardService) rotateCache(db *gorm.DB, ctx context.Context, card *models.CompCard, cacheKey string) {
+	if card.CacheKey == cacheKey {
+		return
+	}
+	if card.ID == "" {
+		// Настройки по умолчанию еще не сохранены - создаем запись, чтобы помнить ключ
+		if err := s.compCardRepo.SaveSettings(db, card); err != nil {
+			log.Printf("Failed to save comp card settings for %s: %v", card.ModelProfileID, err)
+			return
+		}
+	}
+	if err := s.compCardRepo.UpdateCacheKey(db, card.ID, cacheKey); err != nil {
+		log.Printf("Failed to update comp card cache key for %s: %v", card.ModelProfileID, err)
+		return
+	}
+	if card.CacheKey == "" {
+		return
+	}
+	for _, format := range []string{dto.ExportFormatPDF, dto.ExportFormatPNG} {
+		for _, watermarked := range []bool{false, true} {
+			stale := compCardPath(card.ModelProfileID, card.CacheKey, format, watermarked)
+			if exists, err := s.storage.Exists(ctx, stale); err != nil || !exists {
+				continue
+			}
+			if err := s.storage.Delete(ctx, stale); err != nil {
+				log.Printf("Failed to delete stale comp card %s: %v", stale, err)
+			}
+		}
+	}
+}
+
+func (s *compCardService) buildCardData(profile *models.ModelProfile, template string) *document.CompCard {
+	data := &document.CompCard{
+		Template: template,
+		Name:     profile.Name,
+		Footer:   joinNonEmpty(" · ", s.config.Brand, profile.City),
+	}
+	addStat := func(label, value string) {
+		if value != "" {
+			data.Stats = append(data.Stats, document.CompCardStat{Label: label, Value: value})
+		}
+	}
+	if profile.Height > 0 {
+		addStat("Рост", formatMeasure(profile.Height)+" см")
+	}
+	if profile.Weight > 0 {
+		addStat("Вес", formatMeasure(profile.Weight)+" кг")
+	}
+	if profile.Bust > 0 && profile.Waist > 0 && profile.Hips > 0 {
+		addStat("Параметры", formatMeasure(profile.Bust)+"-"+formatMeasure(profile.Waist)+"-"+formatMeasure(profile.Hips))
+	}
+	addStat("Одежда", profile.ClothingSize)
+	addStat("Обувь", profile.ShoeSize)
+	addStat("Волосы", models.HairColorNames[profile.HairColor])
+	addStat("Глаза", models.EyeColorNames[profile.EyeColor])
+	if profile.Age > 0 {
+		addStat("Возраст", strconv.Itoa(profile.Age))
+	}
+	addStat("Город", profile.City)
+	return data
+}
+
+// loadPhoto - фото из хранилища; ошибка не прерывает отрисовку (рисуется заглушка)
+func (s *compCardService) loadPhoto(ctx context.Context, path string) image.Image {
+	reader, err := s.storage.Get(ctx, path)
+	if err != nil {
+		log.Printf("Failed to load comp card photo %s: %v", path, err)
+		return nil
+	}
+	defer reader.Close()
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		log.Printf("Failed to decode comp card photo %s: %v", path, err)
+		return nil
+	}
+	return img
+}
+
+func (s *compCardService) renderFile(data *document.CompCard, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	if format == dto.ExportFormatPNG {
+		pages, err := document.RenderCompCard(data, s.config.PNGScale)
+		if err != nil {
+			return nil, err
+		}
+		if err := png.Encode(&buf, document.JoinPages(pages, 40*s.config.PNGScale)); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	pages, err := document.RenderCompCard(data, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := document.EncodePDF(&buf, pages, s.config.PDFQuality); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// compCardCacheKey - sha256 по всему, что влияет на картинку
+func compCardCacheKey(data *document.CompCard, photos []models.PortfolioItem) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "v%d|%s|%s|%s", compCardLayoutVersion, data.Template, data.Name, data.Footer)
+	for _, stat := range data.Stats {
+		fmt.Fprintf(&b, "|%s=%s", stat.Label, stat.Value)
+	}
+	for _, item := range photos {
+		fmt.Fprintf(&b, "|%s:%s:%d", item.Upload.ID, item.Upload.Path, item.Upload.UpdatedAt.UnixNano())
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:16])
+}
+
+func compCardPath(profileID, cacheKey, format string, watermarked bool) string {
+	if watermarked {
+		return fmt.Sprintf("comp-cards/%s/%s-wm.%s", profileID, cacheKey, format)
+	}
+	return fmt.Sprintf("comp-cards/%s/%s.%s", profileID, cacheKey, format)
+}
+
+func compCardContentType(format string) string {
+	if format == dto.ExportFormatPNG {
+		return "image/png"
+	}
+	return "application/pdf"
+}
+
+// isCompCardPhoto - на композитку попадают только одобренные модерацией фото
+func isCompCardPhoto(item models.PortfolioItem) bool {
+	return item.Upload != nil && item.Upload.IsImage() && item.Upload.Path != "" && item.Upload.IsApproved()
+}
+
+func handleCompCardError(err error) error {
+	if errors.Is(err, repositories.ErrProfileNotFound) ||
+		errors.Is(err, repositories.ErrCompCardNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	return apperrors.InternalError(err)
+}
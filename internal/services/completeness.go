@@ -0,0 +1,49 @@
+package services
+
+import (
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/algorithms"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+)
+
+// modelCompletenessScore - заполненность профиля модели с учетом фото в портфолио
+func modelCompletenessScore(db *gorm.DB, portfolioRepo repositories.PortfolioRepository, profile *models.ModelProfile) (int, []algorithms.CompletenessItem) {
+	// Ошибка подсчета не должна ломать профиль - считаем без фото
+	photos, _ := portfolioRepo.CountPortfolioPhotos(db, profile.ID)
+	return algorithms.ProfileCompleteness(profile, photos)
+}
+
+// buildProfileCompleteness - ответ для профиля; чек-лист только для владельца
+func buildProfileCompleteness(score int, checklist []algorithms.CompletenessItem, owner bool) *dto.ProfileCompleteness {
+	completeness := &dto.ProfileCompleteness{
+		Score:      score,
+		IsComplete: score >= algorithms.CompleteProfileThreshold,
+	}
+	if !owner {
+		return completeness
+	}
+	completeness.Checklist = checklist
+	for _, item := range checklist {
+		if !item.Done {
+			completeness.Missing = append(completeness.Missing, item.Key)
+		}
+	}
+	return completeness
+}
+
+// refreshModelCompleteness пересчитывает и сохраняет заполненность профиля модели
+// (после изменения профиля или портфолио)
+func refreshModelCompleteness(db *gorm.DB, profileRepo repositories.ProfileRepository, portfolioRepo repositories.PortfolioRepository, modelID string) error {
+	profile, err := profileRepo.FindModelProfileByID(db, modelID)
+	if err != nil {
+		return err
+	}
+	score, _ := modelCompletenessScore(db, portfolioRepo, profile)
+	if score == profile.CompletenessScore {
+		return nil
+	}
+	return profileRepo.UpdateModelCompleteness(db, modelID, score)
+}
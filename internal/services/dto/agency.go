@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"mwork_backend/internal/models"
+)
+
+// --- Agency Requests ---
+
+// UpdateAgencyProfileRequest - изменение профиля агентства (nil - поле не меняется)
+type UpdateAgencyProfileRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	City        *string `json:"city,omitempty"`
+	Phone       *string `json:"phone,omitempty"`
+	Website     *string `json:"website,omitempty" validate:"omitempty,url"`
+	Description *string `json:"description,omitempty" validate:"omitempty,max=2000"`
+}
+
+// CreateManagedModelRequest - агентство заводит модель: аккаунт на email модели и профиль.
+// Модель получает доступ к аккаунту через сброс пароля и может уйти из агентства.
+type CreateManagedModelRequest struct {
+	Email     string `json:"email" validate:"required,email"`
+	CopyModel bool   `json:"copy_model"` // модель получает копии уведомлений
+
+	CreateModelProfileRequest
+}
+
+// InviteModelRequest - приглашение существующей модели (model_profiles.id)
+type InviteModelRequest struct {
+	ModelProfileID string `json:"model_profile_id" validate:"required,uuid"`
+	CopyModel      *bool  `json:"copy_model,omitempty"` // по умолчанию true
+}
+
+// AgencyModelsQuery - фильтр списка моделей агентства по статусу (по умолчанию pending и active)
+type AgencyModelsQuery struct {
+	Status string `form:"status" validate:"omitempty,oneof=pending active declined ended"`
+}
+
+// AgencyResponseRequest - отклик на кастинг от имени модели
+type AgencyResponseRequest struct {
+	CastingID string  `json:"casting_id" validate:"required,uuid"`
+	Message   *string `json:"message" validate:"omitempty,max=1000"`
+}
+
+// UpdateRepresentationRequest - настройки представительства со стороны модели
+type UpdateRepresentationRequest struct {
+	CopyModel bool `json:"copy_model"`
+}
+
+// --- Agency Responses ---
+
+// RepresentedBy - агентство модели ("represented by") на публичном профиле
+type RepresentedBy struct {
+	AgencyID   string     `json:"agency_id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	City       string     `json:"city,omitempty"`
+	Website    string     `json:"website,omitempty"`
+	IsVerified bool       `json:"is_verified"`
+	Since      *time.Time `json:"since,omitempty"`
+}
+
+// MyRepresentationResponse - текущее агентство модели и приглашения, ждущие ответа
+type MyRepresentationResponse struct {
+	Current     *models.ModelRepresentation  `json:"current"`
+	Invitations []models.ModelRepresentation `json:"invitations"`
+}
+
+// AgencyProfileStats - статистика публичного профиля агентства
+type AgencyProfileStats struct {
+	ModelsCount int `json:"models_count"`
+}
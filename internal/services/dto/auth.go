@@ -11,7 +11,7 @@ type RegisterRequest struct {
 	Role        models.UserRole `json:"role" validate:"required,is-user-role"` // Используем кастомное правило
 	City        string          `json:"city" validate:"required"`
 	Name        string          `json:"name,omitempty" validate:"required_if=Role model"`
-	CompanyName string          `json:"company_name,omitempty" validate:"required_if=Role employer"`
+	CompanyName string          `json:"company_name,omitempty" validate:"required_if=Role employer,required_if=Role agency"` // Для агентства - название агентства
 }
 
 type LoginRequest struct {
@@ -67,5 +67,5 @@ type AdminCreateUserRequest struct {
 	// Поля для профиля (необязательные, но желательные)
 	Name        string `json:"name"`        // Для модели
 	City        string `json:"city"`        // Для обоих
-	CompanyName string `json:"companyName"` // Для работодателя
+	CompanyName string `json:"companyName"` // Для работодателя и агентства
 }
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"mwork_backend/internal/models"
+)
+
+// --- Availability Requests ---
+
+// CreateAvailabilityRangeRequest - разовый интервал (поездка, отпуск или, наоборот, свободные дни)
+type CreateAvailabilityRangeRequest struct {
+	Status   string    `json:"status" validate:"required,oneof=available unavailable"`
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+	Note     string    `json:"note" validate:"omitempty,max=255"`
+}
+
+// WeeklyAvailabilityRule - правило еженедельного шаблона. Время "HH:MM" по Asia/Almaty;
+// пустые start/end означают весь день, end "24:00" - до конца дня.
+type WeeklyAvailabilityRule struct {
+	Status  string `json:"status" validate:"required,oneof=available unavailable"`
+	Weekday int    `json:"weekday" validate:"min=0,max=6"` // 0 = воскресенье
+	Start   string `json:"start" validate:"omitempty,len=5"`
+	End     string `json:"end" validate:"omitempty,len=5"`
+}
+
+// ReplaceWeeklyAvailabilityRequest - еженедельный шаблон целиком (пустой список очищает шаблон)
+type ReplaceWeeklyAvailabilityRequest struct {
+	Rules []WeeklyAvailabilityRule `json:"rules" validate:"max=50,dive"`
+}
+
+// AvailabilityPeriodQuery - период календаря (по умолчанию - ближайшие 60 дней)
+type AvailabilityPeriodQuery struct {
+	From *time.Time `form:"from" time_format:"2006-01-02"`
+	To   *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// --- Availability Responses ---
+
+// Итог проверки доступности на момент/интервал
+const (
+	AvailabilityStatusAvailable          = "available"           // явно отмечена доступной
+	AvailabilityStatusUnavailable        = "unavailable"         // занята или явно недоступна - исключается из подбора
+	AvailabilityStatusUsuallyUnavailable = "usually_unavailable" // не подходит по еженедельному шаблону - штраф в подборе
+	AvailabilityStatusUnknown            = "unknown"             // календарь не заполнен
+)
+
+// AvailabilityCheck - доступность модели в заданный интервал и причина
+type AvailabilityCheck struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// AvailabilityCalendar - календарь модели за период
+type AvailabilityCalendar struct {
+	ModelProfileID string                           `json:"model_profile_id"`
+	From           time.Time                        `json:"from"`
+	To             time.Time                        `json:"to"`
+	Ranges         []models.ModelAvailabilityRange  `json:"ranges"`
+	Weekly         []models.ModelAvailabilityWeekly `json:"weekly"`
+	Busy           []models.ModelBusySlot           `json:"busy"` // автоматически заблокированные даты
+}
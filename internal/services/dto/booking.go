@@ -0,0 +1,90 @@
+package dto
+
+import (
+	"time"
+
+	"mwork_backend/internal/models"
+)
+
+// --- Booking Requests ---
+
+// CreateBookingRequest - условия бронирования по принятому отклику (создает работодатель)
+type CreateBookingRequest struct {
+	ResponseID       string    `json:"response_id" validate:"required,uuid"`
+	Fee              float64   `json:"fee" validate:"min=0"`
+	Currency         string    `json:"currency" validate:"omitempty,len=3"`
+	StartsAt         time.Time `json:"starts_at" validate:"required"`
+	EndsAt           time.Time `json:"ends_at" validate:"required,gtefield=StartsAt"`
+	Location         string    `json:"location" validate:"required,max=500"`
+	UsageRights      string    `json:"usage_rights" validate:"omitempty,max=2000"`
+	Terms            string    `json:"terms" validate:"omitempty,max=5000"`
+	ContractTemplate string    `json:"contract_template" validate:"omitempty,oneof=standard commercial"`
+}
+
+// UpdateBookingTermsRequest - изменение условий до подписания; создает новую версию договора
+type UpdateBookingTermsRequest struct {
+	Fee              *float64   `json:"fee,omitempty" validate:"omitempty,min=0"`
+	Currency         *string    `json:"currency,omitempty" validate:"omitempty,len=3"`
+	StartsAt         *time.Time `json:"starts_at,omitempty"`
+	EndsAt           *time.Time `json:"ends_at,omitempty"`
+	Location         *string    `json:"location,omitempty" validate:"omitempty,max=500"`
+	UsageRights      *string    `json:"usage_rights,omitempty" validate:"omitempty,max=2000"`
+	Terms            *string    `json:"terms,omitempty" validate:"omitempty,max=5000"`
+	ContractTemplate *string    `json:"contract_template,omitempty" validate:"omitempty,oneof=standard commercial"`
+}
+
+// SignBookingRequest - typed-name подпись. ContractHash подтверждает, какую версию текста видел подписант.
+type SignBookingRequest struct {
+	TypedName    string `json:"typed_name" validate:"required,min=2,max=255"`
+	ContractHash string `json:"contract_hash" validate:"required,len=64"`
+	Agree        bool   `json:"agree" validate:"required"`
+}
+
+// SignatureContext - IP и User-Agent подписанта (заполняет хэндлер)
+type SignatureContext struct {
+	IPAddress string
+	UserAgent string
+}
+
+// UpdateBookingStatusRequest - завершение, отмена или неявка
+type UpdateBookingStatusRequest struct {
+	Status models.BookingStatus `json:"status" validate:"required,oneof=completed cancelled no_show"`
+	Reason *string              `json:"reason,omitempty" validate:"omitempty,max=1000"`
+}
+
+// BookingListFilter - фильтры списка бронирований
+type BookingListFilter struct {
+	Status   string     `form:"status" validate:"omitempty,oneof=pending confirmed completed cancelled no_show"`
+	From     *time.Time `form:"from" time_format:"2006-01-02"`
+	To       *time.Time `form:"to" time_format:"2006-01-02"`
+	Page     int        `form:"page" validate:"omitempty,min=1"`
+	PageSize int        `form:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// --- Booking Responses ---
+
+// BookingResponse - бронирование с точки зрения текущего пользователя
+type BookingResponse struct {
+	*models.Booking
+	CastingTitle string `json:"casting_title"`
+	MyRole       string `json:"my_role"`            // employer | model
+	SignedByMe   bool   `json:"signed_by_me"`       // для текущей версии договора
+	AwaitingSign bool   `json:"awaiting_signature"` // договор ждет подписи текущего пользователя
+}
+
+type BookingListResponse struct {
+	Bookings []BookingResponse `json:"bookings"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+}
+
+// BookingContractResponse - текст договора для ознакомления перед подписью
+type BookingContractResponse struct {
+	BookingID  string                    `json:"booking_id"`
+	Template   string                    `json:"template"`
+	Version    int                       `json:"version"`
+	Hash       string                    `json:"hash"`
+	Body       string                    `json:"body"`
+	Signatures []models.BookingSignature `json:"signatures"`
+}
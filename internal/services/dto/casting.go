@@ -30,6 +30,13 @@ type CreateCastingRequest struct {
 	ExperienceLevel string    `json:"experience_level"`
 	Languages       []string  `json:"languages"`
 	JobType         string    `json:"job_type" validate:"omitempty,is-job-type"` // Кастомное правило
+
+	// Язык title/description и их переводы ({"kk": "...", "en": "..."})
+	ContentLocale   string            `json:"content_locale,omitempty" validate:"omitempty,is-locale"`
+	TitleI18n       map[string]string `json:"title_i18n,omitempty" validate:"omitempty,dive,keys,is-locale,endkeys,omitempty,min=3,max=100"`
+	DescriptionI18n map[string]string `json:"description_i18n,omitempty" validate:"omitempty,dive,keys,is-locale,endkeys,max=5000"`
+
+	CastingMeasurementRequirements
 }
 
 type UpdateCastingRequest struct {
@@ -54,6 +61,33 @@ type UpdateCastingRequest struct {
 	ExperienceLevel *string    `json:"experience_level,omitempty"`
 	Languages       []string   `json:"languages,omitempty"`
 	JobType         *string    `json:"job_type,omitempty" validate:"omitempty,is-job-type"`
+
+	// Переводы: переданные языки заменяются, пустая строка удаляет перевод
+	ContentLocale   *string           `json:"content_locale,omitempty" validate:"omitempty,is-locale"`
+	TitleI18n       map[string]string `json:"title_i18n,omitempty" validate:"omitempty,dive,keys,is-locale,endkeys,omitempty,min=3,max=100"`
+	DescriptionI18n map[string]string `json:"description_i18n,omitempty" validate:"omitempty,dive,keys,is-locale,endkeys,max=5000"`
+
+	CastingMeasurementRequirements
+}
+
+// CastingMeasurementRequirements - требования к параметрам модели, общие для создания и обновления кастинга.
+// Длины и вес (включая height_*/weight_*) передаются в MeasurementUnits, хранятся в см и кг;
+// clothing_size/shoe_size - в выбранной системе размеров (числовым размерам одежды нужен gender).
+// Границы bust/waist/hips можно задать по одной; порядок min <= max проверяет сервис.
+type CastingMeasurementRequirements struct {
+	MeasurementUnits   string   `json:"measurement_units,omitempty" validate:"omitempty,oneof=metric imperial"`
+	ClothingSizeSystem string   `json:"clothing_size_system,omitempty" validate:"omitempty,oneof=int eu ru us uk"`
+	ShoeSizeSystem     string   `json:"shoe_size_system,omitempty" validate:"omitempty,oneof=eu ru us uk"`
+	BustMin            *float64 `json:"bust_min,omitempty" validate:"omitempty,gt=0"`
+	BustMax            *float64 `json:"bust_max,omitempty" validate:"omitempty,gt=0"`
+	WaistMin           *float64 `json:"waist_min,omitempty" validate:"omitempty,gt=0"`
+	WaistMax           *float64 `json:"waist_max,omitempty" validate:"omitempty,gt=0"`
+	HipsMin            *float64 `json:"hips_min,omitempty" validate:"omitempty,gt=0"`
+	HipsMax            *float64 `json:"hips_max,omitempty" validate:"omitempty,gt=0"`
+	HairColors         []string `json:"hair_colors,omitempty" validate:"omitempty,dive,is-hair-color"` // Подходит любой из цветов
+	EyeColors          []string `json:"eye_colors,omitempty" validate:"omitempty,dive,is-eye-color"`
+	TattoosAllowed     *bool    `json:"tattoos_allowed,omitempty"` // nil - не важно
+	PiercingsAllowed   *bool    `json:"piercings_allowed,omitempty"`
 }
 
 type CreateResponseRequest struct {
@@ -62,43 +96,91 @@ type CreateResponseRequest struct {
 	Message   *string `json:"message" validate:"omitempty,max=1000"`
 }
 
+// WithdrawResponseRequest - отзыв отклика моделью (причина необязательна)
+type WithdrawResponseRequest struct {
+	Reason *string `json:"reason" validate:"omitempty,max=1000"`
+}
+
 type UpdateResponseStatusRequest struct {
 	Status models.ResponseStatus `json:"status" validate:"required,is-response-status"` // Кастомное правило
 }
 
+type UpdateCastingStatusRequest struct {
+	Status models.CastingStatus `json:"status" validate:"required,is-casting-status"`
+	Reason string               `json:"reason" validate:"omitempty,max=1000"` // Обязательна при отмене (cancelled)
+	// При закрытии: отклонить все оставшиеся pending-отклики в той же транзакции
+	RejectPending bool                 `json:"reject_pending"`
+	Message       *BulkResponseMessage `json:"message,omitempty"` // Сообщение отклоненным моделям
+}
+
+// --- Bulk Response Actions ---
+
+// BulkResponseMessage - шаблонное сообщение моделям при массовой операции.
+// Плейсхолдеры: {{casting_title}}, {{status}}
+type BulkResponseMessage struct {
+	Channel  string `json:"channel" validate:"required,oneof=notification chat"`
+	Template string `json:"template" validate:"required,max=2000"`
+}
+
+type BulkRejectPendingRequest struct {
+	Message *BulkResponseMessage `json:"message,omitempty"`
+}
+
+type BulkUpdateResponsesRequest struct {
+	ResponseIDs []string              `json:"response_ids" validate:"required,min=1,max=200"`
+	Status      models.ResponseStatus `json:"status" validate:"required,is-response-status"`
+	Message     *BulkResponseMessage  `json:"message,omitempty"`
+}
+
 // --- Casting Responses ---
 
 type CastingResponse struct {
-	ID              string                `json:"id"`
-	EmployerID      string                `json:"employer_id"`
-	Title           string                `json:"title"`
-	Description     string                `json:"description"`
-	PaymentMin      float64               `json:"payment_min"`
-	PaymentMax      float64               `json:"payment_max"`
-	CastingDate     *time.Time            `json:"casting_date,omitempty"`
-	CastingTime     *string               `json:"casting_time,omitempty"`
-	Address         *string               `json:"address,omitempty"`
-	City            string                `json:"city"`
-	Categories      []string              `json:"categories"`
-	Gender          string                `json:"gender"`
-	AgeMin          *int                  `json:"age_min,omitempty"`
-	AgeMax          *int                  `json:"age_max,omitempty"`
-	HeightMin       *float64              `json:"height_min,omitempty"`
-	HeightMax       *float64              `json:"height_max,omitempty"`
-	WeightMin       *float64              `json:"weight_min,omitempty"`
-	WeightMax       *float64              `json:"weight_max,omitempty"`
-	ClothingSize    *string               `json:"clothing_size,omitempty"`
-	ShoeSize        *string               `json:"shoe_size,omitempty"`
-	ExperienceLevel *string               `json:"experience_level,omitempty"`
-	Languages       []string              `json:"languages"`
-	JobType         string                `json:"job_type"`
-	Status          models.CastingStatus  `json:"status"`
-	Views           int                   `json:"views"`
-	Employer        interface{}           `json:"employer,omitempty"`
-	Responses       []ResponseSummary     `json:"responses,omitempty"`
-	Stats           *CastingStatsResponse `json:"stats,omitempty"`
-	CreatedAt       time.Time             `json:"created_at"`
-	UpdatedAt       time.Time             `json:"updated_at"`
+	ID               string                `json:"id"`
+	EmployerID       string                `json:"employer_id"`
+	Title            string                `json:"title"`
+	Description      string                `json:"description"`
+	ContentLocale    string                `json:"content_locale"`
+	TitleI18n        map[string]string     `json:"title_i18n,omitempty"`
+	DescriptionI18n  map[string]string     `json:"description_i18n,omitempty"`
+	Locale           string                `json:"locale,omitempty"` // язык, на котором отданы title/description
+	PaymentMin       float64               `json:"payment_min"`
+	PaymentMax       float64               `json:"payment_max"`
+	CastingDate      *time.Time            `json:"casting_date,omitempty"`
+	CastingTime      *string               `json:"casting_time,omitempty"`
+	Address          *string               `json:"address,omitempty"`
+	City             string                `json:"city"`
+	Categories       []string              `json:"categories"`
+	Gender           string                `json:"gender"`
+	AgeMin           *int                  `json:"age_min,omitempty"`
+	AgeMax           *int                  `json:"age_max,omitempty"`
+	HeightMin        *float64              `json:"height_min,omitempty"`
+	HeightMax        *float64              `json:"height_max,omitempty"`
+	WeightMin        *float64              `json:"weight_min,omitempty"`
+	WeightMax        *float64              `json:"weight_max,omitempty"`
+	ClothingSize     *string               `json:"clothing_size,omitempty"`
+	ShoeSize         *string               `json:"shoe_size,omitempty"`
+	BustMin          *float64              `json:"bust_min,omitempty"`
+	BustMax          *float64              `json:"bust_max,omitempty"`
+	WaistMin         *float64              `json:"waist_min,omitempty"`
+	WaistMax         *float64              `json:"waist_max,omitempty"`
+	HipsMin          *float64              `json:"hips_min,omitempty"`
+	HipsMax          *float64              `json:"hips_max,omitempty"`
+	HairColors       []string              `json:"hair_colors,omitempty"`
+	EyeColors        []string              `json:"eye_colors,omitempty"`
+	TattoosAllowed   *bool                 `json:"tattoos_allowed,omitempty"`
+	PiercingsAllowed *bool                 `json:"piercings_allowed,omitempty"`
+	ExperienceLevel  *string               `json:"experience_level,omitempty"`
+	Languages        []string              `json:"languages"`
+	JobType          string                `json:"job_type"`
+	Status           models.CastingStatus  `json:"status"`
+	Views            int                   `json:"views"`
+	IsPromoted       bool                  `json:"is_promoted"`
+	Employer         interface{}           `json:"employer,omitempty"`
+	EmployerVerified bool                  `json:"employer_verified"` // значок проверенной компании
+	Responses        []ResponseSummary     `json:"responses,omitempty"`
+	Stats            *CastingStatsResponse `json:"stats,omitempty"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
 }
 
 type ResponseSummary struct {
@@ -110,13 +192,39 @@ type ResponseSummary struct {
 	CreatedAt time.Time             `json:"created_at"`
 	Viewed    bool                  `json:"viewed"`
 	Model     interface{}           `json:"model,omitempty"`
+	// Отзыв отклика моделью (запись остается в списке со статусом withdrawn)
+	WithdrawnAt      *time.Time `json:"withdrawn_at,omitempty"`
+	WithdrawalReason *string    `json:"withdrawal_reason,omitempty"`
+	// Приватные пометки работодателя (только в выдаче для владельца кастинга)
+	PrivateNotes *ApplicantPrivateNotes `json:"private_notes,omitempty"`
+}
+
+type BulkResponseItemResult struct {
+	ResponseID string                `json:"response_id"`
+	Success    bool                  `json:"success"`
+	Status     models.ResponseStatus `json:"status,omitempty"`
+	Error      string                `json:"error,omitempty"`
+}
+
+type BulkResponseResult struct {
+	Total     int                      `json:"total"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Results   []BulkResponseItemResult `json:"results"`
+}
+
+type CastingStatusChangeResponse struct {
+	CastingID string               `json:"casting_id"`
+	Status    models.CastingStatus `json:"status"`
+	Rejected  *BulkResponseResult  `json:"rejected,omitempty"` // Если закрытие было с reject_pending
 }
 
 type CastingStatsResponse struct {
-	TotalResponses    int64 `json:"total_responses"`
-	PendingResponses  int64 `json:"pending_responses"`
-	AcceptedResponses int64 `json:"accepted_responses"`
-	RejectedResponses int64 `json:"rejected_responses"`
+	TotalResponses     int64 `json:"total_responses"`
+	PendingResponses   int64 `json:"pending_responses"`
+	AcceptedResponses  int64 `json:"accepted_responses"`
+	RejectedResponses  int64 `json:"rejected_responses"`
+	WithdrawnResponses int64 `json:"withdrawn_responses"`
 }
 
 // --- Search Criteria ---
@@ -0,0 +1,33 @@
+package dto
+
+// --- Comp Card Requests ---
+
+// UpdateCompCardRequest - шаблон и фото композитки в порядке на карточке (первое - главное).
+// Пустой список - берутся первые фото портфолио.
+type UpdateCompCardRequest struct {
+	Template         string   `json:"template" validate:"required,oneof=classic portrait landscape"`
+	PortfolioItemIDs []string `json:"portfolio_item_ids" validate:"max=5,dive,uuid"`
+}
+
+// CompCardDownloadQuery - формат файла (по умолчанию pdf)
+type CompCardDownloadQuery struct {
+	Format string `form:"format" validate:"omitempty,oneof=pdf png"`
+}
+
+// --- Comp Card Responses ---
+
+// CompCardPhoto - фото портфолио, попадающее на карточку
+type CompCardPhoto struct {
+	PortfolioItemID string `json:"portfolio_item_id"`
+	UploadID        string `json:"upload_id"`
+	Title           string `json:"title,omitempty"`
+}
+
+// CompCardResponse - настройки композитки и фото, которые реально будут на карточке
+type CompCardResponse struct {
+	Template         string          `json:"template"`
+	Templates        []string        `json:"templates"`
+	PortfolioItemIDs []string        `json:"portfolio_item_ids"` // сохраненный выбор (пустой - автоматический)
+	Photos           []CompCardPhoto `json:"photos"`
+	MaxPhotos        int             `json:"max_photos"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// --- Employer Note Requests ---
+
+// UpsertEmployerNoteRequest - PUT-семантика: поля пометки перезаписываются целиком
+type UpsertEmployerNoteRequest struct {
+	Note   string   `json:"note" validate:"omitempty,max=5000"`
+	Tags   []string `json:"tags" validate:"omitempty,max=7,dive,oneof=red orange yellow green blue purple gray"`
+	Rating *int     `json:"rating" validate:"omitempty,min=1,max=5"`
+}
+
+// ResponseListFilter - фильтры списка откликов для работодателя (по его приватным пометкам)
+type ResponseListFilter struct {
+	Status    string `form:"status"`
+	Tag       string `form:"tag" validate:"omitempty,oneof=red orange yellow green blue purple gray"`
+	MinRating int    `form:"min_rating" validate:"omitempty,min=1,max=5"`
+	HasNote   bool   `form:"has_note"`
+}
+
+// ModelNoteListFilter - фильтры списка пометок по моделям (те же правила тегов, что при сохранении)
+type ModelNoteListFilter struct {
+	Tag       string `form:"tag" validate:"omitempty,oneof=red orange yellow green blue purple gray"`
+	MinRating int    `form:"min_rating" validate:"omitempty,min=1,max=5"`
+}
+
+// --- Employer Note Responses ---
+
+type EmployerNoteResponse struct {
+	ID         string    `json:"id"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id"`
+	Note       string    `json:"note,omitempty"`
+	Tags       []string  `json:"tags"`
+	Rating     *int      `json:"rating,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ApplicantPrivateNotes - пометки работодателя по отклику и по самой модели
+type ApplicantPrivateNotes struct {
+	Response *EmployerNoteResponse `json:"response,omitempty"`
+	Model    *EmployerNoteResponse `json:"model,omitempty"`
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"mwork_backend/internal/algorithms"
+	"mwork_backend/internal/models"
+)
+
+// Localize подставляет в Description/Title ответа текст на языке locale (с учетом цепочки
+// fallback) и отмечает в Locale, на каком языке он отдан. Переводы (*_i18n) остаются в ответе.
+// Поддерживает профили и кастинги, их срезы и PaginatedResponse с ними; прочее не меняет.
+func Localize(data interface{}, locale string) {
+	switch v := data.(type) {
+	case *PaginatedResponse:
+		if v != nil {
+			Localize(v.Data, locale)
+		}
+	case *ProfileResponse:
+		if v != nil {
+			Localize(v.Data, locale)
+		}
+	case *models.ModelProfile:
+		if v != nil {
+			localizeModelProfile(v, locale)
+		}
+	case []models.ModelProfile:
+		for i := range v {
+			localizeModelProfile(&v[i], locale)
+		}
+	case []*models.ModelProfile:
+		for _, profile := range v {
+			Localize(profile, locale)
+		}
+	case *models.Casting:
+		if v != nil {
+			localizeCasting(v, locale)
+		}
+	case []models.Casting:
+		for i := range v {
+			localizeCasting(&v[i], locale)
+		}
+	case *CastingResponse:
+		if v != nil {
+			v.Title, v.Locale = algorithms.LocalizedValue(v.TitleI18n, v.Title, v.ContentLocale, locale)
+			v.Description, _ = algorithms.LocalizedValue(v.DescriptionI18n, v.Description, v.ContentLocale, locale)
+		}
+	case []*CastingResponse:
+		for _, casting := range v {
+			Localize(casting, locale)
+		}
+	}
+}
+
+func localizeModelProfile(profile *models.ModelProfile, locale string) {
+	profile.Description, profile.Locale = algorithms.LocalizedValue(
+		profile.GetDescriptionI18n(), profile.Description, profile.ContentLocale, locale)
+}
+
+func localizeCasting(casting *models.Casting, locale string) {
+	casting.Title, casting.Locale = algorithms.LocalizedValue(
+		casting.GetTitleI18n(), casting.Title, casting.ContentLocale, locale)
+	casting.Description, _ = algorithms.LocalizedValue(
+		casting.GetDescriptionI18n(), casting.Description, casting.ContentLocale, locale)
+}
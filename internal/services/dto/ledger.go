@@ -0,0 +1,115 @@
+package dto
+
+import "time"
+
+// --- Ledger Requests ---
+
+// LedgerAccountFilter - фильтр списка счетов
+type LedgerAccountFilter struct {
+	Type     string `form:"type" validate:"omitempty,oneof=asset liability revenue expense"`
+	Page     int    `form:"page" validate:"omitempty,min=1"`
+	PageSize int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// LedgerPostingFilter - пагинация выписки по счету
+type LedgerPostingFilter struct {
+	Page     int `form:"page" validate:"omitempty,min=1"`
+	PageSize int `form:"page_size" validate:"omitempty,min=1,max=100"`
+}
+
+// RefundPaymentRequest - полный возврат оплаченного платежа
+type RefundPaymentRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+// ProviderStatementLine - строка выписки платежного провайдера (сумма - в основных единицах, как у провайдера)
+type ProviderStatementLine struct {
+	InvID  string     `json:"inv_id" validate:"required"`
+	Type   string     `json:"type" validate:"required,oneof=payment refund"`
+	Amount float64    `json:"amount" validate:"gt=0"`
+	Date   *time.Time `json:"date"`
+}
+
+// ReconciliationRequest - сверка журнала с выпиской провайдера за период [from, to)
+type ReconciliationRequest struct {
+	From     time.Time               `json:"from" validate:"required"`
+	To       time.Time               `json:"to" validate:"required,gtfield=From"`
+	Currency string                  `json:"currency" validate:"omitempty,len=3"`
+	Lines    []ProviderStatementLine `json:"lines" validate:"max=10000,dive"`
+}
+
+// --- Ledger Responses ---
+
+// LedgerAccountResponse - счет и его остаток в естественном знаке (минимальные единицы)
+type LedgerAccountResponse struct {
+	ID          string    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`
+	OwnerUserID *string   `json:"owner_user_id,omitempty"`
+	Currency    string    `json:"currency"`
+	Balance     int64     `json:"balance"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// LedgerAccountListResponse - счета с остатками и контроль баланса журнала по валютам
+type LedgerAccountListResponse struct {
+	Accounts     []LedgerAccountResponse `json:"accounts"`
+	Total        int64                   `json:"total"`
+	Page         int                     `json:"page"`
+	PageSize     int                     `json:"page_size"`
+	TrialBalance map[string]int64        `json:"trial_balance"` // для корректного журнала - нули
+}
+
+// LedgerPostingResponse - строка выписки по счету
+type LedgerPostingResponse struct {
+	ID          string    `json:"id"`
+	EntryID     string    `json:"entry_id"`
+	Kind        string    `json:"kind"`
+	Reference   string    `json:"reference"`
+	Description string    `json:"description,omitempty"`
+	Amount      int64     `json:"amount"` // в знаке журнала: дебет > 0, кредит < 0
+	Currency    string    `json:"currency"`
+	PostedAt    time.Time `json:"posted_at"`
+}
+
+// LedgerPostingListResponse - выписка по счету
+type LedgerPostingListResponse struct {
+	Account  LedgerAccountResponse   `json:"account"`
+	Postings []LedgerPostingResponse `json:"postings"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"page_size"`
+}
+
+// Результаты сверки по строке
+const (
+	ReconciliationMatched            = "matched"
+	ReconciliationAmountMismatch     = "amount_mismatch"
+	ReconciliationMissingInLedger    = "missing_in_ledger"
+	ReconciliationMissingInStatement = "missing_in_statement"
+)
+
+// ReconciliationItem - расхождение между журналом и выпиской
+type ReconciliationItem struct {
+	InvID           string `json:"inv_id"`
+	Type            string `json:"type"`
+	Status          string `json:"status"`
+	EntryID         string `json:"entry_id,omitempty"`
+	LedgerAmount    *int64 `json:"ledger_amount,omitempty"`
+	StatementAmount *int64 `json:"statement_amount,omitempty"`
+}
+
+// ReconciliationReport - итог сверки; суммы нетто (платежи минус возвраты) в минимальных единицах
+type ReconciliationReport struct {
+	From           time.Time            `json:"from"`
+	To             time.Time            `json:"to"`
+	Currency       string               `json:"currency"`
+	StatementTotal int64                `json:"statement_total"`
+	LedgerTotal    int64                `json:"ledger_total"`
+	Difference     int64                `json:"difference"`
+	Matched        int                  `json:"matched"`
+	Discrepancies  []ReconciliationItem `json:"discrepancies"`
+	Reconciled     bool                 `json:"reconciled"`
+	GeneratedAt    time.Time            `json:"generated_at"`
+}
@@ -20,6 +20,7 @@ type MatchResult struct {
 	Score         float64                 `json:"score"`
 	Reasons       []string                `json:"reasons"`
 	Compatibility *CompatibilityBreakdown `json:"compatibility,omitempty"`
+	Availability  *AvailabilityCheck      `json:"availability,omitempty"` // Только при подборе на дату
 }
 
 // MatchScore
@@ -36,6 +37,7 @@ type CompatibilityResult struct {
 	TotalScore      float64                 `json:"total_score"`
 	Breakdown       *CompatibilityBreakdown `json:"breakdown"`
 	Recommendations []string                `json:"recommendations"`
+	Availability    *AvailabilityCheck      `json:"availability,omitempty"` // На дату кастинга, если она указана
 }
 
 // CompatibilityBreakdown
@@ -63,6 +65,16 @@ type MatchCriteria struct {
 	Languages  []string `json:"languages"`
 	Limit      int      `json:"limit" validate:"omitempty,min=0,max=100"`     // Allow 0 for default
 	MinScore   float64  `json:"min_score" validate:"omitempty,min=0,max=100"` // Allow 0 for default
+
+	// Date - дата съемки: занятые модели исключаются, не подходящие по графику получают штраф
+	Date             *time.Time `json:"date,omitempty"`
+	ExcludeCastingID string     `json:"-"` // Подбор на этот кастинг: его собственные блокировки не учитываются
+	// Requirements - полные требования кастинга (с параметрами фигуры и размерами) для оценки кандидатов
+	Requirements *MatchingCasting `json:"-"`
+	// DownRankIncomplete - понизить балл незаполненных профилей (по умолчанию выключено)
+	DownRankIncomplete bool `json:"down_rank_incomplete,omitempty"`
+	// HiddenForUserID - кто подбирает: модели из его черного списка и заблокировавшие его исключаются
+	HiddenForUserID string `json:"-"`
 }
 
 // SimilarModel
@@ -167,6 +179,20 @@ type MatchingCasting struct {
 	WeightMax  *float64 `json:"weight_max,omitempty"`
 	JobType    string   `json:"job_type"`
 	Languages  []string `json:"languages,omitempty"`
+
+	// Расширенные параметры (см, канонические размеры)
+	ClothingSize     *string  `json:"clothing_size,omitempty"`
+	ShoeSize         *string  `json:"shoe_size,omitempty"`
+	BustMin          *float64 `json:"bust_min,omitempty"`
+	BustMax          *float64 `json:"bust_max,omitempty"`
+	WaistMin         *float64 `json:"waist_min,omitempty"`
+	WaistMax         *float64 `json:"waist_max,omitempty"`
+	HipsMin          *float64 `json:"hips_min,omitempty"`
+	HipsMax          *float64 `json:"hips_max,omitempty"`
+	HairColors       []string `json:"hair_colors,omitempty"`
+	EyeColors        []string `json:"eye_colors,omitempty"`
+	TattoosAllowed   *bool    `json:"tattoos_allowed,omitempty"`
+	PiercingsAllowed *bool    `json:"piercings_allowed,omitempty"`
 }
 
 func CastingToMatchingDTO(casting *models.Casting) *MatchingCasting {
@@ -182,5 +208,18 @@ func CastingToMatchingDTO(casting *models.Casting) *MatchingCasting {
 		WeightMax:  casting.WeightMax,
 		JobType:    casting.JobType,
 		Languages:  casting.GetLanguages(),
+
+		ClothingSize:     casting.ClothingSize,
+		ShoeSize:         casting.ShoeSize,
+		BustMin:          casting.BustMin,
+		BustMax:          casting.BustMax,
+		WaistMin:         casting.WaistMin,
+		WaistMax:         casting.WaistMax,
+		HipsMin:          casting.HipsMin,
+		HipsMax:          casting.HipsMax,
+		HairColors:       casting.GetHairColors(),
+		EyeColors:        casting.GetEyeColors(),
+		TattoosAllowed:   casting.TattoosAllowed,
+		PiercingsAllowed: casting.PiercingsAllowed,
 	}
 }
@@ -0,0 +1,107 @@
+package dto
+
+import "mwork_backend/internal/models"
+
+// --- Reports ---
+
+// CreateReportRequest - жалоба на пользователя или его контент
+type CreateReportRequest struct {
+	TargetType string `json:"target_type" validate:"required,oneof=user casting message review portfolio_item"`
+	TargetID   string `json:"target_id" validate:"required,uuid"`
+	Reason     string `json:"reason" validate:"required,oneof=spam harassment inappropriate_content fake_profile scam other"`
+	Details    string `json:"details,omitempty" validate:"omitempty,max=2000"`
+}
+
+// ReportQueueQuery - очередь модерации (по умолчанию - ожидающие проверки)
+type ReportQueueQuery struct {
+	Status       string `form:"status" validate:"omitempty,oneof=pending resolved dismissed"`
+	TargetType   string `form:"target_type" validate:"omitempty,oneof=user casting message review portfolio_item"`
+	Reason       string `form:"reason" validate:"omitempty,oneof=spam harassment inappropriate_content fake_profile scam other"`
+	TargetUserID string `form:"target_user_id" validate:"omitempty,uuid"`
+	Page         int    `form:"-"` // из ParsePagination
+	PageSize     int    `form:"-"`
+}
+
+// ReportDecisionRequest - решение модератора (меры или причина отклонения)
+type ReportDecisionRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=2000"`
+}
+
+// ReportResponse - жалоба для модератора со счетчиком открытых жалоб на тот же объект
+type ReportResponse struct {
+	Report         *models.Report `json:"report"`
+	PendingReports int64          `json:"pending_reports"`
+}
+
+// --- Photo moderation ---
+
+// PhotoModerationQueueQuery - очередь модерации фото (по умолчанию - ожидающие проверки)
+type PhotoModerationQueueQuery struct {
+	Status   string `form:"status" validate:"omitempty,oneof=pending approved rejected"`
+	Usage    string `form:"usage" validate:"omitempty,oneof=avatar portfolio_photo portfolio_video"`
+	UserID   string `form:"user_id" validate:"omitempty,uuid"`
+	Page     int    `form:"-"` // из ParsePagination
+	PageSize int    `form:"-"`
+}
+
+// ApprovePhotosRequest - массовое одобрение фото
+type ApprovePhotosRequest struct {
+	UploadIDs []string `json:"upload_ids" validate:"required,min=1,max=100,dive,uuid"`
+}
+
+// RejectPhotosRequest - массовое отклонение фото; причина видна владельцу
+type RejectPhotosRequest struct {
+	UploadIDs []string `json:"upload_ids" validate:"required,min=1,max=100,dive,uuid"`
+	Reason    string   `json:"reason" validate:"required,max=500"`
+}
+
+// PhotoAutoApproveRequest - доверие пользователю: его новые фото публикуются без модерации
+type PhotoAutoApproveRequest struct {
+	Enabled bool `json:"enabled"`
+	// ApprovePending - заодно одобрить фото пользователя, ожидающие проверки
+	ApprovePending bool `json:"approve_pending"`
+}
+
+// ModerationPhoto - фото в очереди модерации
+type ModerationPhoto struct {
+	Upload *models.Upload `json:"upload"`
+	URL    string         `json:"url"`
+}
+
+type PhotoModerationItemResult struct {
+	UploadID string                  `json:"upload_id"`
+	Success  bool                    `json:"success"`
+	Status   models.ModerationStatus `json:"status,omitempty"`
+	Error    string                  `json:"error,omitempty"`
+}
+
+type PhotoModerationResult struct {
+	Total     int                         `json:"total"`
+	Succeeded int                         `json:"succeeded"`
+	Failed    int                         `json:"failed"`
+	Results   []PhotoModerationItemResult `json:"results"`
+}
+
+// --- Duplicate photos ---
+
+// DuplicateMatchQueueQuery - совпадения фото разных пользователей (по умолчанию - ожидающие проверки)
+type DuplicateMatchQueueQuery struct {
+	Status   string `form:"status" validate:"omitempty,oneof=pending confirmed dismissed"`
+	UserID   string `form:"user_id" validate:"omitempty,uuid"`
+	Page     int    `form:"-"` // из ParsePagination
+	PageSize int    `form:"-"`
+}
+
+// ResolveDuplicateMatchRequest - confirm отклоняет новое фото (причину увидит владелец),
+// dismiss - ложное срабатывание
+type ResolveDuplicateMatchRequest struct {
+	Action string `json:"action" validate:"required,oneof=confirm dismiss"`
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+// DuplicatePhotoMatch - совпадение с адресами обоих фото для сравнения
+type DuplicatePhotoMatch struct {
+	Match      *models.DuplicatePhotoMatch `json:"match"`
+	URL        string                      `json:"url"`
+	MatchedURL string                      `json:"matched_url"`
+}
@@ -2,6 +2,8 @@ package dto
 
 import (
 	"time"
+
+	"mwork_backend/internal/models"
 )
 
 // Portfolio Request DTOs
@@ -22,14 +24,22 @@ type UpdatePortfolioRequest struct {
 // Portfolio Response DTOs
 
 type PortfolioResponse struct {
-	ID          string          `json:"id"`
-	ModelID     string          `json:"model_id"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	OrderIndex  int             `json:"order_index"`
-	Upload      *UploadResponse `json:"upload"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID          string  `json:"id"`
+	ModelID     string  `json:"model_id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	OrderIndex  int     `json:"order_index"`
+	AlbumID     *string `json:"album_id,omitempty"`
+	LikesCount  int     `json:"likes_count"`
+	SavesCount  int     `json:"saves_count"`
+	// Liked/Saved - реакции текущего пользователя (только для авторизованных запросов)
+	Liked  bool            `json:"liked,omitempty"`
+	Saved  bool            `json:"saved,omitempty"`
+	Upload *UploadResponse `json:"upload"`
+	// Статус модерации фото; неодобренные элементы видят только владелец и админы
+	ModerationStatus models.ModerationStatus `json:"moderation_status"`
+	CreatedAt        time.Time               `json:"created_at"`
+	UpdatedAt        time.Time               `json:"updated_at"`
 }
 
 // Portfolio List Responses
@@ -46,8 +56,59 @@ type UploadListResponse struct {
 
 // Portfolio Reorder DTO
 
+// ReorderPortfolioRequest - порядок элементов портфолио; с album_id - порядок элементов
+// внутри альбома, album_ids - порядок самих альбомов (можно передать вместе с item_ids)
 type ReorderPortfolioRequest struct {
-	ItemIDs []string `json:"item_ids" binding:"required"`
+	ItemIDs  []string `json:"item_ids" validate:"required_without=AlbumIDs,max=500"`
+	AlbumID  string   `json:"album_id,omitempty" validate:"omitempty,uuid"`
+	AlbumIDs []string `json:"album_ids,omitempty" validate:"omitempty,max=100,dive,uuid"`
+}
+
+// Portfolio Album DTOs
+
+type CreateAlbumRequest struct {
+	Title       string                 `json:"title" validate:"required,min=1,max=100"`
+	Description string                 `json:"description" validate:"omitempty,max=1000"`
+	Visibility  models.AlbumVisibility `json:"visibility" validate:"omitempty,oneof=public private"`
+}
+
+type UpdateAlbumRequest struct {
+	Title       *string                 `json:"title,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string                 `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Visibility  *models.AlbumVisibility `json:"visibility,omitempty" validate:"omitempty,oneof=public private"`
+	// CoverItemID - элемент этого альбома; пустая строка возвращает обложку по умолчанию
+	CoverItemID *string `json:"cover_item_id,omitempty" validate:"omitempty,uuid"`
+}
+
+// MovePortfolioItemsRequest - перенос элементов в альбом; без album_id - убрать из альбома
+type MovePortfolioItemsRequest struct {
+	ItemIDs []string `json:"item_ids" validate:"required,min=1,max=100,dive,uuid"`
+	AlbumID *string  `json:"album_id" validate:"omitempty,uuid"`
+}
+
+type AlbumResponse struct {
+	ID          string                 `json:"id"`
+	ModelID     string                 `json:"model_id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Visibility  models.AlbumVisibility `json:"visibility"`
+	OrderIndex  int                    `json:"order_index"`
+	CoverItemID *string                `json:"cover_item_id,omitempty"`
+	// Cover - выбранная обложка или первый видимый элемент альбома
+	Cover     *PortfolioResponse   `json:"cover,omitempty"`
+	ItemCount int                  `json:"item_count"`
+	Items     []*PortfolioResponse `json:"items,omitempty"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// PortfolioEngagementResponse - состояние лайка/сохранения после действия пользователя
+type PortfolioEngagementResponse struct {
+	ItemID     string `json:"item_id"`
+	LikesCount int    `json:"likes_count"`
+	SavesCount int    `json:"saves_count"`
+	Liked      bool   `json:"liked"`
+	Saved      bool   `json:"saved"`
 }
 
 // Portfolio Visibility DTO
@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"mwork_backend/internal/algorithms"
+)
 
 // ==========================
 // Create Requests
@@ -10,8 +14,8 @@ type CreateModelProfileRequest struct {
 	UserID         string   `json:"user_id" validate:"-"` // Устанавливается сервером
 	Name           string   `json:"name" validate:"required"`
 	Age            int      `json:"age" validate:"required,min=16,max=70"`
-	Height         float64  `json:"height" validate:"omitempty,min=100,max=250"`
-	Weight         float64  `json:"weight" validate:"omitempty,min=30,max=200"`
+	Height         float64  `json:"height" validate:"omitempty,gt=0"` // Диапазон проверяется после перевода в см
+	Weight         float64  `json:"weight" validate:"omitempty,gt=0"`
 	Gender         string   `json:"gender" validate:"omitempty,is-gender"` // Кастомное правило
 	Experience     int      `json:"experience" validate:"omitempty,min=0"`
 	HourlyRate     float64  `json:"hourly_rate" validate:"omitempty,min=0"`
@@ -23,6 +27,12 @@ type CreateModelProfileRequest struct {
 	Categories     []string `json:"categories"`
 	BarterAccepted bool     `json:"barter_accepted"`
 	IsPublic       bool     `json:"is_public"`
+
+	// Язык description и его переводы ({"kk": "...", "en": "..."})
+	ContentLocale   string            `json:"content_locale,omitempty" validate:"omitempty,is-locale"`
+	DescriptionI18n map[string]string `json:"description_i18n,omitempty" validate:"omitempty,dive,keys,is-locale,endkeys,max=2000"`
+
+	MeasurementsInput
 }
 
 type CreateEmployerProfileRequest struct {
@@ -36,6 +46,23 @@ type CreateEmployerProfileRequest struct {
 	Description   string `json:"description" validate:"omitempty,max=2000"`
 }
 
+// MeasurementsInput - расширенные параметры модели, общие для создания и обновления профиля.
+// Длины и вес (включая height/weight) передаются в MeasurementUnits, хранятся в см и кг;
+// clothing_size/shoe_size - в выбранной системе размеров, хранятся буквой и размером EU.
+type MeasurementsInput struct {
+	MeasurementUnits   string   `json:"measurement_units,omitempty" validate:"omitempty,oneof=metric imperial"`    // По умолчанию metric
+	ClothingSizeSystem string   `json:"clothing_size_system,omitempty" validate:"omitempty,oneof=int eu ru us uk"` // Числа по умолчанию - RU
+	ShoeSizeSystem     string   `json:"shoe_size_system,omitempty" validate:"omitempty,oneof=eu ru us uk"`         // По умолчанию EU
+	Bust               *float64 `json:"bust,omitempty" validate:"omitempty,gt=0"`
+	Waist              *float64 `json:"waist,omitempty" validate:"omitempty,gt=0"`
+	Hips               *float64 `json:"hips,omitempty" validate:"omitempty,gt=0"`
+	Inseam             *float64 `json:"inseam,omitempty" validate:"omitempty,gt=0"`
+	HairColor          *string  `json:"hair_color,omitempty" validate:"omitempty,is-hair-color"`
+	EyeColor           *string  `json:"eye_color,omitempty" validate:"omitempty,is-eye-color"`
+	HasTattoos         *bool    `json:"has_tattoos,omitempty"`
+	HasPiercings       *bool    `json:"has_piercings,omitempty"`
+}
+
 // ==========================
 // Update Requests
 // ==========================
@@ -46,8 +73,8 @@ type UpdateProfileRequest struct {
 	City           *string  `json:"city,omitempty"`
 	Description    *string  `json:"description,omitempty,max=2000"`
 	Age            *int     `json:"age,omitempty" validate:"omitempty,min=16,max=70"`
-	Height         *float64 `json:"height,omitempty" validate:"omitempty,min=100,max=250"`
-	Weight         *float64 `json:"weight,omitempty" validate:"omitempty,min=30,max=200"`
+	Height         *float64 `json:"height,omitempty" validate:"omitempty,gt=0"` // Диапазон проверяется после перевода в см
+	Weight         *float64 `json:"weight,omitempty" validate:"omitempty,gt=0"`
 	Gender         *string  `json:"gender,omitempty" validate:"omitempty,is-gender"` // Кастомное правило
 	Experience     *int     `json:"experience,omitempty" validate:"omitempty,min=0"` // Разрешен конфликт, выбран тип *int
 	HourlyRate     *float64 `json:"hourly_rate,omitempty"`
@@ -58,6 +85,12 @@ type UpdateProfileRequest struct {
 	BarterAccepted *bool    `json:"barter_accepted,omitempty"`
 	IsPublic       *bool    `json:"is_public,omitempty"`
 
+	// Переводы описания модели: переданные языки заменяются, пустая строка удаляет перевод
+	ContentLocale   *string           `json:"content_locale,omitempty" validate:"omitempty,is-locale"`
+	DescriptionI18n map[string]string `json:"description_i18n,omitempty" validate:"omitempty,dive,keys,is-locale,endkeys,max=2000"`
+
+	MeasurementsInput
+
 	// Employer-specific fields
 	CompanyName   *string `json:"company_name,omitempty" validate:"omitempty,min=2"`
 	ContactPerson *string `json:"contact_person,omitempty"`
@@ -81,13 +114,24 @@ type UpdateProfileRequest struct {
 // ==========================
 
 type ProfileResponse struct {
-	ID        string      `json:"id"`
-	Type      string      `json:"type"` // "model" or "employer"
-	UserID    string      `json:"user_id"`
-	Data      interface{} `json:"data"`
-	Stats     interface{} `json:"stats,omitempty"`
-	CreatedAt time.Time   `json:"created_at"`
-	UpdatedAt time.Time   `json:"updated_at"`
+	ID            string               `json:"id"`
+	Type          string               `json:"type"` // "model", "employer" or "agency"
+	UserID        string               `json:"user_id"`
+	Data          interface{}          `json:"data"`
+	Stats         interface{}          `json:"stats,omitempty"`
+	RepresentedBy *RepresentedBy       `json:"represented_by,omitempty"` // агентство модели
+	Completeness  *ProfileCompleteness `json:"completeness,omitempty"`   // только для моделей
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// ProfileCompleteness - заполненность профиля модели (0-100).
+// Чек-лист и недостающие пункты видит только владелец профиля.
+type ProfileCompleteness struct {
+	Score      int                           `json:"score"`
+	IsComplete bool                          `json:"is_complete"`
+	Checklist  []algorithms.CompletenessItem `json:"checklist,omitempty"`
+	Missing    []string                      `json:"missing,omitempty"`
 }
 
 type ModelProfileStats struct {
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// ProfileViewSummary - просмотры профиля модели за неделю
+type ProfileViewSummary struct {
+	From              time.Time `json:"from"`
+	To                time.Time `json:"to"`
+	TotalViews        int64     `json:"total_views"`
+	UniqueViewers     int64     `json:"unique_viewers"`  // работодатели, открывшие имя
+	AnonymousViews    int64     `json:"anonymous_views"` // просмотры в анонимном режиме
+	PreviousWeekViews int64     `json:"previous_week_views"`
+}
+
+// EmployerPrivacySettings - настройки приватности работодателя
+type EmployerPrivacySettings struct {
+	// AnonymousBrowsing - модели не видят компанию в списке зрителей профиля
+	AnonymousBrowsing *bool `json:"anonymous_browsing" validate:"required"`
+}
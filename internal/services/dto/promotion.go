@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+)
+
+// --- Promotion Requests ---
+
+type CreatePromotionRequest struct {
+	Days int `json:"days" validate:"required,min=1"`
+	// UsePlan - использовать продвижение, включенное в тариф, вместо оплаты
+	UsePlan bool `json:"use_plan"`
+}
+
+// --- Promotion Responses ---
+
+type PromotionResponse struct {
+	Promotion *models.CastingPromotion      `json:"promotion"`
+	Payment   *models.RobokassaInitResponse `json:"payment,omitempty"` // Только для платного продвижения
+}
+
+type CastingPromotionsResponse struct {
+	Promotions []models.CastingPromotion    `json:"promotions"`
+	Stats      *repositories.PromotionStats `json:"stats"`
+}
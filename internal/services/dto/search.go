@@ -20,10 +20,17 @@ type SearchCastingsRequest struct {
 	JobType    string   `form:"job_type" validate:"omitempty,is-job-type"`     // Custom rule
 	Status     string   `form:"status" validate:"omitempty,is-casting-status"` // Custom rule
 	EmployerID string   `form:"employer_id"`
-	Page       int      `form:"page" validate:"omitempty,min=1"`
-	PageSize   int      `form:"page_size" validate:"omitempty,min=1,max=100"`
-	SortBy     string   `form:"sort_by"`
-	SortOrder  string   `form:"sort_order" validate:"omitempty,oneof=asc desc"`
+	// VerifiedEmployer - только кастинги верифицированных работодателей
+	VerifiedEmployer *bool  `form:"verified_employer"`
+	Page             int    `form:"page" validate:"omitempty,min=1"`
+	PageSize         int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+	SortBy           string `form:"sort_by"`
+	SortOrder        string `form:"sort_order" validate:"omitempty,oneof=asc desc"`
+
+	// RequesterID - авторизованный пользователь (необязательно): кастинги заблокированных с ним работодателей скрываются
+	RequesterID string `form:"-" json:"-"`
+	// Язык ответа (?lang= / Accept-Language); устанавливается хендлером
+	Locale string `form:"-" json:"-"`
 }
 
 type AdvancedCastingSearchRequest struct {
@@ -55,10 +62,38 @@ type SearchModelsRequest struct {
 	AcceptsBarter *bool    `form:"accepts_barter"`
 	MinRating     *float64 `form:"min_rating" validate:"omitempty,min=0,max=5"`
 	IsPublic      *bool    `form:"is_public"`
-	Page          int      `form:"page" validate:"omitempty,min=1"`
-	PageSize      int      `form:"page_size" validate:"omitempty,min=1,max=100"`
-	SortBy        string   `form:"sort_by"`
-	SortOrder     string   `form:"sort_order" validate:"omitempty,oneof=asc desc"`
+
+	// Параметры фигуры (см); границы можно задать по одной, порядок min <= max проверяет сервис
+	MinBust      *int     `form:"min_bust" validate:"omitempty,min=0"`
+	MaxBust      *int     `form:"max_bust" validate:"omitempty,min=0"`
+	MinWaist     *int     `form:"min_waist" validate:"omitempty,min=0"`
+	MaxWaist     *int     `form:"max_waist" validate:"omitempty,min=0"`
+	MinHips      *int     `form:"min_hips" validate:"omitempty,min=0"`
+	MaxHips      *int     `form:"max_hips" validate:"omitempty,min=0"`
+	HairColors   []string `form:"hair_colors" validate:"omitempty,dive,is-hair-color"`
+	EyeColors    []string `form:"eye_colors" validate:"omitempty,dive,is-eye-color"`
+	HasTattoos   *bool    `form:"has_tattoos"`
+	HasPiercings *bool    `form:"has_piercings"`
+
+	// Размеры в выбранных системах (числовым размерам одежды нужен gender)
+	ClothingSizes      []string `form:"clothing_sizes"`
+	ClothingSizeSystem string   `form:"clothing_size_system" validate:"omitempty,oneof=int eu ru us uk"`
+	MinShoeSize        string   `form:"min_shoe_size"`
+	MaxShoeSize        string   `form:"max_shoe_size"`
+	ShoeSizeSystem     string   `form:"shoe_size_system" validate:"omitempty,oneof=eu ru us uk"`
+
+	Page      int    `form:"page" validate:"omitempty,min=1"`
+	PageSize  int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+	SortBy    string `form:"sort_by"`
+	SortOrder string `form:"sort_order" validate:"omitempty,oneof=asc desc"`
+
+	// Незаполненные профили - в конце выдачи (по умолчанию выключено)
+	DownRankIncomplete bool `form:"down_rank_incomplete"`
+
+	// RequesterID - авторизованный пользователь (необязательно): заблокированные с ним модели скрываются
+	RequesterID string `form:"-" json:"-"`
+	// Язык ответа (?lang= / Accept-Language); устанавливается хендлером
+	Locale string `form:"-" json:"-"`
 }
 
 type AdvancedModelSearchRequest struct {
@@ -78,6 +113,9 @@ type SearchEmployersRequest struct {
 	IsVerified  *bool  `form:"is_verified"`
 	Page        int    `form:"page" validate:"omitempty,min=1"`
 	PageSize    int    `form:"page_size" validate:"omitempty,min=1,max=100"`
+
+	// RequesterID - авторизованный пользователь (необязательно): заблокированные с ним работодатели скрываются
+	RequesterID string `form:"-" json:"-"`
 }
 
 // --- Reviews ---
@@ -101,6 +139,9 @@ type UnifiedSearchRequest struct {
 	City     string `form:"city"`
 	Page     int    `form:"page" validate:"omitempty,min=1"`
 	PageSize int    `form:"page_size" validate:"omitempty,min=1,max=50"`
+
+	// RequesterID - авторизованный пользователь (необязательно), передается во все виды поиска
+	RequesterID string `form:"-" json:"-"`
 }
 
 // ====================
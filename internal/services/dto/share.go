@@ -0,0 +1,35 @@
+package dto
+
+// --- Share Requests ---
+
+// UpdateSlugRequest - новый адрес публичной страницы (латиница, цифры и дефисы, 3-50 символов).
+// Прежний адрес продолжает работать и перенаправляет на новый.
+type UpdateSlugRequest struct {
+	Slug string `json:"slug" validate:"required,min=3,max=50"`
+}
+
+// --- Share Responses ---
+
+// ShareLinkResponse - ссылка для шеринга (Instagram, Telegram)
+type ShareLinkResponse struct {
+	EntityType string `json:"entity_type"` // model, employer, casting
+	EntityID   string `json:"entity_id"`
+	Slug       string `json:"slug"`
+	URL        string `json:"url"`
+}
+
+// SharePage - данные публичной страницы и ее OpenGraph/Twitter метатегов
+type SharePage struct {
+	EntityType  string `json:"entity_type"`
+	EntityID    string `json:"entity_id"`
+	Slug        string `json:"slug"` // текущий адрес
+	URL         string `json:"url"`  // каноническая ссылка на страницу
+	AppURL      string `json:"app_url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url,omitempty"`
+	OGType      string `json:"og_type"` // profile, website
+	SiteName    string `json:"site_name"`
+	// Redirected - запрошен прежний адрес; клиенту стоит перейти на URL
+	Redirected bool `json:"redirected"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+// --- Shortlist Export Requests ---
+
+// Форматы выгружаемых файлов (шортлист, композитка)
+const (
+	ExportFormatPDF = "pdf"
+	ExportFormatCSV = "csv"
+	ExportFormatPNG = "png"
+)
+
+// ShortlistExportRequest - выбранные отклики кастинга для контакт-листа.
+// Если response_ids пуст, экспортируются все принятые отклики.
+type ShortlistExportRequest struct {
+	ResponseIDs []string `json:"response_ids" validate:"omitempty,max=100,dive,uuid"`
+	Format      string   `json:"format" validate:"required,oneof=pdf csv"`
+}
+
+// --- Shortlist Export Responses ---
+
+// ExportFile - готовый файл для отдачи клиенту
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
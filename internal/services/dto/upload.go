@@ -3,6 +3,8 @@ package dto
 import (
 	"mime/multipart"
 	"time"
+
+	"mwork_backend/internal/models"
 )
 
 // ============================================
@@ -43,6 +45,10 @@ type UploadResponse struct {
 	IsPublic   bool              `json:"is_public"`
 	Metadata   map[string]string `json:"metadata,omitempty"`
 	CreatedAt  time.Time         `json:"created_at"`
+
+	// Модерация (аватары и портфолио)
+	ModerationStatus models.ModerationStatus `json:"moderation_status,omitempty"`
+	RejectionReason  *string                 `json:"rejection_reason,omitempty"`
 }
 
 // StorageUsageResponse - информация об использовании хранилища
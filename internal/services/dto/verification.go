@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"mwork_backend/internal/models"
+)
+
+// --- Verification Requests ---
+
+// SubmitVerificationRequest - заявка работодателя на верификацию.
+// DocumentIDs - загрузки модуля "verification" (POST /uploads, module=verification).
+type SubmitVerificationRequest struct {
+	BIN         string   `json:"bin" validate:"required,len=12,numeric"`
+	LegalName   string   `json:"legal_name" validate:"required,min=2,max=255"`
+	DocumentIDs []string `json:"document_ids" validate:"required,min=1,max=10,dive,uuid"`
+	Comment     string   `json:"comment,omitempty" validate:"omitempty,max=2000"`
+}
+
+// UpdateVerificationRequest - ответ на запрос модератора (needs_info): исправленные данные
+// и дополнительные документы; заявка возвращается в очередь
+type UpdateVerificationRequest struct {
+	BIN         *string  `json:"bin,omitempty" validate:"omitempty,len=12,numeric"`
+	LegalName   *string  `json:"legal_name,omitempty" validate:"omitempty,min=2,max=255"`
+	DocumentIDs []string `json:"document_ids,omitempty" validate:"omitempty,max=10,dive,uuid"`
+	Comment     *string  `json:"comment,omitempty" validate:"omitempty,max=2000"`
+}
+
+// VerificationQueueQuery - очередь модерации (по умолчанию - ожидающие проверки)
+type VerificationQueueQuery struct {
+	Status   string `form:"status" validate:"omitempty,oneof=pending needs_info approved rejected expired"`
+	Page     int    `form:"-"` // из ParsePagination
+	PageSize int    `form:"-"`
+}
+
+// ApproveVerificationRequest - одобрение; срок действия по умолчанию 12 месяцев
+type ApproveVerificationRequest struct {
+	ValidMonths int    `json:"valid_months,omitempty" validate:"omitempty,min=1,max=36"`
+	Note        string `json:"note,omitempty" validate:"omitempty,max=2000"`
+}
+
+// ReviewNoteRequest - отказ (причина) или запрос дополнительных документов (что нужно)
+type ReviewNoteRequest struct {
+	Note string `json:"note" validate:"required,min=3,max=2000"`
+}
+
+// --- Verification Responses ---
+
+// VerificationResponse - заявка с историей статусов и документами
+type VerificationResponse struct {
+	Verification *models.EmployerVerification `json:"verification"`
+	Documents    []VerificationDocument       `json:"documents"`
+}
+
+// VerificationDocument - приложенный к заявке файл; URL отдается через /files с проверкой доступа
+type VerificationDocument struct {
+	ID        string    `json:"id"`
+	Usage     string    `json:"usage"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type EmployerNoteService interface {
+	// Пометки по отклику
+	GetResponseNote(db *gorm.DB, employerID, responseID string) (*dto.EmployerNoteResponse, error)
+	UpsertResponseNote(db *gorm.DB, employerID, responseID string, req *dto.UpsertEmployerNoteRequest) (*dto.EmployerNoteResponse, error)
+	DeleteResponseNote(db *gorm.DB, employerID, responseID string) error
+
+	// Пометки по модели (modelID = users.id модели)
+	GetModelNote(db *gorm.DB, employerID, modelID string) (*dto.EmployerNoteResponse, error)
+	UpsertModelNote(db *gorm.DB, employerID, modelID string, req *dto.UpsertEmployerNoteRequest) (*dto.EmployerNoteResponse, error)
+	DeleteModelNote(db *gorm.DB, employerID, modelID string) error
+	ListModelNotes(db *gorm.DB, employerID, tag string, minRating int) ([]dto.EmployerNoteResponse, error)
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type employerNoteService struct {
+	noteRepo     repositories.EmployerNoteRepository
+	responseRepo repositories.ResponseRepository
+	castingRepo  repositories.CastingRepository
+	userRepo     repositories.UserRepository
+}
+
+func NewEmployerNoteService(
+	noteRepo repositories.EmployerNoteRepository,
+	responseRepo repositories.ResponseRepository,
+	castingRepo repositories.CastingRepository,
+	userRepo repositories.UserRepository,
+) EmployerNoteService {
+	return &employerNoteService{
+		noteRepo:     noteRepo,
+		responseRepo: responseRepo,
+		castingRepo:  castingRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// --- Response notes ---
+
+func (s *employerNoteService) GetResponseNote(db *gorm.DB, employerID, responseID string) (*dto.EmployerNoteResponse, error) {
+	if err := s.checkResponseOwner(db, employerID, responseID); err != nil {
+		return nil, err
+	}
+	note, err := s.noteRepo.FindNote(db, employerID, models.EmployerNoteTargetResponse, responseID)
+	if err != nil {
+		return nil, handleEmployerNoteError(err)
+	}
+	return toEmployerNoteResponse(note), nil
+}
+
+func (s *employerNoteService) UpsertResponseNote(db *gorm.DB, employerID, responseID string, req *dto.UpsertEmployerNoteRequest) (*dto.EmployerNoteResponse, error) {
+	if err := s.checkResponseOwner(db, employerID, responseID); err != nil {
+		return nil, err
+	}
+	return s.upsert(db, employerID, models.EmployerNoteTargetResponse, responseID, req)
+}
+
+func (s *employerNoteService) DeleteResponseNote(db *gorm.DB, employerID, responseID string) error {
+	if err := s.checkResponseOwner(db, employerID, responseID); err != nil {
+		return err
+	}
+	if err := s.noteRepo.DeleteNote(db, employerID, models.EmployerNoteTargetResponse, responseID); err != nil {
+		return handleEmployerNoteError(err)
+	}
+	return nil
+}
+
+// --- Model notes ---
+
+func (s *employerNoteService) GetModelNote(db *gorm.DB, employerID, modelID string) (*dto.EmployerNoteResponse, error) {
+	note, err := s.noteRepo.FindNote(db, employerID, models.EmployerNoteTargetModel, modelID)
+	if err != nil {
+		return nil, handleEmployerNoteError(err)
+	}
+	return toEmployerNoteResponse(note), nil
+}
+
+func (s *employerNoteService) UpsertModelNote(db *gorm.DB, employerID, modelID string, req *dto.UpsertEmployerNoteRequest) (*dto.EmployerNoteResponse, error) {
+	model, err := s.userRepo.FindByID(db, modelID)
+	if err != nil {
+		return nil, handleEmployerNoteError(err)
+	}
+	if model.Role != models.UserRoleModel {
+		return nil, apperrors.ErrInvalidOperation("employer_note", "Notes can only be attached to models")
+	}
+	return s.upsert(db, employerID, models.EmployerNoteTargetModel, modelID, req)
+}
+
+func (s *employerNoteService) DeleteModelNote(db *gorm.DB, employerID, modelID string) error {
+	if err := s.noteRepo.DeleteNote(db, employerID, models.EmployerNoteTargetModel, modelID); err != nil {
+		return handleEmployerNoteError(err)
+	}
+	return nil
+}
+
+func (s *employerNoteService) ListModelNotes(db *gorm.DB, employerID, tag string, minRating int) ([]dto.EmployerNoteResponse, error) {
+	notes, err := s.noteRepo.FindNotesByEmployer(db, employerID, repositories.EmployerNoteCriteria{
+		TargetType: models.EmployerNoteTargetModel,
+		Tag:        tag,
+		MinRating:  minRating,
+	})
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	result := make([]dto.EmployerNoteResponse, 0, len(notes))
+	for i := range notes {
+		result = append(result, *toEmployerNoteResponse(&notes[i]))
+	}
+	return result, nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+func (s *employerNoteService) upsert(db *gorm.DB, employerID, targetType, targetID string, req *dto.UpsertEmployerNoteRequest) (*dto.EmployerNoteResponse, error) {
+	note := &models.EmployerNote{
+		EmployerID: employerID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Note:       req.Note,
+		Rating:     req.Rating,
+	}
+	note.SetTags(req.Tags)
+
+	if err := s.noteRepo.UpsertNote(db, note); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	saved, err := s.noteRepo.FindNote(db, employerID, targetType, targetID)
+	if err != nil {
+		return nil, handleEmployerNoteError(err)
+	}
+	return toEmployerNoteResponse(saved), nil
+}
+
+// checkResponseOwner - пометки на отклик может ставить только владелец кастинга
+func (s *employerNoteService) checkResponseOwner(db *gorm.DB, employerID, responseID string) error {
+	response, err := s.responseRepo.FindResponseByID(db, responseID)
+	if err != nil {
+		return handleEmployerNoteError(err)
+	}
+	casting, err := s.castingRepo.FindCastingByID(db, response.CastingID)
+	if err != nil {
+		return handleEmployerNoteError(err)
+	}
+	employerUser, err := s.userRepo.FindByProfileID(db, casting.EmployerID)
+	if err != nil {
+		return handleEmployerNoteError(err)
+	}
+	if employerUser.ID != employerID {
+		return apperrors.ErrInsufficientPermissions
+	}
+	return nil
+}
+
+func toEmployerNoteResponse(note *models.EmployerNote) *dto.EmployerNoteResponse {
+	if note == nil {
+		return nil
+	}
+	tags := note.GetTags()
+	if tags == nil {
+		tags = []string{}
+	}
+	return &dto.EmployerNoteResponse{
+		ID:         note.ID,
+		TargetType: note.TargetType,
+		TargetID:   note.TargetID,
+		Note:       note.Note,
+		Tags:       tags,
+		Rating:     note.Rating,
+		UpdatedAt:  note.UpdatedAt,
+	}
+}
+
+func handleEmployerNoteError(err error) error {
+	if errors.Is(err, repositories.ErrEmployerNoteNotFound) ||
+		errors.Is(err, repositories.ErrResponseNotFound) ||
+		errors.Is(err, repositories.ErrCastingNotFound) ||
+		errors.Is(err, repositories.ErrUserNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	return apperrors.InternalError(err)
+}
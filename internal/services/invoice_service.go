@@ -0,0 +1,492 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/document"
+	"mwork_backend/internal/email"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type InvoiceService interface {
+	// Выставление. Не открывают свою транзакцию: номер документа берется в транзакции платежа,
+	// поэтому откат платежа не оставляет пропусков в нумерации. Повторный вызов возвращает уже выставленный документ.
+	IssueInvoice(db *gorm.DB, payment *models.PaymentTransaction) (*models.Invoice, error)
+	IssueCreditNote(db *gorm.DB, payment *models.PaymentTransaction, reason string) (*models.Invoice, error)
+
+	// SendInvoiceEmail - PDF документа письмом плательщику (вызывается после коммита)
+	SendInvoiceEmail(db *gorm.DB, invoiceID string) error
+
+	// GetInvoicePDF - счет (или кредит-нота) по платежу для владельца платежа или администратора
+	GetInvoicePDF(db *gorm.DB, paymentID, userID, kind string) (*dto.ExportFile, error)
+}
+
+// =======================
+// 2. КОНФИГУРАЦИЯ
+// =======================
+
+type InvoiceConfig struct {
+	Seller   models.InvoiceParty
+	VATRate  int // НДС в процентах; цены платформы включают НДС
+	Currency string
+}
+
+func GetDefaultInvoiceConfig() *InvoiceConfig {
+	return &InvoiceConfig{
+		Seller: models.InvoiceParty{
+			Name:        "ТОО «MWork»",
+			TaxID:       "БИН 000000000000",
+			Address:     "г. Алматы, пр. Абая, 1",
+			City:        "Алматы",
+			Email:       "billing@mwork.kz",
+			BankAccount: "KZ000000000000000000",
+		},
+		VATRate:  12,
+		Currency: models.LedgerBaseCurrency,
+	}
+}
+
+// invoiceNumberPrefixes - префиксы номеров по видам документов
+var invoiceNumberPrefixes = map[string]string{
+	models.InvoiceKindInvoice:    "INV",
+	models.InvoiceKindCreditNote: "CN",
+}
+
+// =======================
+// 3. РЕАЛИЗАЦИЯ
+// =======================
+type invoiceService struct {
+	invoiceRepo      repositories.InvoiceRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	userRepo         repositories.UserRepository
+	profileRepo      repositories.ProfileRepository
+	emailProvider    email.Provider
+	config           *InvoiceConfig
+}
+
+func NewInvoiceService(
+	invoiceRepo repositories.InvoiceRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	userRepo repositories.UserRepository,
+	profileRepo repositories.ProfileRepository,
+	emailProvider email.Provider,
+	config *InvoiceConfig,
+) InvoiceService {
+	return &invoiceService{
+		invoiceRepo:      invoiceRepo,
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+		profileRepo:      profileRepo,
+		emailProvider:    emailProvider,
+		config:           config,
+	}
+}
+
+func (s *invoiceService) IssueInvoice(db *gorm.DB, payment *models.PaymentTransaction) (*models.Invoice, error) {
+	if existing, err := s.invoiceRepo.FindInvoiceByPayment(db, payment.ID, models.InvoiceKindInvoice); err == nil {
+		return existing, nil
+	} else if !errors.Is(err, repositories.ErrInvoiceNotFound) {
+		return nil, apperrors.InternalError(err)
+	}
+
+	issuedAt := time.Now()
+	if payment.PaidAt != nil {
+		issuedAt = *payment.PaidAt
+	}
+
+	invoice, err := s.buildInvoice(db, payment, models.InvoiceKindInvoice, issuedAt, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.createInvoice(db, invoice); err != nil {
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// IssueCreditNote - кредит-нота на полную сумму счета с отрицательными суммами
+func (s *invoiceService) IssueCreditNote(db *gorm.DB, payment *models.PaymentTransaction, reason string) (*models.Invoice, error) {
+	if existing, err := s.invoiceRepo.FindInvoiceByPayment(db, payment.ID, models.InvoiceKindCreditNote); err == nil {
+		return existing, nil
+	} else if !errors.Is(err, repositories.ErrInvoiceNotFound) {
+		return nil, apperrors.InternalError(err)
+	}
+
+	// Платежи, оплаченные до появления счетов, получают счет задним числом
+	original, err := s.IssueInvoice(db, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	creditNote, err := s.buildInvoice(db, payment, models.InvoiceKindCreditNote, time.Now(), -1)
+	if err != nil {
+		return nil, err
+	}
+	creditNote.OriginalInvoiceID = &original.ID
+	creditNote.Reason = reason
+	// Реквизиты покупателя - как в исходном счете
+	creditNote.BuyerDetails = original.BuyerDetails
+
+	if err := s.createInvoice(db, creditNote); err != nil {
+		return nil, err
+	}
+	return creditNote, nil
+}
+
+func (s *invoiceService) SendInvoiceEmail(db *gorm.DB, invoiceID string) error {
+	invoice, err := s.invoiceRepo.FindInvoiceByID(db, invoiceID)
+	if err != nil {
+		return handleInvoiceError(err)
+	}
+	user, err := s.userRepo.FindByID(db, invoice.UserID)
+	if err != nil {
+		return handleInvoiceError(err)
+	}
+
+	file, err := s.renderPDF(db, invoice)
+	if err != nil {
+		return err
+	}
+
+	title := invoiceTitle(invoice)
+	body := fmt.Sprintf("Здравствуйте, %s!\n\n%s на сумму %s во вложении.\n\nMWork", user.Name, title, formatInvoiceAmount(invoice.TotalAmount, invoice.Currency))
+	msg := &email.Email{
+		To:      []string{user.Email},
+		Subject: title,
+		Body:    body,
+		Attachments: []email.Attachment{{
+			Name:        file.FileName,
+			Content:     file.Data,
+			ContentType: file.ContentType,
+		}},
+	}
+	if err := s.emailProvider.Send(msg); err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	if err := s.invoiceRepo.MarkEmailed(db, invoice.ID, time.Now()); err != nil {
+		return apperrors.InternalError(err)
+	}
+	return nil
+}
+
+func (s *invoiceService) GetInvoicePDF(db *gorm.DB, paymentID, userID, kind string) (*dto.ExportFile, error) {
+	payment, err := s.subscriptionRepo.FindPaymentByID(db, paymentID)
+	if err != nil {
+		return nil, handleInvoiceError(err)
+	}
+
+	if payment.UserID != userID {
+		user, err := s.userRepo.FindByID(db, userID)
+		if err != nil {
+			return nil, handleInvoiceError(err)
+		}
+		if user.Role != models.UserRoleAdmin {
+			return nil, apperrors.ErrInsufficientPermissions
+		}
+	}
+
+	invoice, err := s.invoiceRepo.FindInvoiceByPayment(db, payment.ID, kind)
+	if errors.Is(err, repositories.ErrInvoiceNotFound) {
+		// Документ по старому платежу выставляется при первом запросе
+		invoice, err = s.issueMissing(db, payment, kind)
+	}
+	if err != nil {
+		return nil, handleInvoiceError(err)
+	}
+
+	return s.renderPDF(db, invoice)
+}
+
+// =======================
+// 4. ХЕЛПЕРЫ
+// =======================
+
+// issueMissing - выставление отсутствующего документа в отдельной транзакции
+func (s *invoiceService) issueMissing(db *gorm.DB, payment *models.PaymentTransaction, kind string) (*models.Invoice, error) {
+	switch kind {
+	case models.InvoiceKindInvoice:
+		if payment.Status != models.PaymentStatusPaid && payment.Status != models.PaymentStatusRefunded {
+			return nil, apperrors.ErrInvoiceNotAvailable
+		}
+	case models.InvoiceKindCreditNote:
+		if payment.Status != models.PaymentStatusRefunded {
+			return nil, apperrors.ErrInvoiceNotAvailable
+		}
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	var invoice *models.Invoice
+	var err error
+	if kind == models.InvoiceKindCreditNote {
+		invoice, err = s.IssueCreditNote(tx, payment, "")
+	} else {
+		invoice, err = s.IssueInvoice(tx, payment)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return invoice, nil
+}
+
+// buildInvoice - документ с одной строкой на сумму платежа; sign = -1 для кредит-ноты
+func (s *invoiceService) buildInvoice(db *gorm.DB, payment *models.PaymentTransaction, kind string, issuedAt time.Time, sign int64) (*models.Invoice, error) {
+	year := invoiceLocalTime(issuedAt).Year()
+	sequence, err := s.invoiceRepo.NextNumber(db, kind, year)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	total := models.ToMinorUnits(payment.Amount, s.config.Currency)
+	vat := vatFromGross(total, s.config.VATRate)
+	net := total - vat
+
+	description := payment.Description
+	if description == "" {
+		description = "Подписка на платформе MWork"
+	}
+	description = fmt.Sprintf("%s (платеж %s)", description, payment.InvID)
+
+	invoice := &models.Invoice{
+		Kind:        kind,
+		Number:      fmt.Sprintf("%s-%d-%06d", invoiceNumberPrefixes[kind], year, sequence),
+		Year:        year,
+		Sequence:    sequence,
+		PaymentID:   payment.ID,
+		UserID:      payment.UserID,
+		Currency:    s.config.Currency,
+		VATRate:     s.config.VATRate,
+		NetAmount:   sign * net,
+		VATAmount:   sign * vat,
+		TotalAmount: sign * total,
+		IssuedAt:    issuedAt,
+		Lines: []models.InvoiceLine{{
+			Position:    1,
+			Description: description,
+			Quantity:    1,
+			UnitPrice:   sign * total,
+			NetAmount:   sign * net,
+			VATAmount:   sign * vat,
+			TotalAmount: sign * total,
+		}},
+	}
+	invoice.SetSeller(s.config.Seller)
+	invoice.SetBuyer(s.buyerDetails(db, payment.UserID))
+	return invoice, nil
+}
+
+// buyerDetails - реквизиты компании из профиля работодателя; для моделей - имя и email
+func (s *invoiceService) buyerDetails(db *gorm.DB, userID string) models.InvoiceParty {
+	var party models.InvoiceParty
+	if user, err := s.userRepo.FindByID(db, userID); err == nil {
+		party.Name = user.Name
+		party.Email = user.Email
+	}
+	if profile, err := s.profileRepo.FindEmployerProfileByUserID(db, userID); err == nil {
+		if profile.CompanyName != "" {
+			party.Name = profile.CompanyName
+		}
+		party.CompanyType = profile.CompanyType
+		party.City = profile.City
+		party.Contact = profile.ContactPerson
+		party.Phone = profile.Phone
+	}
+	return party
+}
+
+func (s *invoiceService) createInvoice(db *gorm.DB, invoice *models.Invoice) error {
+	if err := s.invoiceRepo.CreateInvoice(db, invoice); err != nil {
+		return handleInvoiceError(err)
+	}
+	return nil
+}
+
+func (s *invoiceService) renderPDF(db *gorm.DB, invoice *models.Invoice) (*dto.ExportFile, error) {
+	seller := invoice.GetSeller()
+	buyer := invoice.GetBuyer()
+
+	doc := &document.Invoice{
+		Title:    invoiceTitle(invoice),
+		Subtitle: "Дата: " + invoiceLocalTime(invoice.IssuedAt).Format("02.01.2006"),
+		Seller:   document.InvoiceParty{Label: "Продавец", Name: seller.Name, Details: invoicePartyDetails(seller)},
+		Buyer:    document.InvoiceParty{Label: "Покупатель", Name: buyer.Name, Details: invoicePartyDetails(buyer)},
+		Columns: []string{
+			"№", "Наименование", "Кол-во",
+			"Цена, " + invoice.Currency, "НДС, " + invoice.Currency, "Сумма, " + invoice.Currency,
+		},
+		Footer: invoice.Number,
+	}
+	if invoice.OriginalInvoiceID != nil {
+		if original, err := s.invoiceRepo.FindInvoiceByID(db, *invoice.OriginalInvoiceID); err == nil {
+			doc.Subtitle += fmt.Sprintf(" · к счету № %s от %s", original.Number, invoiceLocalTime(original.IssuedAt).Format("02.01.2006"))
+		}
+	}
+
+	for _, line := range invoice.Lines {
+		doc.Lines = append(doc.Lines, document.InvoiceLine{Cells: []string{
+			fmt.Sprintf("%d", line.Position),
+			line.Description,
+			fmt.Sprintf("%d", line.Quantity),
+			formatInvoiceNumber(line.UnitPrice),
+			formatInvoiceNumber(line.VATAmount),
+			formatInvoiceNumber(line.TotalAmount),
+		}})
+	}
+
+	vatLabel := "Без НДС"
+	if invoice.VATRate > 0 {
+		vatLabel = fmt.Sprintf("НДС %d%% (в т.ч.)", invoice.VATRate)
+	}
+	doc.Totals = []document.InvoiceTotal{
+		{Label: "Сумма без НДС", Value: formatInvoiceAmount(invoice.NetAmount, invoice.Currency)},
+		{Label: vatLabel, Value: formatInvoiceAmount(invoice.VATAmount, invoice.Currency)},
+		{Label: "Итого", Value: formatInvoiceAmount(invoice.TotalAmount, invoice.Currency), Bold: true},
+	}
+
+	if invoice.Kind == models.InvoiceKindCreditNote {
+		doc.Note = "Кредит-нота уменьшает сумму исходного счета в связи с возвратом платежа."
+		if invoice.Reason != "" {
+			doc.Note += " Основание: " + invoice.Reason
+		}
+	} else {
+		doc.Note = "Счет оплачен. Документ сформирован автоматически и действителен без подписи и печати."
+	}
+
+	pages, err := document.RenderInvoice(doc)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	var buf bytes.Buffer
+	if err := document.EncodePDF(&buf, pages, 85); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	return &dto.ExportFile{
+		FileName:    invoice.Number + ".pdf",
+		ContentType: "application/pdf",
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func invoiceTitle(invoice *models.Invoice) string {
+	if invoice.Kind == models.InvoiceKindCreditNote {
+		return "Кредит-нота № " + invoice.Number
+	}
+	return "Счет № " + invoice.Number
+}
+
+func invoicePartyDetails(party models.InvoiceParty) []string {
+	var details []string
+	if party.CompanyType != "" {
+		details = append(details, party.CompanyType)
+	}
+	if party.TaxID != "" {
+		details = append(details, party.TaxID)
+	}
+	if party.Address != "" {
+		details = append(details, party.Address)
+	} else if party.City != "" {
+		details = append(details, party.City)
+	}
+	if party.BankAccount != "" {
+		details = append(details, "IBAN "+party.BankAccount)
+	}
+	if party.Contact != "" {
+		details = append(details, party.Contact)
+	}
+	contacts := strings.TrimSpace(strings.Join([]string{party.Phone, party.Email}, " "))
+	if contacts != "" {
+		details = append(details, contacts)
+	}
+	return details
+}
+
+// vatFromGross - НДС, включенный в сумму: gross * rate / (100 + rate), с округлением
+func vatFromGross(gross int64, rate int) int64 {
+	if rate <= 0 {
+		return 0
+	}
+	divisor := int64(100 + rate)
+	return (gross*int64(rate) + divisor/2) / divisor
+}
+
+// formatInvoiceAmount - "12 345,67 KZT"
+func formatInvoiceAmount(amount int64, currency string) string {
+	return formatInvoiceNumber(amount) + " " + currency
+}
+
+// formatInvoiceNumber - "12 345,67" (минимальная единица - сотая часть)
+func formatInvoiceNumber(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	whole := fmt.Sprintf("%d", amount/100)
+	var grouped []string
+	for len(whole) > 3 {
+		grouped = append([]string{whole[len(whole)-3:]}, grouped...)
+		whole = whole[:len(whole)-3]
+	}
+	grouped = append([]string{whole}, grouped...)
+	return fmt.Sprintf("%s%s,%02d", sign, strings.Join(grouped, " "), amount%100)
+}
+
+// invoiceLocalTime - даты и год нумерации считаются по времени Алматы
+func invoiceLocalTime(t time.Time) time.Time {
+	if loc, err := time.LoadLocation("Asia/Almaty"); err == nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// sendInvoiceEmailAsync - отправка после коммита; ошибка письма не влияет на платеж.
+// Уже отправленный документ (повторный callback провайдера) не отправляется снова.
+func sendInvoiceEmailAsync(invoiceService InvoiceService, db *gorm.DB, invoice *models.Invoice) {
+	if invoice == nil || invoice.EmailedAt != nil {
+		return
+	}
+	go func() {
+		if err := invoiceService.SendInvoiceEmail(db, invoice.ID); err != nil {
+			log.Printf("failed to email invoice %s: %v", invoice.Number, err)
+		}
+	}()
+}
+
+func handleInvoiceError(err error) error {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+	if errors.Is(err, repositories.ErrInvoiceNotFound) ||
+		errors.Is(err, repositories.ErrPaymentNotFound) ||
+		errors.Is(err, repositories.ErrUserNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	if errors.Is(err, repositories.ErrInvoiceAlreadyExists) {
+		return apperrors.ErrConflict(err, "invoice", "Invoice for this payment has already been issued")
+	}
+	return apperrors.InternalError(err)
+}
@@ -0,0 +1,434 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type LedgerService interface {
+	// Проводки. Не открывают свою транзакцию: вызываются внутри транзакции платежа,
+	// чтобы статус платежа и журнал менялись атомарно. Повторный вызов - no-op.
+	PostPaymentCapture(db *gorm.DB, payment *models.PaymentTransaction) error
+	PostPaymentRefund(db *gorm.DB, payment *models.PaymentTransaction, adminID, reason string) error
+
+	// Остатки
+	GetMyWallet(db *gorm.DB, userID string, filter *dto.LedgerPostingFilter) (*dto.LedgerPostingListResponse, error)
+	ListAccounts(db *gorm.DB, filter *dto.LedgerAccountFilter) (*dto.LedgerAccountListResponse, error)
+	GetAccountPostings(db *gorm.DB, accountID string, filter *dto.LedgerPostingFilter) (*dto.LedgerPostingListResponse, error)
+	GetEntry(db *gorm.DB, entryID string) (*models.LedgerEntry, error)
+
+	// Сверка с выпиской провайдера
+	Reconcile(db *gorm.DB, req *dto.ReconciliationRequest) (*dto.ReconciliationReport, error)
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type ledgerService struct {
+	ledgerRepo repositories.LedgerRepository
+}
+
+func NewLedgerService(ledgerRepo repositories.LedgerRepository) LedgerService {
+	return &ledgerService{
+		ledgerRepo: ledgerRepo,
+	}
+}
+
+// PostPaymentCapture - деньги пришли от провайдера на кошелек пользователя и сразу списаны в выручку:
+// Дт клиринг / Кт кошелек, Дт кошелек / Кт выручка.
+func (s *ledgerService) PostPaymentCapture(db *gorm.DB, payment *models.PaymentTransaction) error {
+	amount := models.ToMinorUnits(payment.Amount, models.LedgerBaseCurrency)
+	if amount <= 0 {
+		return apperrors.ErrInvalidPaymentAmount
+	}
+
+	clearing, wallet, revenue, err := s.paymentAccounts(db, payment.UserID, models.LedgerBaseCurrency)
+	if err != nil {
+		return err
+	}
+
+	postedAt := time.Now()
+	if payment.PaidAt != nil {
+		postedAt = *payment.PaidAt
+	}
+
+	entry := &models.LedgerEntry{
+		Reference:   paymentLedgerReference(payment.ID, models.LedgerEntryPayment),
+		Kind:        models.LedgerEntryPayment,
+		Description: paymentLedgerDescription(payment, "Оплата"),
+		PaymentID:   &payment.ID,
+		PostedAt:    postedAt,
+		Postings: []models.LedgerPosting{
+			{AccountID: clearing.ID, Amount: amount, Currency: clearing.Currency},
+			{AccountID: wallet.ID, Amount: -amount, Currency: wallet.Currency},
+			{AccountID: wallet.ID, Amount: amount, Currency: wallet.Currency},
+			{AccountID: revenue.ID, Amount: -amount, Currency: revenue.Currency},
+		},
+	}
+	return s.createEntry(db, entry)
+}
+
+// PostPaymentRefund - обратная проводка: Дт выручка / Кт кошелек, Дт кошелек / Кт клиринг
+func (s *ledgerService) PostPaymentRefund(db *gorm.DB, payment *models.PaymentTransaction, adminID, reason string) error {
+	amount := models.ToMinorUnits(payment.Amount, models.LedgerBaseCurrency)
+	if amount <= 0 {
+		return apperrors.ErrInvalidPaymentAmount
+	}
+
+	clearing, wallet, revenue, err := s.paymentAccounts(db, payment.UserID, models.LedgerBaseCurrency)
+	if err != nil {
+		return err
+	}
+
+	description := paymentLedgerDescription(payment, "Возврат")
+	if reason != "" {
+		description += ": " + reason
+	}
+
+	entry := &models.LedgerEntry{
+		Reference:   paymentLedgerReference(payment.ID, models.LedgerEntryRefund),
+		Kind:        models.LedgerEntryRefund,
+		Description: description,
+		PaymentID:   &payment.ID,
+		CreatedBy:   &adminID,
+		PostedAt:    time.Now(),
+		Postings: []models.LedgerPosting{
+			{AccountID: revenue.ID, Amount: amount, Currency: revenue.Currency},
+			{AccountID: wallet.ID, Amount: -amount, Currency: wallet.Currency},
+			{AccountID: wallet.ID, Amount: amount, Currency: wallet.Currency},
+			{AccountID: clearing.ID, Amount: -amount, Currency: clearing.Currency},
+		},
+	}
+	return s.createEntry(db, entry)
+}
+
+func (s *ledgerService) GetMyWallet(db *gorm.DB, userID string, filter *dto.LedgerPostingFilter) (*dto.LedgerPostingListResponse, error) {
+	normalizeLedgerPage(&filter.Page, &filter.PageSize)
+
+	account, err := s.ledgerRepo.FindAccountByCode(db, models.LedgerWalletCode(userID), models.LedgerBaseCurrency)
+	if err != nil {
+		if errors.Is(err, repositories.ErrLedgerAccountNotFound) {
+			// Кошелек создается при первой проводке - до нее остаток нулевой
+			return &dto.LedgerPostingListResponse{
+				Account: dto.LedgerAccountResponse{
+					Code:        models.LedgerWalletCode(userID),
+					Name:        "Кошелек пользователя",
+					Type:        string(models.LedgerAccountLiability),
+					OwnerUserID: &userID,
+					Currency:    models.LedgerBaseCurrency,
+				},
+				Postings: []dto.LedgerPostingResponse{},
+				Page:     filter.Page,
+				PageSize: filter.PageSize,
+			}, nil
+		}
+		return nil, handleLedgerError(err)
+	}
+
+	return s.accountStatement(db, account, filter)
+}
+
+func (s *ledgerService) ListAccounts(db *gorm.DB, filter *dto.LedgerAccountFilter) (*dto.LedgerAccountListResponse, error) {
+	normalizeLedgerPage(&filter.Page, &filter.PageSize)
+
+	balances, total, err := s.ledgerRepo.ListAccountBalances(db, filter.Type, filter.Page, filter.PageSize)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	trial, err := s.ledgerRepo.GetTrialBalance(db)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	accounts := make([]dto.LedgerAccountResponse, 0, len(balances))
+	for i := range balances {
+		accounts = append(accounts, buildLedgerAccountResponse(&balances[i].LedgerAccount, balances[i].PostingsSum))
+	}
+
+	return &dto.LedgerAccountListResponse{
+		Accounts:     accounts,
+		Total:        total,
+		Page:         filter.Page,
+		PageSize:     filter.PageSize,
+		TrialBalance: trial,
+	}, nil
+}
+
+func (s *ledgerService) GetAccountPostings(db *gorm.DB, accountID string, filter *dto.LedgerPostingFilter) (*dto.LedgerPostingListResponse, error) {
+	normalizeLedgerPage(&filter.Page, &filter.PageSize)
+
+	account, err := s.ledgerRepo.FindAccountByID(db, accountID)
+	if err != nil {
+		return nil, handleLedgerError(err)
+	}
+	return s.accountStatement(db, account, filter)
+}
+
+func (s *ledgerService) GetEntry(db *gorm.DB, entryID string) (*models.LedgerEntry, error) {
+	entry, err := s.ledgerRepo.FindEntryByID(db, entryID)
+	if err != nil {
+		return nil, handleLedgerError(err)
+	}
+	return entry, nil
+}
+
+// Reconcile - сопоставляет строки выписки с проводками по счету клиринга по паре (inv_id, тип).
+// Проводки по платежам из выписки учитываются, даже если попали в журнал вне периода.
+func (s *ledgerService) Reconcile(db *gorm.DB, req *dto.ReconciliationRequest) (*dto.ReconciliationReport, error) {
+	currency := strings.ToUpper(req.Currency)
+	if currency == "" {
+		currency = models.LedgerBaseCurrency
+	}
+
+	report := &dto.ReconciliationReport{
+		From:          req.From,
+		To:            req.To,
+		Currency:      currency,
+		Discrepancies: []dto.ReconciliationItem{},
+		GeneratedAt:   time.Now(),
+	}
+
+	// 1. Выписка: (inv_id, тип) -> сумма; дубликаты в выписке суммируются
+	statement := make(map[string]int64)
+	var statementOrder []string
+	invIDs := make([]string, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		amount := models.ToMinorUnits(line.Amount, currency)
+		if line.Type == models.LedgerEntryRefund {
+			amount = -amount
+		}
+		key := reconciliationKey(line.InvID, line.Type)
+		if _, seen := statement[key]; !seen {
+			statementOrder = append(statementOrder, key)
+			invIDs = append(invIDs, line.InvID)
+		}
+		statement[key] += amount
+		report.StatementTotal += amount
+	}
+
+	// 2. Журнал
+	clearing, err := s.ledgerRepo.FindAccountByCode(db, models.LedgerCodeProviderClearing, currency)
+	if err != nil && !errors.Is(err, repositories.ErrLedgerAccountNotFound) {
+		return nil, apperrors.InternalError(err)
+	}
+	var rows []repositories.LedgerReconciliationRow
+	if clearing != nil {
+		rows, err = s.ledgerRepo.FindReconciliationRows(db, clearing.ID, req.From, req.To, invIDs)
+		if err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+	}
+
+	ledger := make(map[string]repositories.LedgerReconciliationRow)
+	var ledgerOrder []string
+	for _, row := range rows {
+		key := reconciliationKey(row.InvID, row.Kind)
+		if existing, ok := ledger[key]; ok {
+			existing.Amount += row.Amount
+			ledger[key] = existing
+		} else {
+			ledger[key] = row
+			ledgerOrder = append(ledgerOrder, key)
+		}
+		// В итог журнала - только проводки периода, как и в выписке провайдера
+		if !row.PostedAt.Before(req.From) && row.PostedAt.Before(req.To) {
+			report.LedgerTotal += row.Amount
+		}
+	}
+
+	// 3. Сопоставление
+	for _, key := range statementOrder {
+		invID, kind := splitReconciliationKey(key)
+		statementAmount := statement[key]
+		row, ok := ledger[key]
+		if !ok {
+			report.Discrepancies = append(report.Discrepancies, dto.ReconciliationItem{
+				InvID:           invID,
+				Type:            kind,
+				Status:          dto.ReconciliationMissingInLedger,
+				StatementAmount: &statementAmount,
+			})
+			continue
+		}
+		ledgerAmount := row.Amount
+		if ledgerAmount != statementAmount {
+			report.Discrepancies = append(report.Discrepancies, dto.ReconciliationItem{
+				InvID:           invID,
+				Type:            kind,
+				Status:          dto.ReconciliationAmountMismatch,
+				EntryID:         row.EntryID,
+				LedgerAmount:    &ledgerAmount,
+				StatementAmount: &statementAmount,
+			})
+			continue
+		}
+		report.Matched++
+	}
+	for _, key := range ledgerOrder {
+		if _, ok := statement[key]; ok {
+			continue
+		}
+		row := ledger[key]
+		ledgerAmount := row.Amount
+		report.Discrepancies = append(report.Discrepancies, dto.ReconciliationItem{
+			InvID:        row.InvID,
+			Type:         row.Kind,
+			Status:       dto.ReconciliationMissingInStatement,
+			EntryID:      row.EntryID,
+			LedgerAmount: &ledgerAmount,
+		})
+	}
+
+	report.Difference = report.StatementTotal - report.LedgerTotal
+	report.Reconciled = report.Difference == 0 && len(report.Discrepancies) == 0
+	return report, nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// paymentAccounts - счета, участвующие в проводках платежа (создаются при первом использовании)
+func (s *ledgerService) paymentAccounts(db *gorm.DB, userID, currency string) (*models.LedgerAccount, *models.LedgerAccount, *models.LedgerAccount, error) {
+	clearing, err := s.ledgerRepo.FindOrCreateAccount(db, &models.LedgerAccount{
+		Code:     models.LedgerCodeProviderClearing,
+		Name:     "Клиринг Robokassa",
+		Type:     models.LedgerAccountAsset,
+		Currency: currency,
+	})
+	if err != nil {
+		return nil, nil, nil, apperrors.InternalError(err)
+	}
+	wallet, err := s.ledgerRepo.FindOrCreateAccount(db, &models.LedgerAccount{
+		Code:        models.LedgerWalletCode(userID),
+		Name:        "Кошелек пользователя",
+		Type:        models.LedgerAccountLiability,
+		OwnerUserID: &userID,
+		Currency:    currency,
+	})
+	if err != nil {
+		return nil, nil, nil, apperrors.InternalError(err)
+	}
+	revenue, err := s.ledgerRepo.FindOrCreateAccount(db, &models.LedgerAccount{
+		Code:     models.LedgerCodePlatformRevenue,
+		Name:     "Выручка платформы",
+		Type:     models.LedgerAccountRevenue,
+		Currency: currency,
+	})
+	if err != nil {
+		return nil, nil, nil, apperrors.InternalError(err)
+	}
+	return clearing, wallet, revenue, nil
+}
+
+// createEntry - повтор проводки с тем же reference (повторный callback провайдера) не ошибка
+func (s *ledgerService) createEntry(db *gorm.DB, entry *models.LedgerEntry) error {
+	if err := s.ledgerRepo.CreateEntry(db, entry); err != nil {
+		if errors.Is(err, repositories.ErrLedgerEntryExists) {
+			return nil
+		}
+		return handleLedgerError(err)
+	}
+	return nil
+}
+
+func (s *ledgerService) accountStatement(db *gorm.DB, account *models.LedgerAccount, filter *dto.LedgerPostingFilter) (*dto.LedgerPostingListResponse, error) {
+	sum, err := s.ledgerRepo.GetAccountBalance(db, account.ID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	postings, total, err := s.ledgerRepo.FindAccountPostings(db, account.ID, filter.Page, filter.PageSize)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	items := make([]dto.LedgerPostingResponse, 0, len(postings))
+	for _, p := range postings {
+		item := dto.LedgerPostingResponse{
+			ID:       p.ID,
+			EntryID:  p.EntryID,
+			Amount:   p.Amount,
+			Currency: p.Currency,
+		}
+		if p.Entry != nil {
+			item.Kind = p.Entry.Kind
+			item.Reference = p.Entry.Reference
+			item.Description = p.Entry.Description
+			item.PostedAt = p.Entry.PostedAt
+		}
+		items = append(items, item)
+	}
+
+	return &dto.LedgerPostingListResponse{
+		Account:  buildLedgerAccountResponse(account, sum),
+		Postings: items,
+		Total:    total,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}, nil
+}
+
+func buildLedgerAccountResponse(account *models.LedgerAccount, postingsSum int64) dto.LedgerAccountResponse {
+	return dto.LedgerAccountResponse{
+		ID:          account.ID,
+		Code:        account.Code,
+		Name:        account.Name,
+		Type:        string(account.Type),
+		OwnerUserID: account.OwnerUserID,
+		Currency:    account.Currency,
+		Balance:     account.NormalBalance(postingsSum),
+		CreatedAt:   account.CreatedAt,
+	}
+}
+
+func normalizeLedgerPage(page, pageSize *int) {
+	if *page < 1 {
+		*page = 1
+	}
+	if *pageSize < 1 {
+		*pageSize = 20
+	}
+}
+
+func paymentLedgerReference(paymentID, kind string) string {
+	return fmt.Sprintf("payment:%s:%s", paymentID, kind)
+}
+
+func paymentLedgerDescription(payment *models.PaymentTransaction, action string) string {
+	if payment.Description != "" {
+		return fmt.Sprintf("%s %s (%s)", action, payment.InvID, payment.Description)
+	}
+	return fmt.Sprintf("%s %s", action, payment.InvID)
+}
+
+func reconciliationKey(invID, kind string) string {
+	return kind + "|" + invID
+}
+
+func splitReconciliationKey(key string) (string, string) {
+	kind, invID, _ := strings.Cut(key, "|")
+	return invID, kind
+}
+
+func handleLedgerError(err error) error {
+	if errors.Is(err, repositories.ErrLedgerAccountNotFound) ||
+		errors.Is(err, repositories.ErrLedgerEntryNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	if errors.Is(err, repositories.ErrLedgerEntryUnbalanced) {
+		return apperrors.ErrLedgerEntryUnbalanced
+	}
+	return apperrors.InternalError(err)
+}
@@ -3,9 +3,12 @@ package services
 import (
 	"errors"
 	"gorm.io/gorm"
+	"log"
 	"math"
 	"sort"
+	"time"
 
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
 	"mwork_backend/internal/services/dto"
@@ -50,6 +53,7 @@ type matchingService struct {
 	portfolioRepo    repositories.PortfolioRepository
 	notificationRepo repositories.NotificationRepository
 	userRepo         repositories.UserRepository
+	availability     AvailabilityService
 }
 
 // Default matching weights
@@ -61,6 +65,12 @@ var defaultWeights = &dto.MatchingWeights{
 	Specialized:  0.2,
 }
 
+// Учет календаря доступности при подборе
+const (
+	castingAvailabilitySlot = 3 * time.Hour // Сколько времени от CastingDate считается занятым кастингом
+	availabilityPenalty     = 20.0          // Штраф (баллы) за несовпадение с еженедельным графиком
+)
+
 // ✅ Конструктор обновлен (db убран)
 func NewMatchingService(
 	// ❌ 'db *gorm.DB,' УДАЛЕНО
@@ -70,6 +80,7 @@ func NewMatchingService(
 	portfolioRepo repositories.PortfolioRepository,
 	notificationRepo repositories.NotificationRepository,
 	userRepo repositories.UserRepository, // 👈 userRepo добавлен для UpdateMatchingWeights
+	availability AvailabilityService,
 ) MatchingService {
 	return &matchingService{
 		// ❌ 'db: db,' УДАЛЕНО
@@ -79,6 +90,7 @@ func NewMatchingService(
 		portfolioRepo:    portfolioRepo,
 		notificationRepo: notificationRepo,
 		userRepo:         userRepo, // 👈 userRepo добавлен
+		availability:     availability,
 	}
 }
 
@@ -107,19 +119,7 @@ func float64PtrToIntPtr(f *float64) *int {
 
 // FindModelsForCasting - 'db' добавлен
 func (s *matchingService) FindModelsForCasting(db *gorm.DB, casting *models.Casting, limit int) ([]*dto.MatchResult, error) {
-	criteria := &dto.MatchingCasting{
-		City:       casting.City,
-		Categories: casting.GetCategories(),
-		Gender:     casting.Gender,
-		AgeMin:     casting.AgeMin,
-		AgeMax:     casting.AgeMax,
-		HeightMin:  casting.HeightMin,
-		HeightMax:  casting.HeightMax,
-		WeightMin:  casting.WeightMin,
-		WeightMax:  casting.WeightMax,
-		JobType:    casting.JobType,
-		Languages:  casting.GetLanguages(),
-	}
+	criteria := dto.CastingToMatchingDTO(casting)
 
 	matchCriteria := &dto.MatchCriteria{
 		City:       criteria.City,
@@ -134,6 +134,14 @@ func (s *matchingService) FindModelsForCasting(db *gorm.DB, casting *models.Cast
 		Languages:  criteria.Languages,
 		Limit:      limit,
 		MinScore:   50.0,
+
+		Date:             casting.CastingDate,
+		ExcludeCastingID: casting.ID,
+		Requirements:     criteria,
+	}
+	// Модели, заблокированные с работодателем, в подбор не попадают
+	if employerUser, err := s.userRepo.FindByProfileID(db, casting.EmployerID); err == nil {
+		matchCriteria.HiddenForUserID = employerUser.ID
 	}
 
 	// ✅ Передаем 'db'
@@ -190,19 +198,7 @@ func (s *matchingService) CalculateMatchScore(model *models.ModelProfile, castin
 
 // (CalculateMatchScoreWithModel - чистая функция, без изменений)
 func (s *matchingService) CalculateMatchScoreWithModel(model *models.ModelProfile, casting *models.Casting) (*dto.MatchScore, error) {
-	castingDTO := &dto.MatchingCasting{
-		City:       casting.City,
-		Categories: casting.GetCategories(),
-		Gender:     casting.Gender,
-		AgeMin:     casting.AgeMin,
-		AgeMax:     casting.AgeMax,
-		HeightMin:  casting.HeightMin,
-		HeightMax:  casting.HeightMax,
-		WeightMin:  casting.WeightMin,
-		WeightMax:  casting.WeightMax,
-		JobType:    casting.JobType,
-		Languages:  casting.GetLanguages(),
-	}
+	castingDTO := dto.CastingToMatchingDTO(casting)
 
 	return s.CalculateMatchScore(model, castingDTO)
 }
@@ -228,6 +224,12 @@ func (s *matchingService) FindModelsByCriteria(db *gorm.DB, criteria *dto.MatchC
 		Page:       1,
 		PageSize:   criteria.Limit,
 		IsPublic:   &[]bool{true}[0],
+
+		HiddenForUserID: criteria.HiddenForUserID,
+	}
+	if criteria.Date != nil && searchCriteria.PageSize > 0 {
+		// Часть кандидатов может отсеяться по календарю - берем с запасом
+		searchCriteria.PageSize *= 2
 	}
 
 	// ✅ Используем 'db' из параметра
@@ -251,11 +253,17 @@ func (s *matchingService) FindModelsByCriteria(db *gorm.DB, criteria *dto.MatchC
 			JobType:    criteria.JobType,
 			Languages:  criteria.Languages,
 		}
+		if criteria.Requirements != nil {
+			mockCasting = criteria.Requirements
+		}
 
 		score, err := s.CalculateMatchScore(&model, mockCasting)
 		if err != nil {
 			continue
 		}
+		if criteria.DownRankIncomplete {
+			score.TotalScore *= algorithms.CompletenessRankFactor(model.CompletenessScore)
+		}
 
 		if score.TotalScore >= criteria.MinScore {
 			matchResults = append(matchResults, &dto.MatchResult{
@@ -268,6 +276,10 @@ func (s *matchingService) FindModelsByCriteria(db *gorm.DB, criteria *dto.MatchC
 		}
 	}
 
+	if criteria.Date != nil {
+		matchResults = s.applyAvailability(db, matchResults, *criteria.Date, criteria.ExcludeCastingID, criteria.MinScore)
+	}
+
 	sort.Slice(matchResults, func(i, j int) bool {
 		return matchResults[i].Score > matchResults[j].Score
 	})
@@ -298,13 +310,27 @@ func (s *matchingService) GetModelCompatibility(db *gorm.DB, modelID, castingID
 		return nil, apperrors.InternalError(err)
 	}
 
-	return &dto.CompatibilityResult{
+	result := &dto.CompatibilityResult{
 		ModelID:         modelID,
 		CastingID:       castingID,
 		TotalScore:      score.TotalScore,
 		Breakdown:       score.Breakdown,
 		Recommendations: s.generateRecommendations(score, model, casting),
-	}, nil
+	}
+
+	if casting.CastingDate != nil {
+		from := *casting.CastingDate
+		checks, err := s.availability.CheckAvailability(db, []string{model.ID}, from, from.Add(castingAvailabilitySlot), casting.ID)
+		if err != nil {
+			return nil, err
+		}
+		result.Availability = checks[model.ID]
+		if result.Availability.Status == dto.AvailabilityStatusUnavailable || result.Availability.Status == dto.AvailabilityStatusUsuallyUnavailable {
+			result.Recommendations = append(result.Recommendations, "Модель может быть недоступна на дату кастинга: "+result.Availability.Reason)
+		}
+	}
+
+	return result, nil
 }
 
 // FindSimilarModels - 'db' добавлен
@@ -520,6 +546,21 @@ func (s *matchingService) calculatePhysicalScoreDTO(model *models.ModelProfile,
 		}
 		criteriaCount++
 	}
+	// Расширенные параметры: каждое заданное требование - отдельный критерий
+	requirements := algorithms.MeasurementRequirements{
+		ClothingSize: casting.ClothingSize, ShoeSize: casting.ShoeSize,
+		BustMin: casting.BustMin, BustMax: casting.BustMax,
+		WaistMin: casting.WaistMin, WaistMax: casting.WaistMax,
+		HipsMin: casting.HipsMin, HipsMax: casting.HipsMax,
+		HairColors: casting.HairColors, EyeColors: casting.EyeColors,
+		TattoosAllowed: casting.TattoosAllowed, PiercingsAllowed: casting.PiercingsAllowed,
+	}
+	for _, matched := range requirements.Criteria(model) {
+		if matched {
+			score += 50.0
+		}
+		criteriaCount++
+	}
 	if criteriaCount == 0 {
 		return 100.0
 	}
@@ -660,3 +701,47 @@ func handleMatchingError(err error) error {
 	}
 	return apperrors.InternalError(err)
 }
+
+// applyAvailability - исключает занятых на дату моделей и штрафует не подходящих по еженедельному графику.
+// Ошибка календаря не должна ломать подбор: в этом случае результаты возвращаются как есть.
+func (s *matchingService) applyAvailability(db *gorm.DB, results []*dto.MatchResult, date time.Time, excludeCastingID string, minScore float64) []*dto.MatchResult {
+	if len(results) == 0 || s.availability == nil {
+		return results
+	}
+
+	profileIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		profileIDs = append(profileIDs, result.ModelID)
+	}
+
+	checks, err := s.availability.CheckAvailability(db, profileIDs, date, date.Add(castingAvailabilitySlot), excludeCastingID)
+	if err != nil {
+		log.Printf("Failed to check models availability: %v", err)
+		return results
+	}
+
+	filtered := results[:0]
+	for _, result := range results {
+		check := checks[result.ModelID]
+		if check == nil {
+			filtered = append(filtered, result)
+			continue
+		}
+		result.Availability = check
+
+		switch check.Status {
+		case dto.AvailabilityStatusUnavailable:
+			continue
+		case dto.AvailabilityStatusUsuallyUnavailable:
+			result.Score = math.Max(0, result.Score-availabilityPenalty)
+			result.Reasons = append(result.Reasons, check.Reason)
+			if result.Score < minScore {
+				continue
+			}
+		case dto.AvailabilityStatusAvailable:
+			result.Reasons = append(result.Reasons, "Свободна на дату кастинга")
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered
+}
@@ -0,0 +1,277 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"mwork_backend/internal/algorithms"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+
+	"gorm.io/datatypes"
+)
+
+// =======================
+// 1. ДОПУСТИМЫЕ ДИАПАЗОНЫ
+// =======================
+
+// measurementRange - допустимые значения параметра после перевода в см/кг
+type measurementRange struct {
+	name     string
+	min, max float64
+	unit     string
+}
+
+var (
+	heightRange = measurementRange{"height", 100, 250, "cm"}
+	weightRange = measurementRange{"weight", 30, 200, "kg"}
+	bustRange   = measurementRange{"bust", 40, 200, "cm"}
+	waistRange  = measurementRange{"waist", 40, 200, "cm"}
+	hipsRange   = measurementRange{"hips", 40, 200, "cm"}
+	inseamRange = measurementRange{"inseam", 40, 130, "cm"}
+)
+
+func (r measurementRange) check(value float64) error {
+	if value < r.min || value > r.max {
+		return apperrors.ErrInvalidOperation("profile",
+			fmt.Sprintf("%s must be between %g and %g %s", r.name, r.min, r.max, r.unit))
+	}
+	return nil
+}
+
+// =======================
+// 2. ПРОФИЛЬ МОДЕЛИ
+// =======================
+
+// applyModelMeasurements переводит параметры запроса в см/кг и канонические размеры и записывает в профиль.
+// nil - значение не меняется. Пол профиля должен быть уже выставлен: от него зависят числовые размеры.
+func applyModelMeasurements(profile *models.ModelProfile, height, weight *float64, clothingSize, shoeSize *string, m *dto.MeasurementsInput) error {
+	units := m.MeasurementUnits
+
+	lengths := []struct {
+		value  *float64
+		target *float64
+		rng    measurementRange
+	}{
+		{height, &profile.Height, heightRange},
+		{m.Bust, &profile.Bust, bustRange},
+		{m.Waist, &profile.Waist, waistRange},
+		{m.Hips, &profile.Hips, hipsRange},
+		{m.Inseam, &profile.Inseam, inseamRange},
+	}
+	for _, l := range lengths {
+		if l.value == nil {
+			continue
+		}
+		cm := algorithms.ToCentimeters(*l.value, units)
+		if err := l.rng.check(cm); err != nil {
+			return err
+		}
+		*l.target = cm
+	}
+
+	if weight != nil {
+		kg := algorithms.ToKilograms(*weight, units)
+		if err := weightRange.check(kg); err != nil {
+			return err
+		}
+		profile.Weight = kg
+	}
+
+	if clothingSize != nil {
+		size, err := algorithms.NormalizeClothingSize(*clothingSize, m.ClothingSizeSystem, profile.Gender)
+		if err != nil {
+			return sizeError("profile", "clothing_size", err)
+		}
+		profile.ClothingSize = size
+	}
+	if shoeSize != nil {
+		size, err := algorithms.NormalizeShoeSize(*shoeSize, m.ShoeSizeSystem, profile.Gender)
+		if err != nil {
+			return sizeError("profile", "shoe_size", err)
+		}
+		profile.ShoeSizeEU = size
+		profile.ShoeSize = algorithms.FormatShoeSize(size)
+	}
+
+	if m.HairColor != nil {
+		profile.HairColor = *m.HairColor
+	}
+	if m.EyeColor != nil {
+		profile.EyeColor = *m.EyeColor
+	}
+	if m.HasTattoos != nil {
+		profile.HasTattoos = *m.HasTattoos
+	}
+	if m.HasPiercings != nil {
+		profile.HasPiercings = *m.HasPiercings
+	}
+	return nil
+}
+
+// =======================
+// 3. ТРЕБОВАНИЯ КАСТИНГА
+// =======================
+
+// castingRequirementsInput - поля кастинга, которые были в запросе и до расширенных параметров
+type castingRequirementsInput struct {
+	HeightMin, HeightMax *float64
+	WeightMin, WeightMax *float64
+	ClothingSize         *string
+	ShoeSize             *string
+}
+
+// applyCastingRequirements переводит требования кастинга в см/кг и канонические размеры.
+// nil - значение не меняется, пустой размер - требование снимается. Пол кастинга должен быть уже выставлен.
+func applyCastingRequirements(casting *models.Casting, base castingRequirementsInput, r *dto.CastingMeasurementRequirements) error {
+	units := r.MeasurementUnits
+
+	lengths := []struct {
+		value  *float64
+		target **float64
+	}{
+		{base.HeightMin, &casting.HeightMin}, {base.HeightMax, &casting.HeightMax},
+		{r.BustMin, &casting.BustMin}, {r.BustMax, &casting.BustMax},
+		{r.WaistMin, &casting.WaistMin}, {r.WaistMax, &casting.WaistMax},
+		{r.HipsMin, &casting.HipsMin}, {r.HipsMax, &casting.HipsMax},
+	}
+	for _, l := range lengths {
+		if l.value != nil {
+			cm := algorithms.ToCentimeters(*l.value, units)
+			*l.target = &cm
+		}
+	}
+	for _, w := range []struct {
+		value  *float64
+		target **float64
+	}{{base.WeightMin, &casting.WeightMin}, {base.WeightMax, &casting.WeightMax}} {
+		if w.value != nil {
+			kg := algorithms.ToKilograms(*w.value, units)
+			*w.target = &kg
+		}
+	}
+
+	if base.ClothingSize != nil {
+		casting.ClothingSize = nil
+		if *base.ClothingSize != "" {
+			size, err := algorithms.NormalizeClothingSize(*base.ClothingSize, r.ClothingSizeSystem, casting.Gender)
+			if err != nil {
+				return sizeError("casting", "clothing_size", err)
+			}
+			casting.ClothingSize = &size
+		}
+	}
+	if base.ShoeSize != nil {
+		casting.ShoeSize = nil
+		if *base.ShoeSize != "" {
+			size, err := algorithms.NormalizeShoeSize(*base.ShoeSize, r.ShoeSizeSystem, casting.Gender)
+			if err != nil {
+				return sizeError("casting", "shoe_size", err)
+			}
+			formatted := algorithms.FormatShoeSize(size)
+			casting.ShoeSize = &formatted
+		}
+	}
+
+	if r.HairColors != nil {
+		data, _ := json.Marshal(r.HairColors)
+		casting.HairColors = datatypes.JSON(data)
+	}
+	if r.EyeColors != nil {
+		data, _ := json.Marshal(r.EyeColors)
+		casting.EyeColors = datatypes.JSON(data)
+	}
+	if r.TattoosAllowed != nil {
+		casting.TattoosAllowed = r.TattoosAllowed
+	}
+	if r.PiercingsAllowed != nil {
+		casting.PiercingsAllowed = r.PiercingsAllowed
+	}
+
+	// Порядок границ проверяется по итоговым значениям: при обновлении вторая граница может быть старой
+	for _, b := range []struct {
+		name     string
+		min, max *float64
+	}{
+		{"bust", casting.BustMin, casting.BustMax},
+		{"waist", casting.WaistMin, casting.WaistMax},
+		{"hips", casting.HipsMin, casting.HipsMax},
+	} {
+		if b.min != nil && b.max != nil && *b.min > *b.max {
+			return apperrors.ErrInvalidOperation("casting", fmt.Sprintf("%s_min must not exceed %s_max", b.name, b.name))
+		}
+	}
+	return nil
+}
+
+// =======================
+// 4. ПОИСК МОДЕЛЕЙ
+// =======================
+
+// applyMeasurementFilters переносит фильтры по параметрам в критерии поиска, переводя размеры в канонический вид
+func applyMeasurementFilters(criteria *repositories.ModelSearchCriteria, req *dto.SearchModelsRequest) error {
+	for _, b := range []struct {
+		name     string
+		min, max *int
+	}{
+		{"bust", req.MinBust, req.MaxBust},
+		{"waist", req.MinWaist, req.MaxWaist},
+		{"hips", req.MinHips, req.MaxHips},
+	} {
+		if b.min != nil && b.max != nil && *b.min > *b.max {
+			return apperrors.ErrInvalidOperation("search", fmt.Sprintf("min_%s must not exceed max_%s", b.name, b.name))
+		}
+	}
+
+	criteria.MinBust, criteria.MaxBust = req.MinBust, req.MaxBust
+	criteria.MinWaist, criteria.MaxWaist = req.MinWaist, req.MaxWaist
+	criteria.MinHips, criteria.MaxHips = req.MinHips, req.MaxHips
+	criteria.HairColors = req.HairColors
+	criteria.EyeColors = req.EyeColors
+	criteria.HasTattoos = req.HasTattoos
+	criteria.HasPiercings = req.HasPiercings
+
+	for _, value := range req.ClothingSizes {
+		size, err := algorithms.NormalizeClothingSize(value, req.ClothingSizeSystem, req.Gender)
+		if err != nil {
+			return sizeError("search", "clothing_sizes", err)
+		}
+		if size != "" {
+			criteria.ClothingSizes = append(criteria.ClothingSizes, size)
+		}
+	}
+
+	shoeBounds := []struct {
+		value  string
+		target **float64
+	}{
+		{req.MinShoeSize, &criteria.MinShoeSize},
+		{req.MaxShoeSize, &criteria.MaxShoeSize},
+	}
+	for _, b := range shoeBounds {
+		if b.value == "" {
+			continue
+		}
+		size, err := algorithms.NormalizeShoeSize(b.value, req.ShoeSizeSystem, req.Gender)
+		if err != nil {
+			return sizeError("search", "shoe_size", err)
+		}
+		*b.target = &size
+	}
+	return nil
+}
+
+// =======================
+// 5. ХЕЛПЕРЫ
+// =======================
+
+func sizeError(domain, field string, err error) error {
+	if errors.Is(err, algorithms.ErrSizeGenderRequired) {
+		return apperrors.ErrInvalidOperation(domain,
+			fmt.Sprintf("%s: set gender or use international letters (XS-XXL) for this size", field))
+	}
+	return apperrors.ErrInvalidOperation(domain, fmt.Sprintf("%s: %v", field, err))
+}
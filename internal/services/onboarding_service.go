@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/algorithms"
+	"mwork_backend/internal/email"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/pkg/apperrors"
+)
+
+const (
+	// completenessNudgeInterval - не чаще одного напоминания в неделю
+	completenessNudgeInterval = 7 * 24 * time.Hour
+	// completenessNudgeGrace - новым профилям даем сутки на заполнение
+	completenessNudgeGrace = 24 * time.Hour
+	// completenessNudgeBatch - профилей за один запуск
+	completenessNudgeBatch = 200
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type OnboardingService interface {
+	// Фоновая задача: напоминания моделям с незаполненным профилем
+	SendCompletenessNudges(db *gorm.DB) error
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type onboardingService struct {
+	profileRepo      repositories.ProfileRepository
+	portfolioRepo    repositories.PortfolioRepository
+	userRepo         repositories.UserRepository
+	notificationRepo repositories.NotificationRepository
+	emailProvider    email.Provider
+}
+
+func NewOnboardingService(
+	profileRepo repositories.ProfileRepository,
+	portfolioRepo repositories.PortfolioRepository,
+	userRepo repositories.UserRepository,
+	notificationRepo repositories.NotificationRepository,
+	emailProvider email.Provider,
+) OnboardingService {
+	return &onboardingService{
+		profileRepo:      profileRepo,
+		portfolioRepo:    portfolioRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		emailProvider:    emailProvider,
+	}
+}
+
+// SendCompletenessNudges - уведомление и письмо со списком недостающих пунктов.
+// Балл пересчитывается перед отправкой: дозаполненные профили только обновляют сохраненный балл.
+func (s *onboardingService) SendCompletenessNudges(db *gorm.DB) error {
+	now := time.Now()
+	profiles, err := s.profileRepo.FindIncompleteModelProfiles(db, algorithms.CompleteProfileThreshold,
+		now.Add(-completenessNudgeInterval), now.Add(-completenessNudgeGrace), completenessNudgeBatch)
+	if err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	for i := range profiles {
+		profile := &profiles[i]
+		score, checklist := modelCompletenessScore(db, s.portfolioRepo, profile)
+		if score != profile.CompletenessScore {
+			if err := s.profileRepo.UpdateModelCompleteness(db, profile.ID, score); err != nil {
+				return apperrors.InternalError(err)
+			}
+		}
+		if score >= algorithms.CompleteProfileThreshold {
+			continue
+		}
+
+		completeness := buildProfileCompleteness(score, checklist, true)
+		if err := s.notificationRepo.CreateProfileCompletenessNotification(db, profile.UserID, score, completeness.Missing); err != nil {
+			return apperrors.InternalError(err)
+		}
+		// Письмо - по возможности: отметка о напоминании ставится и без него
+		if err := s.sendCompletenessEmail(db, profile.UserID, profile.Name, score, checklist); err != nil {
+			log.Printf("failed to email completeness nudge to user %s: %v", profile.UserID, err)
+		}
+		if err := s.profileRepo.MarkCompletenessNudged(db, profile.ID, now); err != nil {
+			return apperrors.InternalError(err)
+		}
+	}
+	return nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+func (s *onboardingService) sendCompletenessEmail(db *gorm.DB, userID, name string, score int, checklist []algorithms.CompletenessItem) error {
+	if s.emailProvider == nil {
+		return nil
+	}
+	user, err := s.userRepo.FindByID(db, userID)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, item := range checklist {
+		if !item.Done {
+			missing = append(missing, item.Label)
+		}
+	}
+	data := map[string]interface{}{
+		"Name":       name,
+		"Score":      score,
+		"Missing":    missing,
+		"ProfileURL": "https://mwork.ru/profile/edit",
+	}
+	return s.emailProvider.SendTemplate([]string{user.Email}, fmt.Sprintf("Ваш профиль заполнен на %d%%", score),
+		"profile_completeness", data)
+}
@@ -0,0 +1,391 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/imageprocessor"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/internal/storage"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type PhotoModerationService interface {
+	GetQueue(ctx context.Context, db *gorm.DB, query *dto.PhotoModerationQueueQuery) ([]*dto.ModerationPhoto, int64, error)
+	ApprovePhotos(db *gorm.DB, moderatorID string, req *dto.ApprovePhotosRequest) (*dto.PhotoModerationResult, error)
+	RejectPhotos(db *gorm.DB, moderatorID string, req *dto.RejectPhotosRequest) (*dto.PhotoModerationResult, error)
+
+	// Доверенные пользователи
+	SetAutoApprove(db *gorm.DB, moderatorID, userID string, req *dto.PhotoAutoApproveRequest) error
+
+	// Похожие фото разных пользователей (украденные снимки)
+	GetDuplicateMatches(ctx context.Context, db *gorm.DB, query *dto.DuplicateMatchQueueQuery) ([]*dto.DuplicatePhotoMatch, int64, error)
+	ResolveDuplicateMatch(db *gorm.DB, moderatorID, matchID string, req *dto.ResolveDuplicateMatchRequest) (*models.DuplicatePhotoMatch, error)
+	HashUnhashedImages(ctx context.Context, db *gorm.DB, batchSize int) (int, error)
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type photoModerationService struct {
+	uploadRepo       repositories.UploadRepository
+	userRepo         repositories.UserRepository
+	notificationRepo repositories.NotificationRepository
+	duplicateRepo    repositories.DuplicatePhotoRepository
+	profileRepo      repositories.ProfileRepository
+	portfolioRepo    repositories.PortfolioRepository
+	storage          storage.Storage
+}
+
+func NewPhotoModerationService(
+	uploadRepo repositories.UploadRepository,
+	userRepo repositories.UserRepository,
+	notificationRepo repositories.NotificationRepository,
+	duplicateRepo repositories.DuplicatePhotoRepository,
+	profileRepo repositories.ProfileRepository,
+	portfolioRepo repositories.PortfolioRepository,
+	storage storage.Storage,
+) PhotoModerationService {
+	return &photoModerationService{
+		uploadRepo:       uploadRepo,
+		userRepo:         userRepo,
+		notificationRepo: notificationRepo,
+		duplicateRepo:    duplicateRepo,
+		profileRepo:      profileRepo,
+		portfolioRepo:    portfolioRepo,
+		storage:          storage,
+	}
+}
+
+// GetQueue - очередь модерации; по умолчанию ожидающие проверки, старые первыми
+func (s *photoModerationService) GetQueue(ctx context.Context, db *gorm.DB, query *dto.PhotoModerationQueueQuery) ([]*dto.ModerationPhoto, int64, error) {
+	status := models.ModerationStatus(query.Status)
+	if status == "" {
+		status = models.ModerationStatusPending
+	}
+	uploads, total, err := s.uploadRepo.FindModerationQueue(db, repositories.PhotoModerationCriteria{
+		Status:   status,
+		Usage:    query.Usage,
+		UserID:   query.UserID,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	})
+	if err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+
+	photos := make([]*dto.ModerationPhoto, 0, len(uploads))
+	for _, upload := range uploads {
+		photos = append(photos, &dto.ModerationPhoto{Upload: upload, URL: s.photoURL(ctx, upload)})
+	}
+	return photos, total, nil
+}
+
+// ApprovePhotos - одобренные фото становятся видны другим пользователям
+// (в том числе ранее отклоненные - модератор может пересмотреть решение)
+func (s *photoModerationService) ApprovePhotos(db *gorm.DB, moderatorID string, req *dto.ApprovePhotosRequest) (*dto.PhotoModerationResult, error) {
+	return s.decide(db, moderatorID, req.UploadIDs, models.ModerationStatusApproved, nil)
+}
+
+// RejectPhotos - отклоненные фото скрываются (в том числе уже опубликованные), владельцы получают уведомление с причиной
+func (s *photoModerationService) RejectPhotos(db *gorm.DB, moderatorID string, req *dto.RejectPhotosRequest) (*dto.PhotoModerationResult, error) {
+	return s.decide(db, moderatorID, req.UploadIDs, models.ModerationStatusRejected, &req.Reason)
+}
+
+// SetAutoApprove - новые фото доверенного пользователя публикуются сразу; по запросу одобряются и ожидающие
+func (s *photoModerationService) SetAutoApprove(db *gorm.DB, moderatorID, userID string, req *dto.PhotoAutoApproveRequest) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	if err := s.userRepo.SetPhotoAutoApprove(tx, userID, req.Enabled); err != nil {
+		return handlePhotoModerationError(err)
+	}
+
+	if req.ApprovePending {
+		pending, _, err := s.uploadRepo.FindModerationQueue(tx, repositories.PhotoModerationCriteria{
+			Status:   models.ModerationStatusPending,
+			UserID:   userID,
+			Page:     1,
+			PageSize: maxPendingApprovedOnTrust,
+		})
+		if err != nil {
+			return apperrors.InternalError(err)
+		}
+		if len(pending) > 0 {
+			ids := make([]string, 0, len(pending))
+			for _, upload := range pending {
+				ids = append(ids, upload.ID)
+			}
+			if err := s.uploadRepo.SetModerationStatus(tx, ids, models.ModerationStatusApproved, moderatorID, nil); err != nil {
+				return apperrors.InternalError(err)
+			}
+			if err := s.refreshOwnersCompleteness(tx, []string{userID}); err != nil {
+				return apperrors.InternalError(err)
+			}
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// GetDuplicateMatches - очередь похожих фото разных пользователей, старые первыми
+func (s *photoModerationService) GetDuplicateMatches(ctx context.Context, db *gorm.DB, query *dto.DuplicateMatchQueueQuery) ([]*dto.DuplicatePhotoMatch, int64, error) {
+	status := models.DuplicatePhotoMatchStatus(query.Status)
+	if status == "" {
+		status = models.DuplicateMatchPending
+	}
+	matches, total, err := s.duplicateRepo.FindMatches(db, repositories.DuplicateMatchCriteria{
+		Status:   status,
+		UserID:   query.UserID,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	})
+	if err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+
+	result := make([]*dto.DuplicatePhotoMatch, 0, len(matches))
+	for _, match := range matches {
+		item := &dto.DuplicatePhotoMatch{Match: match}
+		if match.Upload != nil {
+			item.URL = s.photoURL(ctx, match.Upload)
+		}
+		if match.MatchedUpload != nil {
+			item.MatchedURL = s.photoURL(ctx, match.MatchedUpload)
+		}
+		result = append(result, item)
+	}
+	return result, total, nil
+}
+
+// ResolveDuplicateMatch - подтвержденное совпадение отклоняет новое фото (владелец получает
+// уведомление с причиной); отклоненное совпадение оставляет фото в обычной очереди модерации
+func (s *photoModerationService) ResolveDuplicateMatch(db *gorm.DB, moderatorID, matchID string, req *dto.ResolveDuplicateMatchRequest) (*models.DuplicatePhotoMatch, error) {
+	match, err := s.duplicateRepo.FindMatchByID(db, matchID)
+	if err != nil {
+		return nil, handlePhotoModerationError(err)
+	}
+	if match.Status != models.DuplicateMatchPending {
+		return nil, apperrors.ErrInvalidOperation("photo_moderation", "duplicate match is already resolved")
+	}
+
+	match.Status = models.DuplicateMatchDismissed
+	if req.Action == "confirm" {
+		reason := req.Reason
+		if reason == "" {
+			reason = defaultDuplicateRejectionReason
+		}
+		if _, err := s.decide(db, moderatorID, []string{match.UploadID}, models.ModerationStatusRejected, &reason); err != nil {
+			return nil, err
+		}
+		match.Status = models.DuplicateMatchConfirmed
+	}
+
+	now := time.Now()
+	match.ReviewedBy = &moderatorID
+	match.ReviewedAt = &now
+	if err := s.duplicateRepo.UpdateMatchStatus(db, match); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return match, nil
+}
+
+// HashUnhashedImages - хэширует изображения, загруженные до появления проверки, и сверяет
+// аватары и фото портфолио с уже хэшированными фото других пользователей.
+// Возвращает количество обработанных файлов (меньше batchSize - очередь пуста).
+func (s *photoModerationService) HashUnhashedImages(ctx context.Context, db *gorm.DB, batchSize int) (int, error) {
+	uploads, err := s.duplicateRepo.FindUnhashedImages(db, batchSize)
+	if err != nil {
+		return 0, apperrors.InternalError(err)
+	}
+
+	for _, upload := range uploads {
+		hash, err := s.hashStoredImage(ctx, upload)
+		if err != nil {
+			log.Printf("WARN: perceptual hash failed for upload %s: %v", upload.ID, err)
+			if err := s.duplicateRepo.MarkPerceptualHashFailed(db, upload.ID); err != nil {
+				return 0, apperrors.InternalError(err)
+			}
+			continue
+		}
+
+		tx := db.Begin()
+		if tx.Error != nil {
+			return 0, apperrors.InternalError(tx.Error)
+		}
+		if err := s.indexPerceptualHash(tx, upload, hash); err != nil {
+			tx.Rollback()
+			return 0, apperrors.InternalError(err)
+		}
+		if err := tx.Commit().Error; err != nil {
+			return 0, apperrors.InternalError(err)
+		}
+	}
+	return len(uploads), nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// defaultDuplicateRejectionReason - причина отклонения подтвержденной копии чужого фото
+const defaultDuplicateRejectionReason = "This photo matches a photo of another user"
+
+// photoURL - адрес фото для модератора
+func (s *photoModerationService) photoURL(ctx context.Context, upload *models.Upload) string {
+	if s.storage != nil {
+		if url, err := s.storage.GetURL(ctx, upload.Path); err == nil {
+			return url
+		}
+	}
+	return fmt.Sprintf("/api/v1/files/%s", upload.ID)
+}
+
+// hashStoredImage - перцептивный хэш файла из хранилища
+func (s *photoModerationService) hashStoredImage(ctx context.Context, upload *models.Upload) (uint64, error) {
+	reader, err := s.storage.Get(ctx, upload.Path)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return 0, err
+	}
+	return imageprocessor.PerceptualHash(data)
+}
+
+// indexPerceptualHash - сохраняет хэш и заносит совпадения с чужими фото в очередь проверки
+func (s *photoModerationService) indexPerceptualHash(db *gorm.DB, upload *models.Upload, hash uint64) error {
+	if err := s.duplicateRepo.SetPerceptualHash(db, upload.ID, hash); err != nil {
+		return err
+	}
+	if !models.IsModeratedUsage(upload.Usage) {
+		return nil
+	}
+	similar, err := s.duplicateRepo.FindSimilarUploads(db, hash, duplicatePhotoMaxDistance, upload.UserID, maxDuplicateMatches)
+	if err != nil {
+		return err
+	}
+	return s.duplicateRepo.CreateMatches(db, duplicateMatches(upload, similar))
+}
+
+// refreshOwnersCompleteness - в заполненность профиля идут только одобренные фото,
+// поэтому после решения модератора она пересчитывается (у пользователей без профиля модели - пропуск)
+func (s *photoModerationService) refreshOwnersCompleteness(db *gorm.DB, userIDs []string) error {
+	for _, userID := range userIDs {
+		profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+		if errors.Is(err, repositories.ErrProfileNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := refreshModelCompleteness(db, s.profileRepo, s.portfolioRepo, profile.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxPendingApprovedOnTrust - сколько ожидающих фото одобряется при выдаче доверия
+const maxPendingApprovedOnTrust = 500
+
+// decide - решение по пакету фото: отсутствующие и не подлежащие модерации файлы
+// попадают в результат с ошибкой, остальные обновляются в одной транзакции
+func (s *photoModerationService) decide(db *gorm.DB, moderatorID string, uploadIDs []string, status models.ModerationStatus, reason *string) (*dto.PhotoModerationResult, error) {
+	uploads, err := s.uploadRepo.FindByIDs(db, uploadIDs)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	byID := make(map[string]*models.Upload, len(uploads))
+	for _, upload := range uploads {
+		byID[upload.ID] = upload
+	}
+
+	result := &dto.PhotoModerationResult{Total: len(uploadIDs)}
+	var accepted, owners []string
+	rejectedByOwner := map[string][]string{}
+	seenOwners := map[string]bool{}
+	seen := map[string]bool{}
+	for _, id := range uploadIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		upload, ok := byID[id]
+		switch {
+		case !ok:
+			result.Results = append(result.Results, dto.PhotoModerationItemResult{UploadID: id, Error: "upload not found"})
+			continue
+		case !models.IsModeratedUsage(upload.Usage):
+			result.Results = append(result.Results, dto.PhotoModerationItemResult{UploadID: id, Error: "upload is not subject to photo moderation"})
+			continue
+		}
+
+		accepted = append(accepted, id)
+		if !seenOwners[upload.UserID] {
+			seenOwners[upload.UserID] = true
+			owners = append(owners, upload.UserID)
+		}
+		if status == models.ModerationStatusRejected && upload.ModerationStatus != models.ModerationStatusRejected {
+			rejectedByOwner[upload.UserID] = append(rejectedByOwner[upload.UserID], id)
+		}
+		result.Results = append(result.Results, dto.PhotoModerationItemResult{UploadID: id, Success: true, Status: status})
+	}
+
+	if len(accepted) > 0 {
+		tx := db.Begin()
+		if tx.Error != nil {
+			return nil, apperrors.InternalError(tx.Error)
+		}
+		defer tx.Rollback()
+
+		if err := s.uploadRepo.SetModerationStatus(tx, accepted, status, moderatorID, reason); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		for ownerID, ids := range rejectedByOwner {
+			if err := s.notificationRepo.CreatePhotoRejectedNotification(tx, ownerID, ids, *reason); err != nil {
+				return nil, apperrors.InternalError(err)
+			}
+		}
+		if err := s.refreshOwnersCompleteness(tx, owners); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+	}
+
+	for _, item := range result.Results {
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+func handlePhotoModerationError(err error) error {
+	if errors.Is(err, repositories.ErrUserNotFound) || errors.Is(err, repositories.ErrDuplicateMatchNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	return apperrors.InternalError(err)
+}
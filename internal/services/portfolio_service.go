@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"sort"
+	"time"
 
 	"gorm.io/gorm"
 
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
 	"mwork_backend/internal/services/dto" // <-- Используем DTO универсального сервиса
@@ -22,14 +25,32 @@ import (
 type PortfolioService interface {
 	// Portfolio operations
 	CreatePortfolioItem(ctx context.Context, db *gorm.DB, userID string, req *dto.CreatePortfolioRequest, file *multipart.FileHeader) (*dto.PortfolioResponse, error)
-	GetPortfolioItem(ctx context.Context, db *gorm.DB, itemID string) (*dto.PortfolioResponse, error)
-	GetModelPortfolio(ctx context.Context, db *gorm.DB, modelID string) ([]*dto.PortfolioResponse, error)
+	GetPortfolioItem(ctx context.Context, db *gorm.DB, itemID, requesterID string) (*dto.PortfolioResponse, error)
+	GetModelPortfolio(ctx context.Context, db *gorm.DB, modelID, requesterID string) ([]*dto.PortfolioResponse, error)
 	UpdatePortfolioItem(ctx context.Context, db *gorm.DB, userID, itemID string, req *dto.UpdatePortfolioRequest) error
 	UpdatePortfolioOrder(ctx context.Context, db *gorm.DB, userID string, req *dto.ReorderPortfolioRequest) error
 	DeletePortfolioItem(ctx context.Context, db *gorm.DB, userID, itemID string) error
 	GetPortfolioStats(ctx context.Context, db *gorm.DB, modelID string) (*repositories.PortfolioStats, error)
 	TogglePortfolioVisibility(ctx context.Context, db *gorm.DB, userID, itemID string, req *dto.PortfolioVisibilityRequest) error
 
+	// Albums
+	CreateAlbum(ctx context.Context, db *gorm.DB, userID string, req *dto.CreateAlbumRequest) (*dto.AlbumResponse, error)
+	GetModelAlbums(ctx context.Context, db *gorm.DB, modelID, requesterID string) ([]*dto.AlbumResponse, error)
+	GetAlbum(ctx context.Context, db *gorm.DB, albumID, requesterID string) (*dto.AlbumResponse, error)
+	UpdateAlbum(ctx context.Context, db *gorm.DB, userID, albumID string, req *dto.UpdateAlbumRequest) (*dto.AlbumResponse, error)
+	DeleteAlbum(ctx context.Context, db *gorm.DB, userID, albumID string) error
+	MovePortfolioItems(ctx context.Context, db *gorm.DB, userID string, req *dto.MovePortfolioItemsRequest) error
+
+	// Likes & saves
+	LikeItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error)
+	UnlikeItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error)
+	SaveItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error)
+	UnsaveItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error)
+	GetSavedItems(ctx context.Context, db *gorm.DB, userID string, page, pageSize int) ([]*dto.PortfolioResponse, int64, error)
+
+	// Featured ranking (фоновая задача)
+	RecomputeFeaturedScores(ctx context.Context, db *gorm.DB) (int, error)
+
 	// Combined operations
 	// (CreatePortfolioWithUpload и DeletePortfolioWithUpload удалены, т.к. стали дубликатами)
 	GetFeaturedPortfolio(ctx context.Context, db *gorm.DB, limit int) (*dto.PortfolioListResponse, error)
@@ -144,6 +165,11 @@ func (s *portfolioService) CreatePortfolioItem(ctx context.Context, db *gorm.DB,
 		return nil, apperrors.InternalError(err)
 	}
 
+	// Новое фото влияет на заполненность профиля
+	if err := refreshModelCompleteness(tx, s.profileRepo, s.portfolioRepo, targetModelProfile.ID); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
 	// 4. Коммитим транзакцию
 	if err := tx.Commit().Error; err != nil {
 		return nil, apperrors.InternalError(err)
@@ -158,26 +184,51 @@ func (s *portfolioService) CreatePortfolioItem(ctx context.Context, db *gorm.DB,
 	return s.buildPortfolioResponse(db, newItem), nil
 }
 
-func (s *portfolioService) GetPortfolioItem(ctx context.Context, db *gorm.DB, itemID string) (*dto.PortfolioResponse, error) {
-	item, err := s.portfolioRepo.FindPortfolioItemByID(db, itemID)
+// GetPortfolioItem - фото на модерации, отклоненное или из закрытого альбома другим пользователям не показывается (404)
+func (s *portfolioService) GetPortfolioItem(ctx context.Context, db *gorm.DB, itemID, requesterID string) (*dto.PortfolioResponse, error) {
+	item, err := s.findVisibleItem(db, itemID, requesterID)
 	if err != nil {
-		return nil, handlePortfolioError(err)
+		return nil, err
 	}
-	return s.buildPortfolioResponse(db, item), nil
+	responses := []*dto.PortfolioResponse{s.buildPortfolioResponse(db, item)}
+	if err := s.applyReactions(db, requesterID, responses); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return responses[0], nil
 }
 
-func (s *portfolioService) GetModelPortfolio(ctx context.Context, db *gorm.DB, modelID string) ([]*dto.PortfolioResponse, error) {
+// GetModelPortfolio - владелец и админы видят все элементы со статусом модерации, остальные -
+// только одобренные и не лежащие в закрытых альбомах
+func (s *portfolioService) GetModelPortfolio(ctx context.Context, db *gorm.DB, modelID, requesterID string) ([]*dto.PortfolioResponse, error) {
 	items, err := s.portfolioRepo.FindPortfolioByModel(db, modelID)
 	if err != nil {
 		return nil, apperrors.InternalError(err)
 	}
+	showAll := s.canSeeHidden(db, modelID, requesterID)
+
+	privateAlbums := map[string]bool{}
+	if !showAll {
+		albums, err := s.portfolioRepo.FindAlbumsByModel(db, modelID)
+		if err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		for _, album := range albums {
+			privateAlbums[album.ID] = !album.IsPublic()
+		}
+	}
 
 	var responses []*dto.PortfolioResponse
 	for _, item := range items {
+		if !showAll && (!isPortfolioItemApproved(&item) || (item.AlbumID != nil && privateAlbums[*item.AlbumID])) {
+			continue
+		}
 		// item уже содержит item.Upload благодаря Preload в репозитории
 		responses = append(responses, s.buildPortfolioResponse(db, &item))
 	}
 
+	if err := s.applyReactions(db, requesterID, responses); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
 	return responses, nil
 }
 
@@ -230,6 +281,13 @@ func (s *portfolioService) UpdatePortfolioOrder(ctx context.Context, db *gorm.DB
 		return errors.New("model profile not found")
 	}
 
+	// Порядок элементов внутри альбома: все элементы должны лежать в этом альбоме
+	if req.AlbumID != "" {
+		if _, err := s.findOwnedAlbum(tx, modelProfile.ID, req.AlbumID); err != nil {
+			return err
+		}
+	}
+
 	for _, itemID := range req.ItemIDs {
 		item, err := s.portfolioRepo.FindPortfolioItemByID(tx, itemID)
 		if err != nil {
@@ -238,10 +296,32 @@ func (s *portfolioService) UpdatePortfolioOrder(ctx context.Context, db *gorm.DB
 		if item.ModelID != modelProfile.ID {
 			return errors.New("access denied for some items")
 		}
+		if req.AlbumID != "" && (item.AlbumID == nil || *item.AlbumID != req.AlbumID) {
+			return apperrors.ErrInvalidOperation("portfolio", "item "+itemID+" is not in this album")
+		}
 	}
 
-	if err := s.portfolioRepo.ReorderPortfolioItems(tx, modelProfile.ID, req.ItemIDs); err != nil {
-		return apperrors.InternalError(err)
+	if len(req.ItemIDs) > 0 {
+		if req.AlbumID != "" {
+			err = s.portfolioRepo.ReorderAlbumItems(tx, req.AlbumID, req.ItemIDs)
+		} else {
+			err = s.portfolioRepo.ReorderPortfolioItems(tx, modelProfile.ID, req.ItemIDs)
+		}
+		if err != nil {
+			return apperrors.InternalError(err)
+		}
+	}
+
+	// Порядок самих альбомов
+	if len(req.AlbumIDs) > 0 {
+		for _, albumID := range req.AlbumIDs {
+			if _, err := s.findOwnedAlbum(tx, modelProfile.ID, albumID); err != nil {
+				return err
+			}
+		}
+		if err := s.portfolioRepo.ReorderAlbums(tx, modelProfile.ID, req.AlbumIDs); err != nil {
+			return apperrors.InternalError(err)
+		}
 	}
 	return tx.Commit().Error
 }
@@ -281,6 +361,10 @@ func (s *portfolioService) DeletePortfolioItem(ctx context.Context, db *gorm.DB,
 		}
 	}
 
+	if err := refreshModelCompleteness(tx, s.profileRepo, s.portfolioRepo, modelProfile.ID); err != nil {
+		return apperrors.InternalError(err)
+	}
+
 	return tx.Commit().Error
 }
 
@@ -316,6 +400,273 @@ func (s *portfolioService) TogglePortfolioVisibility(ctx context.Context, db *go
 	return tx.Commit().Error
 }
 
+// Albums
+
+func (s *portfolioService) CreateAlbum(ctx context.Context, db *gorm.DB, userID string, req *dto.CreateAlbumRequest) (*dto.AlbumResponse, error) {
+	modelProfile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, apperrors.NewForbiddenError("Albums can only be created by a user with a Model profile.")
+	}
+
+	album := &models.PortfolioAlbum{
+		ModelID:     modelProfile.ID,
+		Title:       req.Title,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+	}
+	if album.Visibility == "" {
+		album.Visibility = models.AlbumVisibilityPublic
+	}
+	if err := s.portfolioRepo.CreateAlbum(db, album); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.buildAlbumResponse(db, album, nil, false), nil
+}
+
+// GetModelAlbums - альбомы модели с обложкой и числом элементов; закрытые альбомы видят только владелец и админы
+func (s *portfolioService) GetModelAlbums(ctx context.Context, db *gorm.DB, modelID, requesterID string) ([]*dto.AlbumResponse, error) {
+	albums, err := s.portfolioRepo.FindAlbumsByModel(db, modelID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	items, err := s.portfolioRepo.FindPortfolioByModel(db, modelID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	showAll := s.canSeeHidden(db, modelID, requesterID)
+
+	byAlbum := map[string][]models.PortfolioItem{}
+	for _, item := range items {
+		if item.AlbumID == nil || (!showAll && !isPortfolioItemApproved(&item)) {
+			continue
+		}
+		byAlbum[*item.AlbumID] = append(byAlbum[*item.AlbumID], item)
+	}
+
+	responses := make([]*dto.AlbumResponse, 0, len(albums))
+	for i := range albums {
+		if !showAll && !albums[i].IsPublic() {
+			continue
+		}
+		albumItems := byAlbum[albums[i].ID]
+		sort.SliceStable(albumItems, func(a, b int) bool {
+			return albumItems[a].AlbumOrderIndex < albumItems[b].AlbumOrderIndex
+		})
+		responses = append(responses, s.buildAlbumResponse(db, &albums[i], albumItems, false))
+	}
+	return responses, nil
+}
+
+// GetAlbum - альбом с элементами по порядку; закрытый альбом для посторонних не существует (404)
+func (s *portfolioService) GetAlbum(ctx context.Context, db *gorm.DB, albumID, requesterID string) (*dto.AlbumResponse, error) {
+	album, err := s.portfolioRepo.FindAlbumByID(db, albumID)
+	if err != nil {
+		return nil, handlePortfolioError(err)
+	}
+	showAll := s.canSeeHidden(db, album.ModelID, requesterID)
+	if !showAll && !album.IsPublic() {
+		return nil, apperrors.ErrNotFound(repositories.ErrPortfolioAlbumNotFound)
+	}
+
+	items, err := s.portfolioRepo.FindAlbumItems(db, albumID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	visible := make([]models.PortfolioItem, 0, len(items))
+	for _, item := range items {
+		if showAll || isPortfolioItemApproved(&item) {
+			visible = append(visible, item)
+		}
+	}
+	return s.buildAlbumResponse(db, album, visible, true), nil
+}
+
+func (s *portfolioService) UpdateAlbum(ctx context.Context, db *gorm.DB, userID, albumID string, req *dto.UpdateAlbumRequest) (*dto.AlbumResponse, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	modelProfile, err := s.profileRepo.FindModelProfileByUserID(tx, userID)
+	if err != nil {
+		return nil, apperrors.NewForbiddenError("Access denied")
+	}
+	album, err := s.findOwnedAlbum(tx, modelProfile.ID, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		album.Title = *req.Title
+	}
+	if req.Description != nil {
+		album.Description = *req.Description
+	}
+	if req.Visibility != nil {
+		album.Visibility = *req.Visibility
+	}
+	if req.CoverItemID != nil {
+		if *req.CoverItemID == "" {
+			album.CoverItemID = nil
+		} else {
+			item, err := s.portfolioRepo.FindPortfolioItemByID(tx, *req.CoverItemID)
+			if err != nil {
+				return nil, handlePortfolioError(err)
+			}
+			if item.AlbumID == nil || *item.AlbumID != album.ID {
+				return nil, apperrors.ErrInvalidOperation("portfolio", "cover must be an item of this album")
+			}
+			album.CoverItemID = &item.ID
+		}
+	}
+
+	if err := s.portfolioRepo.UpdateAlbum(tx, album); err != nil {
+		return nil, handlePortfolioError(err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.GetAlbum(ctx, db, albumID, userID)
+}
+
+// DeleteAlbum - удаляется только альбом, его элементы остаются в портфолио
+func (s *portfolioService) DeleteAlbum(ctx context.Context, db *gorm.DB, userID, albumID string) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	modelProfile, err := s.profileRepo.FindModelProfileByUserID(tx, userID)
+	if err != nil {
+		return apperrors.NewForbiddenError("Access denied")
+	}
+	if _, err := s.findOwnedAlbum(tx, modelProfile.ID, albumID); err != nil {
+		return err
+	}
+	if err := s.portfolioRepo.DeleteAlbum(tx, albumID); err != nil {
+		return handlePortfolioError(err)
+	}
+	return tx.Commit().Error
+}
+
+// MovePortfolioItems - перенос элементов в альбом (в конец) или обратно в общий список
+func (s *portfolioService) MovePortfolioItems(ctx context.Context, db *gorm.DB, userID string, req *dto.MovePortfolioItemsRequest) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	modelProfile, err := s.profileRepo.FindModelProfileByUserID(tx, userID)
+	if err != nil {
+		return apperrors.NewForbiddenError("Access denied")
+	}
+
+	var albumID *string
+	if req.AlbumID != nil && *req.AlbumID != "" {
+		if _, err := s.findOwnedAlbum(tx, modelProfile.ID, *req.AlbumID); err != nil {
+			return err
+		}
+		albumID = req.AlbumID
+	}
+
+	for _, itemID := range req.ItemIDs {
+		item, err := s.portfolioRepo.FindPortfolioItemByID(tx, itemID)
+		if err != nil {
+			return handlePortfolioError(err)
+		}
+		if item.ModelID != modelProfile.ID {
+			return apperrors.NewForbiddenError("Access denied for some items")
+		}
+	}
+
+	if err := s.portfolioRepo.MoveItemsToAlbum(tx, req.ItemIDs, albumID); err != nil {
+		return apperrors.InternalError(err)
+	}
+	return tx.Commit().Error
+}
+
+// Likes & saves
+
+// LikeItem - лайкнуть можно видимую пользователю чужую работу; повторный лайк ничего не меняет
+func (s *portfolioService) LikeItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error) {
+	return s.react(db, userID, itemID, func(tx *gorm.DB) (bool, error) {
+		return s.portfolioRepo.LikeItem(tx, itemID, userID)
+	})
+}
+
+func (s *portfolioService) UnlikeItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error) {
+	return s.react(db, userID, itemID, func(tx *gorm.DB) (bool, error) {
+		return s.portfolioRepo.UnlikeItem(tx, itemID, userID)
+	})
+}
+
+func (s *portfolioService) SaveItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error) {
+	return s.react(db, userID, itemID, func(tx *gorm.DB) (bool, error) {
+		return s.portfolioRepo.SaveItem(tx, itemID, userID)
+	})
+}
+
+func (s *portfolioService) UnsaveItem(ctx context.Context, db *gorm.DB, userID, itemID string) (*dto.PortfolioEngagementResponse, error) {
+	return s.react(db, userID, itemID, func(tx *gorm.DB) (bool, error) {
+		return s.portfolioRepo.UnsaveItem(tx, itemID, userID)
+	})
+}
+
+// GetSavedItems - сохраненные работы; ставшие недоступными (отклонены, скрыты в закрытый альбом) не показываются,
+// но остаются в сохраненных и вернутся, если снова станут видны
+func (s *portfolioService) GetSavedItems(ctx context.Context, db *gorm.DB, userID string, page, pageSize int) ([]*dto.PortfolioResponse, int64, error) {
+	items, total, err := s.portfolioRepo.FindSavedItems(db, userID, page, pageSize)
+	if err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+
+	responses := make([]*dto.PortfolioResponse, 0, len(items))
+	for i := range items {
+		if _, err := s.findVisibleItem(db, items[i].ID, userID); err != nil {
+			continue
+		}
+		responses = append(responses, s.buildPortfolioResponse(db, &items[i]))
+	}
+	if err := s.applyReactions(db, userID, responses); err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+	return responses, total, nil
+}
+
+// RecomputeFeaturedScores - пересчет рейтинга featured (см. algorithms.FeaturedScore);
+// возвращает число работ, попавших в подборку. Кандидаты читаются без транзакции,
+// запись рейтингов - одна короткая транзакция в репозитории.
+func (s *portfolioService) RecomputeFeaturedScores(ctx context.Context, db *gorm.DB) (int, error) {
+	candidates, err := s.portfolioRepo.FindFeaturedCandidates(db)
+	if err != nil {
+		return 0, apperrors.InternalError(err)
+	}
+
+	now := time.Now()
+	scores := make(map[string]float64, len(candidates))
+	for _, c := range candidates {
+		score := algorithms.FeaturedScore(algorithms.FeaturedSignals{
+			Likes:             c.LikesCount,
+			Saves:             c.SavesCount,
+			CreatedAt:         c.CreatedAt,
+			Approved:          c.ModerationStatus == models.ModerationStatusApproved,
+			ProfileRating:     c.Rating,
+			CompletenessScore: c.CompletenessScore,
+		}, now)
+		if score > 0 {
+			scores[c.ItemID] = score
+		}
+	}
+
+	if err := s.portfolioRepo.UpdateFeaturedScores(db, scores); err != nil {
+		return 0, apperrors.InternalError(err)
+	}
+	return len(scores), nil
+}
+
 // ▼▼▼ УДАЛЕНО: Все операции Upload теперь в UploadService ▼▼▼
 // func (s *portfolioService) UploadFile(...)
 // func (s *portfolioService) GetUpload(...)
@@ -385,9 +736,15 @@ func (s *portfolioService) buildPortfolioResponse(db *gorm.DB, item *models.Port
 		Title:       item.Title,
 		Description: item.Description,
 		OrderIndex:  item.OrderIndex,
+		AlbumID:     item.AlbumID,
+		LikesCount:  item.LikesCount,
+		SavesCount:  item.SavesCount,
 		CreatedAt:   item.CreatedAt,
 		UpdatedAt:   item.UpdatedAt,
 	}
+	if item.Upload != nil {
+		response.ModerationStatus = item.Upload.ModerationStatus
+	}
 
 	// Используем 'item.Upload', который был получен через Preload
 	if item.Upload != nil {
@@ -404,6 +761,9 @@ func (s *portfolioService) buildPortfolioResponse(db *gorm.DB, item *models.Port
 			Size:       item.Upload.Size,
 			IsPublic:   item.Upload.IsPublic,
 			CreatedAt:  item.Upload.CreatedAt,
+
+			ModerationStatus: item.Upload.ModerationStatus,
+			RejectionReason:  item.Upload.RejectionReason,
 		}
 	}
 
@@ -412,10 +772,155 @@ func (s *portfolioService) buildPortfolioResponse(db *gorm.DB, item *models.Port
 
 // buildUploadResponse - Удален (теперь в 'buildPortfolioResponse')
 
+// buildAlbumResponse - items уже отфильтрованы по видимости и отсортированы; обложка -
+// выбранный элемент, если он виден, иначе первый элемент альбома
+func (s *portfolioService) buildAlbumResponse(db *gorm.DB, album *models.PortfolioAlbum, items []models.PortfolioItem, withItems bool) *dto.AlbumResponse {
+	response := &dto.AlbumResponse{
+		ID:          album.ID,
+		ModelID:     album.ModelID,
+		Title:       album.Title,
+		Description: album.Description,
+		Visibility:  album.Visibility,
+		OrderIndex:  album.OrderIndex,
+		CoverItemID: album.CoverItemID,
+		ItemCount:   len(items),
+		CreatedAt:   album.CreatedAt,
+		UpdatedAt:   album.UpdatedAt,
+	}
+
+	for i := range items {
+		if album.CoverItemID != nil && items[i].ID == *album.CoverItemID {
+			response.Cover = s.buildPortfolioResponse(db, &items[i])
+			break
+		}
+	}
+	if response.Cover == nil && len(items) > 0 {
+		response.Cover = s.buildPortfolioResponse(db, &items[0])
+	}
+
+	if withItems {
+		response.Items = make([]*dto.PortfolioResponse, 0, len(items))
+		for i := range items {
+			response.Items = append(response.Items, s.buildPortfolioResponse(db, &items[i]))
+		}
+	}
+	return response
+}
+
+// findVisibleItem - элемент, который видит requesterID; скрытый от него - 404
+func (s *portfolioService) findVisibleItem(db *gorm.DB, itemID, requesterID string) (*models.PortfolioItem, error) {
+	item, err := s.portfolioRepo.FindPortfolioItemByID(db, itemID)
+	if err != nil {
+		return nil, handlePortfolioError(err)
+	}
+	if !s.canSeeHidden(db, item.ModelID, requesterID) {
+		hidden := !isPortfolioItemApproved(item)
+		if item.AlbumID != nil {
+			album, err := s.portfolioRepo.FindAlbumByID(db, *item.AlbumID)
+			hidden = hidden || (err == nil && !album.IsPublic())
+		}
+		if hidden {
+			return nil, apperrors.ErrNotFound(repositories.ErrPortfolioItemNotFound)
+		}
+	}
+	return item, nil
+}
+
+// react - лайк/сохранение в одной транзакции со счетчиками; свои работы оценивать нельзя
+func (s *portfolioService) react(db *gorm.DB, userID, itemID string, apply func(tx *gorm.DB) (bool, error)) (*dto.PortfolioEngagementResponse, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	item, err := s.findVisibleItem(tx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile, err := s.profileRepo.FindModelProfileByID(tx, item.ModelID); err == nil && profile.UserID == userID {
+		return nil, apperrors.ErrInvalidOperation("portfolio", "cannot like or save your own work")
+	}
+
+	if _, err := apply(tx); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	item, err = s.portfolioRepo.FindPortfolioItemByID(tx, itemID)
+	if err != nil {
+		return nil, handlePortfolioError(err)
+	}
+	liked, saved, err := s.portfolioRepo.FindUserReactions(tx, userID, []string{itemID})
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	return &dto.PortfolioEngagementResponse{
+		ItemID:     item.ID,
+		LikesCount: item.LikesCount,
+		SavesCount: item.SavesCount,
+		Liked:      liked[itemID],
+		Saved:      saved[itemID],
+	}, nil
+}
+
+// applyReactions - отмечает в ответах лайки и сохранения текущего пользователя
+func (s *portfolioService) applyReactions(db *gorm.DB, userID string, responses []*dto.PortfolioResponse) error {
+	if userID == "" || len(responses) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(responses))
+	for _, response := range responses {
+		ids = append(ids, response.ID)
+	}
+	liked, saved, err := s.portfolioRepo.FindUserReactions(db, userID, ids)
+	if err != nil {
+		return err
+	}
+	for _, response := range responses {
+		response.Liked = liked[response.ID]
+		response.Saved = saved[response.ID]
+	}
+	return nil
+}
+
+// findOwnedAlbum - альбом указанной модели; чужой альбом - 403
+func (s *portfolioService) findOwnedAlbum(db *gorm.DB, modelID, albumID string) (*models.PortfolioAlbum, error) {
+	album, err := s.portfolioRepo.FindAlbumByID(db, albumID)
+	if err != nil {
+		return nil, handlePortfolioError(err)
+	}
+	if album.ModelID != modelID {
+		return nil, apperrors.NewForbiddenError("Access denied to this album")
+	}
+	return album, nil
+}
+
+// canSeeHidden - неодобренные фото и закрытые альбомы видят сама модель и админы
+func (s *portfolioService) canSeeHidden(db *gorm.DB, modelID, requesterID string) bool {
+	if requesterID == "" {
+		return false
+	}
+	if profile, err := s.profileRepo.FindModelProfileByID(db, modelID); err == nil && profile.UserID == requesterID {
+		return true
+	}
+	user, err := s.userRepo.FindByID(db, requesterID)
+	return err == nil && user.Role == models.UserRoleAdmin
+}
+
+// isPortfolioItemApproved - элемент без файла показывать нечего, считается неодобренным
+func isPortfolioItemApproved(item *models.PortfolioItem) bool {
+	return item.Upload != nil && item.Upload.IsApproved()
+}
+
 // (Вспомогательный хелпер для ошибок - без изменений)
 func handlePortfolioError(err error) error {
 	if errors.Is(err, gorm.ErrRecordNotFound) ||
-		errors.Is(err, repositories.ErrPortfolioItemNotFound) {
+		errors.Is(err, repositories.ErrPortfolioItemNotFound) ||
+		errors.Is(err, repositories.ErrPortfolioAlbumNotFound) {
 		return apperrors.ErrNotFound(err)
 	}
 	if errors.Is(err, repositories.ErrUserNotFound) {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"gorm.io/gorm"
 
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
 	"mwork_backend/internal/services/dto"
@@ -27,6 +28,7 @@ type ProfileService interface {
 	SearchEmployers(db *gorm.DB, criteria *dto.SearchEmployersRequest) (*dto.PaginatedResponse, error)
 	GetModelStats(db *gorm.DB, modelID string) (*dto.ModelProfileStats, error)
 	ToggleProfileVisibility(db *gorm.DB, userID string, isPublic bool) error
+	GetMyCompleteness(db *gorm.DB, userID string) (*dto.ProfileCompleteness, error)
 }
 
 // =======================
@@ -39,6 +41,9 @@ type ProfileServiceImpl struct {
 	portfolioRepo    repositories.PortfolioRepository
 	reviewRepo       repositories.ReviewRepository
 	notificationRepo repositories.NotificationRepository
+	agencyRepo       repositories.AgencyRepository
+	profileViewRepo  repositories.ProfileViewRepository
+	blockRepo        repositories.BlockRepository
 }
 
 // ✅ Конструктор обновлен (db убран)
@@ -49,6 +54,9 @@ func NewProfileService(
 	portfolioRepo repositories.PortfolioRepository,
 	reviewRepo repositories.ReviewRepository,
 	notificationRepo repositories.NotificationRepository,
+	agencyRepo repositories.AgencyRepository,
+	profileViewRepo repositories.ProfileViewRepository,
+	blockRepo repositories.BlockRepository,
 ) ProfileService {
 	return &ProfileServiceImpl{
 		// ❌ 'db: db,' УДАЛЕНО
@@ -57,6 +65,9 @@ func NewProfileService(
 		portfolioRepo:    portfolioRepo,
 		reviewRepo:       reviewRepo,
 		notificationRepo: notificationRepo,
+		agencyRepo:       agencyRepo,
+		profileViewRepo:  profileViewRepo,
+		blockRepo:        blockRepo,
 	}
 }
 
@@ -98,20 +109,27 @@ func (s *ProfileServiceImpl) CreateModelProfile(db *gorm.DB, req *dto.CreateMode
 		UserID:         req.UserID,
 		Name:           req.Name,
 		Age:            req.Age,
-		Height:         float64(req.Height),
-		Weight:         float64(req.Weight),
 		Gender:         req.Gender,
 		Experience:     req.Experience,
 		HourlyRate:     req.HourlyRate,
 		Description:    req.Description,
-		ClothingSize:   req.ClothingSize,
-		ShoeSize:       req.ShoeSize,
 		City:           req.City,
 		Languages:      datatypes.JSON(languagesJSON),
 		Categories:     datatypes.JSON(categoriesJSON),
 		BarterAccepted: req.BarterAccepted,
 		IsPublic:       req.IsPublic,
+		ContentLocale:  models.DefaultLocale,
 	}
+	if req.ContentLocale != "" {
+		profile.ContentLocale = req.ContentLocale
+	}
+	profile.SetDescriptionI18n(mergeLocalized(nil, req.DescriptionI18n, profile.ContentLocale))
+	// Рост, вес и размеры - в см/кг и каноническом виде
+	if err := applyModelMeasurements(profile, &req.Height, &req.Weight, &req.ClothingSize, &req.ShoeSize, &req.MeasurementsInput); err != nil {
+		return err
+	}
+	// Портфолио у нового профиля еще пусто
+	profile.CompletenessScore, _ = algorithms.ProfileCompleteness(profile, 0)
 
 	// ✅ Передаем tx
 	if err := s.profileRepo.CreateModelProfile(tx, profile); err != nil {
@@ -167,10 +185,21 @@ func (s *ProfileServiceImpl) GetProfile(db *gorm.DB, userID, requesterID string)
 	if err != nil {
 		return nil, handleProfileError(err)
 	}
+	// Профиль скрыт от пользователей из черного списка (и от тех, кого владелец заблокировал сам)
+	if requesterID != "" && requesterID != userID {
+		if err := ensureNotBlocked(db, s.blockRepo, requesterID, userID); err != nil {
+			if errors.Is(err, apperrors.ErrUserBlocked) {
+				return nil, apperrors.ErrNotFound(repositories.ErrProfileNotFound)
+			}
+			return nil, err
+		}
+	}
 
 	var profileData interface{}
 	var profileType string
 	var stats interface{}
+	var agency *dto.RepresentedBy
+	var completeness *dto.ProfileCompleteness
 
 	switch user.Role {
 	case models.UserRoleModel:
@@ -192,7 +221,20 @@ func (s *ProfileServiceImpl) GetProfile(db *gorm.DB, userID, requesterID string)
 		if requesterID != userID {
 			// ✅ Передаем 'db' (пул) в go рутину
 			go s.profileRepo.IncrementModelProfileViews(db, profile.ID)
+			// Просмотр работодателем попадает в список "кто смотрел мой профиль"
+			if requesterID != "" {
+				_ = recordProfileView(db, s.profileViewRepo, s.profileRepo, profile.ID, requesterID)
+			}
 		}
+		agency = representedBy(db, s.agencyRepo, profile.ID)
+
+		score, checklist := modelCompletenessScore(db, s.portfolioRepo, profile)
+		if score != profile.CompletenessScore {
+			// Сохраненный балл устарел (например, файл портфолио удален) - исправляем
+			_ = s.profileRepo.UpdateModelCompleteness(db, profile.ID, score)
+			profile.CompletenessScore = score
+		}
+		completeness = buildProfileCompleteness(score, checklist, requesterID == userID)
 
 	case models.UserRoleEmployer:
 		// ✅ Передаем db
@@ -208,21 +250,52 @@ func (s *ProfileServiceImpl) GetProfile(db *gorm.DB, userID, requesterID string)
 			stats = employerStats
 		}
 
+	case models.UserRoleAgency:
+		profile, err := s.agencyRepo.FindAgencyProfileByUserID(db, userID)
+		if err != nil {
+			return nil, handleAgencyError(err)
+		}
+		profileData = profile
+		profileType = "agency"
+
+		if represented, err := s.agencyRepo.FindRepresentationsByAgency(db, profile.ID,
+			[]string{models.RepresentationStatusActive}); err == nil {
+			stats = &dto.AgencyProfileStats{ModelsCount: len(represented)}
+		}
+
 	default:
 		return nil, apperrors.ErrInvalidUserRole
 	}
 
 	return &dto.ProfileResponse{
-		ID:        userID,
-		Type:      profileType,
-		UserID:    userID,
-		Data:      profileData,
-		Stats:     stats,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:            userID,
+		Type:          profileType,
+		UserID:        userID,
+		Data:          profileData,
+		Stats:         stats,
+		RepresentedBy: agency,
+		Completeness:  completeness,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
 	}, nil
 }
 
+// GetMyCompleteness - заполненность профиля модели с чек-листом (для владельца)
+func (s *ProfileServiceImpl) GetMyCompleteness(db *gorm.DB, userID string) (*dto.ProfileCompleteness, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleProfileError(err)
+	}
+
+	score, checklist := modelCompletenessScore(db, s.portfolioRepo, profile)
+	if score != profile.CompletenessScore {
+		if err := s.profileRepo.UpdateModelCompleteness(db, profile.ID, score); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+	}
+	return buildProfileCompleteness(score, checklist, true), nil
+}
+
 // ==========================
 // Profile Update
 // ==========================
@@ -272,13 +345,34 @@ func (s *ProfileServiceImpl) updateModelProfile(db *gorm.DB, userID string, req
 	if req.City != nil {
 		profile.City = *req.City
 	}
-	if req.Height != nil {
-		profile.Height = float64(*req.Height)
+	if req.Gender != nil {
+		profile.Gender = *req.Gender
 	}
-	if req.Weight != nil {
-		profile.Weight = float64(*req.Weight)
+	// Рост, вес, размеры и параметры фигуры (с переводом единиц)
+	if err := applyModelMeasurements(profile, req.Height, req.Weight, req.ClothingSize, req.ShoeSize, &req.MeasurementsInput); err != nil {
+		return err
+	}
+	if req.Age != nil {
+		profile.Age = *req.Age
+	}
+	if req.Description != nil {
+		profile.Description = *req.Description
+	}
+	if req.ContentLocale != nil {
+		profile.ContentLocale = *req.ContentLocale
+	}
+	if req.DescriptionI18n != nil || req.ContentLocale != nil {
+		profile.SetDescriptionI18n(mergeLocalized(profile.GetDescriptionI18n(), req.DescriptionI18n, profile.ContentLocale))
+	}
+	if req.Experience != nil {
+		profile.Experience = *req.Experience
+	}
+	if req.HourlyRate != nil {
+		profile.HourlyRate = *req.HourlyRate
+	}
+	if req.BarterAccepted != nil {
+		profile.BarterAccepted = *req.BarterAccepted
 	}
-	// ... (другие поля)
 	if req.Languages != nil {
 		languagesJSON, err := json.Marshal(req.Languages)
 		if err != nil {
@@ -293,6 +387,7 @@ func (s *ProfileServiceImpl) updateModelProfile(db *gorm.DB, userID string, req
 		}
 		profile.Categories = datatypes.JSON(categoriesJSON)
 	}
+	profile.CompletenessScore, _ = modelCompletenessScore(db, s.portfolioRepo, profile)
 
 	// ✅ Используем 'db' из параметра
 	return s.profileRepo.UpdateModelProfile(db, profile)
@@ -345,6 +440,14 @@ func (s *ProfileServiceImpl) SearchModels(db *gorm.DB, criteria *dto.SearchModel
 		PageSize:      criteria.PageSize,
 		SortBy:        criteria.SortBy,
 		SortOrder:     criteria.SortOrder,
+
+		DownRankIncomplete: criteria.DownRankIncomplete,
+		HiddenForUserID:    criteria.RequesterID,
+		QueryLocales:       algorithms.SearchLocales(criteria.Query, criteria.Locale),
+	}
+
+	if err := applyMeasurementFilters(&searchCriteria, criteria); err != nil {
+		return nil, err
 	}
 
 	// ✅ Используем 'db' из параметра
@@ -358,12 +461,13 @@ func (s *ProfileServiceImpl) SearchModels(db *gorm.DB, criteria *dto.SearchModel
 // SearchEmployers - 'db' добавлен
 func (s *ProfileServiceImpl) SearchEmployers(db *gorm.DB, criteria *dto.SearchEmployersRequest) (*dto.PaginatedResponse, error) {
 	repoCriteria := repositories.EmployerSearchCriteria{
-		Query:       criteria.Query,
-		City:        criteria.City,
-		CompanyType: criteria.CompanyType,
-		IsVerified:  criteria.IsVerified,
-		Page:        criteria.Page,
-		PageSize:    criteria.PageSize,
+		Query:           criteria.Query,
+		City:            criteria.City,
+		CompanyType:     criteria.CompanyType,
+		IsVerified:      criteria.IsVerified,
+		Page:            criteria.Page,
+		PageSize:        criteria.PageSize,
+		HiddenForUserID: criteria.RequesterID,
 	}
 
 	// ✅ Используем 'db' из параметра
@@ -382,9 +486,7 @@ func (s *ProfileServiceImpl) validateModelProfileData(req *dto.CreateModelProfil
 	if req.Age < 16 || req.Age > 70 {
 		return errors.New("age must be between 16 and 70")
 	}
-	if req.Height < 100 || req.Height > 250 {
-		return errors.New("height must be between 100 and 250 cm")
-	}
+	// Рост и вес проверяются после перевода в см/кг (applyModelMeasurements)
 	if req.HourlyRate < 0 {
 		return errors.New("hourly rate cannot be negative")
 	}
@@ -479,6 +581,20 @@ func buildPaginatedResponse(data interface{}, total int64, page, pageSize int) *
 	}
 }
 
+// mergeLocalized применяет изменения переводов: переданные языки заменяются, пустая строка удаляет перевод.
+// Перевод на язык основного текста не хранится - его заменяет сам текст.
+func mergeLocalized(current, changes map[string]string, baseLocale string) map[string]string {
+	merged := make(map[string]string, len(current)+len(changes))
+	for locale, text := range current {
+		merged[locale] = text
+	}
+	for locale, text := range changes {
+		merged[locale] = text
+	}
+	delete(merged, baseLocale)
+	return merged
+}
+
 // (handleProfileError - хелпер, без изменений)
 func handleProfileError(err error) error {
 	if errors.Is(err, gorm.ErrRecordNotFound) ||
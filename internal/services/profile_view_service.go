@@ -0,0 +1,242 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+const (
+	// PlanFeatureProfileViewers - ключ в SubscriptionPlan.Features: список зрителей и сводка просмотров
+	PlanFeatureProfileViewers = "profile_viewers"
+
+	// profileViewDedupWindow - повторный просмотр тем же работодателем раньше не записывается
+	profileViewDedupWindow = 30 * time.Minute
+	// profileViewersWindow - сколько дней хранится список "недавних" зрителей
+	profileViewersWindow = 30 * 24 * time.Hour
+	// profileViewSummaryPeriod - период еженедельной сводки
+	profileViewSummaryPeriod = 7 * 24 * time.Hour
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type ProfileViewService interface {
+	// Модель (userID = users.id модели); доступно с тарифом PlanFeatureProfileViewers
+	GetMyViewers(db *gorm.DB, userID string, page, pageSize int) ([]repositories.ProfileViewer, int64, error)
+	GetMyViewSummary(db *gorm.DB, userID string) (*dto.ProfileViewSummary, error)
+
+	// Работодатель: анонимный просмотр
+	GetPrivacySettings(db *gorm.DB, userID string) (*dto.EmployerPrivacySettings, error)
+	UpdatePrivacySettings(db *gorm.DB, userID string, req *dto.EmployerPrivacySettings) (*dto.EmployerPrivacySettings, error)
+
+	// Фоновая задача: еженедельная сводка просмотров моделям
+	SendWeeklySummaries(db *gorm.DB) error
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type profileViewService struct {
+	profileViewRepo  repositories.ProfileViewRepository
+	profileRepo      repositories.ProfileRepository
+	subscriptionRepo repositories.SubscriptionRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewProfileViewService(
+	profileViewRepo repositories.ProfileViewRepository,
+	profileRepo repositories.ProfileRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	notificationRepo repositories.NotificationRepository,
+) ProfileViewService {
+	return &profileViewService{
+		profileViewRepo:  profileViewRepo,
+		profileRepo:      profileRepo,
+		subscriptionRepo: subscriptionRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// --- Модель ---
+
+// GetMyViewers - работодатели, смотревшие профиль за 30 дней (без анонимных)
+func (s *profileViewService) GetMyViewers(db *gorm.DB, userID string, page, pageSize int) ([]repositories.ProfileViewer, int64, error) {
+	profile, err := s.premiumModelProfile(db, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	viewers, total, err := s.profileViewRepo.FindRecentViewers(db, profile.ID,
+		time.Now().Add(-profileViewersWindow), page, pageSize)
+	if err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+	return viewers, total, nil
+}
+
+// GetMyViewSummary - просмотры за последние 7 дней в сравнении с предыдущей неделей
+func (s *profileViewService) GetMyViewSummary(db *gorm.DB, userID string) (*dto.ProfileViewSummary, error) {
+	profile, err := s.premiumModelProfile(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.weekSummary(db, profile.ID, time.Now())
+}
+
+// --- Работодатель ---
+
+func (s *profileViewService) GetPrivacySettings(db *gorm.DB, userID string) (*dto.EmployerPrivacySettings, error) {
+	employer, err := s.profileRepo.FindEmployerProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleProfileError(err)
+	}
+	return &dto.EmployerPrivacySettings{AnonymousBrowsing: &employer.AnonymousBrowsing}, nil
+}
+
+func (s *profileViewService) UpdatePrivacySettings(db *gorm.DB, userID string, req *dto.EmployerPrivacySettings) (*dto.EmployerPrivacySettings, error) {
+	employer, err := s.profileRepo.FindEmployerProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleProfileError(err)
+	}
+
+	if req.AnonymousBrowsing != nil && *req.AnonymousBrowsing != employer.AnonymousBrowsing {
+		if err := s.profileRepo.UpdateEmployerAnonymousBrowsing(db, employer.ID, *req.AnonymousBrowsing); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		employer.AnonymousBrowsing = *req.AnonymousBrowsing
+	}
+	return &dto.EmployerPrivacySettings{AnonymousBrowsing: &employer.AnonymousBrowsing}, nil
+}
+
+// --- Фоновые задачи ---
+
+// SendWeeklySummaries - уведомление с количеством просмотров за неделю всем моделям, которых смотрели.
+// Сводка содержит только счетчики, поэтому приходит и без тарифа (имена - по подписке).
+// Вызывается ежедневно: модель получает сводку, только если с предыдущей прошла неделя,
+// поэтому перезапуски сервиса не пропускают и не дублируют сводки.
+func (s *profileViewService) SendWeeklySummaries(db *gorm.DB) error {
+	now := time.Now()
+	modelIDs, err := s.profileViewRepo.FindViewedModelIDs(db, now.Add(-profileViewSummaryPeriod))
+	if err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	for _, modelID := range modelIDs {
+		profile, err := s.profileRepo.FindModelProfileByID(db, modelID)
+		if err != nil {
+			continue // профиль удален
+		}
+		last, err := s.profileViewRepo.FindLastSummaryTime(db, profile.UserID)
+		if err != nil {
+			return apperrors.InternalError(err)
+		}
+		if last != nil && now.Sub(*last) < profileViewSummaryPeriod {
+			continue
+		}
+		summary, err := s.weekSummary(db, modelID, now)
+		if err != nil {
+			return err
+		}
+		if summary.TotalViews == 0 {
+			continue
+		}
+
+		jsonData, err := json.Marshal(summary)
+		if err != nil {
+			return apperrors.InternalError(err)
+		}
+		notification := &models.Notification{
+			UserID:  profile.UserID,
+			Type:    repositories.NotificationTypeProfileView,
+			Title:   "Просмотры профиля за неделю",
+			Message: fmt.Sprintf("За неделю ваш профиль просмотрели %d раз(а), работодателей: %d", summary.TotalViews, summary.UniqueViewers),
+			Data:    datatypes.JSON(jsonData),
+		}
+		if err := s.notificationRepo.CreateNotification(db, notification); err != nil {
+			return apperrors.InternalError(err)
+		}
+	}
+	return nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// premiumModelProfile - профиль модели, если ее тариф включает просмотр зрителей
+func (s *profileViewService) premiumModelProfile(db *gorm.DB, userID string) (*models.ModelProfile, error) {
+	profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleProfileError(err)
+	}
+
+	subscription, err := s.subscriptionRepo.FindUserSubscription(db, userID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrSubscriptionNotFound) {
+			return nil, apperrors.ErrProfileViewersNotIncluded
+		}
+		return nil, apperrors.InternalError(err)
+	}
+	if subscription.Status != models.SubscriptionStatusActive ||
+		!planHasFeature(&subscription.Plan, PlanFeatureProfileViewers) {
+		return nil, apperrors.ErrProfileViewersNotIncluded
+	}
+	return profile, nil
+}
+
+func (s *profileViewService) weekSummary(db *gorm.DB, modelID string, now time.Time) (*dto.ProfileViewSummary, error) {
+	weekStart := now.Add(-profileViewSummaryPeriod)
+	current, err := s.profileViewRepo.GetViewStats(db, modelID, weekStart, now)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	previous, err := s.profileViewRepo.GetViewStats(db, modelID, weekStart.Add(-profileViewSummaryPeriod), weekStart)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	return &dto.ProfileViewSummary{
+		From:              weekStart,
+		To:                now,
+		TotalViews:        current.TotalViews,
+		UniqueViewers:     current.UniqueViewers,
+		AnonymousViews:    current.AnonymousViews,
+		PreviousWeekViews: previous.TotalViews,
+	}, nil
+}
+
+// recordProfileView - просмотр профиля модели работодателем (остальные роли не записываются).
+// Вызывается из ProfileService.GetProfile; ошибки не должны мешать показу профиля.
+func recordProfileView(db *gorm.DB, profileViewRepo repositories.ProfileViewRepository, profileRepo repositories.ProfileRepository, modelID, viewerUserID string) error {
+	employer, err := profileRepo.FindEmployerProfileByUserID(db, viewerUserID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrProfileNotFound) {
+			return nil // не работодатель
+		}
+		return err
+	}
+
+	now := time.Now()
+	recent, err := profileViewRepo.HasRecentView(db, modelID, viewerUserID, now.Add(-profileViewDedupWindow))
+	if err != nil || recent {
+		return err
+	}
+
+	return profileViewRepo.CreateView(db, &models.ProfileView{
+		ModelID:           modelID,
+		ViewerUserID:      viewerUserID,
+		EmployerProfileID: &employer.ID,
+		IsAnonymous:       employer.AnonymousBrowsing,
+		ViewedAt:          now,
+	})
+}
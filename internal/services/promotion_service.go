@@ -0,0 +1,285 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type PromotionService interface {
+	CreatePromotion(db *gorm.DB, castingID, userID string, req *dto.CreatePromotionRequest) (*dto.PromotionResponse, error)
+	GetCastingPromotions(db *gorm.DB, castingID, userID string) (*dto.CastingPromotionsResponse, error)
+	GetMyPromotions(db *gorm.DB, userID string) ([]models.CastingPromotion, error)
+	ExpirePromotions(db *gorm.DB) (int64, error)
+}
+
+// =======================
+// 2. КОНФИГУРАЦИЯ
+// =======================
+
+// PlanFeatureCastingPromotion - ключ в SubscriptionPlan.Features,
+// дающий право на продвижение в рамках лимита "promotions"
+const PlanFeatureCastingPromotion = "casting_promotion"
+
+type PromotionConfig struct {
+	PricePerDay float64 // Стоимость одного дня продвижения
+	Currency    string
+	MaxDays     int
+}
+
+func GetDefaultPromotionConfig() *PromotionConfig {
+	return &PromotionConfig{
+		PricePerDay: 1000,
+		Currency:    "KZT",
+		MaxDays:     30,
+	}
+}
+
+// =======================
+// 3. РЕАЛИЗАЦИЯ
+// =======================
+type promotionService struct {
+	promotionRepo       repositories.PromotionRepository
+	castingRepo         repositories.CastingRepository
+	userRepo            repositories.UserRepository
+	subscriptionRepo    repositories.SubscriptionRepository
+	subscriptionService SubscriptionService
+	config              *PromotionConfig
+}
+
+func NewPromotionService(
+	promotionRepo repositories.PromotionRepository,
+	castingRepo repositories.CastingRepository,
+	userRepo repositories.UserRepository,
+	subscriptionRepo repositories.SubscriptionRepository,
+	subscriptionService SubscriptionService,
+	config *PromotionConfig,
+) PromotionService {
+	if config == nil {
+		config = GetDefaultPromotionConfig()
+	}
+	return &promotionService{
+		promotionRepo:       promotionRepo,
+		castingRepo:         castingRepo,
+		userRepo:            userRepo,
+		subscriptionRepo:    subscriptionRepo,
+		subscriptionService: subscriptionService,
+		config:              config,
+	}
+}
+
+// CreatePromotion - продвижение за счет тарифа (сразу активно) или через оплату (активируется в callback)
+func (s *promotionService) CreatePromotion(db *gorm.DB, castingID, userID string, req *dto.CreatePromotionRequest) (*dto.PromotionResponse, error) {
+	if req.Days < 1 || req.Days > s.config.MaxDays {
+		return nil, apperrors.ErrInvalidPromotionDuration
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	casting, err := s.castingRepo.FindCastingByID(tx, castingID)
+	if err != nil {
+		return nil, handlePromotionError(err)
+	}
+	if err := s.checkCastingOwner(tx, casting, userID); err != nil {
+		return nil, err
+	}
+	if casting.Status != models.CastingStatusActive && casting.Status != models.CastingStatusDraft {
+		return nil, apperrors.ErrInvalidCastingStatus
+	}
+
+	promotion := &models.CastingPromotion{
+		CastingID:  casting.ID,
+		EmployerID: userID,
+		Days:       req.Days,
+		Currency:   s.config.Currency,
+		Status:     models.PromotionStatusPending,
+	}
+
+	var payment *models.RobokassaInitResponse
+
+	if req.UsePlan {
+		// 1. Продвижение, включенное в тариф
+		subscription, err := s.subscriptionRepo.FindUserSubscription(tx, userID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrSubscriptionNotFound) {
+				return nil, apperrors.ErrPromotionNotIncluded
+			}
+			return nil, apperrors.InternalError(err)
+		}
+		if !planHasFeature(&subscription.Plan, PlanFeatureCastingPromotion) {
+			return nil, apperrors.ErrPromotionNotIncluded
+		}
+		if err := s.subscriptionRepo.IncrementSubscriptionUsage(tx, userID, "promotions"); err != nil {
+			if errors.Is(err, repositories.ErrSubscriptionLimit) {
+				return nil, apperrors.ErrSubscriptionLimit
+			}
+			return nil, apperrors.InternalError(err)
+		}
+
+		promotion.Source = models.PromotionSourcePlan
+		if err := s.promotionRepo.CreatePromotion(tx, promotion); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+
+		startsAt := time.Now()
+		if active, err := s.promotionRepo.FindLastScheduledPromotion(tx, casting.ID); err == nil && active.EndsAt.After(startsAt) {
+			startsAt = *active.EndsAt
+		}
+		endsAt := startsAt.AddDate(0, 0, req.Days)
+		if err := s.promotionRepo.ActivatePromotion(tx, promotion.ID, startsAt, endsAt); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		promotion.Status = models.PromotionStatusActive
+		promotion.StartsAt = &startsAt
+		promotion.EndsAt = &endsAt
+	} else {
+		// 2. Платное продвижение через платежный поток подписок
+		amount := s.config.PricePerDay * float64(req.Days)
+		description := fmt.Sprintf("Продвижение кастинга '%s' на %d дн.", casting.Title, req.Days)
+
+		paymentTx, initResp, err := s.subscriptionService.CreateServicePayment(tx, userID, amount, s.config.Currency, description)
+		if err != nil {
+			return nil, err
+		}
+
+		promotion.Source = models.PromotionSourcePayment
+		promotion.Amount = amount
+		promotion.PaymentID = &paymentTx.ID
+		if err := s.promotionRepo.CreatePromotion(tx, promotion); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		payment = initResp
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	return &dto.PromotionResponse{
+		Promotion: promotion,
+		Payment:   payment,
+	}, nil
+}
+
+func (s *promotionService) GetCastingPromotions(db *gorm.DB, castingID, userID string) (*dto.CastingPromotionsResponse, error) {
+	casting, err := s.castingRepo.FindCastingByID(db, castingID)
+	if err != nil {
+		return nil, handlePromotionError(err)
+	}
+	if err := s.checkCastingOwner(db, casting, userID); err != nil {
+		return nil, err
+	}
+
+	promotions, err := s.promotionRepo.FindPromotionsByCasting(db, castingID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	stats, err := s.promotionRepo.GetPromotionStats(db, castingID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	return &dto.CastingPromotionsResponse{
+		Promotions: promotions,
+		Stats:      stats,
+	}, nil
+}
+
+func (s *promotionService) GetMyPromotions(db *gorm.DB, userID string) ([]models.CastingPromotion, error) {
+	promotions, err := s.promotionRepo.FindPromotionsByEmployer(db, userID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return promotions, nil
+}
+
+// ExpirePromotions - для фонового воркера
+func (s *promotionService) ExpirePromotions(db *gorm.DB) (int64, error) {
+	count, err := s.promotionRepo.ExpirePromotions(db)
+	if err != nil {
+		return 0, apperrors.InternalError(err)
+	}
+	return count, nil
+}
+
+// =======================
+// 4. ХЕЛПЕРЫ
+// =======================
+
+func (s *promotionService) checkCastingOwner(db *gorm.DB, casting *models.Casting, userID string) error {
+	employerUser, err := s.userRepo.FindByProfileID(db, casting.EmployerID)
+	if err != nil {
+		return handlePromotionError(err)
+	}
+	if employerUser.ID != userID {
+		return apperrors.ErrInsufficientPermissions
+	}
+	return nil
+}
+
+// planHasFeature - проверяет булев флаг в SubscriptionPlan.Features
+func planHasFeature(plan *models.SubscriptionPlan, feature string) bool {
+	if plan == nil || len(plan.Features) == 0 {
+		return false
+	}
+	var features map[string]interface{}
+	if err := json.Unmarshal(plan.Features, &features); err != nil {
+		return false
+	}
+	enabled, ok := features[feature].(bool)
+	return ok && enabled
+}
+
+func handlePromotionError(err error) error {
+	if errors.Is(err, repositories.ErrPromotionNotFound) ||
+		errors.Is(err, repositories.ErrCastingNotFound) ||
+		errors.Is(err, repositories.ErrUserNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	return apperrors.InternalError(err)
+}
+
+// applyPromotionFlags - проставляет Casting.IsPromoted и засчитывает показ продвигаемым кастингам.
+// Используется лентами и поиском (CastingService, SearchService).
+func applyPromotionFlags(db *gorm.DB, promotionRepo repositories.PromotionRepository, castings []models.Casting) {
+	if len(castings) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(castings))
+	for _, casting := range castings {
+		ids = append(ids, casting.ID)
+	}
+
+	promoted, err := promotionRepo.FindPromotedCastingIDs(db, ids)
+	if err != nil {
+		fmt.Printf("Failed to load promoted castings: %v\n", err)
+		return
+	}
+
+	var promotedIDs []string
+	for i := range castings {
+		if promoted[castings[i].ID] {
+			castings[i].IsPromoted = true
+			promotedIDs = append(promotedIDs, castings[i].ID)
+		}
+	}
+
+	if len(promotedIDs) > 0 {
+		go promotionRepo.IncrementImpressions(db, promotedIDs)
+	}
+}
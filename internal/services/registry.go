@@ -7,20 +7,36 @@ import (
 
 // ServiceContainer содержит все сервисы приложения.
 type ServiceContainer struct {
-	UserService         UserService
-	AuthService         AuthService
-	ProfileService      ProfileService
-	CastingService      CastingService
-	ResponseService     ResponseService
-	ReviewService       ReviewService
-	PortfolioService    PortfolioService
-	MatchingService     MatchingService
-	NotificationService NotificationService
-	SubscriptionService SubscriptionService
-	SearchService       SearchService
-	AnalyticsService    AnalyticsService
-	ChatService         ChatService
-	UploadService       UploadService
-	EmailService        email.Provider
-	storage             storage.Storage // (Можно сделать приватным, если он нужен только внутри других сервисов)
+	UserService            UserService
+	AuthService            AuthService
+	ProfileService         ProfileService
+	CastingService         CastingService
+	ResponseService        ResponseService
+	ReviewService          ReviewService
+	PortfolioService       PortfolioService
+	MatchingService        MatchingService
+	NotificationService    NotificationService
+	SubscriptionService    SubscriptionService
+	SearchService          SearchService
+	AnalyticsService       AnalyticsService
+	ChatService            ChatService
+	UploadService          UploadService
+	PromotionService       PromotionService
+	EmployerNoteService    EmployerNoteService
+	ShortlistExportService ShortlistExportService
+	BookingService         BookingService
+	AvailabilityService    AvailabilityService
+	LedgerService          LedgerService
+	InvoiceService         InvoiceService
+	CompCardService        CompCardService
+	AgencyService          AgencyService
+	VerificationService    VerificationService
+	OnboardingService      OnboardingService
+	ShareService           ShareService
+	ProfileViewService     ProfileViewService
+	BlockService           BlockService
+	ReportService          ReportService
+	PhotoModerationService PhotoModerationService
+	EmailService           email.Provider
+	storage                storage.Storage // (Можно сделать приватным, если он нужен только внутри других сервисов)
 }
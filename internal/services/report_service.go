@@ -0,0 +1,258 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type ReportService interface {
+	// Пользователь
+	CreateReport(db *gorm.DB, reporterID string, req *dto.CreateReportRequest) (*models.Report, error)
+
+	// Модерация
+	ListReports(db *gorm.DB, query *dto.ReportQueueQuery) ([]models.Report, int64, error)
+	GetReport(db *gorm.DB, reportID string) (*dto.ReportResponse, error)
+	ResolveReport(db *gorm.DB, adminID, reportID string, req *dto.ReportDecisionRequest) (*dto.ReportResponse, error)
+	DismissReport(db *gorm.DB, adminID, reportID string, req *dto.ReportDecisionRequest) (*dto.ReportResponse, error)
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type reportService struct {
+	reportRepo       repositories.ReportRepository
+	userRepo         repositories.UserRepository
+	castingRepo      repositories.CastingRepository
+	chatRepo         repositories.ChatRepository
+	reviewRepo       repositories.ReviewRepository
+	portfolioRepo    repositories.PortfolioRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewReportService(
+	reportRepo repositories.ReportRepository,
+	userRepo repositories.UserRepository,
+	castingRepo repositories.CastingRepository,
+	chatRepo repositories.ChatRepository,
+	reviewRepo repositories.ReviewRepository,
+	portfolioRepo repositories.PortfolioRepository,
+	notificationRepo repositories.NotificationRepository,
+) ReportService {
+	return &reportService{
+		reportRepo:       reportRepo,
+		userRepo:         userRepo,
+		castingRepo:      castingRepo,
+		chatRepo:         chatRepo,
+		reviewRepo:       reviewRepo,
+		portfolioRepo:    portfolioRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// --- Пользователь ---
+
+// CreateReport - жалоба попадает в очередь модерации; повторная жалоба на тот же объект,
+// пока первая не рассмотрена, не принимается
+func (s *reportService) CreateReport(db *gorm.DB, reporterID string, req *dto.CreateReportRequest) (*models.Report, error) {
+	targetUserID, err := s.resolveTargetOwner(db, reporterID, req.TargetType, req.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if targetUserID != nil && *targetUserID == reporterID {
+		return nil, apperrors.ErrInvalidOperation("report", "You cannot report yourself or your own content")
+	}
+	if req.Reason == models.ReportReasonOther && req.Details == "" {
+		return nil, apperrors.ErrInvalidOperation("report", "Details are required for reason 'other'")
+	}
+
+	report := &models.Report{
+		ReporterID:   reporterID,
+		TargetType:   req.TargetType,
+		TargetID:     req.TargetID,
+		TargetUserID: targetUserID,
+		Reason:       req.Reason,
+		Details:      req.Details,
+		Status:       models.ReportStatusPending,
+	}
+	if err := s.reportRepo.CreateReport(db, report); err != nil {
+		return nil, handleReportError(err)
+	}
+	return report, nil
+}
+
+// --- Модерация ---
+
+// ListReports - очередь модерации; по умолчанию ожидающие проверки, старые первыми
+func (s *reportService) ListReports(db *gorm.DB, query *dto.ReportQueueQuery) ([]models.Report, int64, error) {
+	status := query.Status
+	if status == "" {
+		status = models.ReportStatusPending
+	}
+	reports, total, err := s.reportRepo.FindReports(db, repositories.ReportCriteria{
+		Status:       status,
+		TargetType:   query.TargetType,
+		Reason:       query.Reason,
+		TargetUserID: query.TargetUserID,
+		Page:         query.Page,
+		PageSize:     query.PageSize,
+	})
+	if err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+	return reports, total, nil
+}
+
+func (s *reportService) GetReport(db *gorm.DB, reportID string) (*dto.ReportResponse, error) {
+	report, err := s.reportRepo.FindReportByID(db, reportID)
+	if err != nil {
+		return nil, handleReportError(err)
+	}
+	pending, err := s.reportRepo.CountPendingReports(db, report.TargetType, report.TargetID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return &dto.ReportResponse{Report: report, PendingReports: pending}, nil
+}
+
+// ResolveReport - нарушение подтверждено (меры принимаются модератором отдельно: блокировка, удаление контента)
+func (s *reportService) ResolveReport(db *gorm.DB, adminID, reportID string, req *dto.ReportDecisionRequest) (*dto.ReportResponse, error) {
+	return s.decide(db, adminID, reportID, models.ReportStatusResolved, req.Note,
+		"Жалоба рассмотрена",
+		"Спасибо! Модератор подтвердил нарушение и принял меры")
+}
+
+// DismissReport - нарушение не найдено
+func (s *reportService) DismissReport(db *gorm.DB, adminID, reportID string, req *dto.ReportDecisionRequest) (*dto.ReportResponse, error) {
+	return s.decide(db, adminID, reportID, models.ReportStatusDismissed, req.Note,
+		"Жалоба рассмотрена",
+		"Модератор не нашел нарушения правил")
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// decide - решение модератора по ожидающей жалобе с уведомлением автора
+func (s *reportService) decide(db *gorm.DB, adminID, reportID, status, note, title, message string) (*dto.ReportResponse, error) {
+	report, err := s.reportRepo.FindReportByID(db, reportID)
+	if err != nil {
+		return nil, handleReportError(err)
+	}
+	if report.Status != models.ReportStatusPending {
+		return nil, apperrors.ErrInvalidStatus("report", "Only pending reports can be reviewed")
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	report.Status = status
+	report.ReviewerID = &adminID
+	report.ResolutionNote = note
+	report.ReviewedAt = &now
+	if err := s.reportRepo.UpdateReport(tx, report); err != nil {
+		return nil, handleReportError(err)
+	}
+	if err := s.notificationRepo.CreateReportNotification(tx, report.ReporterID, report.ID, status, title, message); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.GetReport(db, report.ID)
+}
+
+// resolveTargetOwner - проверяет, что объект жалобы существует и доступен автору,
+// и возвращает users.id его владельца (nil для системных сообщений)
+func (s *reportService) resolveTargetOwner(db *gorm.DB, reporterID, targetType, targetID string) (*string, error) {
+	var profileID string
+
+	switch targetType {
+	case models.ReportTargetUser:
+		user, err := s.userRepo.FindByID(db, targetID)
+		if err != nil {
+			return nil, handleReportError(err)
+		}
+		return &user.ID, nil
+
+	case models.ReportTargetCasting:
+		casting, err := s.castingRepo.FindCastingByID(db, targetID)
+		if err != nil {
+			return nil, handleReportError(err)
+		}
+		profileID = casting.EmployerID
+
+	case models.ReportTargetMessage:
+		message, err := s.chatRepo.FindMessageByID(db, targetID)
+		if err != nil {
+			return nil, handleReportError(err)
+		}
+		// Жаловаться на сообщение может только участник диалога
+		inDialog, err := s.chatRepo.IsUserInDialog(db, message.DialogID, reporterID)
+		if err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		if !inDialog {
+			return nil, apperrors.ErrNotFound(repositories.ErrMessageNotFound)
+		}
+		if message.SenderID == "system" {
+			return nil, nil
+		}
+		return &message.SenderID, nil
+
+	case models.ReportTargetReview:
+		review, err := s.reviewRepo.FindReviewByID(db, targetID)
+		if err != nil {
+			return nil, handleReportError(err)
+		}
+		profileID = review.EmployerID
+
+	case models.ReportTargetPortfolioItem:
+		item, err := s.portfolioRepo.FindPortfolioItemByID(db, targetID)
+		if err != nil {
+			return nil, handleReportError(err)
+		}
+		profileID = item.ModelID
+
+	default:
+		return nil, apperrors.ErrInvalidOperation("report", "Unknown report target type")
+	}
+
+	owner, err := s.userRepo.FindByProfileID(db, profileID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			return nil, nil // профиль удален - жалоба остается без владельца
+		}
+		return nil, apperrors.InternalError(err)
+	}
+	return &owner.ID, nil
+}
+
+func handleReportError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrReportNotFound),
+		errors.Is(err, repositories.ErrUserNotFound),
+		errors.Is(err, repositories.ErrCastingNotFound),
+		errors.Is(err, repositories.ErrMessageNotFound),
+		errors.Is(err, repositories.ErrReviewNotFound),
+		errors.Is(err, repositories.ErrPortfolioItemNotFound):
+		return apperrors.ErrNotFound(err)
+	case errors.Is(err, repositories.ErrReportExists):
+		return apperrors.ErrReportAlreadyPending
+	}
+	return apperrors.InternalError(err)
+}
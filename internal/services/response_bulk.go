@@ -0,0 +1,214 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/models/chat"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+// Каналы доставки шаблонного сообщения при массовых операциях
+const (
+	BulkMessageChannelNotification = "notification"
+	BulkMessageChannelChat         = "chat"
+)
+
+// responseBulkUpdater - массовая смена статуса откликов одного кастинга.
+// Используется ResponseService (bulk-эндпоинты) и CastingService (закрытие с reject_pending).
+// Apply работает внутри транзакции вызывающего, уведомления - через afterCommit.
+type responseBulkUpdater struct {
+	responseRepo     repositories.ResponseRepository
+	reviewRepo       repositories.ReviewRepository
+	chatRepo         repositories.ChatRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+func newResponseBulkUpdater(
+	responseRepo repositories.ResponseRepository,
+	reviewRepo repositories.ReviewRepository,
+	chatRepo repositories.ChatRepository,
+	notificationRepo repositories.NotificationRepository,
+) *responseBulkUpdater {
+	return &responseBulkUpdater{
+		responseRepo:     responseRepo,
+		reviewRepo:       reviewRepo,
+		chatRepo:         chatRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// bulkOutcome - результат Apply: отчет для клиента + измененные отклики для afterCommit
+type bulkOutcome struct {
+	Result  *dto.BulkResponseResult
+	Changed []models.CastingResponse
+	Status  models.ResponseStatus
+	Message *dto.BulkResponseMessage
+}
+
+// RejectPending - отклоняет все pending-отклики кастинга
+func (u *responseBulkUpdater) RejectPending(tx *gorm.DB, casting *models.Casting, employerUserID string, message *dto.BulkResponseMessage) (*bulkOutcome, error) {
+	pending, err := u.responseRepo.FindResponsesByStatus(tx, casting.ID, models.ResponseStatusPending)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	ids := make([]string, 0, len(pending))
+	for _, response := range pending {
+		ids = append(ids, response.ID)
+	}
+	return u.Apply(tx, casting, employerUserID, ids, models.ResponseStatusRejected, message)
+}
+
+// Apply - переводит выбранные отклики в status. Невалидные элементы попадают в отчет,
+// ошибки БД прерывают всю операцию (транзакция откатывается вызывающим).
+func (u *responseBulkUpdater) Apply(tx *gorm.DB, casting *models.Casting, employerUserID string, responseIDs []string, status models.ResponseStatus, message *dto.BulkResponseMessage) (*bulkOutcome, error) {
+	if status == models.ResponseStatusWithdrawn {
+		return nil, apperrors.ErrInvalidStatus("response", "Employer cannot set 'withdrawn' status")
+	}
+
+	found, err := u.responseRepo.FindResponsesByIDs(tx, casting.ID, responseIDs)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	byID := make(map[string]models.CastingResponse, len(found))
+	for _, response := range found {
+		byID[response.ID] = response
+	}
+
+	outcome := &bulkOutcome{
+		Result:  &dto.BulkResponseResult{Results: []dto.BulkResponseItemResult{}},
+		Status:  status,
+		Message: message,
+	}
+	seen := make(map[string]bool, len(responseIDs))
+	var changedIDs []string
+
+	for _, id := range responseIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		item := dto.BulkResponseItemResult{ResponseID: id}
+		response, ok := byID[id]
+		switch {
+		case !ok:
+			item.Error = "response not found in this casting"
+		case response.Status == models.ResponseStatusWithdrawn:
+			item.Error = "response was withdrawn by the model"
+		case response.Status == status:
+			item.Error = fmt.Sprintf("response is already '%s'", status)
+		default:
+			item.Success = true
+			item.Status = status
+			changedIDs = append(changedIDs, id)
+			outcome.Changed = append(outcome.Changed, response)
+		}
+		outcome.Result.Results = append(outcome.Result.Results, item)
+	}
+
+	if _, err := u.responseRepo.BulkUpdateResponseStatus(tx, changedIDs, status); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	for i := range outcome.Changed {
+		response := &outcome.Changed[i]
+		if status == models.ResponseStatusAccepted {
+			// Как и в одиночном UpdateResponseStatus: ошибка заготовки отзыва не блокирует операцию
+			if err := createResponseReviewPlaceholder(tx, u.reviewRepo, casting, response); err != nil {
+				log.Printf("Failed to create review placeholder: %v", err)
+			}
+		}
+		if message != nil && message.Channel == BulkMessageChannelChat {
+			if err := u.sendChatMessage(tx, casting, employerUserID, response.ModelID, renderBulkMessage(message.Template, casting, status)); err != nil {
+				return nil, apperrors.InternalError(err)
+			}
+		}
+	}
+
+	outcome.Result.Total = len(outcome.Result.Results)
+	outcome.Result.Succeeded = len(outcome.Changed)
+	outcome.Result.Failed = outcome.Result.Total - outcome.Result.Succeeded
+	return outcome, nil
+}
+
+// afterCommit - уведомления моделям (стандартные или по шаблону)
+func (u *responseBulkUpdater) afterCommit(db *gorm.DB, casting *models.Casting, outcome *bulkOutcome) {
+	if outcome == nil || len(outcome.Changed) == 0 {
+		return
+	}
+
+	text := ""
+	if outcome.Message != nil {
+		if outcome.Message.Channel == BulkMessageChannelChat {
+			// Модели уже получили сообщение в чат внутри транзакции
+			return
+		}
+		text = renderBulkMessage(outcome.Message.Template, casting, outcome.Status)
+	}
+
+	items := make([]repositories.ResponseStatusNotificationData, 0, len(outcome.Changed))
+	for _, response := range outcome.Changed {
+		items = append(items, repositories.ResponseStatusNotificationData{
+			ModelID:      response.ModelID,
+			CastingID:    casting.ID,
+			ResponseID:   response.ID,
+			CastingTitle: casting.Title,
+			Status:       outcome.Status,
+			Message:      text,
+		})
+	}
+
+	go func() {
+		if err := u.notificationRepo.CreateBulkResponseStatusNotifications(db, items); err != nil {
+			log.Printf("Failed to create bulk status notifications: %v", err)
+		}
+	}()
+}
+
+// sendChatMessage - сообщение в существующий диалог с моделью или в новый диалог по кастингу
+func (u *responseBulkUpdater) sendChatMessage(tx *gorm.DB, casting *models.Casting, employerUserID, modelID, content string) error {
+	dialog, err := u.chatRepo.FindDialogBetweenUsers(tx, employerUserID, modelID)
+	if err != nil || dialog == nil {
+		dialog, err = u.chatRepo.CreateCastingDialog(tx, casting, employerUserID, modelID)
+		if err != nil {
+			return err
+		}
+	}
+	return u.chatRepo.CreateMessage(tx, &chat.Message{
+		DialogID:  dialog.ID,
+		SenderID:  employerUserID,
+		Type:      "text",
+		Content:   content,
+		Status:    "sent",
+		CreatedAt: time.Now(),
+	})
+}
+
+// renderBulkMessage - подставляет {{casting_title}} и {{status}} в шаблон
+func renderBulkMessage(template string, casting *models.Casting, status models.ResponseStatus) string {
+	return strings.NewReplacer(
+		"{{casting_title}}", casting.Title,
+		"{{status}}", string(status),
+	).Replace(template)
+}
+
+// createResponseReviewPlaceholder - заготовка отзыва после принятия отклика
+func createResponseReviewPlaceholder(db *gorm.DB, reviewRepo repositories.ReviewRepository, casting *models.Casting, response *models.CastingResponse) error {
+	review := &models.Review{
+		ModelID:    response.ModelID,
+		EmployerID: casting.EmployerID,
+		CastingID:  &casting.ID,
+		Rating:     0,
+		ReviewText: "",
+		Status:     models.ReviewStatusPending,
+	}
+	return reviewRepo.CreateReview(db, review)
+}
@@ -20,12 +20,29 @@ import (
 type ResponseService interface {
 	CreateResponse(db *gorm.DB, modelID, castingID string, req *dto.CreateResponseRequest) (*models.CastingResponse, error)
 	GetModelResponses(db *gorm.DB, modelID string) ([]models.CastingResponse, error)
-	DeleteResponse(db *gorm.DB, modelID, responseID string) error
-	GetCastingResponses(db *gorm.DB, castingID, employerID string) ([]dto.ResponseSummary, error)
+	WithdrawResponse(db *gorm.DB, modelID, responseID string, req *dto.WithdrawResponseRequest) error
+	GetCastingResponses(db *gorm.DB, castingID, employerID string, filter *dto.ResponseListFilter) ([]dto.ResponseSummary, error)
 	UpdateResponseStatus(db *gorm.DB, employerID, responseID string, status models.ResponseStatus) error
 	MarkResponseAsViewed(db *gorm.DB, employerID, responseID string) error
 	GetResponseStats(db *gorm.DB, castingID string) (*dto.CastingStatsResponse, error)
 	GetResponse(db *gorm.DB, responseID, userID string) (*models.CastingResponse, error)
+
+	// Bulk operations (одна транзакция, отчет по каждому отклику)
+	BulkRejectPending(db *gorm.DB, employerID, castingID string, req *dto.BulkRejectPendingRequest) (*dto.BulkResponseResult, error)
+	BulkUpdateStatus(db *gorm.DB, employerID, castingID string, req *dto.BulkUpdateResponsesRequest) (*dto.BulkResponseResult, error)
+}
+
+// ResponseConfig - правила отзыва и повторного отклика
+type ResponseConfig struct {
+	ReapplyCooldown time.Duration // Пауза после отзыва, прежде чем можно откликнуться снова
+	MaxReapplies    int           // Сколько раз можно повторно откликнуться на один кастинг
+}
+
+func GetDefaultResponseConfig() *ResponseConfig {
+	return &ResponseConfig{
+		ReapplyCooldown: 24 * time.Hour,
+		MaxReapplies:    2,
+	}
 }
 
 // =======================
@@ -39,6 +56,10 @@ type ResponseServiceImpl struct {
 	subscriptionRepo repositories.SubscriptionRepository
 	notificationRepo repositories.NotificationRepository
 	reviewRepo       repositories.ReviewRepository
+	noteRepo         repositories.EmployerNoteRepository
+	blockRepo        repositories.BlockRepository
+	bulkUpdater      *responseBulkUpdater
+	config           *ResponseConfig
 }
 
 // ✅ Конструктор обновлен (db убран)
@@ -50,7 +71,14 @@ func NewResponseService(
 	subscriptionRepo repositories.SubscriptionRepository,
 	notificationRepo repositories.NotificationRepository,
 	reviewRepo repositories.ReviewRepository,
+	chatRepo repositories.ChatRepository,
+	noteRepo repositories.EmployerNoteRepository,
+	blockRepo repositories.BlockRepository,
+	config *ResponseConfig,
 ) ResponseService {
+	if config == nil {
+		config = GetDefaultResponseConfig()
+	}
 	return &ResponseServiceImpl{
 		// ❌ 'db: db,' УДАЛЕНО
 		responseRepo:     responseRepo,
@@ -59,6 +87,10 @@ func NewResponseService(
 		subscriptionRepo: subscriptionRepo,
 		notificationRepo: notificationRepo,
 		reviewRepo:       reviewRepo,
+		noteRepo:         noteRepo,
+		blockRepo:        blockRepo,
+		bulkUpdater:      newResponseBulkUpdater(responseRepo, reviewRepo, chatRepo, notificationRepo),
+		config:           config,
 	}
 }
 
@@ -97,11 +129,20 @@ func (s *ResponseServiceImpl) CreateResponse(db *gorm.DB, modelID, castingID str
 	if casting.EmployerID == modelID {
 		return nil, errors.New("cannot respond to your own casting")
 	}
+	// Откликнуться на кастинг работодателя из черного списка (или заблокировавшего модель) нельзя
+	if employerUser, err := s.userRepo.FindByProfileID(tx, casting.EmployerID); err == nil {
+		if err := ensureNotBlocked(tx, s.blockRepo, modelID, employerUser.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	// ✅ Передаем tx
 	existingResponse, _ := s.responseRepo.FindResponseByCastingAndModel(tx, castingID, modelID)
 	if existingResponse != nil {
-		return nil, errors.New("you have already responded to this casting")
+		if existingResponse.Status != models.ResponseStatusWithdrawn {
+			return nil, errors.New("you have already responded to this casting")
+		}
+		return s.reapply(db, tx, model, casting, existingResponse, req)
 	}
 
 	// ✅ Передаем tx
@@ -120,10 +161,11 @@ func (s *ResponseServiceImpl) CreateResponse(db *gorm.DB, modelID, castingID str
 	}
 
 	response := &models.CastingResponse{
-		CastingID: castingID,
-		ModelID:   modelID,
-		Message:   req.Message,
-		Status:    models.ResponseStatusPending,
+		CastingID:    castingID,
+		ModelID:      modelID,
+		Message:      req.Message,
+		Status:       models.ResponseStatusPending,
+		QuotaCharged: true,
 	}
 
 	// ✅ Передаем tx
@@ -167,53 +209,147 @@ func (s *ResponseServiceImpl) GetModelResponses(db *gorm.DB, modelID string) ([]
 	return responses, nil
 }
 
-// DeleteResponse - 'db' добавлен
-func (s *ResponseServiceImpl) DeleteResponse(db *gorm.DB, modelID, responseID string) error {
-	// ✅ Начинаем транзакцию из переданного 'db'
+// reapply - повторный отклик на кастинг после отзыва (внутри транзакции CreateResponse).
+// Запись переиспользуется, поэтому лимит подписки списывается не более одного раза на кастинг.
+func (s *ResponseServiceImpl) reapply(db, tx *gorm.DB, model *models.User, casting *models.Casting, response *models.CastingResponse, req *dto.CreateResponseRequest) (*models.CastingResponse, error) {
+	if response.WithdrawCount > s.config.MaxReapplies {
+		return nil, apperrors.ErrResponseWithdrawLimit
+	}
+	if response.WithdrawnAt != nil {
+		availableAt := response.WithdrawnAt.Add(s.config.ReapplyCooldown)
+		if time.Now().Before(availableAt) {
+			return nil, apperrors.ErrReapplyCooldown(availableAt)
+		}
+	}
+
+	// Лимит списывается заново, только если он был возвращен при первом отзыве
+	if !response.QuotaCharged {
+		canRespond, err := s.subscriptionRepo.CanUserRespond(tx, model.ID)
+		if err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		if !canRespond {
+			return nil, errors.New("subscription limit reached")
+		}
+		if err := s.subscriptionRepo.IncrementSubscriptionUsage(tx, model.ID, "responses"); err != nil {
+			return nil, fmt.Errorf("failed to update subscription: %w", err)
+		}
+	}
+
+	if err := s.responseRepo.ReapplyResponse(tx, response.ID, req.Message, true); err != nil {
+		return nil, handleResponseError(err)
+	}
+
+	updated, err := s.responseRepo.FindResponseByID(tx, response.ID)
+	if err != nil {
+		return nil, handleResponseError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	go func() {
+		if err := s.notificationRepo.CreateNewResponseNotification(
+			db,
+			casting.EmployerID,
+			casting.ID,
+			updated.ID,
+			model.Email,
+		); err != nil {
+			log.Printf("Failed to create notification: %v", err)
+		}
+	}()
+
+	return updated, nil
+}
+
+// withdrawableResponseStatuses - из каких статусов модель может отозвать отклик
+var withdrawableResponseStatuses = []models.ResponseStatus{
+	models.ResponseStatusPending,
+	models.ResponseStatusAccepted,
+	models.ResponseStatusApproved,
+}
+
+// WithdrawResponse - отзыв отклика моделью. Запись не удаляется: работодатель видит статус withdrawn и причину.
+func (s *ResponseServiceImpl) WithdrawResponse(db *gorm.DB, modelID, responseID string, req *dto.WithdrawResponseRequest) error {
 	tx := db.Begin()
 	if tx.Error != nil {
 		return apperrors.InternalError(tx.Error)
 	}
 	defer tx.Rollback()
 
-	// ✅ Передаем tx
 	response, err := s.responseRepo.FindResponseByID(tx, responseID)
 	if err != nil {
 		return handleResponseError(err)
 	}
 
 	if response.ModelID != modelID {
-		return errors.New("access denied")
+		return apperrors.ErrInsufficientPermissions
 	}
-	if response.Status != models.ResponseStatusPending {
-		return errors.New("cannot delete response that has been reviewed")
+	if !isWithdrawableResponseStatus(response.Status) {
+		return apperrors.ErrInvalidStatus("response", fmt.Sprintf("Cannot withdraw response in status '%s'", response.Status))
 	}
 
-	// ✅ Передаем tx
-	err = s.responseRepo.DeleteResponse(tx, responseID)
-	if err != nil {
-		return apperrors.InternalError(err)
+	// Лимит возвращается только за первый отзыв еще не рассмотренного отклика,
+	// иначе цикл "отозвал - откликнулся" позволял бы бесконечно обнулять счетчик
+	quotaCharged := response.QuotaCharged
+	if quotaCharged && response.Status == models.ResponseStatusPending && response.WithdrawCount == 0 {
+		if err := s.subscriptionRepo.DecrementSubscriptionUsage(tx, modelID, "responses"); err != nil {
+			log.Printf("Failed to decrement subscription usage: %v", err)
+		} else {
+			quotaCharged = false
+		}
 	}
 
-	// ✅ Передаем tx
-	if err := s.subscriptionRepo.DecrementSubscriptionUsage(tx, modelID, "responses"); err != nil {
-		log.Printf("Failed to decrement subscription usage: %v", err)
+	var reason *string
+	if req != nil && req.Reason != nil && *req.Reason != "" {
+		reason = req.Reason
 	}
 
-	// ✅ Коммитим транзакцию
-	return tx.Commit().Error
+	if err := s.responseRepo.WithdrawResponse(tx, responseID, withdrawableResponseStatuses, reason, quotaCharged); err != nil {
+		return handleResponseError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	// Уведомляем работодателя *после* коммита
+	go func() {
+		employerUserID := response.Casting.EmployerID
+		if employerUser, err := s.userRepo.FindByProfileID(db, response.Casting.EmployerID); err == nil {
+			employerUserID = employerUser.ID
+		}
+		if err := s.notificationRepo.CreateResponseWithdrawnNotification(
+			db,
+			employerUserID,
+			response.CastingID,
+			response.ID,
+			response.Casting.Title,
+			reason,
+		); err != nil {
+			log.Printf("Failed to create withdrawal notification: %v", err)
+		}
+	}()
+
+	return nil
 }
 
-// GetCastingResponses - 'db' добавлен
-func (s *ResponseServiceImpl) GetCastingResponses(db *gorm.DB, castingID, employerID string) ([]dto.ResponseSummary, error) {
+// GetCastingResponses - 'db' добавлен. Отклики дополняются приватными пометками работодателя
+// и фильтруются по ним (tag, min_rating, has_note).
+func (s *ResponseServiceImpl) GetCastingResponses(db *gorm.DB, castingID, employerID string, filter *dto.ResponseListFilter) ([]dto.ResponseSummary, error) {
 	// ✅ Используем 'db' из параметра
 	casting, err := s.castingRepo.FindCastingByID(db, castingID)
 	if err != nil {
 		return nil, handleResponseError(err)
 	}
-	if casting.EmployerID != employerID {
+	if !s.isCastingOwner(db, casting, employerID) {
 		return nil, errors.New("access denied")
 	}
+	if filter == nil {
+		filter = &dto.ResponseListFilter{}
+	}
 
 	// ✅ Используем 'db' из параметра
 	responses, err := s.responseRepo.FindResponsesByCasting(db, castingID)
@@ -221,8 +357,32 @@ func (s *ResponseServiceImpl) GetCastingResponses(db *gorm.DB, castingID, employ
 		return nil, apperrors.InternalError(err)
 	}
 
+	responseIDs := make([]string, 0, len(responses))
+	modelIDs := make([]string, 0, len(responses))
+	for _, response := range responses {
+		responseIDs = append(responseIDs, response.ID)
+		modelIDs = append(modelIDs, response.ModelID)
+	}
+	responseNotes, err := s.noteRepo.FindNotesByTargets(db, employerID, models.EmployerNoteTargetResponse, responseIDs)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	modelNotes, err := s.noteRepo.FindNotesByTargets(db, employerID, models.EmployerNoteTargetModel, modelIDs)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
 	var summaries []dto.ResponseSummary
 	for _, response := range responses {
+		if filter.Status != "" && string(response.Status) != filter.Status {
+			continue
+		}
+		responseNote := responseNotes[response.ID]
+		modelNote := modelNotes[response.ModelID]
+		if !matchesNoteFilter(filter, responseNote, modelNote) {
+			continue
+		}
+
 		// ✅ Используем 'db' из параметра
 		model, err := s.userRepo.FindByID(db, response.ModelID)
 		modelName := ""
@@ -237,6 +397,15 @@ func (s *ResponseServiceImpl) GetCastingResponses(db *gorm.DB, castingID, employ
 			Message:   response.Message,
 			Status:    response.Status,
 			CreatedAt: response.CreatedAt,
+
+			WithdrawnAt:      response.WithdrawnAt,
+			WithdrawalReason: response.WithdrawalReason,
+		}
+		if responseNote != nil || modelNote != nil {
+			summary.PrivateNotes = &dto.ApplicantPrivateNotes{
+				Response: toEmployerNoteResponse(responseNote),
+				Model:    toEmployerNoteResponse(modelNote),
+			}
 		}
 		summaries = append(summaries, summary)
 	}
@@ -244,8 +413,13 @@ func (s *ResponseServiceImpl) GetCastingResponses(db *gorm.DB, castingID, employ
 	return summaries, nil
 }
 
-// UpdateResponseStatus - 'db' добавлен
+// UpdateResponseStatus - 'db' добавлен. Отзыв - действие только модели (WithdrawResponse),
+// отозванный отклик работодатель изменить не может (как и при массовом обновлении).
 func (s *ResponseServiceImpl) UpdateResponseStatus(db *gorm.DB, employerID, responseID string, status models.ResponseStatus) error {
+	if status == models.ResponseStatusWithdrawn {
+		return apperrors.ErrInvalidStatus("response", "Employer cannot set 'withdrawn' status")
+	}
+
 	// ✅ Начинаем транзакцию из переданного 'db'
 	tx := db.Begin()
 	if tx.Error != nil {
@@ -265,8 +439,11 @@ func (s *ResponseServiceImpl) UpdateResponseStatus(db *gorm.DB, employerID, resp
 		return handleResponseError(err)
 	}
 
-	if casting.EmployerID != employerID {
-		return errors.New("access denied")
+	if !s.isCastingOwner(tx, casting, employerID) {
+		return apperrors.ErrInsufficientPermissions
+	}
+	if response.Status == models.ResponseStatusWithdrawn {
+		return apperrors.ErrInvalidStatus("response", "Response was withdrawn by the model")
 	}
 
 	oldStatus := response.Status
@@ -351,10 +528,11 @@ func (s *ResponseServiceImpl) GetResponseStats(db *gorm.DB, castingID string) (*
 	}
 
 	return &dto.CastingStatsResponse{
-		TotalResponses:    stats.TotalResponses,
-		PendingResponses:  stats.PendingResponses,
-		AcceptedResponses: stats.AcceptedResponses,
-		RejectedResponses: stats.RejectedResponses,
+		TotalResponses:     stats.TotalResponses,
+		PendingResponses:   stats.PendingResponses,
+		AcceptedResponses:  stats.AcceptedResponses,
+		RejectedResponses:  stats.RejectedResponses,
+		WithdrawnResponses: stats.WithdrawnResponses,
 	}, nil
 }
 
@@ -379,27 +557,104 @@ func (s *ResponseServiceImpl) GetResponse(db *gorm.DB, responseID, userID string
 	return response, nil
 }
 
+// Bulk Operations
+
+// BulkRejectPending - отклоняет все pending-отклики кастинга
+func (s *ResponseServiceImpl) BulkRejectPending(db *gorm.DB, employerID, castingID string, req *dto.BulkRejectPendingRequest) (*dto.BulkResponseResult, error) {
+	return s.runBulk(db, employerID, castingID, func(tx *gorm.DB, casting *models.Casting) (*bulkOutcome, error) {
+		return s.bulkUpdater.RejectPending(tx, casting, employerID, req.Message)
+	})
+}
+
+// BulkUpdateStatus - переводит выбранные отклики кастинга в указанный статус
+func (s *ResponseServiceImpl) BulkUpdateStatus(db *gorm.DB, employerID, castingID string, req *dto.BulkUpdateResponsesRequest) (*dto.BulkResponseResult, error) {
+	return s.runBulk(db, employerID, castingID, func(tx *gorm.DB, casting *models.Casting) (*bulkOutcome, error) {
+		return s.bulkUpdater.Apply(tx, casting, employerID, req.ResponseIDs, req.Status, req.Message)
+	})
+}
+
+// runBulk - транзакция + проверка владельца кастинга + уведомления после коммита
+func (s *ResponseServiceImpl) runBulk(db *gorm.DB, employerID, castingID string, apply func(tx *gorm.DB, casting *models.Casting) (*bulkOutcome, error)) (*dto.BulkResponseResult, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	casting, err := s.castingRepo.FindCastingByID(tx, castingID)
+	if err != nil {
+		return nil, handleResponseError(err)
+	}
+	employerUser, err := s.userRepo.FindByProfileID(tx, casting.EmployerID)
+	if err != nil {
+		return nil, handleResponseError(err)
+	}
+	if employerUser.ID != employerID {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+
+	outcome, err := apply(tx, casting)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	s.bulkUpdater.afterCommit(db, casting, outcome)
+	return outcome.Result, nil
+}
+
 // Helper Methods
 
 // createReviewPlaceholder - (уже был 'db')
 func (s *ResponseServiceImpl) createReviewPlaceholder(db *gorm.DB, casting *models.Casting, response *models.CastingResponse) error {
-	review := &models.Review{
-		ModelID:    response.ModelID,
-		EmployerID: casting.EmployerID,
-		CastingID:  &casting.ID,
-		Rating:     0,
-		ReviewText: "",
-		Status:     models.ReviewStatusPending,
-	}
-
 	// ✅ Передаем db
-	if err := s.reviewRepo.CreateReview(db, review); err != nil {
+	if err := createResponseReviewPlaceholder(db, s.reviewRepo, casting, response); err != nil {
 		log.Printf("Failed to create review placeholder: %v", err)
 		return err
 	}
 	return nil
 }
 
+// isCastingOwner - casting.EmployerID хранит employer_profiles.id; прямое сравнение
+// оставлено для кастингов, созданных со ссылкой на users.id
+func (s *ResponseServiceImpl) isCastingOwner(db *gorm.DB, casting *models.Casting, userID string) bool {
+	if casting.EmployerID == userID {
+		return true
+	}
+	employerUser, err := s.userRepo.FindByProfileID(db, casting.EmployerID)
+	return err == nil && employerUser.ID == userID
+}
+
+// matchesNoteFilter - пометка на отклике приоритетнее пометки на модели (для рейтинга),
+// тег ищется в обеих
+func matchesNoteFilter(filter *dto.ResponseListFilter, responseNote, modelNote *models.EmployerNote) bool {
+	if filter.HasNote && responseNote == nil && modelNote == nil {
+		return false
+	}
+	if filter.Tag != "" {
+		hasTag := (responseNote != nil && responseNote.HasTag(filter.Tag)) ||
+			(modelNote != nil && modelNote.HasTag(filter.Tag))
+		if !hasTag {
+			return false
+		}
+	}
+	if filter.MinRating > 0 {
+		var rating *int
+		if responseNote != nil && responseNote.Rating != nil {
+			rating = responseNote.Rating
+		} else if modelNote != nil {
+			rating = modelNote.Rating
+		}
+		if rating == nil || *rating < filter.MinRating {
+			return false
+		}
+	}
+	return true
+}
+
 // (Вспомогательный хелпер для ошибок - без изменений)
 func handleResponseError(err error) error {
 	if errors.Is(err, gorm.ErrRecordNotFound) ||
@@ -411,5 +666,17 @@ func handleResponseError(err error) error {
 	if errors.Is(err, repositories.ErrResponseAlreadyExists) {
 		return apperrors.ErrAlreadyExists(err)
 	}
+	if errors.Is(err, repositories.ErrResponseStatusChanged) {
+		return apperrors.ErrResponseStatusChanged
+	}
 	return apperrors.InternalError(err)
 }
+
+func isWithdrawableResponseStatus(status models.ResponseStatus) bool {
+	for _, allowed := range withdrawableResponseStatuses {
+		if status == allowed {
+			return true
+		}
+	}
+	return false
+}
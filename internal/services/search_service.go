@@ -5,6 +5,7 @@ import (
 	"gorm.io/gorm"
 	"strings"
 
+	"mwork_backend/internal/algorithms"
 	"mwork_backend/internal/repositories"
 	"mwork_backend/internal/services/dto"
 	"mwork_backend/pkg/apperrors"
@@ -42,6 +43,7 @@ type searchService struct {
 	profileRepo   repositories.ProfileRepository
 	portfolioRepo repositories.PortfolioRepository
 	reviewRepo    repositories.ReviewRepository
+	promotionRepo repositories.PromotionRepository
 	// TODO: Тебе понадобятся репозитории для истории поиска и аналитики
 	// searchHistoryRepo repositories.SearchHistoryRepository
 	// analyticsRepo     repositories.AnalyticsRepository
@@ -54,6 +56,7 @@ func NewSearchService(
 	profileRepo repositories.ProfileRepository,
 	portfolioRepo repositories.PortfolioRepository,
 	reviewRepo repositories.ReviewRepository,
+	promotionRepo repositories.PromotionRepository,
 ) SearchService {
 	return &searchService{
 		// ❌ 'db: db,' УДАЛЕНО
@@ -61,6 +64,7 @@ func NewSearchService(
 		profileRepo:   profileRepo,
 		portfolioRepo: portfolioRepo,
 		reviewRepo:    reviewRepo,
+		promotionRepo: promotionRepo,
 	}
 }
 
@@ -74,21 +78,24 @@ func (s *searchService) SearchCastings(db *gorm.DB, req *dto.SearchCastingsReque
 		return nil, err
 	}
 	criteria := repositories.CastingSearchCriteria{
-		Query:      req.Query,
-		City:       req.City,
-		Categories: req.Categories,
-		Gender:     req.Gender,
-		MinAge:     req.MinAge,
-		MaxAge:     req.MaxAge,
-		MinSalary:  req.MinSalary,
-		MaxSalary:  req.MaxSalary,
-		JobType:    req.JobType,
-		Status:     req.Status,
-		EmployerID: req.EmployerID,
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		SortBy:     req.SortBy,
-		SortOrder:  req.SortOrder,
+		Query:            req.Query,
+		City:             req.City,
+		Categories:       req.Categories,
+		Gender:           req.Gender,
+		MinAge:           req.MinAge,
+		MaxAge:           req.MaxAge,
+		MinSalary:        req.MinSalary,
+		MaxSalary:        req.MaxSalary,
+		JobType:          req.JobType,
+		Status:           req.Status,
+		EmployerID:       req.EmployerID,
+		VerifiedEmployer: req.VerifiedEmployer,
+		Page:             req.Page,
+		PageSize:         req.PageSize,
+		SortBy:           req.SortBy,
+		SortOrder:        req.SortOrder,
+		QueryLocales:     algorithms.SearchLocales(req.Query, req.Locale),
+		HiddenForUserID:  req.RequesterID,
 	}
 
 	// ✅ Используем 'db' из параметра
@@ -96,26 +103,32 @@ func (s *searchService) SearchCastings(db *gorm.DB, req *dto.SearchCastingsReque
 	if err != nil {
 		return nil, apperrors.InternalError(err)
 	}
+	// Флаг "promoted" + учет показов продвигаемых кастингов
+	applyPromotionFlags(db, s.promotionRepo, castings)
 	return s.buildPaginatedResponse(castings, total, req.Page, req.PageSize), nil
 }
 
 // SearchCastingsAdvanced - 'db' добавлен
 func (s *searchService) SearchCastingsAdvanced(db *gorm.DB, req *dto.AdvancedCastingSearchRequest) (*dto.PaginatedResponse, error) {
 	basicReq := &dto.SearchCastingsRequest{
-		Query:      req.Query,
-		City:       req.City,
-		Categories: req.Categories,
-		MinSalary:  req.MinSalary,
-		MaxSalary:  req.MaxSalary,
-		Gender:     req.Gender,
-		MinAge:     req.MinAge,
-		MaxAge:     req.MaxAge,
-		JobType:    req.JobType,
-		Status:     req.Status,
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		SortBy:     req.SortBy,
-		SortOrder:  req.SortOrder,
+		Query:            req.Query,
+		City:             req.City,
+		Categories:       req.Categories,
+		MinSalary:        req.MinSalary,
+		MaxSalary:        req.MaxSalary,
+		Gender:           req.Gender,
+		MinAge:           req.MinAge,
+		MaxAge:           req.MaxAge,
+		JobType:          req.JobType,
+		Status:           req.Status,
+		EmployerID:       req.EmployerID,
+		VerifiedEmployer: req.VerifiedEmployer,
+		Page:             req.Page,
+		PageSize:         req.PageSize,
+		SortBy:           req.SortBy,
+		SortOrder:        req.SortOrder,
+		RequesterID:      req.RequesterID,
+		Locale:           req.Locale,
 	}
 
 	// ✅ Передаем 'db'
@@ -183,6 +196,14 @@ func (s *searchService) SearchModels(db *gorm.DB, req *dto.SearchModelsRequest)
 		PageSize:      req.PageSize,
 		SortBy:        req.SortBy,
 		SortOrder:     req.SortOrder,
+
+		DownRankIncomplete: req.DownRankIncomplete,
+		HiddenForUserID:    req.RequesterID,
+		QueryLocales:       algorithms.SearchLocales(req.Query, req.Locale),
+	}
+
+	if err := applyMeasurementFilters(&criteria, req); err != nil {
+		return nil, err
 	}
 
 	// ✅ Используем 'db' из параметра
@@ -195,31 +216,18 @@ func (s *searchService) SearchModels(db *gorm.DB, req *dto.SearchModelsRequest)
 
 // SearchModelsAdvanced - 'db' добавлен
 func (s *searchService) SearchModelsAdvanced(db *gorm.DB, req *dto.AdvancedModelSearchRequest) (*dto.PaginatedResponse, error) {
-	basicReq := &dto.SearchModelsRequest{
-		Query:         req.Query,
-		City:          req.City,
-		Categories:    req.Categories,
-		Gender:        req.Gender,
-		MinAge:        req.MinAge,
-		MaxAge:        req.MaxAge,
-		MinHeight:     req.MinHeight,
-		MaxHeight:     req.MaxHeight,
-		MinWeight:     req.MinWeight,
-		MaxWeight:     req.MaxWeight,
-		MinPrice:      req.MinPrice,
-		MaxPrice:      req.MaxPrice,
-		MinExperience: req.MinExperience,
-		Languages:     req.Languages,
-		AcceptsBarter: req.AcceptsBarter,
-		MinRating:     req.MinRating,
-		Page:          req.Page,
-		PageSize:      req.PageSize,
-		SortBy:        req.SortBy,
-		SortOrder:     req.SortOrder,
+	basicReq := req.SearchModelsRequest
+	basicReq.IsPublic = nil
+	// Одиночные размеры расширенного поиска - частный случай фильтров по размерам
+	if req.ClothingSize != "" {
+		basicReq.ClothingSizes = append(basicReq.ClothingSizes, req.ClothingSize)
+	}
+	if req.ShoeSize != "" {
+		basicReq.MinShoeSize, basicReq.MaxShoeSize = req.ShoeSize, req.ShoeSize
 	}
 
 	// ✅ Передаем 'db'
-	response, err := s.SearchModels(db, basicReq)
+	response, err := s.SearchModels(db, &basicReq)
 	if err != nil {
 		return nil, err
 	}
@@ -265,12 +273,13 @@ func (s *searchService) SearchEmployers(db *gorm.DB, req *dto.SearchEmployersReq
 		return nil, err
 	}
 	criteria := repositories.EmployerSearchCriteria{
-		Query:       req.Query,
-		City:        req.City,
-		CompanyType: req.CompanyType,
-		IsVerified:  req.IsVerified,
-		Page:        req.Page,
-		PageSize:    req.PageSize,
+		Query:           req.Query,
+		City:            req.City,
+		CompanyType:     req.CompanyType,
+		IsVerified:      req.IsVerified,
+		Page:            req.Page,
+		PageSize:        req.PageSize,
+		HiddenForUserID: req.RequesterID,
 	}
 
 	// ✅ Используем 'db' из параметра
@@ -288,40 +297,40 @@ func (s *searchService) UnifiedSearch(db *gorm.DB, req *dto.UnifiedSearchRequest
 
 	switch req.Type {
 	case "all", "":
-		castingReq := &dto.SearchCastingsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize / 3}
+		castingReq := &dto.SearchCastingsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize / 3, RequesterID: req.RequesterID}
 		// ✅ Передаем 'db'
 		if castings, err := s.SearchCastings(db, castingReq); err == nil {
 			response.Castings = castings
 			totalResults += int(castings.Total)
 		}
-		modelReq := &dto.SearchModelsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize / 3}
+		modelReq := &dto.SearchModelsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize / 3, RequesterID: req.RequesterID}
 		// ✅ Передаем 'db'
 		if models, err := s.SearchModels(db, modelReq); err == nil {
 			response.Models = models
 			totalResults += int(models.Total)
 		}
-		employerReq := &dto.SearchEmployersRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize / 3}
+		employerReq := &dto.SearchEmployersRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize / 3, RequesterID: req.RequesterID}
 		// ✅ Передаем 'db'
 		if employers, err := s.SearchEmployers(db, employerReq); err == nil {
 			response.Employers = employers
 			totalResults += int(employers.Total)
 		}
 	case "castings":
-		castingReq := &dto.SearchCastingsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize}
+		castingReq := &dto.SearchCastingsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize, RequesterID: req.RequesterID}
 		// ✅ Передаем 'db'
 		if castings, err := s.SearchCastings(db, castingReq); err == nil {
 			response.Castings = castings
 			totalResults = int(castings.Total)
 		}
 	case "models":
-		modelReq := &dto.SearchModelsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize}
+		modelReq := &dto.SearchModelsRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize, RequesterID: req.RequesterID}
 		// ✅ Передаем 'db'
 		if models, err := s.SearchModels(db, modelReq); err == nil {
 			response.Models = models
 			totalResults = int(models.Total)
 		}
 	case "employers":
-		employerReq := &dto.SearchEmployersRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize}
+		employerReq := &dto.SearchEmployersRequest{Query: req.Query, City: req.City, Page: req.Page, PageSize: req.PageSize, RequesterID: req.RequesterID}
 		// ✅ Передаем 'db'
 		if employers, err := s.SearchEmployers(db, employerReq); err == nil {
 			response.Employers = employers
@@ -0,0 +1,529 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/algorithms"
+	"mwork_backend/internal/imageprocessor"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/internal/storage"
+	"mwork_backend/pkg/apperrors"
+)
+
+// ShareConfig - параметры публичных страниц
+type ShareConfig struct {
+	SiteURL      string // адрес сайта без завершающего "/"
+	SiteName     string // og:site_name
+	DefaultImage string // превью, если у сущности нет фото
+}
+
+func GetDefaultShareConfig() ShareConfig {
+	return ShareConfig{
+		SiteURL:      "https://mwork.ru",
+		SiteName:     "MWork",
+		DefaultImage: "https://mwork.ru/static/og-default.png",
+	}
+}
+
+// sharePaths - сегмент адреса публичной страницы: /share/{models|employers|castings}/:slug
+var sharePaths = map[string]string{
+	models.SlugEntityModel:    "models",
+	models.SlugEntityEmployer: "employers",
+	models.SlugEntityCasting:  "castings",
+}
+
+const (
+	// shareDescriptionLength - длина og:description (превью обрезают около 200 символов)
+	shareDescriptionLength = 200
+	// slugCandidates - попыток base, base-2 ... до суффикса из ID
+	slugCandidates = 9
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type ShareService interface {
+	// Владелец (userID = users.id). Если адрес еще не выбран, он создается из имени.
+	GetMyShareLink(db *gorm.DB, userID string) (*dto.ShareLinkResponse, error)
+	UpdateMySlug(db *gorm.DB, userID string, req *dto.UpdateSlugRequest) (*dto.ShareLinkResponse, error)
+	GetCastingShareLink(db *gorm.DB, userID, castingID string) (*dto.ShareLinkResponse, error)
+	UpdateCastingSlug(db *gorm.DB, userID, castingID string, req *dto.UpdateSlugRequest) (*dto.ShareLinkResponse, error)
+
+	// Публичная страница: entityPath - models, employers или castings.
+	// Закрытые профили и неопубликованные кастинги не отдаются (404).
+	GetSharePage(ctx context.Context, db *gorm.DB, entityPath, slug string) (*dto.SharePage, error)
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type shareService struct {
+	slugRepo      repositories.SlugRepository
+	profileRepo   repositories.ProfileRepository
+	castingRepo   repositories.CastingRepository
+	userRepo      repositories.UserRepository
+	portfolioRepo repositories.PortfolioRepository
+	uploadRepo    repositories.UploadRepository
+	storage       storage.Storage
+	config        ShareConfig
+}
+
+func NewShareService(
+	slugRepo repositories.SlugRepository,
+	profileRepo repositories.ProfileRepository,
+	castingRepo repositories.CastingRepository,
+	userRepo repositories.UserRepository,
+	portfolioRepo repositories.PortfolioRepository,
+	uploadRepo repositories.UploadRepository,
+	storage storage.Storage,
+	config ShareConfig,
+) ShareService {
+	return &shareService{
+		slugRepo:      slugRepo,
+		profileRepo:   profileRepo,
+		castingRepo:   castingRepo,
+		userRepo:      userRepo,
+		portfolioRepo: portfolioRepo,
+		uploadRepo:    uploadRepo,
+		storage:       storage,
+		config:        config,
+	}
+}
+
+// --- Владелец ---
+
+func (s *shareService) GetMyShareLink(db *gorm.DB, userID string) (*dto.ShareLinkResponse, error) {
+	entityType, entityID, current, name, err := s.myProfile(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := s.ensureSlug(db, entityType, entityID, current, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.shareLink(entityType, entityID, slug), nil
+}
+
+func (s *shareService) UpdateMySlug(db *gorm.DB, userID string, req *dto.UpdateSlugRequest) (*dto.ShareLinkResponse, error) {
+	entityType, entityID, current, _, err := s.myProfile(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := s.changeSlug(db, entityType, entityID, current, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.shareLink(entityType, entityID, slug), nil
+}
+
+func (s *shareService) GetCastingShareLink(db *gorm.DB, userID, castingID string) (*dto.ShareLinkResponse, error) {
+	casting, err := s.myCasting(db, userID, castingID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := s.ensureSlug(db, models.SlugEntityCasting, casting.ID, casting.Slug, casting.Title)
+	if err != nil {
+		return nil, err
+	}
+	return s.shareLink(models.SlugEntityCasting, casting.ID, slug), nil
+}
+
+func (s *shareService) UpdateCastingSlug(db *gorm.DB, userID, castingID string, req *dto.UpdateSlugRequest) (*dto.ShareLinkResponse, error) {
+	casting, err := s.myCasting(db, userID, castingID)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := s.changeSlug(db, models.SlugEntityCasting, casting.ID, casting.Slug, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+	return s.shareLink(models.SlugEntityCasting, casting.ID, slug), nil
+}
+
+// --- Публичные страницы ---
+
+// GetSharePage - данные страницы по текущему или прежнему адресу
+func (s *shareService) GetSharePage(ctx context.Context, db *gorm.DB, entityPath, slug string) (*dto.SharePage, error) {
+	entityType := ""
+	for t, path := range sharePaths {
+		if path == entityPath {
+			entityType = t
+		}
+	}
+	if entityType == "" {
+		return nil, apperrors.ErrNotFound(repositories.ErrSlugNotFound)
+	}
+
+	slug = strings.ToLower(slug)
+	entityID, err := s.slugRepo.FindEntityIDBySlug(db, entityType, slug)
+	if errors.Is(err, repositories.ErrSlugNotFound) {
+		redirect, redirectErr := s.slugRepo.FindRedirect(db, entityType, slug)
+		if redirectErr != nil {
+			return nil, handleShareError(redirectErr)
+		}
+		entityID = redirect.EntityID
+	} else if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	var page *dto.SharePage
+	switch entityType {
+	case models.SlugEntityModel:
+		page, err = s.modelPage(ctx, db, entityID)
+	case models.SlugEntityEmployer:
+		page, err = s.employerPage(ctx, db, entityID)
+	default:
+		page, err = s.castingPage(ctx, db, entityID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	page.EntityType = entityType
+	page.EntityID = entityID
+	page.URL = s.pageURL(entityType, page.Slug)
+	page.SiteName = s.config.SiteName
+	page.Redirected = page.Slug != slug
+	if page.ImageURL == "" {
+		page.ImageURL = s.config.DefaultImage
+	}
+	return page, nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// myProfile - профиль модели или работодателя, которому принадлежит адрес
+func (s *shareService) myProfile(db *gorm.DB, userID string) (entityType, entityID string, slug *string, name string, err error) {
+	user, err := s.userRepo.FindByID(db, userID)
+	if err != nil {
+		return "", "", nil, "", handleProfileError(err)
+	}
+
+	switch user.Role {
+	case models.UserRoleModel:
+		profile, err := s.profileRepo.FindModelProfileByUserID(db, userID)
+		if err != nil {
+			return "", "", nil, "", handleProfileError(err)
+		}
+		return models.SlugEntityModel, profile.ID, profile.Slug, profile.Name, nil
+	case models.UserRoleEmployer:
+		profile, err := s.profileRepo.FindEmployerProfileByUserID(db, userID)
+		if err != nil {
+			return "", "", nil, "", handleProfileError(err)
+		}
+		return models.SlugEntityEmployer, profile.ID, profile.Slug, profile.CompanyName, nil
+	default:
+		return "", "", nil, "", apperrors.ErrInvalidUserRole
+	}
+}
+
+// myCasting - кастинг работодателя userID
+func (s *shareService) myCasting(db *gorm.DB, userID, castingID string) (*models.Casting, error) {
+	employer, err := s.profileRepo.FindEmployerProfileByUserID(db, userID)
+	if err != nil {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+	casting, err := s.castingRepo.FindCastingByID(db, castingID)
+	if err != nil {
+		return nil, handleCastingError(err)
+	}
+	if casting.EmployerID != employer.ID {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+	return casting, nil
+}
+
+// ensureSlug - текущий адрес или новый из имени: base, base-2 ... base-9, base-<id>
+func (s *shareService) ensureSlug(db *gorm.DB, entityType, entityID string, current *string, name string) (string, error) {
+	if current != nil && *current != "" {
+		return *current, nil
+	}
+
+	base := algorithms.Slugify(name)
+	if len(base) > algorithms.MaxSlugLength-9 {
+		base = strings.TrimRight(base[:algorithms.MaxSlugLength-9], "-")
+	}
+	if !algorithms.ValidSlug(base) {
+		base = strings.Trim(entityType+"-"+base, "-")
+	}
+
+	candidates := []string{base}
+	for i := 2; i <= slugCandidates; i++ {
+		candidates = append(candidates, fmt.Sprintf("%s-%d", base, i))
+	}
+	candidates = append(candidates, fmt.Sprintf("%s-%s", base, strings.ReplaceAll(entityID, "-", "")[:8]))
+
+	for _, candidate := range candidates {
+		if !algorithms.ValidSlug(candidate) {
+			continue
+		}
+		available, err := s.slugAvailable(db, entityType, entityID, candidate)
+		if err != nil {
+			return "", apperrors.InternalError(err)
+		}
+		if !available {
+			continue
+		}
+		err = s.slugRepo.UpdateEntitySlug(db, entityType, entityID, candidate)
+		if errors.Is(err, repositories.ErrSlugTaken) {
+			continue // адрес заняли параллельно
+		}
+		if err != nil {
+			return "", apperrors.InternalError(err)
+		}
+		return candidate, nil
+	}
+	return "", apperrors.ErrSlugTaken
+}
+
+// changeSlug - новый адрес; прежний сохраняется в истории для перенаправления
+func (s *shareService) changeSlug(db *gorm.DB, entityType, entityID string, current *string, requested string) (string, error) {
+	slug := strings.ToLower(strings.TrimSpace(requested))
+	if !algorithms.ValidSlug(slug) {
+		return "", apperrors.ErrInvalidOperation("slug",
+			"address must be 3-50 latin letters, digits or single hyphens and must not be reserved")
+	}
+	if current != nil && *current == slug {
+		return slug, nil
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return "", apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	available, err := s.slugAvailable(tx, entityType, entityID, slug)
+	if err != nil {
+		return "", apperrors.InternalError(err)
+	}
+	if !available {
+		return "", apperrors.ErrSlugTaken
+	}
+
+	// Возврат к своему прежнему адресу: он больше не перенаправление
+	if err := s.slugRepo.DeleteRedirect(tx, entityType, slug); err != nil {
+		return "", apperrors.InternalError(err)
+	}
+	if current != nil && *current != "" {
+		if err := s.slugRepo.CreateRedirect(tx, &models.SlugRedirect{
+			EntityType: entityType,
+			EntityID:   entityID,
+			Slug:       *current,
+		}); err != nil {
+			return "", handleShareError(err)
+		}
+	}
+	if err := s.slugRepo.UpdateEntitySlug(tx, entityType, entityID, slug); err != nil {
+		return "", handleShareError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return "", apperrors.InternalError(err)
+	}
+	return slug, nil
+}
+
+// slugAvailable - адрес свободен или уже принадлежит этой сущности (текущий или прежний)
+func (s *shareService) slugAvailable(db *gorm.DB, entityType, entityID, slug string) (bool, error) {
+	ownerID, err := s.slugRepo.FindEntityIDBySlug(db, entityType, slug)
+	if err == nil && ownerID != entityID {
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, repositories.ErrSlugNotFound) {
+		return false, err
+	}
+
+	redirect, err := s.slugRepo.FindRedirect(db, entityType, slug)
+	if err == nil && redirect.EntityID != entityID {
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, repositories.ErrSlugNotFound) {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *shareService) modelPage(ctx context.Context, db *gorm.DB, modelID string) (*dto.SharePage, error) {
+	profile, err := s.profileRepo.FindModelProfileByID(db, modelID)
+	if err != nil {
+		return nil, handleShareError(err)
+	}
+	// Закрытый профиль не раскрываем даже по старой ссылке
+	if !profile.IsPublic || profile.Slug == nil {
+		return nil, apperrors.ErrNotFound(repositories.ErrSlugNotFound)
+	}
+
+	facts := []string{"Модель"}
+	if profile.City != "" {
+		facts = append(facts, profile.City)
+	}
+	if profile.Height > 0 {
+		facts = append(facts, fmt.Sprintf("рост %g см", profile.Height))
+	}
+	if categories := profile.GetCategories(); len(categories) > 0 {
+		facts = append(facts, strings.Join(categories, ", "))
+	}
+
+	page := &dto.SharePage{
+		Slug:        *profile.Slug,
+		AppURL:      fmt.Sprintf("%s/profiles/%s", s.config.SiteURL, profile.UserID),
+		Title:       fmt.Sprintf("%s — модель | %s", profile.Name, s.config.SiteName),
+		Description: shareDescription(strings.Join(facts, " · "), profile.Description),
+		OGType:      "profile",
+	}
+
+	if items, err := s.portfolioRepo.FindPublicPortfolioByModel(db, profile.ID); err == nil {
+		for _, item := range items {
+			if item.Upload != nil && item.Upload.IsImage() && item.Upload.IsPublic && item.Upload.IsApproved() {
+				// Через раздачу файлов: анонимные краулеры получают копию с водяным знаком
+				page.ImageURL = fmt.Sprintf("%s/api/v1/files/%s/%s", s.config.SiteURL, item.Upload.ID, imageprocessor.SizeLarge.Name)
+				break
+			}
+		}
+	}
+	return page, nil
+}
+
+func (s *shareService) employerPage(ctx context.Context, db *gorm.DB, employerID string) (*dto.SharePage, error) {
+	profile, err := s.profileRepo.FindEmployerProfileByID(db, employerID)
+	if err != nil {
+		return nil, handleShareError(err)
+	}
+	if profile.Slug == nil {
+		return nil, apperrors.ErrNotFound(repositories.ErrSlugNotFound)
+	}
+
+	facts := []string{"Работодатель"}
+	if profile.City != "" {
+		facts = append(facts, profile.City)
+	}
+	if profile.IsVerified {
+		facts = append(facts, "проверенная компания")
+	}
+
+	return &dto.SharePage{
+		Slug:        *profile.Slug,
+		AppURL:      fmt.Sprintf("%s/profiles/%s", s.config.SiteURL, profile.UserID),
+		Title:       fmt.Sprintf("%s | %s", profile.CompanyName, s.config.SiteName),
+		Description: shareDescription(strings.Join(facts, " · "), profile.Description),
+		ImageURL:    s.entityImage(ctx, db, "employer_profile", profile.ID, "cover_photo", "avatar"),
+		OGType:      "profile",
+	}, nil
+}
+
+func (s *shareService) castingPage(ctx context.Context, db *gorm.DB, castingID string) (*dto.SharePage, error) {
+	casting, err := s.castingRepo.FindCastingByID(db, castingID)
+	if err != nil {
+		return nil, handleShareError(err)
+	}
+	// Черновики и отмененные кастинги не публикуются
+	if casting.Slug == nil ||
+		(casting.Status != models.CastingStatusActive && casting.Status != models.CastingStatusClosed) {
+		return nil, apperrors.ErrNotFound(repositories.ErrSlugNotFound)
+	}
+
+	facts := []string{"Кастинг", casting.City}
+	if casting.CastingDate != nil {
+		facts = append(facts, casting.CastingDate.Format("02.01.2006"))
+	}
+	if casting.PaymentMax > 0 {
+		facts = append(facts, fmt.Sprintf("оплата до %.0f ₸", casting.PaymentMax))
+	}
+	if casting.Status == models.CastingStatusClosed {
+		facts = append(facts, "набор закрыт")
+	}
+
+	image := s.entityImage(ctx, db, "casting", casting.ID, "requirement_photo", "casting_attachment")
+	if image == "" {
+		image = s.entityImage(ctx, db, "employer_profile", casting.EmployerID, "cover_photo", "avatar")
+	}
+
+	return &dto.SharePage{
+		Slug:        *casting.Slug,
+		AppURL:      fmt.Sprintf("%s/castings/%s", s.config.SiteURL, casting.ID),
+		Title:       fmt.Sprintf("%s — %s | %s", casting.Title, casting.Employer.CompanyName, s.config.SiteName),
+		Description: shareDescription(strings.Join(facts, " · "), casting.Description),
+		ImageURL:    image,
+		OGType:      "website",
+	}, nil
+}
+
+// entityImage - первое публичное изображение сущности с одним из назначений (по приоритету)
+func (s *shareService) entityImage(ctx context.Context, db *gorm.DB, entityType, entityID string, usages ...string) string {
+	uploads, err := s.uploadRepo.FindByEntity(db, entityType, entityID)
+	if err != nil {
+		return ""
+	}
+	for _, usage := range usages {
+		for _, upload := range uploads {
+			if upload.Usage == usage && upload.IsImage() && upload.IsPublic && upload.IsApproved() {
+				return s.fileURL(ctx, upload.Path)
+			}
+		}
+	}
+	return ""
+}
+
+// fileURL - абсолютная ссылка на файл (краулеры не разрешают относительные og:image)
+func (s *shareService) fileURL(ctx context.Context, path string) string {
+	url, err := s.storage.GetURL(ctx, path)
+	if err != nil {
+		return ""
+	}
+	if strings.HasPrefix(url, "/") {
+		return s.config.SiteURL + url
+	}
+	return url
+}
+
+func (s *shareService) shareLink(entityType, entityID, slug string) *dto.ShareLinkResponse {
+	return &dto.ShareLinkResponse{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Slug:       slug,
+		URL:        s.pageURL(entityType, slug),
+	}
+}
+
+func (s *shareService) pageURL(entityType, slug string) string {
+	return fmt.Sprintf("%s/share/%s/%s", s.config.SiteURL, sharePaths[entityType], slug)
+}
+
+// shareDescription - краткие факты и начало описания в пределах shareDescriptionLength
+func shareDescription(facts, text string) string {
+	description := facts
+	if text = strings.Join(strings.Fields(text), " "); text != "" {
+		description += ". " + text
+	}
+	if runes := []rune(description); len(runes) > shareDescriptionLength {
+		description = strings.TrimSpace(string(runes[:shareDescriptionLength-1])) + "…"
+	}
+	return description
+}
+
+func handleShareError(err error) error {
+	if errors.Is(err, repositories.ErrSlugNotFound) ||
+		errors.Is(err, repositories.ErrProfileNotFound) ||
+		errors.Is(err, repositories.ErrCastingNotFound) ||
+		errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	if errors.Is(err, repositories.ErrSlugTaken) {
+		return apperrors.ErrSlugTaken
+	}
+	return apperrors.InternalError(err)
+}
@@ -0,0 +1,461 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg" // декодеры для фото из портфолио
+	_ "image/png"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/webp"
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/document"
+	"mwork_backend/internal/imageprocessor"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/internal/storage"
+	"mwork_backend/pkg/apperrors"
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type ShortlistExportService interface {
+	// ExportShortlist - контакт-лист выбранных откликов (PDF для печати или CSV для таблиц)
+	ExportShortlist(db *gorm.DB, ctx context.Context, castingID, employerID string, req *dto.ShortlistExportRequest) (*dto.ExportFile, error)
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type shortlistExportService struct {
+	responseRepo  repositories.ResponseRepository
+	castingRepo   repositories.CastingRepository
+	userRepo      repositories.UserRepository
+	profileRepo   repositories.ProfileRepository
+	portfolioRepo repositories.PortfolioRepository
+	noteRepo      repositories.EmployerNoteRepository
+	storage       storage.Storage
+	// watermark - знак на фото моделей, чей отклик работодатель еще не принял (nil - без знака)
+	watermark *imageprocessor.Watermark
+}
+
+func NewShortlistExportService(
+	responseRepo repositories.ResponseRepository,
+	castingRepo repositories.CastingRepository,
+	userRepo repositories.UserRepository,
+	profileRepo repositories.ProfileRepository,
+	portfolioRepo repositories.PortfolioRepository,
+	noteRepo repositories.EmployerNoteRepository,
+	storage storage.Storage,
+	watermark *imageprocessor.Watermark,
+) ShortlistExportService {
+	return &shortlistExportService{
+		responseRepo:  responseRepo,
+		castingRepo:   castingRepo,
+		userRepo:      userRepo,
+		profileRepo:   profileRepo,
+		portfolioRepo: portfolioRepo,
+		noteRepo:      noteRepo,
+		storage:       storage,
+		watermark:     watermark,
+	}
+}
+
+// shortlistApplicant - данные одного кандидата для обоих форматов
+type shortlistApplicant struct {
+	Response       models.CastingResponse
+	Profile        *models.ModelProfile
+	Photo          *models.Upload
+	Accepted       bool // работодатель принял отклик модели: фото без водяного знака
+	EmployerRating *int
+	Tags           []string
+}
+
+func (s *shortlistExportService) ExportShortlist(db *gorm.DB, ctx context.Context, castingID, employerID string, req *dto.ShortlistExportRequest) (*dto.ExportFile, error) {
+	casting, err := s.castingRepo.FindCastingByID(db, castingID)
+	if err != nil {
+		return nil, handleShortlistExportError(err)
+	}
+	employerUser, err := s.userRepo.FindByProfileID(db, casting.EmployerID)
+	if err != nil {
+		return nil, handleShortlistExportError(err)
+	}
+	if employerUser.ID != employerID {
+		return nil, apperrors.ErrInsufficientPermissions
+	}
+
+	responses, err := s.findResponses(db, castingID, req.ResponseIDs)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	if len(responses) == 0 {
+		return nil, apperrors.ErrInvalidOperation("shortlist", "No responses to export")
+	}
+
+	applicants, err := s.collectApplicants(db, employerID, responses)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	baseName := fmt.Sprintf("shortlist-%s-%s", shortID(casting.ID), time.Now().Format("20060102"))
+	switch req.Format {
+	case dto.ExportFormatCSV:
+		data, err := s.renderCSV(ctx, applicants)
+		if err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		return &dto.ExportFile{FileName: baseName + ".csv", ContentType: "text/csv; charset=utf-8", Data: data}, nil
+	default:
+		data, err := s.renderPDF(ctx, casting, applicants)
+		if err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		return &dto.ExportFile{FileName: baseName + ".pdf", ContentType: "application/pdf", Data: data}, nil
+	}
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// findResponses - выбранные отклики в порядке запроса (или все принятые); отозванные не попадают в лист
+func (s *shortlistExportService) findResponses(db *gorm.DB, castingID string, ids []string) ([]models.CastingResponse, error) {
+	var found []models.CastingResponse
+	if len(ids) == 0 {
+		for _, status := range []models.ResponseStatus{models.ResponseStatusAccepted, models.ResponseStatusApproved} {
+			byStatus, err := s.responseRepo.FindResponsesByStatus(db, castingID, status)
+			if err != nil {
+				return nil, err
+			}
+			found = append(found, byStatus...)
+		}
+		return found, nil
+	}
+
+	byIDs, err := s.responseRepo.FindResponsesByIDs(db, castingID, ids)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]models.CastingResponse, len(byIDs))
+	for _, response := range byIDs {
+		index[response.ID] = response
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		response, ok := index[id]
+		if !ok || seen[id] || response.Status == models.ResponseStatusWithdrawn {
+			continue
+		}
+		seen[id] = true
+		found = append(found, response)
+	}
+	return found, nil
+}
+
+func (s *shortlistExportService) collectApplicants(db *gorm.DB, employerID string, responses []models.CastingResponse) ([]shortlistApplicant, error) {
+	responseIDs := make([]string, 0, len(responses))
+	modelIDs := make([]string, 0, len(responses))
+	for _, response := range responses {
+		responseIDs = append(responseIDs, response.ID)
+		modelIDs = append(modelIDs, response.ModelID)
+	}
+
+	responseNotes, err := s.noteRepo.FindNotesByTargets(db, employerID, models.EmployerNoteTargetResponse, responseIDs)
+	if err != nil {
+		return nil, err
+	}
+	modelNotes, err := s.noteRepo.FindNotesByTargets(db, employerID, models.EmployerNoteTargetModel, modelIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	applicants := make([]shortlistApplicant, 0, len(responses))
+	for _, response := range responses {
+		applicant := shortlistApplicant{Response: response}
+
+		profile, err := s.profileRepo.FindModelProfileByUserID(db, response.ModelID)
+		if err == nil {
+			applicant.Profile = profile
+			applicant.Photo = s.primaryPhoto(db, profile.ID)
+		} else if !errors.Is(err, repositories.ErrProfileNotFound) {
+			return nil, err
+		}
+		if applicant.Accepted, err = s.responseRepo.HasAcceptedResponse(db, employerID, response.ModelID); err != nil {
+			return nil, err
+		}
+
+		// Пометка на отклике приоритетнее пометки на модели (как в фильтре списка откликов)
+		for _, note := range []*models.EmployerNote{responseNotes[response.ID], modelNotes[response.ModelID]} {
+			if note == nil {
+				continue
+			}
+			if applicant.EmployerRating == nil {
+				applicant.EmployerRating = note.Rating
+			}
+			for _, tag := range note.GetTags() {
+				if !containsString(applicant.Tags, tag) {
+					applicant.Tags = append(applicant.Tags, tag)
+				}
+			}
+		}
+
+		applicants = append(applicants, applicant)
+	}
+	return applicants, nil
+}
+
+// primaryPhoto - первое фото портфолио вне закрытых альбомов (по order_index)
+func (s *shortlistExportService) primaryPhoto(db *gorm.DB, profileID string) *models.Upload {
+	items, err := s.portfolioRepo.FindPublicPortfolioByModel(db, profileID)
+	if err != nil {
+		return nil
+	}
+	for _, item := range items {
+		if item.Upload != nil && item.Upload.FileType == "image" && item.Upload.Path != "" && item.Upload.IsApproved() {
+			return item.Upload
+		}
+	}
+	return nil
+}
+
+// loadPhoto - фото из хранилища; ошибка не прерывает экспорт (рисуется заглушка).
+// Фото моделей, чей отклик не принят, получают водяной знак, как и при просмотре файла.
+func (s *shortlistExportService) loadPhoto(ctx context.Context, applicant shortlistApplicant) image.Image {
+	if applicant.Photo == nil {
+		return nil
+	}
+	path := applicant.Photo.Path
+	reader, err := s.storage.Get(ctx, path)
+	if err != nil {
+		log.Printf("Failed to load shortlist photo %s: %v", path, err)
+		return nil
+	}
+	defer reader.Close()
+
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		log.Printf("Failed to decode shortlist photo %s: %v", path, err)
+		return nil
+	}
+	if !applicant.Accepted && s.watermark != nil {
+		img = s.watermark.Apply(img)
+	}
+	return img
+}
+
+// photoURL - прямая ссылка на оригинал для принятых моделей, иначе ссылка на раздачу
+// файлов, которая сама решает, показывать ли фото с водяным знаком
+func (s *shortlistExportService) photoURL(ctx context.Context, applicant shortlistApplicant) string {
+	if applicant.Photo == nil {
+		return ""
+	}
+	if !applicant.Accepted {
+		return fmt.Sprintf("/api/v1/files/%s/%s", applicant.Photo.ID, imageprocessor.SizeLarge.Name)
+	}
+	url, err := s.storage.GetURL(ctx, applicant.Photo.Path)
+	if err != nil {
+		return ""
+	}
+	return url
+}
+
+func (s *shortlistExportService) renderPDF(ctx context.Context, casting *models.Casting, applicants []shortlistApplicant) ([]byte, error) {
+	sheet := &document.ContactSheet{
+		Title:    casting.Title,
+		Subtitle: fmt.Sprintf("Шортлист · кандидатов: %d · %s", len(applicants), time.Now().Format("02.01.2006")),
+	}
+
+	for _, applicant := range applicants {
+		entry := document.ContactSheetEntry{
+			Name:  applicantName(applicant),
+			Photo: s.loadPhoto(ctx, applicant),
+		}
+		if profile := applicant.Profile; profile != nil {
+			entry.Details = append(entry.Details,
+				joinNonEmpty(" · ", ageLabel(profile.Age), profile.City),
+				joinNonEmpty(" · ", measureLabel("Рост", profile.Height, "см"), measureLabel("Вес", profile.Weight, "кг")),
+				joinNonEmpty(" · ", labeled("Одежда", profile.ClothingSize), labeled("Обувь", profile.ShoeSize)),
+			)
+			if profile.Rating > 0 {
+				entry.Details = append(entry.Details, fmt.Sprintf("Рейтинг: %.1f", profile.Rating))
+			}
+		}
+		if applicant.EmployerRating != nil {
+			entry.Details = append(entry.Details, fmt.Sprintf("Ваша оценка: %s", strings.Repeat("★", *applicant.EmployerRating)))
+		}
+		if applicant.Response.Message != nil {
+			entry.Message = *applicant.Response.Message
+		}
+		entry.Details = removeEmpty(entry.Details)
+		sheet.Entries = append(sheet.Entries, entry)
+	}
+
+	pages, err := document.RenderContactSheet(sheet)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := document.EncodePDF(&buf, pages, 85); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *shortlistExportService) renderCSV(ctx context.Context, applicants []shortlistApplicant) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff") // BOM: Excel корректно открывает UTF-8
+	w := csv.NewWriter(&buf)
+
+	header := []string{
+		"response_id", "model_id", "name", "age", "gender", "city",
+		"height_cm", "weight_kg", "clothing_size", "shoe_size",
+		"bust_cm", "waist_cm", "hips_cm", "hair_color", "eye_color",
+		"rating", "employer_rating", "tags", "status", "message", "photo_url",
+	}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, applicant := range applicants {
+		row := make([]string, 0, len(header))
+		row = append(row, applicant.Response.ID, applicant.Response.ModelID, applicantName(applicant))
+
+		if profile := applicant.Profile; profile != nil {
+			row = append(row,
+				strconv.Itoa(profile.Age),
+				profile.Gender,
+				profile.City,
+				formatMeasure(profile.Height),
+				formatMeasure(profile.Weight),
+				profile.ClothingSize,
+				profile.ShoeSize,
+				formatMeasure(profile.Bust),
+				formatMeasure(profile.Waist),
+				formatMeasure(profile.Hips),
+				profile.HairColor,
+				profile.EyeColor,
+				strconv.FormatFloat(profile.Rating, 'f', 1, 64),
+			)
+		} else {
+			row = append(row, "", "", "", "", "", "", "", "", "", "", "", "", "")
+		}
+
+		employerRating := ""
+		if applicant.EmployerRating != nil {
+			employerRating = strconv.Itoa(*applicant.EmployerRating)
+		}
+		message := ""
+		if applicant.Response.Message != nil {
+			message = *applicant.Response.Message
+		}
+
+		row = append(row, employerRating, strings.Join(applicant.Tags, ";"), string(applicant.Response.Status), message, s.photoURL(ctx, applicant))
+		for i := range row {
+			row[i] = escapeCSVFormula(row[i])
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// escapeCSVFormula - значения моделей, начинающиеся с =, +, -, @, табуляции или CR,
+// Excel исполняет как формулы; апостроф заставляет показать их как текст
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func applicantName(applicant shortlistApplicant) string {
+	if applicant.Profile != nil && applicant.Profile.Name != "" {
+		return applicant.Profile.Name
+	}
+	if applicant.Response.Model.Name != "" {
+		return applicant.Response.Model.Name
+	}
+	return "Модель " + shortID(applicant.Response.ModelID)
+}
+
+func ageLabel(age int) string {
+	if age <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Возраст: %d", age)
+}
+
+func measureLabel(label string, value float64, unit string) string {
+	if value <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s %s", label, formatMeasure(value), unit)
+}
+
+func labeled(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return label + ": " + value
+}
+
+func formatMeasure(value float64) string {
+	if value <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func joinNonEmpty(sep string, parts ...string) string {
+	return strings.Join(removeEmpty(parts), sep)
+}
+
+func removeEmpty(values []string) []string {
+	result := values[:0]
+	for _, value := range values {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func shortID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+func handleShortlistExportError(err error) error {
+	if errors.Is(err, repositories.ErrCastingNotFound) ||
+		errors.Is(err, repositories.ErrUserNotFound) {
+		return apperrors.ErrNotFound(err)
+	}
+	return apperrors.InternalError(err)
+}
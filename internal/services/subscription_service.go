@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
 	"mwork_backend/pkg/apperrors"
 	"strings"
 	"time"
@@ -40,11 +41,16 @@ type SubscriptionService interface {
 	ProcessPayment(db *gorm.DB, paymentID string) error
 	GetPaymentHistory(db *gorm.DB, userID string) ([]*models.PaymentTransaction, error)
 	GetPaymentStatus(db *gorm.DB, paymentID string) (*models.PaymentTransaction, error)
+	// RefundPayment - полный возврат оплаченного платежа с обратной проводкой в журнале
+	RefundPayment(db *gorm.DB, adminID, paymentID string, req *dto.RefundPaymentRequest) (*models.PaymentTransaction, error)
 
 	// Robokassa integration
 	InitRobokassaPayment(db *gorm.DB, userID, planID string) (*models.RobokassaInitResponse, error)
 	ProcessRobokassaCallback(db *gorm.DB, data *models.RobokassaCallbackData) error
 	CheckRobokassaPayment(db *gorm.DB, paymentID string) (*models.PaymentStatusResponse, error)
+	// CreateServicePayment - платеж за разовую услугу (например, продвижение кастинга).
+	// Не открывает свою транзакцию: вызывается внутри транзакции вызывающего сервиса.
+	CreateServicePayment(db *gorm.DB, userID string, amount float64, currency, description string) (*models.PaymentTransaction, *models.RobokassaInitResponse, error)
 
 	// Admin operations
 	GetPlatformSubscriptionStats(db *gorm.DB) (*repositories.PlatformSubscriptionStats, error)
@@ -62,6 +68,9 @@ type subscriptionService struct {
 	subscriptionRepo repositories.SubscriptionRepository
 	userRepo         repositories.UserRepository
 	notificationRepo repositories.NotificationRepository
+	promotionRepo    repositories.PromotionRepository
+	ledgerService    LedgerService
+	invoiceService   InvoiceService
 }
 
 // ✅ Конструктор обновлен (db убран)
@@ -70,12 +79,18 @@ func NewSubscriptionService(
 	subscriptionRepo repositories.SubscriptionRepository,
 	userRepo repositories.UserRepository,
 	notificationRepo repositories.NotificationRepository,
+	promotionRepo repositories.PromotionRepository,
+	ledgerService LedgerService,
+	invoiceService InvoiceService,
 ) SubscriptionService {
 	return &subscriptionService{
 		// ❌ 'db: db,' УДАЛЕНО
 		subscriptionRepo: subscriptionRepo,
 		userRepo:         userRepo,
 		notificationRepo: notificationRepo,
+		promotionRepo:    promotionRepo,
+		ledgerService:    ledgerService,
+		invoiceService:   invoiceService,
 	}
 }
 
@@ -388,7 +403,7 @@ func (s *subscriptionService) CreatePayment(db *gorm.DB, userID, planID string)
 
 	payment := &models.PaymentTransaction{
 		UserID:         userID,
-		SubscriptionID: "", // (Оставлено как в оригинале)
+		SubscriptionID: nil, // (Оставлено как в оригинале)
 		Amount:         plan.Price,
 		Status:         models.PaymentStatusPending,
 		InvID:          generateInvoiceID(),
@@ -432,7 +447,20 @@ func (s *subscriptionService) ProcessPayment(db *gorm.DB, paymentID string) erro
 	if err := s.subscriptionRepo.UpdatePaymentStatus(tx, payment.InvID, models.PaymentStatusPaid, &paidAt); err != nil {
 		return apperrors.InternalError(err)
 	}
-	return tx.Commit().Error
+	payment.PaidAt = &paidAt
+	if err := s.ledgerService.PostPaymentCapture(tx, payment); err != nil {
+		return err
+	}
+	invoice, err := s.invoiceService.IssueInvoice(tx, payment)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	sendInvoiceEmailAsync(s.invoiceService, db, invoice)
+	return nil
 }
 
 func (s *subscriptionService) GetPaymentHistory(db *gorm.DB, userID string) ([]*models.PaymentTransaction, error) {
@@ -457,6 +485,42 @@ func (s *subscriptionService) GetPaymentStatus(db *gorm.DB, paymentID string) (*
 	return payment, nil
 }
 
+func (s *subscriptionService) RefundPayment(db *gorm.DB, adminID, paymentID string, req *dto.RefundPaymentRequest) (*models.PaymentTransaction, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	payment, err := s.subscriptionRepo.FindPaymentByID(tx, paymentID)
+	if err != nil {
+		return nil, handleSubscriptionError(err)
+	}
+	if payment.Status != models.PaymentStatusPaid {
+		return nil, apperrors.ErrPaymentNotRefundable
+	}
+
+	if err := s.subscriptionRepo.TransitionPaymentStatus(tx, payment.ID, models.PaymentStatusPaid, models.PaymentStatusRefunded); err != nil {
+		return nil, handleSubscriptionError(err)
+	}
+	payment.Status = models.PaymentStatusRefunded
+
+	if err := s.ledgerService.PostPaymentRefund(tx, payment, adminID, req.Reason); err != nil {
+		return nil, err
+	}
+	creditNote, err := s.invoiceService.IssueCreditNote(tx, payment, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	sendInvoiceEmailAsync(s.invoiceService, db, creditNote)
+	return payment, nil
+}
+
 // Robokassa integration
 
 func (s *subscriptionService) InitRobokassaPayment(db *gorm.DB, userID, planID string) (*models.RobokassaInitResponse, error) {
@@ -506,27 +570,70 @@ func (s *subscriptionService) ProcessRobokassaCallback(db *gorm.DB, data *models
 		return apperrors.ErrInvalidPaymentAmount
 	}
 
+	// Повтор callback-а по оплаченному или возвращенному платежу: подтверждаем провайдеру,
+	// но не проводим, не выставляем счет и не продлеваем подписку повторно
+	if payment.Status == models.PaymentStatusPaid || payment.Status == models.PaymentStatusRefunded {
+		return nil
+	}
+	if err := s.subscriptionRepo.TransitionPaymentStatus(tx, payment.ID, models.PaymentStatusPending, models.PaymentStatusPaid); err != nil {
+		if errors.Is(err, repositories.ErrPaymentStatusChanged) {
+			// Параллельный callback уже провел платеж
+			return nil
+		}
+		return apperrors.InternalError(err)
+	}
 	paidAt := time.Now()
-	// ✅ Передаем tx
-	if err := s.subscriptionRepo.UpdatePaymentStatus(tx, data.InvID, models.PaymentStatusPaid, &paidAt); err != nil {
+	payment.Status = models.PaymentStatusPaid
+	payment.PaidAt = &paidAt
+	if err := s.ledgerService.PostPaymentCapture(tx, payment); err != nil {
+		return err
+	}
+	invoice, err := s.invoiceService.IssueInvoice(tx, payment)
+	if err != nil {
+		return err
+	}
+
+	// Платеж за продвижение кастинга - активируем промо вместо подписки
+	promotion, err := s.promotionRepo.FindPromotionByPaymentID(tx, payment.ID)
+	if err == nil {
+		if err := s.activatePromotionInTx(tx, promotion, paidAt); err != nil {
+			return err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return apperrors.InternalError(err)
+		}
+		sendInvoiceEmailAsync(s.invoiceService, db, invoice)
+		return nil
+	}
+	if !errors.Is(err, repositories.ErrPromotionNotFound) {
 		return apperrors.InternalError(err)
 	}
 
+	planID := ""
+	if payment.SubscriptionID != nil {
+		planID = *payment.SubscriptionID
+	}
+
 	// ✅ Передаем tx
 	_, err = s.subscriptionRepo.FindUserSubscription(tx, payment.UserID)
 	if err != nil {
 		// Ошибка - значит подписки нет. Создаем новую.
-		if _, err := s.createSubscriptionInTx(tx, payment.UserID, payment.SubscriptionID); err != nil {
+		if _, err := s.createSubscriptionInTx(tx, payment.UserID, planID); err != nil {
 			return err
 		}
 	} else {
 		// Подписка есть - обновляем.
-		if err := s.renewSubscriptionInTx(tx, payment.UserID, payment.SubscriptionID); err != nil {
+		if err := s.renewSubscriptionInTx(tx, payment.UserID, planID); err != nil {
 			return err
 		}
 	}
 
-	return tx.Commit().Error
+	if err := tx.Commit().Error; err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	sendInvoiceEmailAsync(s.invoiceService, db, invoice)
+	return nil
 }
 
 func (s *subscriptionService) CheckRobokassaPayment(db *gorm.DB, paymentID string) (*models.PaymentStatusResponse, error) {
@@ -545,6 +652,38 @@ func (s *subscriptionService) CheckRobokassaPayment(db *gorm.DB, paymentID strin
 	}, nil
 }
 
+func (s *subscriptionService) CreateServicePayment(db *gorm.DB, userID string, amount float64, currency, description string) (*models.PaymentTransaction, *models.RobokassaInitResponse, error) {
+	// Привязываем платеж к текущей подписке пользователя (если она есть)
+	var subscriptionID *string
+	if subscription, err := s.subscriptionRepo.FindUserSubscription(db, userID); err == nil {
+		subscriptionID = &subscription.ID
+	}
+
+	payment := &models.PaymentTransaction{
+		UserID:         userID,
+		SubscriptionID: subscriptionID,
+		Amount:         amount,
+		Status:         models.PaymentStatusPending,
+		InvID:          generateInvoiceID(),
+		Description:    description,
+	}
+	if err := s.subscriptionRepo.CreatePaymentTransaction(db, payment); err != nil {
+		return nil, nil, apperrors.InternalError(err)
+	}
+
+	paymentURL, err := s.generateRobokassaURL(payment.InvID, payment.Amount, currency)
+	if err != nil {
+		return nil, nil, apperrors.InternalError(err)
+	}
+
+	return payment, &models.RobokassaInitResponse{
+		PaymentURL: paymentURL,
+		InvoiceID:  payment.InvID,
+		Amount:     payment.Amount,
+		Currency:   currency,
+	}, nil
+}
+
 // Admin operations
 
 func (s *subscriptionService) GetPlatformSubscriptionStats(db *gorm.DB) (*repositories.PlatformSubscriptionStats, error) {
@@ -697,6 +836,23 @@ func (s *subscriptionService) renewSubscriptionInTx(tx *gorm.DB, userID, planID
 	return s.subscriptionRepo.RenewUserSubscription(tx, userID, planID, newEndDate)
 }
 
+// (Внутренний хелпер для ProcessRobokassaCallback)
+// Если у кастинга уже есть активное промо, новое начинается после его окончания.
+func (s *subscriptionService) activatePromotionInTx(tx *gorm.DB, promotion *models.CastingPromotion, paidAt time.Time) error {
+	if promotion.Status != models.PromotionStatusPending {
+		return nil
+	}
+	startsAt := paidAt
+	if active, err := s.promotionRepo.FindLastScheduledPromotion(tx, promotion.CastingID); err == nil && active.EndsAt.After(startsAt) {
+		startsAt = *active.EndsAt
+	}
+	endsAt := startsAt.AddDate(0, 0, promotion.Days)
+	if err := s.promotionRepo.ActivatePromotion(tx, promotion.ID, startsAt, endsAt); err != nil {
+		return apperrors.InternalError(err)
+	}
+	return nil
+}
+
 // (Вспомогательный хелпер для ошибок - без изменений)
 func handleSubscriptionError(err error) error {
 	if errors.Is(err, gorm.ErrRecordNotFound) ||
@@ -705,6 +861,9 @@ func handleSubscriptionError(err error) error {
 		errors.Is(err, repositories.ErrPaymentNotFound) {
 		return apperrors.ErrNotFound(err)
 	}
+	if errors.Is(err, repositories.ErrPaymentStatusChanged) {
+		return apperrors.ErrPaymentNotRefundable
+	}
 	if errors.Is(err, repositories.ErrUserNotFound) {
 		return apperrors.ErrNotFound(err)
 	}
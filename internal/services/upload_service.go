@@ -1,16 +1,19 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"mime/multipart"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"mwork_backend/internal/imageprocessor"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
 	"mwork_backend/internal/services/dto"
@@ -56,9 +59,11 @@ type UploadService interface {
 }
 
 type uploadService struct {
-	uploadRepo repositories.UploadRepository
-	storage    storage.Storage
-	config     *UploadConfig
+	uploadRepo    repositories.UploadRepository
+	userRepo      repositories.UserRepository
+	duplicateRepo repositories.DuplicatePhotoRepository
+	storage       storage.Storage
+	config        *UploadConfig
 }
 
 // ============================================
@@ -70,6 +75,10 @@ type UploadConfig struct {
 	MaxFileSize    int64
 	MaxUserStorage int64
 
+	// BlockDuplicatePhotos - отклонять загрузку фото, похожего на фото другого пользователя
+	// (по умолчанию такое фото только отправляется на проверку)
+	BlockDuplicatePhotos bool
+
 	// Настройки для разных модулей
 	Modules map[string]*ModuleConfig
 }
@@ -80,6 +89,7 @@ type ModuleConfig struct {
 	MaxFileSize   int64          // Переопределение размера
 	ImageQuality  int            // Качество для изображений
 	Validation    ValidationFunc // Кастомная валидация
+	Private       bool           // Файлы модуля никогда не публичны (доступ - владелец и админ)
 }
 
 type ValidationFunc func(db *gorm.DB, userID string, req *dto.UniversalUploadRequest) error
@@ -90,6 +100,8 @@ type ValidationFunc func(db *gorm.DB, userID string, req *dto.UniversalUploadReq
 
 func NewUploadService(
 	uploadRepo repositories.UploadRepository,
+	userRepo repositories.UserRepository,
+	duplicateRepo repositories.DuplicatePhotoRepository,
 	storage storage.Storage,
 	config *UploadConfig,
 ) UploadService {
@@ -98,9 +110,11 @@ func NewUploadService(
 	}
 
 	return &uploadService{
-		uploadRepo: uploadRepo,
-		storage:    storage,
-		config:     config,
+		uploadRepo:    uploadRepo,
+		userRepo:      userRepo,
+		duplicateRepo: duplicateRepo,
+		storage:       storage,
+		config:        config,
 	}
 }
 
@@ -147,18 +161,52 @@ func (s *uploadService) UploadFile(ctx context.Context, db *gorm.DB, req *dto.Un
 		}
 	}
 
+	// JPEG/PNG очищаются от EXIF (геолокация, устройство) и поворачиваются по тегу ориентации
+	sanitized, err := s.sanitizeImage(req.File, moduleConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Перцептивный хэш: аватары и фото портфолио сверяются с фото других пользователей
+	hash, hashed := perceptualHashOf(req.File, sanitized)
+	var similar []repositories.SimilarUpload
+	if hashed && models.IsModeratedUsage(req.Usage) {
+		similar, err = s.duplicateRepo.FindSimilarUploads(db, hash, duplicatePhotoMaxDistance, req.UserID, maxDuplicateMatches)
+		if err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		if len(similar) > 0 && s.config.BlockDuplicatePhotos {
+			return nil, apperrors.ErrDuplicatePhoto
+		}
+	}
+
 	// Создаём запись в БД
-	upload, err := s.createUploadRecord(db, req, moduleConfig) // ИСПОЛЬЗУЕМ db
+	upload, err := s.createUploadRecord(db, req, moduleConfig, sanitized, len(similar) > 0) // ИСПОЛЬЗУЕМ db
 	if err != nil {
 		return nil, err
 	}
 
+	if hashed {
+		if err := s.duplicateRepo.SetPerceptualHash(db, upload.ID, hash); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+		if err := s.duplicateRepo.CreateMatches(db, duplicateMatches(upload, similar)); err != nil {
+			return nil, apperrors.InternalError(err)
+		}
+	}
+
 	// Сохраняем файл в storage
-	src, err := req.File.Open()
-	if err != nil {
-		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	var src io.Reader
+	if sanitized != nil {
+		src = bytes.NewReader(sanitized.Data)
+	} else {
+		file, err := req.File.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+		}
+		defer file.Close()
+		src = file
 	}
-	defer src.Close()
 
 	if err := s.storage.Save(ctx, upload.Path, src, upload.MimeType); err != nil {
 		return nil, fmt.Errorf("failed to save file to storage: %w", err)
@@ -340,10 +388,7 @@ func (s *uploadService) validateFile(file *multipart.FileHeader, config *ModuleC
 	}
 
 	// Проверка MIME-типа
-	mimeType := file.Header.Get("Content-Type")
-	if mimeType == "" {
-		mimeType = getMimeTypeFromFilename(file.Filename)
-	}
+	mimeType := getUploadMimeType(file)
 
 	allowed := false
 	for _, allowedType := range config.AllowedTypes {
@@ -373,17 +418,43 @@ func (s *uploadService) checkStorageLimits(db *gorm.DB, userID string, fileSize
 	return nil
 }
 
-func (s *uploadService) createUploadRecord(db *gorm.DB, req *dto.UniversalUploadRequest, config *ModuleConfig) (*models.Upload, error) {
+// sanitizeImage читает JPEG/PNG в память и удаляет из него метаданные.
+// Для остальных типов возвращает nil - файл сохраняется потоком как есть.
+func (s *uploadService) sanitizeImage(file *multipart.FileHeader, config *ModuleConfig) (*imageprocessor.SanitizedImage, error) {
+	mimeType := getUploadMimeType(file)
+	if mimeType != "image/jpeg" && mimeType != "image/png" {
+		return nil, nil
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	sanitized, err := imageprocessor.NewProcessor(config.ImageQuality).SanitizeImage(data)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	if sanitized.HadGPS {
+		log.Printf("INFO: removed GPS metadata from uploaded image %s", file.Filename)
+	}
+	return sanitized, nil
+}
+
+func (s *uploadService) createUploadRecord(db *gorm.DB, req *dto.UniversalUploadRequest, config *ModuleConfig, sanitized *imageprocessor.SanitizedImage, duplicateFlagged bool) (*models.Upload, error) {
 	// Проверка usage
 	if !contains(config.AllowedUsages, req.Usage) {
 		return nil, apperrors.ErrInvalidUploadUsage
 	}
 
 	// Генерация пути
-	mimeType := req.File.Header.Get("Content-Type")
-	if mimeType == "" {
-		mimeType = getMimeTypeFromFilename(req.File.Filename)
-	}
+	mimeType := getUploadMimeType(req.File)
 
 	fileExt := filepath.Ext(req.File.Filename)
 	fileName := fmt.Sprintf("%d_%s%s", time.Now().UnixNano(), generateSecureRandomString(8), fileExt)
@@ -400,6 +471,27 @@ func (s *uploadService) createUploadRecord(db *gorm.DB, req *dto.UniversalUpload
 	}
 	// ▲▲▲ ИСПРАВЛЕНО ▲▲▲
 
+	// Размер и безопасные метаданные берём из очищенного изображения;
+	// серверные ключи перекрывают присланные клиентом
+	size := req.File.Size
+	if sanitized != nil {
+		size = int64(len(sanitized.Data))
+		if metadata == nil {
+			metadata = make(models.JSONMap)
+		}
+		metadata[models.UploadMetaExifStripped] = true
+		metadata[models.UploadMetaOrientationCorrected] = sanitized.Rotated
+		if sanitized.Width > 0 && sanitized.Height > 0 {
+			metadata[models.UploadMetaWidth] = sanitized.Width
+			metadata[models.UploadMetaHeight] = sanitized.Height
+		}
+		if sanitized.CapturedAt != nil {
+			metadata[models.UploadMetaCapturedAt] = sanitized.CapturedAt.Format(time.RFC3339)
+		} else {
+			delete(metadata, models.UploadMetaCapturedAt)
+		}
+	}
+
 	upload := &models.Upload{
 		UserID:     req.UserID,
 		Module:     req.Module,
@@ -409,11 +501,21 @@ func (s *uploadService) createUploadRecord(db *gorm.DB, req *dto.UniversalUpload
 		Usage:      req.Usage,
 		Path:       filePath,
 		MimeType:   mimeType,
-		Size:       req.File.Size,
-		IsPublic:   req.IsPublic,
+		Size:       size,
+		IsPublic:   req.IsPublic && !config.Private,
 		Metadata:   metadata, // <-- Используем преобразованную карту
 	}
 
+	status, err := s.initialModerationStatus(db, req.UserID, req.Usage)
+	if err != nil {
+		return nil, err
+	}
+	// Фото, похожее на чужое, ждет проверки даже у доверенных пользователей
+	if duplicateFlagged {
+		status = models.ModerationStatusPending
+	}
+	upload.ModerationStatus = status
+
 	if err := s.uploadRepo.Create(db, upload); err != nil {
 		return nil, apperrors.InternalError(err)
 	}
@@ -427,6 +529,10 @@ func (s *uploadService) buildUploadResponse(ctx context.Context, upload *models.
 	if err != nil {
 		url = fmt.Sprintf("/api/v1/files/%s", upload.ID)
 	}
+	// Файлы приватных модулей отдаются только через /files с проверкой доступа
+	if config, ok := s.config.Modules[upload.Module]; ok && config.Private {
+		url = fmt.Sprintf("/api/v1/files/%s", upload.ID)
+	}
 
 	// ▼▼▼ ИСПРАВЛЕНИЕ ОШИБКИ 2 ▼▼▼
 	// Преобразуем models.JSONMap (map[string]interface{}) обратно в map[string]string
@@ -434,11 +540,12 @@ func (s *uploadService) buildUploadResponse(ctx context.Context, upload *models.
 	if upload.Metadata != nil {
 		metadata = make(map[string]string, len(upload.Metadata))
 		for k, v := range upload.Metadata {
-			// Используем type assertion для безопасного преобразования
+			// Строки отдаём как есть, числа и флаги (width, exif_stripped...) - в строковом виде
 			if vStr, ok := v.(string); ok {
 				metadata[k] = vStr
+			} else if v != nil {
+				metadata[k] = fmt.Sprint(v)
 			}
-			// (Примечание: если в JSONMap есть не-строковые значения, они будут проигнорированы)
 		}
 	}
 	// ▲▲▲ ИСПРАВЛЕНО ▲▲▲
@@ -457,7 +564,26 @@ func (s *uploadService) buildUploadResponse(ctx context.Context, upload *models.
 		IsPublic:   upload.IsPublic,
 		Metadata:   metadata, // <-- Используем преобразованную карту
 		CreatedAt:  upload.CreatedAt,
+
+		ModerationStatus: upload.ModerationStatus,
+		RejectionReason:  upload.RejectionReason,
+	}
+}
+
+// initialModerationStatus - аватары и фото портфолио ждут модерации,
+// если их загрузил не админ и не доверенный пользователь
+func (s *uploadService) initialModerationStatus(db *gorm.DB, userID, usage string) (models.ModerationStatus, error) {
+	if !models.IsModeratedUsage(usage) {
+		return models.ModerationStatusApproved, nil
+	}
+	user, err := s.userRepo.FindByID(db, userID)
+	if err != nil {
+		return "", handleUploadError(err)
 	}
+	if user.Role == models.UserRoleAdmin || user.PhotoAutoApprove {
+		return models.ModerationStatusApproved, nil
+	}
+	return models.ModerationStatusPending, nil
 }
 
 // ▼▼▼ ИМЕНЕНО (Проблема 3) ▼▼▼
@@ -501,6 +627,10 @@ func (s *uploadService) deleteResizedVersions(ctx context.Context, originalPath
 	// ctx := context.TODO() // Контекст из параметров
 	// ▲▲▲ ИЗМЕНЕНО (Проблема 4) ▲▲▲
 	sizes := []string{"thumbnail", "small", "medium"}
+	// Кэшированные копии с водяным знаком (см. FileHandler.ServeResizedImage)
+	for _, size := range []imageprocessor.ImageSize{imageprocessor.SizeThumbnail, imageprocessor.SizeSmall, imageprocessor.SizeMedium, imageprocessor.SizeLarge} {
+		sizes = append(sizes, imageprocessor.WatermarkedSizeName(size))
+	}
 
 	for _, size := range sizes {
 		resizedPath := getResizedPath(originalPath, size)
@@ -514,6 +644,14 @@ func (s *uploadService) deleteResizedVersions(ctx context.Context, originalPath
 // КОНФИГУРАЦИИ ПО УМОЛЧАНИЮ
 // ============================================
 
+const (
+	// duplicatePhotoMaxDistance - фото в пределах 6 бит dHash из 64 считаются одним снимком
+	// (пережатие, ресайз, легкая цветокоррекция)
+	duplicatePhotoMaxDistance = 6
+	// maxDuplicateMatches - сколько ближайших совпадений сохраняется для одного фото
+	maxDuplicateMatches = 10
+)
+
 func GetDefaultUploadConfig() *UploadConfig {
 	return &UploadConfig{
 		MaxFileSize:    50 * 1024 * 1024,  // 50MB
@@ -543,6 +681,15 @@ func GetDefaultUploadConfig() *UploadConfig {
 				MaxFileSize:   5 * 1024 * 1024,
 				ImageQuality:  90,
 			},
+			// Документы для верификации работодателя - только владелец и модераторы
+			"verification": {
+				AllowedTypes:  []string{"image/jpeg", "image/png", "application/pdf"},
+				AllowedUsages: []string{"business_registration", "certificate", "other"},
+				MaxFileSize:   10 * 1024 * 1024,
+				ImageQuality:  90,
+				Validation:    validateEmployerUpload,
+				Private:       true,
+			},
 		},
 	}
 }
@@ -572,6 +719,57 @@ func getMimeTypeFromFilename(filename string) string {
 	return "application/octet-stream"
 }
 
+// perceptualHashOf - хэш очищенного изображения (или исходного файла для GIF);
+// false - не изображение или файл не удалось декодировать
+func perceptualHashOf(file *multipart.FileHeader, sanitized *imageprocessor.SanitizedImage) (uint64, bool) {
+	var data []byte
+	if sanitized != nil {
+		data = sanitized.Data
+	} else {
+		if !strings.HasPrefix(getUploadMimeType(file), "image/") {
+			return 0, false
+		}
+		src, err := file.Open()
+		if err != nil {
+			return 0, false
+		}
+		defer src.Close()
+		if data, err = io.ReadAll(src); err != nil {
+			return 0, false
+		}
+	}
+
+	hash, err := imageprocessor.PerceptualHash(data)
+	if err != nil {
+		return 0, false
+	}
+	return hash, true
+}
+
+// duplicateMatches - записи для очереди проверки: upload похож на фото других пользователей
+func duplicateMatches(upload *models.Upload, similar []repositories.SimilarUpload) []*models.DuplicatePhotoMatch {
+	matches := make([]*models.DuplicatePhotoMatch, 0, len(similar))
+	for _, match := range similar {
+		matches = append(matches, &models.DuplicatePhotoMatch{
+			UploadID:        upload.ID,
+			MatchedUploadID: match.UploadID,
+			UserID:          upload.UserID,
+			MatchedUserID:   match.UserID,
+			Distance:        match.Distance,
+			Status:          models.DuplicateMatchPending,
+		})
+	}
+	return matches
+}
+
+// getUploadMimeType - MIME-тип из заголовка части формы, иначе по расширению
+func getUploadMimeType(file *multipart.FileHeader) string {
+	if mimeType := file.Header.Get("Content-Type"); mimeType != "" {
+		return mimeType
+	}
+	return getMimeTypeFromFilename(file.Filename)
+}
+
 func getFileTypeFromMIME(mimeType string) string {
 	if strings.HasPrefix(mimeType, "image/") {
 		return "image"
@@ -606,6 +804,18 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
+// validateEmployerUpload - модуль доступен только работодателям
+func validateEmployerUpload(db *gorm.DB, userID string, req *dto.UniversalUploadRequest) error {
+	var user models.User
+	if err := db.Select("id", "role").First(&user, "id = ?", userID).Error; err != nil {
+		return handleUploadError(err)
+	}
+	if user.Role != models.UserRoleEmployer {
+		return apperrors.ErrInvalidUserRole
+	}
+	return nil
+}
+
 func handleUploadError(err error) error {
 	if err == gorm.ErrRecordNotFound {
 		return apperrors.ErrNotFound(err)
@@ -2,7 +2,6 @@ package services
 
 import (
 	"errors"
-	"math"
 	"mwork_backend/internal/models"
 	"mwork_backend/internal/repositories"
 	"mwork_backend/internal/services/dto"
@@ -89,7 +88,9 @@ func (s *UserServiceImpl) UpdateProfile(db *gorm.DB, userID string, req *dto.Upd
 		if err != nil {
 			return handleRepositoryError(err)
 		}
-		updateModelProfile(profile, req) // хелпер не трогает db
+		if err := updateModelProfile(profile, req); err != nil { // хелпер не трогает db
+			return err
+		}
 		// ✅ Передаем 'tx'
 		if err := s.profileRepo.UpdateModelProfile(tx, profile); err != nil {
 			return apperrors.InternalError(err)
@@ -186,7 +187,8 @@ func (s *UserServiceImpl) UpdateUserStatus(db *gorm.DB, adminID, userID string,
 	return tx.Commit().Error
 }
 
-// VerifyEmployer - 'db' добавлен
+// VerifyEmployer - ручная верификация без заявки (основной путь - EmployerVerificationService).
+// Значок действует стандартный срок и затем требует повторной верификации.
 func (s *UserServiceImpl) VerifyEmployer(db *gorm.DB, adminID, employerID string) error {
 	// ✅ Начинаем транзакцию из переданного 'db'
 	tx := db.Begin()
@@ -206,7 +208,8 @@ func (s *UserServiceImpl) VerifyEmployer(db *gorm.DB, adminID, employerID string
 	}
 
 	// ✅ Передаем 'tx'
-	if err := s.profileRepo.VerifyEmployerProfile(tx, employerID); err != nil {
+	verifiedUntil := time.Now().AddDate(0, models.VerificationValidityMonths, 0)
+	if err := s.profileRepo.VerifyEmployerProfile(tx, employerID, &verifiedUntil); err != nil {
 		return handleRepositoryError(err)
 	}
 
@@ -321,7 +324,7 @@ func (s *UserServiceImpl) buildUserResponse(db *gorm.DB, user *models.User) (*dt
 
 // Хелперы 'updateModelProfile' и 'updateEmployerProfile' не меняются,
 // так как они не взаимодействуют с базой данных.
-func updateModelProfile(profile *models.ModelProfile, req *dto.UpdateProfileRequest) {
+func updateModelProfile(profile *models.ModelProfile, req *dto.UpdateProfileRequest) error {
 	if req.Name != nil {
 		profile.Name = *req.Name
 	}
@@ -331,12 +334,6 @@ func updateModelProfile(profile *models.ModelProfile, req *dto.UpdateProfileRequ
 	if req.Age != nil {
 		profile.Age = *req.Age
 	}
-	if req.Height != nil {
-		profile.Height = float64(math.Round(*req.Height))
-	}
-	if req.Weight != nil {
-		profile.Weight = float64(math.Round(*req.Weight))
-	}
 	if req.Gender != nil {
 		profile.Gender = *req.Gender
 	}
@@ -349,12 +346,6 @@ func updateModelProfile(profile *models.ModelProfile, req *dto.UpdateProfileRequ
 	if req.Description != nil {
 		profile.Description = *req.Description
 	}
-	if req.ClothingSize != nil {
-		profile.ClothingSize = *req.ClothingSize
-	}
-	if req.ShoeSize != nil {
-		profile.ShoeSize = *req.ShoeSize
-	}
 	if req.BarterAccepted != nil {
 		profile.BarterAccepted = *req.BarterAccepted
 	}
@@ -367,6 +358,8 @@ func updateModelProfile(profile *models.ModelProfile, req *dto.UpdateProfileRequ
 	if req.Categories != nil {
 		profile.SetCategories(req.Categories)
 	}
+	// Рост, вес, размеры и параметры фигуры (с переводом единиц)
+	return applyModelMeasurements(profile, req.Height, req.Weight, req.ClothingSize, req.ShoeSize, &req.MeasurementsInput)
 }
 
 func updateEmployerProfile(profile *models.EmployerProfile, req *dto.UpdateProfileRequest) {
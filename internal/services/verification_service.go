@@ -0,0 +1,424 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/apperrors"
+)
+
+const (
+	// verificationUploadModule - модуль UploadService для документов заявки
+	verificationUploadModule = "verification"
+	// verificationEntityType - документы привязываются к заявке как к сущности
+	verificationEntityType = "employer_verification"
+	// reverificationWindowDays - за сколько дней до истечения можно подать повторную заявку
+	reverificationWindowDays = 30
+)
+
+// =======================
+// 1. ИНТЕРФЕЙС
+// =======================
+type VerificationService interface {
+	// Работодатель (userID = users.id работодателя)
+	SubmitVerification(db *gorm.DB, userID string, req *dto.SubmitVerificationRequest) (*dto.VerificationResponse, error)
+	GetMyVerification(db *gorm.DB, userID string) (*dto.VerificationResponse, error)
+	UpdateMyVerification(db *gorm.DB, userID string, req *dto.UpdateVerificationRequest) (*dto.VerificationResponse, error)
+
+	// Модерация (adminID = users.id модератора)
+	ListVerifications(db *gorm.DB, query *dto.VerificationQueueQuery) ([]models.EmployerVerification, int64, error)
+	GetVerification(db *gorm.DB, verificationID string) (*dto.VerificationResponse, error)
+	ApproveVerification(db *gorm.DB, adminID, verificationID string, req *dto.ApproveVerificationRequest) (*dto.VerificationResponse, error)
+	RejectVerification(db *gorm.DB, adminID, verificationID string, req *dto.ReviewNoteRequest) (*dto.VerificationResponse, error)
+	RequestMoreInfo(db *gorm.DB, adminID, verificationID string, req *dto.ReviewNoteRequest) (*dto.VerificationResponse, error)
+
+	// Фоновая задача: истечение срока верификации
+	ExpireVerifications(db *gorm.DB) error
+}
+
+// =======================
+// 2. РЕАЛИЗАЦИЯ
+// =======================
+type verificationService struct {
+	verificationRepo repositories.VerificationRepository
+	profileRepo      repositories.ProfileRepository
+	uploadRepo       repositories.UploadRepository
+	notificationRepo repositories.NotificationRepository
+}
+
+func NewVerificationService(
+	verificationRepo repositories.VerificationRepository,
+	profileRepo repositories.ProfileRepository,
+	uploadRepo repositories.UploadRepository,
+	notificationRepo repositories.NotificationRepository,
+) VerificationService {
+	return &verificationService{
+		verificationRepo: verificationRepo,
+		profileRepo:      profileRepo,
+		uploadRepo:       uploadRepo,
+		notificationRepo: notificationRepo,
+	}
+}
+
+// --- Работодатель ---
+
+// SubmitVerification - новая заявка. Пока заявка на рассмотрении, вторую подать нельзя;
+// верифицированный работодатель может подать повторную заявку за 30 дней до истечения срока.
+func (s *verificationService) SubmitVerification(db *gorm.DB, userID string, req *dto.SubmitVerificationRequest) (*dto.VerificationResponse, error) {
+	employer, err := s.profileRepo.FindEmployerProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleVerificationError(err)
+	}
+
+	latest, err := s.verificationRepo.FindLatestVerification(db, employer.ID)
+	if err != nil && !errors.Is(err, repositories.ErrVerificationNotFound) {
+		return nil, apperrors.InternalError(err)
+	}
+	if latest != nil && latest.IsOpen() {
+		return nil, apperrors.ErrVerificationInProgress
+	}
+	reverificationFrom := time.Now().AddDate(0, 0, reverificationWindowDays)
+	if employer.IsVerified && (employer.VerifiedUntil == nil || employer.VerifiedUntil.After(reverificationFrom)) {
+		return nil, apperrors.ErrAlreadyVerified
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	verification := &models.EmployerVerification{
+		EmployerProfileID: employer.ID,
+		UserID:            userID,
+		Status:            models.VerificationStatusPending,
+		BIN:               req.BIN,
+		LegalName:         req.LegalName,
+		Comment:           req.Comment,
+		SubmittedAt:       time.Now(),
+	}
+	if err := s.verificationRepo.CreateVerification(tx, verification); err != nil {
+		return nil, handleVerificationError(err)
+	}
+	if err := s.attachDocuments(tx, userID, verification.ID, req.DocumentIDs); err != nil {
+		return nil, err
+	}
+	if err := s.recordTransition(tx, verification, "", &userID, models.VerificationActorEmployer, req.Comment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.GetVerification(db, verification.ID)
+}
+
+// GetMyVerification - текущая (последняя) заявка работодателя с историей
+func (s *verificationService) GetMyVerification(db *gorm.DB, userID string) (*dto.VerificationResponse, error) {
+	employer, err := s.profileRepo.FindEmployerProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleVerificationError(err)
+	}
+	verification, err := s.verificationRepo.FindLatestVerification(db, employer.ID)
+	if err != nil {
+		return nil, handleVerificationError(err)
+	}
+	return s.buildVerificationResponse(db, verification)
+}
+
+// UpdateMyVerification - ответ на запрос модератора: заявка needs_info возвращается в очередь
+func (s *verificationService) UpdateMyVerification(db *gorm.DB, userID string, req *dto.UpdateVerificationRequest) (*dto.VerificationResponse, error) {
+	employer, err := s.profileRepo.FindEmployerProfileByUserID(db, userID)
+	if err != nil {
+		return nil, handleVerificationError(err)
+	}
+	verification, err := s.verificationRepo.FindLatestVerification(db, employer.ID)
+	if err != nil {
+		return nil, handleVerificationError(err)
+	}
+	if verification.Status != models.VerificationStatusNeedsInfo {
+		return nil, apperrors.ErrInvalidStatus("verification", "Only requests awaiting more information can be updated")
+	}
+
+	if req.BIN != nil {
+		verification.BIN = *req.BIN
+	}
+	if req.LegalName != nil {
+		verification.LegalName = *req.LegalName
+	}
+	if req.Comment != nil {
+		verification.Comment = *req.Comment
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	from := verification.Status
+	verification.Status = models.VerificationStatusPending
+	verification.SubmittedAt = time.Now()
+	if err := s.verificationRepo.UpdateVerification(tx, verification); err != nil {
+		return nil, handleVerificationError(err)
+	}
+	if err := s.attachDocuments(tx, userID, verification.ID, req.DocumentIDs); err != nil {
+		return nil, err
+	}
+	if err := s.recordTransition(tx, verification, from, &userID, models.VerificationActorEmployer, verification.Comment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.GetVerification(db, verification.ID)
+}
+
+// --- Модерация ---
+
+// ListVerifications - очередь модерации; по умолчанию ожидающие проверки, старые первыми
+func (s *verificationService) ListVerifications(db *gorm.DB, query *dto.VerificationQueueQuery) ([]models.EmployerVerification, int64, error) {
+	status := query.Status
+	if status == "" {
+		status = models.VerificationStatusPending
+	}
+	verifications, total, err := s.verificationRepo.FindVerifications(db, repositories.VerificationCriteria{
+		Status:   status,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	})
+	if err != nil {
+		return nil, 0, apperrors.InternalError(err)
+	}
+	return verifications, total, nil
+}
+
+func (s *verificationService) GetVerification(db *gorm.DB, verificationID string) (*dto.VerificationResponse, error) {
+	verification, err := s.verificationRepo.FindVerificationByID(db, verificationID)
+	if err != nil {
+		return nil, handleVerificationError(err)
+	}
+	return s.buildVerificationResponse(db, verification)
+}
+
+// ApproveVerification - значок "verified" работодателю на срок действия верификации
+func (s *verificationService) ApproveVerification(db *gorm.DB, adminID, verificationID string, req *dto.ApproveVerificationRequest) (*dto.VerificationResponse, error) {
+	months := req.ValidMonths
+	if months == 0 {
+		months = models.VerificationValidityMonths
+	}
+	expiresAt := time.Now().AddDate(0, months, 0)
+
+	return s.review(db, adminID, verificationID, models.VerificationStatusApproved, req.Note,
+		func(tx *gorm.DB, verification *models.EmployerVerification) error {
+			verification.ExpiresAt = &expiresAt
+			return s.profileRepo.VerifyEmployerProfile(tx, verification.EmployerProfileID, &expiresAt)
+		},
+		"Компания верифицирована",
+		fmt.Sprintf("Верификация действует до %s", expiresAt.Format("02.01.2006")))
+}
+
+// RejectVerification - отказ с причиной; действующий значок (при повторной заявке) сохраняется до истечения
+func (s *verificationService) RejectVerification(db *gorm.DB, adminID, verificationID string, req *dto.ReviewNoteRequest) (*dto.VerificationResponse, error) {
+	return s.review(db, adminID, verificationID, models.VerificationStatusRejected, req.Note, nil,
+		"Заявка на верификацию отклонена",
+		req.Note)
+}
+
+// RequestMoreInfo - заявка возвращается работодателю за дополнительными документами
+func (s *verificationService) RequestMoreInfo(db *gorm.DB, adminID, verificationID string, req *dto.ReviewNoteRequest) (*dto.VerificationResponse, error) {
+	return s.review(db, adminID, verificationID, models.VerificationStatusNeedsInfo, req.Note, nil,
+		"Нужны дополнительные документы для верификации",
+		req.Note)
+}
+
+// ExpireVerifications - одобренные заявки с истекшим сроком переходят в expired, значок снимается.
+// Работодатель, успевший пройти повторную верификацию, значок сохраняет и уведомление не получает.
+func (s *verificationService) ExpireVerifications(db *gorm.DB) error {
+	now := time.Now()
+	verifications, err := s.verificationRepo.FindExpiredVerifications(db, now)
+	if err != nil {
+		return apperrors.InternalError(err)
+	}
+
+	for i := range verifications {
+		verification := &verifications[i]
+		if err := s.expire(db, verification, now); err != nil {
+			return err
+		}
+	}
+
+	// Значки с истекшим сроком, в том числе выданные вручную без заявки
+	if _, err := s.profileRepo.RevokeExpiredEmployerVerifications(db, now); err != nil {
+		return apperrors.InternalError(err)
+	}
+	return nil
+}
+
+// =======================
+// 3. ХЕЛПЕРЫ
+// =======================
+
+// review - решение модератора по заявке, ожидающей проверки
+func (s *verificationService) review(
+	db *gorm.DB,
+	adminID, verificationID, to, note string,
+	apply func(tx *gorm.DB, verification *models.EmployerVerification) error,
+	title, message string,
+) (*dto.VerificationResponse, error) {
+	verification, err := s.verificationRepo.FindVerificationByID(db, verificationID)
+	if err != nil {
+		return nil, handleVerificationError(err)
+	}
+	if verification.Status != models.VerificationStatusPending {
+		return nil, apperrors.ErrInvalidStatus("verification", "Only pending requests can be reviewed")
+	}
+
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	from := verification.Status
+	now := time.Now()
+	verification.Status = to
+	verification.ReviewerID = &adminID
+	verification.ReviewNote = note
+	verification.ReviewedAt = &now
+	if apply != nil {
+		if err := apply(tx, verification); err != nil {
+			return nil, handleVerificationError(err)
+		}
+	}
+	if err := s.verificationRepo.UpdateVerification(tx, verification); err != nil {
+		return nil, handleVerificationError(err)
+	}
+	if err := s.recordTransition(tx, verification, from, &adminID, models.VerificationActorAdmin, note); err != nil {
+		return nil, err
+	}
+	if err := s.notificationRepo.CreateVerificationNotification(tx, verification.UserID, verification.ID, to, title, message); err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+	return s.GetVerification(db, verification.ID)
+}
+
+// expire - истечение одной одобренной заявки
+func (s *verificationService) expire(db *gorm.DB, verification *models.EmployerVerification, now time.Time) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return apperrors.InternalError(tx.Error)
+	}
+	defer tx.Rollback()
+
+	from := verification.Status
+	verification.Status = models.VerificationStatusExpired
+	if err := s.verificationRepo.UpdateVerification(tx, verification); err != nil {
+		return handleVerificationError(err)
+	}
+	if err := s.recordTransition(tx, verification, from, nil, models.VerificationActorSystem, "verification period ended"); err != nil {
+		return err
+	}
+
+	employer, err := s.profileRepo.FindEmployerProfileByID(tx, verification.EmployerProfileID)
+	if err != nil {
+		return handleVerificationError(err)
+	}
+	renewed := employer.IsVerified && employer.VerifiedUntil != nil && employer.VerifiedUntil.After(now)
+	if !renewed {
+		if err := s.notificationRepo.CreateVerificationNotification(tx, verification.UserID, verification.ID, verification.Status,
+			"Срок верификации истек",
+			"Подайте повторную заявку, чтобы вернуть значок проверенной компании"); err != nil {
+			return apperrors.InternalError(err)
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// attachDocuments - привязывает загрузки модуля "verification" текущего работодателя к заявке
+func (s *verificationService) attachDocuments(tx *gorm.DB, userID, verificationID string, documentIDs []string) error {
+	for _, documentID := range documentIDs {
+		upload, err := s.uploadRepo.FindByID(tx, documentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrNotFound(fmt.Errorf("document %s not found", documentID))
+			}
+			return apperrors.InternalError(err)
+		}
+		if upload.UserID != userID || upload.Module != verificationUploadModule {
+			return apperrors.ErrInvalidOperation("verification", fmt.Sprintf("Document %s is not your verification upload", documentID))
+		}
+		if upload.EntityType == verificationEntityType && upload.EntityID != "" && upload.EntityID != verificationID {
+			return apperrors.ErrInvalidOperation("verification", fmt.Sprintf("Document %s is attached to another request", documentID))
+		}
+
+		upload.EntityType = verificationEntityType
+		upload.EntityID = verificationID
+		if err := s.uploadRepo.Update(tx, upload); err != nil {
+			return apperrors.InternalError(err)
+		}
+	}
+	return nil
+}
+
+func (s *verificationService) recordTransition(tx *gorm.DB, verification *models.EmployerVerification, from string, actorID *string, actorType, note string) error {
+	transition := &models.EmployerVerificationTransition{
+		VerificationID: verification.ID,
+		FromStatus:     from,
+		ToStatus:       verification.Status,
+		ActorID:        actorID,
+		ActorType:      actorType,
+		Note:           note,
+	}
+	if err := s.verificationRepo.CreateTransition(tx, transition); err != nil {
+		return apperrors.InternalError(err)
+	}
+	return nil
+}
+
+func (s *verificationService) buildVerificationResponse(db *gorm.DB, verification *models.EmployerVerification) (*dto.VerificationResponse, error) {
+	uploads, err := s.uploadRepo.FindByEntity(db, verificationEntityType, verification.ID)
+	if err != nil {
+		return nil, apperrors.InternalError(err)
+	}
+
+	documents := make([]dto.VerificationDocument, 0, len(uploads))
+	for _, upload := range uploads {
+		documents = append(documents, dto.VerificationDocument{
+			ID:        upload.ID,
+			Usage:     upload.Usage,
+			MimeType:  upload.MimeType,
+			Size:      upload.Size,
+			URL:       fmt.Sprintf("/api/v1/files/%s", upload.ID),
+			CreatedAt: upload.CreatedAt,
+		})
+	}
+
+	return &dto.VerificationResponse{
+		Verification: verification,
+		Documents:    documents,
+	}, nil
+}
+
+func handleVerificationError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrVerificationNotFound),
+		errors.Is(err, repositories.ErrProfileNotFound):
+		return apperrors.ErrNotFound(err)
+	case errors.Is(err, repositories.ErrVerificationExists):
+		return apperrors.ErrVerificationInProgress
+	}
+	return apperrors.InternalError(err)
+}
@@ -52,6 +52,13 @@ func registerCustomRules(v *validator.Validate) {
 
 	// 'is-job-type': Проверяет тип работы (из Casting)
 	mustRegister("is-job-type", validateJobType)
+
+	// 'is-hair-color' / 'is-eye-color': Цвет волос и глаз (из models.HairColors / models.EyeColors)
+	mustRegister("is-hair-color", validateHairColor)
+	mustRegister("is-eye-color", validateEyeColor)
+
+	// 'is-locale': Язык контента (из models.Locales)
+	mustRegister("is-locale", validateLocale)
 }
 
 // --- Функции валидации ---
@@ -65,7 +72,7 @@ func validateUserRole(fl validator.FieldLevel) bool {
 
 	// Проверяем, соответствует ли строка одному из наших типов
 	switch models.UserRole(value) {
-	case models.UserRoleModel, models.UserRoleEmployer, models.UserRoleAdmin:
+	case models.UserRoleModel, models.UserRoleEmployer, models.UserRoleAdmin, models.UserRoleAgency:
 		return true
 	default:
 		return false
@@ -157,3 +164,18 @@ func validateJobType(fl validator.FieldLevel) bool {
 		return false
 	}
 }
+
+func validateHairColor(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	return value == "" || models.IsHairColor(value)
+}
+
+func validateEyeColor(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	return value == "" || models.IsEyeColor(value)
+}
+
+func validateLocale(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	return value == "" || models.IsLocale(value)
+}
@@ -6,43 +6,67 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+
+	"mwork_backend/internal/services"
 )
 
 type CastingWorker struct {
-	db *gorm.DB
+	db               *gorm.DB
+	castingService   services.CastingService
+	promotionService services.PromotionService
 }
 
-func NewCastingWorker(db *gorm.DB) *CastingWorker {
-	return &CastingWorker{db: db}
+func NewCastingWorker(db *gorm.DB, castingService services.CastingService, promotionService services.PromotionService) *CastingWorker {
+	return &CastingWorker{db: db, castingService: castingService, promotionService: promotionService}
 }
 
 // Start запускает фоновые задачи для кастингов
 func (w *CastingWorker) Start(ctx context.Context) {
-	// Автозакрытие просроченных кастингов каждый час
+	// Автозакрытие просроченных кастингов при старте и далее каждый час
 	go w.autoCloseCastings(ctx)
+	// Завершение истекших продвижений каждые 10 минут
+	go w.expirePromotions(ctx)
 }
 
-// autoCloseCastings автоматически закрывает кастинги с прошедшей датой
+// autoCloseCastings автоматически закрывает кастинги с прошедшей датой.
+// Смена статуса идет через state machine (CastingService), чтобы переход попал в журнал.
 func (w *CastingWorker) autoCloseCastings(ctx context.Context) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
+	w.closeExpiredCastings()
 	for {
 		select {
 		case <-ctx.Done():
 			log.Println("Casting worker stopped")
 			return
 		case <-ticker.C:
-			result := w.db.Exec(`
-				UPDATE castings 
-				SET status = 'closed', updated_at = NOW()
-				WHERE status = 'active' 
-				AND event_date < NOW()
-			`)
-			if result.Error != nil {
-				log.Printf("Error auto-closing castings: %v", result.Error)
-			} else if result.RowsAffected > 0 {
-				log.Printf("Auto-closed %d expired castings", result.RowsAffected)
+			w.closeExpiredCastings()
+		}
+	}
+}
+
+func (w *CastingWorker) closeExpiredCastings() {
+	if err := w.castingService.CloseExpiredCastings(w.db); err != nil {
+		log.Printf("Error auto-closing castings: %v", err)
+	}
+}
+
+// expirePromotions переводит истекшие продвижения кастингов в статус expired
+func (w *CastingWorker) expirePromotions(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := w.promotionService.ExpirePromotions(w.db)
+			if err != nil {
+				log.Printf("Error expiring casting promotions: %v", err)
+			} else if count > 0 {
+				log.Printf("Expired %d casting promotions", count)
 			}
 		}
 	}
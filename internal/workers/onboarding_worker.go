@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/services"
+)
+
+type OnboardingWorker struct {
+	db                *gorm.DB
+	onboardingService services.OnboardingService
+}
+
+func NewOnboardingWorker(db *gorm.DB, onboardingService services.OnboardingService) *OnboardingWorker {
+	return &OnboardingWorker{db: db, onboardingService: onboardingService}
+}
+
+// Start запускает фоновые задачи онбординга моделей
+func (w *OnboardingWorker) Start(ctx context.Context) {
+	// Напоминания о заполнении профиля раз в сутки
+	go w.sendCompletenessNudges(ctx)
+}
+
+// sendCompletenessNudges - уведомления и письма моделям с незаполненным профилем
+// (не чаще раза в неделю на профиль)
+func (w *OnboardingWorker) sendCompletenessNudges(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Onboarding worker stopped")
+			return
+		case <-ticker.C:
+			if err := w.onboardingService.SendCompletenessNudges(w.db); err != nil {
+				log.Printf("Error sending profile completeness nudges: %v", err)
+			}
+		}
+	}
+}
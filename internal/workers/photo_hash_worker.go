@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/services"
+)
+
+// photoHashBatchSize - изображений за один проход
+const photoHashBatchSize = 200
+
+type PhotoHashWorker struct {
+	db                     *gorm.DB
+	photoModerationService services.PhotoModerationService
+}
+
+func NewPhotoHashWorker(db *gorm.DB, photoModerationService services.PhotoModerationService) *PhotoHashWorker {
+	return &PhotoHashWorker{db: db, photoModerationService: photoModerationService}
+}
+
+// Start запускает хэширование старых изображений для поиска дубликатов
+func (w *PhotoHashWorker) Start(ctx context.Context) {
+	// Дохэширование при старте и далее каждые 10 минут
+	go w.hashUnhashedImages(ctx)
+}
+
+func (w *PhotoHashWorker) hashUnhashedImages(ctx context.Context) {
+	ticker := time.NewTicker(10 * time.Minute)
+	defer ticker.Stop()
+
+	w.drainQueue(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Photo hash worker stopped")
+			return
+		case <-ticker.C:
+			w.drainQueue(ctx)
+		}
+	}
+}
+
+// drainQueue обрабатывает очередь пачками, пока она не опустеет
+func (w *PhotoHashWorker) drainQueue(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.photoModerationService.HashUnhashedImages(ctx, w.db, photoHashBatchSize)
+		if err != nil {
+			log.Printf("Error hashing uploaded images: %v", err)
+			return
+		}
+		if processed < photoHashBatchSize {
+			return
+		}
+	}
+}
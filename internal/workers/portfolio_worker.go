@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/services"
+)
+
+type PortfolioWorker struct {
+	db               *gorm.DB
+	portfolioService services.PortfolioService
+}
+
+func NewPortfolioWorker(db *gorm.DB, portfolioService services.PortfolioService) *PortfolioWorker {
+	return &PortfolioWorker{db: db, portfolioService: portfolioService}
+}
+
+// Start запускает фоновые задачи портфолио
+func (w *PortfolioWorker) Start(ctx context.Context) {
+	// Пересчет рейтинга featured при старте и далее раз в час
+	go w.recomputeFeatured(ctx)
+}
+
+func (w *PortfolioWorker) recomputeFeatured(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	// Без первого пересчета лента featured пуста до первого тика
+	w.recompute(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Portfolio worker stopped")
+			return
+		case <-ticker.C:
+			w.recompute(ctx)
+		}
+	}
+}
+
+func (w *PortfolioWorker) recompute(ctx context.Context) {
+	if _, err := w.portfolioService.RecomputeFeaturedScores(ctx, w.db); err != nil {
+		log.Printf("Error recomputing featured portfolio scores: %v", err)
+	}
+}
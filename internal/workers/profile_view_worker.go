@@ -0,0 +1,49 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/services"
+)
+
+type ProfileViewWorker struct {
+	db                 *gorm.DB
+	profileViewService services.ProfileViewService
+}
+
+func NewProfileViewWorker(db *gorm.DB, profileViewService services.ProfileViewService) *ProfileViewWorker {
+	return &ProfileViewWorker{db: db, profileViewService: profileViewService}
+}
+
+// Start запускает фоновые задачи просмотров профилей
+func (w *ProfileViewWorker) Start(ctx context.Context) {
+	// Еженедельная сводка просмотров моделям: проверка при старте и далее раз в сутки,
+	// сервис сам пропускает моделей, получивших сводку меньше недели назад
+	go w.sendWeeklySummaries(ctx)
+}
+
+func (w *ProfileViewWorker) sendWeeklySummaries(ctx context.Context) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	w.sendDueSummaries()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Profile view worker stopped")
+			return
+		case <-ticker.C:
+			w.sendDueSummaries()
+		}
+	}
+}
+
+func (w *ProfileViewWorker) sendDueSummaries() {
+	if err := w.profileViewService.SendWeeklySummaries(w.db); err != nil {
+		log.Printf("Error sending weekly profile view summaries: %v", err)
+	}
+}
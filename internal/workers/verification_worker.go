@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+
+	"mwork_backend/internal/services"
+)
+
+type VerificationWorker struct {
+	db                  *gorm.DB
+	verificationService services.VerificationService
+}
+
+func NewVerificationWorker(db *gorm.DB, verificationService services.VerificationService) *VerificationWorker {
+	return &VerificationWorker{db: db, verificationService: verificationService}
+}
+
+// Start запускает фоновые задачи верификации работодателей
+func (w *VerificationWorker) Start(ctx context.Context) {
+	// Истечение срока верификации каждые 6 часов
+	go w.expireVerifications(ctx)
+}
+
+// expireVerifications переводит заявки с истекшим сроком в expired и снимает значок "verified".
+// Переход идет через VerificationService, чтобы попасть в историю статусов и уведомить работодателя.
+func (w *VerificationWorker) expireVerifications(ctx context.Context) {
+	ticker := time.NewTicker(6 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Verification worker stopped")
+			return
+		case <-ticker.C:
+			if err := w.verificationService.ExpireVerifications(w.db); err != nil {
+				log.Printf("Error expiring employer verifications: %v", err)
+			}
+		}
+	}
+}
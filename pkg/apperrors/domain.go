@@ -2,6 +2,7 @@ package apperrors
 
 import (
 	"net/http"
+	"time"
 )
 
 /*
@@ -115,6 +116,14 @@ var ErrStorageLimitExceeded = New(
 	http.StatusForbidden, // 403 (Запрещено использовать больше места)
 )
 
+// ErrDuplicatePhoto - фото почти совпадает с фото другого пользователя (если такие загрузки запрещены).
+var ErrDuplicatePhoto = New(
+	CodeConflict,
+	"upload",
+	"This photo matches an image uploaded by another user",
+	http.StatusConflict, // 409
+)
+
 // --- Subscriptions & Payments (НОВЫЙ РАЗДЕЛ) ---
 
 // ErrSubscriptionCancelled - подписка уже отменена.
@@ -213,6 +222,51 @@ var ErrInvalidCastingStatus = New(
 	http.StatusConflict, // 409
 )
 
+// ErrCastingHasPendingResponses - нельзя закрыть кастинг, пока есть необработанные отклики.
+var ErrCastingHasPendingResponses = New(
+	CodeConflict,
+	"casting",
+	"Casting has pending responses: reject them or close with reject_pending=true",
+	http.StatusConflict, // 409
+)
+
+// ErrCastingStatusChanged - статус кастинга изменился параллельно (напр. воркером).
+var ErrCastingStatusChanged = New(
+	CodeConflict,
+	"casting",
+	"Casting status was changed by another operation, please retry",
+	http.StatusConflict, // 409
+)
+
+// --- Responses (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrReapplyCooldown - повторный отклик после отзыва возможен только по истечении cooldown.
+// Фабрика: в details отдаем время, когда отклик снова станет доступен.
+func ErrReapplyCooldown(availableAt time.Time) *AppError {
+	return New(
+		CodeLimitExceeded,
+		"response",
+		"You withdrew this response recently, re-apply is temporarily unavailable",
+		http.StatusTooManyRequests, // 429
+	).WithDetails(map[string]interface{}{"available_at": availableAt})
+}
+
+// ErrResponseWithdrawLimit - модель исчерпала число отзывов/повторных откликов на кастинг.
+var ErrResponseWithdrawLimit = New(
+	CodeLimitExceeded,
+	"response",
+	"Re-apply limit for this casting has been reached",
+	http.StatusConflict, // 409
+)
+
+// ErrResponseStatusChanged - статус отклика изменился параллельно (напр. работодатель уже принял).
+var ErrResponseStatusChanged = New(
+	CodeConflict,
+	"response",
+	"Response status was changed by another operation, please retry",
+	http.StatusConflict, // 409
+)
+
 // --- Auth & User Status (НОВЫЙ РАЗДЕЛ) ---
 
 // ErrWeakPassword - пароль слишком слабый.
@@ -288,3 +342,173 @@ var ErrProfileNotPublic = New(
 	"This profile is private",
 	http.StatusForbidden, // 403
 )
+
+// --- Casting Promotions (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrPromotionNotIncluded - продвижение не входит в тариф пользователя.
+var ErrPromotionNotIncluded = New(
+	CodeForbidden,
+	"promotion",
+	"Casting promotion is not included in your plan",
+	http.StatusForbidden, // 403
+)
+
+// ErrInvalidPromotionDuration - недопустимая длительность продвижения.
+var ErrInvalidPromotionDuration = New(
+	CodeValidationFailed,
+	"promotion",
+	"Invalid promotion duration",
+	http.StatusBadRequest, // 400
+)
+
+// --- Bookings (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrBookingAlreadyExists - по отклику уже есть действующее бронирование.
+var ErrBookingAlreadyExists = New(
+	CodeAlreadyExists,
+	"booking",
+	"An active booking already exists for this response",
+	http.StatusConflict, // 409
+)
+
+// ErrBookingStatusChanged - статус бронирования изменился параллельно (напр. вторая сторона уже отменила).
+var ErrBookingStatusChanged = New(
+	CodeConflict,
+	"booking",
+	"Booking status was changed by another operation, please retry",
+	http.StatusConflict, // 409
+)
+
+// ErrContractVersionMismatch - подписант видел устаревшую версию договора.
+var ErrContractVersionMismatch = New(
+	CodeConflict,
+	"booking",
+	"Contract has been changed, please review the current version before signing",
+	http.StatusConflict, // 409
+)
+
+// ErrSignatureNameMismatch - набранное имя не совпадает с именем в аккаунте.
+var ErrSignatureNameMismatch = New(
+	CodeValidationFailed,
+	"booking",
+	"Typed name must match the name on your account",
+	http.StatusBadRequest, // 400
+)
+
+// --- Ledger (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrLedgerEntryUnbalanced - сумма строк проводки не равна нулю (ошибка в коде проводки).
+var ErrLedgerEntryUnbalanced = New(
+	CodeInternalError,
+	"ledger",
+	"Ledger entry is not balanced",
+	http.StatusInternalServerError, // 500
+)
+
+// ErrPaymentNotRefundable - вернуть можно только оплаченный платеж.
+var ErrPaymentNotRefundable = New(
+	CodeInvalidStatus,
+	"payment",
+	"Only paid payments can be refunded",
+	http.StatusConflict, // 409
+)
+
+// --- Invoices (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrInvoiceNotAvailable - счет выставляется только по оплаченному платежу, кредит-нота - по возвращенному.
+var ErrInvoiceNotAvailable = New(
+	CodeInvalidStatus,
+	"invoice",
+	"Invoice is not available for this payment yet",
+	http.StatusConflict, // 409
+)
+
+// --- Agencies (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrNotRepresented - агентство может действовать только от имени моделей с активным представительством.
+var ErrNotRepresented = New(
+	CodeForbidden,
+	"agency",
+	"This model is not represented by your agency",
+	http.StatusForbidden, // 403
+)
+
+// ErrRepresentationExists - агентство уже пригласило или представляет эту модель.
+var ErrRepresentationExists = New(
+	CodeAlreadyExists,
+	"agency",
+	"Your agency has already invited or represents this model",
+	http.StatusConflict, // 409
+)
+
+// ErrAlreadyRepresented - у модели уже есть агентство; сначала нужно из него уйти.
+var ErrAlreadyRepresented = New(
+	CodeConflict,
+	"agency",
+	"Model is already represented by an agency",
+	http.StatusConflict, // 409
+)
+
+// --- Employer verification (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrVerificationInProgress - у работодателя уже есть заявка на рассмотрении.
+var ErrVerificationInProgress = New(
+	CodeConflict,
+	"verification",
+	"Verification request is already under review",
+	http.StatusConflict, // 409
+)
+
+// ErrAlreadyVerified - повторная заявка принимается только незадолго до истечения верификации.
+var ErrAlreadyVerified = New(
+	CodeConflict,
+	"verification",
+	"Employer is already verified; re-verification opens 30 days before expiry",
+	http.StatusConflict, // 409
+)
+
+// --- Slugs (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrSlugTaken - адрес занят другой сущностью (в том числе как прежний адрес).
+var ErrSlugTaken = New(
+	CodeConflict,
+	"slug",
+	"This address is already taken",
+	http.StatusConflict, // 409
+)
+
+// --- Profile viewers (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrProfileViewersNotIncluded - список зрителей профиля не входит в тариф модели.
+var ErrProfileViewersNotIncluded = New(
+	CodeForbidden,
+	"profile_views",
+	"Profile viewers are available with a premium plan",
+	http.StatusForbidden, // 403
+)
+
+// --- Blocks and reports (НОВЫЙ РАЗДЕЛ) ---
+
+// ErrUserBlocked - один из пользователей добавил другого в черный список.
+var ErrUserBlocked = New(
+	CodeForbidden,
+	"block",
+	"This action is not available: one of the users has blocked the other",
+	http.StatusForbidden, // 403
+)
+
+// ErrAlreadyBlocked - пользователь уже в черном списке.
+var ErrAlreadyBlocked = New(
+	CodeConflict,
+	"block",
+	"User is already blocked",
+	http.StatusConflict, // 409
+)
+
+// ErrReportAlreadyPending - жалоба на этот объект уже ждет модератора.
+var ErrReportAlreadyPending = New(
+	CodeConflict,
+	"report",
+	"You have already reported this; the report is pending review",
+	http.StatusConflict, // 409
+)
@@ -85,6 +85,11 @@ func (ts *TestServer) RollbackTransaction(t *testing.T, tx *gorm.DB) {
 // ⭐️ 3. SendRequest ИЗМЕНЕН ⭐️
 // Теперь он принимает 'tx *gorm.DB'
 func (ts *TestServer) SendRequest(t *testing.T, tx *gorm.DB, method, path, token string, body interface{}) (*http.Response, string) {
+	return ts.SendRequestWithHeaders(t, tx, method, path, token, body, nil)
+}
+
+// SendRequestWithHeaders - SendRequest с дополнительными заголовками (например, Accept-Language)
+func (ts *TestServer) SendRequestWithHeaders(t *testing.T, tx *gorm.DB, method, path, token string, body interface{}, headers map[string]string) (*http.Response, string) {
 	url := ts.Server.URL + path
 
 	var reqBody io.Reader = nil
@@ -115,6 +120,9 @@ func (ts *TestServer) SendRequest(t *testing.T, tx *gorm.DB, method, path, token
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
 
 	res, err := ts.Server.Client().Do(req)
 	if err != nil {
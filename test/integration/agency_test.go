@@ -0,0 +1,133 @@
+package integration_test
+
+import (
+	"fmt"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAgency_RepresentationFlow - приглашение агентства, действия от имени модели,
+// уведомления агентству, "represented by" и уход модели из агентства
+func TestAgency_RepresentationFlow(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	agencyEmail := fmt.Sprintf("agency_%d@test.com", time.Now().UnixNano())
+	agencyToken, agencyUser := helpers.CreateAndLoginUser(t, ts, tx, "Test Agency", agencyEmail, "password123", models.UserRoleAgency)
+	agency := &models.AgencyProfile{UserID: agencyUser.ID, Name: "Star Models", City: "Almaty"}
+	assert.NoError(t, tx.Create(agency).Error)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, employerUser, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerUser.ID, "Agency Casting", "Almaty")
+
+	// 2. Приглашение: до принятия агентство не может действовать от имени модели
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPost, "/api/v1/agency/models/invite", agencyToken, map[string]interface{}{
+		"model_profile_id": modelProfile.ID,
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/agency/models/invite", agencyToken, map[string]interface{}{
+		"model_profile_id": modelProfile.ID,
+	})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/agency/models/"+modelProfile.ID+"/responses", agencyToken, map[string]interface{}{
+		"casting_id": casting.ID,
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	var representation models.ModelRepresentation
+	assert.NoError(t, tx.First(&representation, "model_profile_id = ?", modelProfile.ID).Error)
+	assert.Equal(t, models.RepresentationStatusPending, representation.Status)
+	t.Logf("АГЕНТСТВО: Приглашение модели (201/409/403) - Успешно.")
+
+	// 3. Модель видит приглашение и принимает его
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/me/representation", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, representation.ID)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/profiles/me/representation/invitations/"+representation.ID+"/accept", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"represented_by"`)
+	assert.Contains(t, bodyStr, "Star Models")
+	t.Logf("АГЕНТСТВО: Модель приняла приглашение, represented_by на профиле (200) - Успешно.")
+
+	// 4. Агентство ведет профиль и откликается от имени модели
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/agency/models/"+modelProfile.ID, agencyToken, map[string]interface{}{
+		"hair_color": "brown",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/agency/models/"+modelProfile.ID+"/responses", agencyToken, map[string]interface{}{
+		"casting_id": casting.ID,
+		"message":    "От агентства Star Models",
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+
+	var response models.CastingResponse
+	assert.NoError(t, tx.First(&response, "casting_id = ? AND model_id = ?", casting.ID, modelUser.ID).Error)
+	t.Logf("АГЕНТСТВО: Профиль и отклик от имени модели (200/201) - Успешно.")
+
+	// 5. Уведомление модели уходит агентству (и копией модели - по умолчанию включено)
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/responses/"+response.ID+"/status", employerToken, map[string]interface{}{
+		"status": models.ResponseStatusAccepted,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var agencyNotifications, modelNotifications int64
+	tx.Model(&models.Notification{}).Where("user_id = ? AND type = ?", agencyUser.ID, "response_status").Count(&agencyNotifications)
+	tx.Model(&models.Notification{}).Where("user_id = ? AND type = ?", modelUser.ID, "response_status").Count(&modelNotifications)
+	assert.Equal(t, int64(1), agencyNotifications)
+	assert.Equal(t, int64(1), modelNotifications)
+	t.Logf("АГЕНТСТВО: Уведомления модели доставлены агентству - Успешно.")
+
+	// 6. Модель уходит из агентства - агентство больше не может действовать от ее имени
+	res, _ = ts.SendRequest(t, tx, http.MethodDelete, "/api/v1/profiles/me/representation", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/agency/models/"+modelProfile.ID, agencyToken, map[string]interface{}{
+		"hair_color": "black",
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotContains(t, bodyStr, `"represented_by"`)
+	t.Logf("АГЕНТСТВО: Модель покинула агентство (200/403) - Успешно.")
+
+	// 7. Агентство заводит новую модель сама
+	managedEmail := fmt.Sprintf("managed_%d@test.com", time.Now().UnixNano())
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/agency/models", agencyToken, map[string]interface{}{
+		"email":  managedEmail,
+		"name":   "Managed Model",
+		"age":    20,
+		"city":   "Almaty",
+		"gender": "female",
+		"height": 175,
+		"weight": 55,
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+
+	var managedUser models.User
+	assert.NoError(t, tx.First(&managedUser, "email = ?", managedEmail).Error)
+	assert.Equal(t, models.UserRoleModel, managedUser.Role)
+
+	var managed models.ModelRepresentation
+	assert.NoError(t, tx.First(&managed, "model_user_id = ?", managedUser.ID).Error)
+	assert.Equal(t, models.RepresentationStatusActive, managed.Status)
+	assert.True(t, managed.IsManaged)
+	t.Logf("АГЕНТСТВО: Создание модели агентством (201) - Успешно.")
+}
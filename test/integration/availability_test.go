@@ -0,0 +1,124 @@
+package integration_test
+
+import (
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAvailability_Calendar - интервалы, еженедельный шаблон и автоблокировка по принятому отклику
+func TestAvailability_Calendar(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	tripStart := time.Now().AddDate(0, 0, 10).UTC().Truncate(time.Hour)
+
+	// 2. Действие: Модель отмечает поездку
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/profiles/me/availability/ranges", modelToken, map[string]interface{}{
+		"status":    "unavailable",
+		"starts_at": tripStart,
+		"ends_at":   tripStart.Add(72 * time.Hour),
+		"note":      "Поездка в Стамбул",
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	t.Logf("КАЛЕНДАРЬ: Интервал недоступности (201) - Успешно. Ответ: %s", bodyStr)
+
+	// 3. Невалидный интервал (400)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/profiles/me/availability/ranges", modelToken, map[string]interface{}{
+		"status":    "unavailable",
+		"starts_at": tripStart,
+		"ends_at":   tripStart.Add(-time.Hour),
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 4. Еженедельный шаблон
+	res, bodyStr = ts.SendRequest(t, tx, "PUT", "/api/v1/profiles/me/availability/weekly", modelToken, map[string]interface{}{
+		"rules": []map[string]interface{}{
+			{"status": "unavailable", "weekday": 0},
+			{"status": "available", "weekday": 1, "start": "10:00", "end": "18:00"},
+		},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"total":2`)
+
+	// 5. Принятый отклик блокирует день кастинга
+	castingDate := time.Now().AddDate(0, 0, 5)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг с датой", "Almaty")
+	assert.NoError(t, tx.Model(&models.Casting{}).Where("id = ?", casting.ID).Update("event_date", castingDate).Error)
+	CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusAccepted)
+
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/profiles/me/availability", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, "Поездка в Стамбул")
+	assert.Contains(t, bodyStr, `"source":"casting"`)
+	assert.Contains(t, bodyStr, "Кастинг с датой")
+
+	// 6. Работодатель видит занятость без заметок и названий проектов
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/profiles/models/"+modelProfile.ID+"/availability", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"status":"unavailable"`)
+	assert.NotContains(t, bodyStr, "Поездка в Стамбул")
+	assert.NotContains(t, bodyStr, "Кастинг с датой")
+	t.Logf("КАЛЕНДАРЬ: Просмотр работодателем (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 7. Работодатель не может редактировать календарь (403)
+	res, _ = ts.SendRequest(t, tx, "PUT", "/api/v1/profiles/me/availability/weekly", employerToken, map[string]interface{}{
+		"rules": []map[string]interface{}{},
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+}
+
+// TestAvailability_Matching - занятая на дату кастинга модель не попадает в подбор
+func TestAvailability_Matching(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	busyToken, _, busyProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	_, _, freeProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	_, bookedUser, bookedProfile := helpers.CreateAndLoginModel(t, ts, tx)
+
+	castingDate := time.Now().AddDate(0, 0, 7).UTC().Truncate(time.Hour)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Подбор на дату", "Almaty")
+	assert.NoError(t, tx.Model(&models.Casting{}).Where("id = ?", casting.ID).Update("event_date", castingDate).Error)
+
+	res, _ := ts.SendRequest(t, tx, "POST", "/api/v1/profiles/me/availability/ranges", busyToken, map[string]interface{}{
+		"status":    "unavailable",
+		"starts_at": castingDate.Add(-24 * time.Hour),
+		"ends_at":   castingDate.Add(24 * time.Hour),
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+
+	// Принятый отклик на другой кастинг в тот же день тоже занимает модель
+	otherCasting := CreateTestCasting(t, tx, employerProfile.ID, "Другой кастинг в тот же день", "Almaty")
+	assert.NoError(t, tx.Model(&models.Casting{}).Where("id = ?", otherCasting.ID).Update("event_date", castingDate).Error)
+	CreateTestResponse(t, tx, otherCasting.ID, bookedUser.ID, models.ResponseStatusAccepted)
+
+	// 2. Действие: Подбор моделей на кастинг
+	res, bodyStr := ts.SendRequest(t, tx, "GET", "/api/v1/matching/castings/"+casting.ID+"/models?min_score=0", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotContains(t, bodyStr, busyProfile.ID)
+	assert.NotContains(t, bodyStr, bookedProfile.ID)
+	assert.Contains(t, bodyStr, freeProfile.ID)
+
+	// 3. Совместимость показывает причину
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/matching/compatibility?model_id="+busyProfile.ID+"&casting_id="+casting.ID, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"availability":{"status":"unavailable"`)
+	t.Logf("КАЛЕНДАРЬ: Подбор учитывает доступность (200) - Успешно. Ответ: %s", bodyStr)
+}
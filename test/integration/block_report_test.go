@@ -0,0 +1,184 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"mwork_backend/internal/models"
+	chatmodels "mwork_backend/internal/models/chat"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBlocksAndReports - черный список (сообщения, отклики, профиль и поиск) и жалобы
+// с очередью модерации
+func TestBlocksAndReports(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, employerUser, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	adminEmail := fmt.Sprintf("admin_%d@test.com", time.Now().UnixNano())
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Test Admin", adminEmail, "password123", models.UserRoleAdmin)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Blocked Casting", "Almaty")
+
+	modelName := fmt.Sprintf("Blocked Model %d", time.Now().UnixNano())
+	assert.NoError(t, tx.Model(&models.ModelProfile{}).Where("id = ?", modelProfile.ID).Update("name", modelName).Error)
+	companyName := fmt.Sprintf("Blocked Company %d", time.Now().UnixNano())
+	assert.NoError(t, tx.Model(&models.EmployerProfile{}).Where("id = ?", employerProfile.ID).Update("company_name", companyName).Error)
+
+	// Диалог создан до блокировки
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPost, "/api/v1/dialogs", employerToken, map[string]interface{}{
+		"participant_ids": []string{modelUser.ID},
+		"is_group":        false,
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	var dialog chatmodels.Dialog
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &dialog))
+
+	// 2. Блокировка: себя - 400, повторно - 409
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/blocks/"+modelUser.ID, modelToken, nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/blocks/"+employerUser.ID, modelToken, nil)
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/blocks/"+employerUser.ID, modelToken, nil)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/blocks", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, employerUser.ID)
+	assert.Contains(t, bodyStr, `"total":1`)
+	t.Logf("БЛОКИРОВКА: Черный список (201/400/409) - Успешно.")
+
+	// 3. Заблокированный работодатель не может писать и создавать диалоги
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/messages", employerToken, map[string]interface{}{
+		"dialog_id": dialog.ID,
+		"content":   "Почему вы не отвечаете?",
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/dialogs", employerToken, map[string]interface{}{
+		"participant_ids": []string{modelUser.ID},
+		"is_group":        false,
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("БЛОКИРОВКА: Сообщения и диалоги запрещены (403) - Успешно.")
+
+	// 4. Профиль и поиск: работодатель модель не видит, гость видит
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, employerToken, nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	searchURL := "/api/v1/profiles/models/search?query=Blocked%20Model"
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, searchURL, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, modelName)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, searchURL, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NotContains(t, bodyStr, modelName)
+
+	unified := map[string]interface{}{"query": modelName}
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/search/unified", "", unified)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, modelName)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/search/unified", employerToken, unified)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NotContains(t, bodyStr, modelName)
+
+	// Модель не видит кастинги и карточку заблокированного работодателя
+	castingsURL := "/api/v1/castings?query=Blocked%20Casting"
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, castingsURL, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, casting.ID)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, castingsURL, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NotContains(t, bodyStr, casting.ID)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/search/castings", modelToken, map[string]interface{}{"query": "Blocked Casting"})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NotContains(t, bodyStr, casting.ID)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/search/employers", modelToken, map[string]interface{}{"query": companyName})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NotContains(t, bodyStr, companyName)
+	employersURL := "/api/v1/profiles/employers/search?query=" + url.QueryEscape(companyName)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, employersURL, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, companyName)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, employersURL, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NotContains(t, bodyStr, companyName)
+	t.Logf("БЛОКИРОВКА: Профиль и поиск скрыты (404/200) - Успешно.")
+
+	// 5. Модель не может откликнуться на кастинг заблокированного работодателя
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/responses/castings/"+casting.ID, modelToken, map[string]interface{}{
+		"message": "Хочу участвовать",
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("БЛОКИРОВКА: Отклик на кастинг запрещен (403) - Успешно.")
+
+	// 6. Жалоба: "other" без описания - 400, повторная - 409
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/reports", modelToken, map[string]interface{}{
+		"target_type": "user",
+		"target_id":   employerUser.ID,
+		"reason":      "other",
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/reports", modelToken, map[string]interface{}{
+		"target_type": "user",
+		"target_id":   employerUser.ID,
+		"reason":      "harassment",
+		"details":     "Пишет в нерабочее время",
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	var report models.Report
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &report))
+	assert.Equal(t, employerUser.ID, *report.TargetUserID)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/reports", modelToken, map[string]interface{}{
+		"target_type": "user",
+		"target_id":   employerUser.ID,
+		"reason":      "spam",
+	})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+	t.Logf("ЖАЛОБЫ: Создание жалобы (201/400/409) - Успешно.")
+
+	// 7. Очередь модерации: только администратор; решение уведомляет автора
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/admin/reports", modelToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/admin/reports?target_user_id="+employerUser.ID, adminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, report.ID)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/reports/"+report.ID+"/resolve", adminToken, map[string]interface{}{
+		"note": "Работодателю вынесено предупреждение",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"status":"resolved"`)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/reports/"+report.ID+"/dismiss", adminToken, map[string]interface{}{})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var notifications int64
+	tx.Model(&models.Notification{}).Where("user_id = ? AND type = ?", modelUser.ID, "report").Count(&notifications)
+	assert.Equal(t, int64(1), notifications)
+	t.Logf("ЖАЛОБЫ: Очередь модерации и решение (403/200/400) - Успешно.")
+
+	// 8. После разблокировки профиль снова доступен
+	res, _ = ts.SendRequest(t, tx, http.MethodDelete, "/api/v1/blocks/"+employerUser.ID, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	t.Logf("БЛОКИРОВКА: Разблокировка (200) - Успешно.")
+}
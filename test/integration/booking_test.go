@@ -0,0 +1,221 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bookingFromBody - id и хэш договора из ответа
+func bookingFromBody(t *testing.T, bodyStr string) (string, string) {
+	var booking struct {
+		ID           string `json:"id"`
+		ContractHash string `json:"contract_hash"`
+	}
+	err := json.Unmarshal([]byte(bodyStr), &booking)
+	assert.NoError(t, err, "Не удалось распарсить JSON")
+	return booking.ID, booking.ContractHash
+}
+
+// TestBooking_CreateSignConfirm - бронирование по принятому отклику, подписи обеих сторон и подтверждение
+func TestBooking_CreateSignConfirm(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, employerUser, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Съемка лукбука", "Almaty")
+	response := CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusAccepted)
+
+	startsAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	bookingBody := map[string]interface{}{
+		"response_id":       response.ID,
+		"fee":               150000,
+		"currency":          "KZT",
+		"starts_at":         startsAt,
+		"ends_at":           startsAt.Add(6 * time.Hour),
+		"location":          "Алматы, студия Light",
+		"usage_rights":      "Интернет-магазин, 1 год",
+		"contract_template": "commercial",
+	}
+
+	// 2. Действие: Работодатель создает бронирование
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/bookings", employerToken, bookingBody)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Contains(t, bodyStr, `"status":"pending"`)
+	bookingID, contractHash := bookingFromBody(t, bodyStr)
+	assert.Len(t, contractHash, 64)
+	t.Logf("БРОНИРОВАНИЕ: Создание (201) - Успешно. Ответ: %s", bodyStr)
+
+	// 3. Повторное бронирование по тому же отклику (409)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/bookings", employerToken, bookingBody)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	// 4. Договор содержит условия
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/bookings/"+bookingID+"/contract", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, "150000.00 KZT")
+	assert.Contains(t, bodyStr, "Интернет-магазин, 1 год")
+
+	// 5. Подпись с чужим именем (400) и устаревшим хэшем (409)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/bookings/"+bookingID+"/sign", modelToken, map[string]interface{}{
+		"typed_name":    "Someone Else",
+		"contract_hash": contractHash,
+		"agree":         true,
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/bookings/"+bookingID+"/sign", modelToken, map[string]interface{}{
+		"typed_name":    modelUser.Name,
+		"contract_hash": "0000000000000000000000000000000000000000000000000000000000000000",
+		"agree":         true,
+	})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	// 6. Модель подписывает - бронирование еще ждет работодателя
+	res, bodyStr = ts.SendRequest(t, tx, "POST", "/api/v1/bookings/"+bookingID+"/sign", modelToken, map[string]interface{}{
+		"typed_name":    modelUser.Name,
+		"contract_hash": contractHash,
+		"agree":         true,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"status":"pending"`)
+	assert.Contains(t, bodyStr, `"signed_by_me":true`)
+
+	// 7. Работодатель подписывает - бронирование подтверждено
+	res, bodyStr = ts.SendRequest(t, tx, "POST", "/api/v1/bookings/"+bookingID+"/sign", employerToken, map[string]interface{}{
+		"typed_name":    employerUser.Name,
+		"contract_hash": contractHash,
+		"agree":         true,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"status":"confirmed"`)
+	assert.Contains(t, bodyStr, `"ip_address"`)
+	t.Logf("БРОНИРОВАНИЕ: Подписи обеих сторон (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 8. Условия после подтверждения не меняются (400)
+	res, _ = ts.SendRequest(t, tx, "PUT", "/api/v1/bookings/"+bookingID+"/terms", employerToken, map[string]interface{}{
+		"fee": 200000,
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 9. Завершить до начала работ нельзя, отменить - можно
+	res, _ = ts.SendRequest(t, tx, "PATCH", "/api/v1/bookings/"+bookingID+"/status", employerToken, map[string]interface{}{
+		"status": "completed",
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, "PATCH", "/api/v1/bookings/"+bookingID+"/status", modelToken, map[string]interface{}{
+		"status": "cancelled",
+		"reason": "Болезнь",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"status":"cancelled"`)
+	t.Logf("БРОНИРОВАНИЕ: Отмена моделью (200) - Успешно.")
+}
+
+// TestBooking_TermsChangeResetsSignatures - новая версия договора требует повторной подписи
+func TestBooking_TermsChangeResetsSignatures(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Показ", "Astana")
+	response := CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusAccepted)
+
+	startsAt := time.Now().Add(72 * time.Hour).UTC().Truncate(time.Second)
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/bookings", employerToken, map[string]interface{}{
+		"response_id": response.ID,
+		"fee":         80000,
+		"starts_at":   startsAt,
+		"ends_at":     startsAt.Add(3 * time.Hour),
+		"location":    "Астана, Expo",
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	bookingID, firstHash := bookingFromBody(t, bodyStr)
+
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/bookings/"+bookingID+"/sign", modelToken, map[string]interface{}{
+		"typed_name":    modelUser.Name,
+		"contract_hash": firstHash,
+		"agree":         true,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// 2. Действие: Работодатель меняет гонорар
+	res, bodyStr = ts.SendRequest(t, tx, "PUT", "/api/v1/bookings/"+bookingID+"/terms", employerToken, map[string]interface{}{
+		"fee": 90000,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"contract_version":2`)
+	_, secondHash := bookingFromBody(t, bodyStr)
+	assert.NotEqual(t, firstHash, secondHash)
+
+	// 3. Подпись модели на первой версии больше не действует
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/bookings/"+bookingID, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"signed_by_me":false`)
+	assert.Contains(t, bodyStr, `"awaiting_signature":true`)
+	t.Logf("БРОНИРОВАНИЕ: Новая версия договора сбрасывает подписи - Успешно.")
+}
+
+// TestBooking_Security - бронирование по непринятому отклику и доступ посторонних
+func TestBooking_Security(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerTokenA, _, profileA := helpers.CreateAndLoginEmployer(t, ts, tx)
+	employerTokenB, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, profileA.ID, "Casting A", "Almaty")
+	pending := CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusPending)
+
+	startsAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	body := map[string]interface{}{
+		"response_id": pending.ID,
+		"fee":         50000,
+		"starts_at":   startsAt,
+		"ends_at":     startsAt.Add(2 * time.Hour),
+		"location":    "Almaty",
+	}
+
+	// 2. Отклик еще не принят (400)
+	res, _ := ts.SendRequest(t, tx, "POST", "/api/v1/bookings", employerTokenA, body)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 3. Модель не может создавать бронирования (403)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/bookings", modelToken, body)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 4. Чужой работодатель не видит бронирование (403)
+	tx.Model(&models.CastingResponse{}).Where("id = ?", pending.ID).Update("status", models.ResponseStatusAccepted)
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/bookings", employerTokenB, body)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, "POST", "/api/v1/bookings", employerTokenA, body)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	bookingID, _ := bookingFromBody(t, bodyStr)
+
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/bookings/"+bookingID, employerTokenB, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/bookings/"+bookingID+"/contract/pdf", employerTokenB, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("БРОНИРОВАНИЕ: Проверки доступа - Успешно.")
+}
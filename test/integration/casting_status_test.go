@@ -0,0 +1,83 @@
+package integration_test
+
+import (
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCastingStatus_StateMachine - допустимые переходы, guards и журнал переходов
+func TestCastingStatus_StateMachine(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	_, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг со статусами", "Almaty")
+	CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusPending)
+
+	statusURL := "/api/v1/castings/" + casting.ID + "/status"
+
+	// 2. Закрытие при необработанных откликах (409)
+	res, _ := ts.SendRequest(t, tx, "PUT", statusURL, employerToken, map[string]interface{}{"status": "closed"})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+	t.Logf("СТАТУС КАСТИНГА: Закрытие с pending-откликами (409) - Успешно.")
+
+	// 3. Отмена без причины (400)
+	res, _ = ts.SendRequest(t, tx, "PUT", statusURL, employerToken, map[string]interface{}{"status": "cancelled"})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 4. Отмена с причиной (200)
+	res, _ = ts.SendRequest(t, tx, "PUT", statusURL, employerToken, map[string]interface{}{
+		"status": "cancelled",
+		"reason": "Съемка перенесена",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	t.Logf("СТАТУС КАСТИНГА: Отмена с причиной (200) - Успешно.")
+
+	// 5. cancelled - терминальный статус (409)
+	res, _ = ts.SendRequest(t, tx, "PUT", statusURL, employerToken, map[string]interface{}{"status": "active"})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+	t.Logf("СТАТУС КАСТИНГА: Возврат из cancelled в active запрещен (409) - Успешно.")
+
+	// 6. Журнал переходов
+	res, bodyStr := ts.SendRequest(t, tx, "GET", "/api/v1/castings/"+casting.ID+"/status-history", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"from_status":"active"`)
+	assert.Contains(t, bodyStr, `"to_status":"cancelled"`)
+	assert.Contains(t, bodyStr, `"actor_type":"employer"`)
+	assert.Contains(t, bodyStr, "Съемка перенесена")
+	t.Logf("СТАТУС КАСТИНГА: Журнал переходов (200) - Успешно. Ответ: %s", bodyStr)
+}
+
+// TestCastingStatus_Security - чужой работодатель не может менять статус и смотреть журнал
+func TestCastingStatus_Security(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	_, _, profileA := helpers.CreateAndLoginEmployer(t, ts, tx)
+	castingA := CreateTestCasting(t, tx, profileA.ID, "Casting A", "Almaty")
+	employerTokenB, _ := helpers.CreateAndLoginUser(t, ts, tx, "Employer B", "status-b@test.com", "pass123", models.UserRoleEmployer)
+
+	// 2. Смена статуса чужого кастинга (403)
+	res, _ := ts.SendRequest(t, tx, "PUT", "/api/v1/castings/"+castingA.ID+"/status", employerTokenB, map[string]interface{}{
+		"status": "closed",
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 3. Журнал переходов чужого кастинга (403)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/castings/"+castingA.ID+"/status-history", employerTokenB, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("СТАТУС КАСТИНГА: Проверки безопасности - Успешно.")
+}
@@ -0,0 +1,126 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// createCompCardPhoto - фото портфолио с загрузкой-изображением (файла в хранилище нет - рисуется заглушка)
+func createCompCardPhoto(t *testing.T, tx *gorm.DB, user *models.User, profile *models.ModelProfile, title string, order int) models.PortfolioItem {
+	t.Helper()
+	upload := models.Upload{
+		UserID:     user.ID,
+		Module:     "portfolio",
+		EntityType: "portfolio",
+		EntityID:   profile.ID,
+		FileType:   "image",
+		Usage:      "portfolio_photo",
+		Path:       "portfolio/comp-card-test/" + title + ".jpg",
+		MimeType:   "image/jpeg",
+		Size:       1024,
+		IsPublic:   true,
+	}
+	assert.NoError(t, tx.Create(&upload).Error)
+	item := models.PortfolioItem{ModelID: profile.ID, UploadID: &upload.ID, Title: title, OrderIndex: order}
+	assert.NoError(t, tx.Create(&item).Error)
+	return item
+}
+
+// TestCompCard_SettingsRenderAndCache - выбор фото и шаблона, PDF/PNG, инвалидация кэша при изменении профиля
+func TestCompCard_SettingsRenderAndCache(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: модель с двумя фото, чужое фото
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	_, otherUser, otherProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	first := createCompCardPhoto(t, tx, modelUser, modelProfile, "first", 0)
+	second := createCompCardPhoto(t, tx, modelUser, modelProfile, "second", 1)
+	foreign := createCompCardPhoto(t, tx, otherUser, otherProfile, "foreign", 0)
+
+	// Фото из закрытого альбома стоит первым, но на композитку не попадает
+	privateAlbum := models.PortfolioAlbum{ModelID: modelProfile.ID, Title: "private", Visibility: models.AlbumVisibilityPrivate}
+	assert.NoError(t, tx.Create(&privateAlbum).Error)
+	hidden := createCompCardPhoto(t, tx, modelUser, modelProfile, "hidden", -1)
+	assert.NoError(t, tx.Model(&hidden).Update("album_id", privateAlbum.ID).Error)
+
+	// 2. По умолчанию: шаблон classic, фото в порядке портфолио
+	res, bodyStr := ts.SendRequest(t, tx, "GET", "/api/v1/profiles/me/comp-card", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var card dto.CompCardResponse
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &card))
+	assert.Equal(t, "classic", card.Template)
+	if assert.Len(t, card.Photos, 2) {
+		assert.Equal(t, first.ID, card.Photos[0].PortfolioItemID)
+	}
+
+	// 3. Чужое фото выбрать нельзя (400)
+	res, _ = ts.SendRequest(t, tx, "PUT", "/api/v1/profiles/me/comp-card", modelToken, map[string]interface{}{
+		"template": "portrait", "portfolio_item_ids": []string{foreign.ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// Фото из закрытого альбома тоже (400)
+	res, _ = ts.SendRequest(t, tx, "PUT", "/api/v1/profiles/me/comp-card", modelToken, map[string]interface{}{
+		"template": "portrait", "portfolio_item_ids": []string{hidden.ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 4. Свой выбор: порядок модели, первое фото - главное
+	res, bodyStr = ts.SendRequest(t, tx, "PUT", "/api/v1/profiles/me/comp-card", modelToken, map[string]interface{}{
+		"template": "portrait", "portfolio_item_ids": []string{second.ID, first.ID},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &card))
+	assert.Equal(t, "portrait", card.Template)
+	if assert.Len(t, card.Photos, 2) {
+		assert.Equal(t, second.ID, card.Photos[0].PortfolioItemID)
+	}
+	t.Logf("КОМПОЗИТКА: Выбор шаблона и фото (200) - Успешно.")
+
+	// 5. PDF и PNG
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/profiles/me/comp-card/download?format=pdf", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/pdf", res.Header.Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(bodyStr, "%PDF"))
+
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/profiles/me/comp-card/download?format=png", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "image/png", res.Header.Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(bodyStr, "\x89PNG"))
+
+	var stored models.CompCard
+	assert.NoError(t, tx.First(&stored, "model_profile_id = ?", modelProfile.ID).Error)
+	assert.NotEmpty(t, stored.CacheKey)
+
+	// 6. Изменение профиля дает новый ключ кэша
+	assert.NoError(t, tx.Model(&models.ModelProfile{}).Where("id = ?", modelProfile.ID).Update("height", 180).Error)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/profiles/me/comp-card/download", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var refreshed models.CompCard
+	assert.NoError(t, tx.First(&refreshed, "model_profile_id = ?", modelProfile.ID).Error)
+	assert.NotEqual(t, stored.CacheKey, refreshed.CacheKey)
+	t.Logf("КОМПОЗИТКА: Инвалидация кэша после изменения профиля - Успешно.")
+
+	// 7. Работодатель скачивает композитку публичной модели; скрытая - 403
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/profiles/models/"+modelProfile.ID+"/comp-card?format=png", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	assert.NoError(t, tx.Model(&models.ModelProfile{}).Where("id = ?", modelProfile.ID).Update("is_public", false).Error)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/profiles/models/"+modelProfile.ID+"/comp-card", employerToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+}
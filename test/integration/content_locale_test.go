@@ -0,0 +1,115 @@
+package integration_test
+
+import (
+	"fmt"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestContentLocales - переводы описания модели и кастинга, выбор языка по ?lang= и
+// Accept-Language, цепочка fallback и поиск по тексту на казахском
+func TestContentLocales(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	marker := fmt.Sprintf("%d", time.Now().UnixNano())
+	kkDescription := "Кәсіби фотомодельмін " + marker
+
+	// 2. Неизвестный язык перевода - 400
+	res, _ := ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me", modelToken, map[string]interface{}{
+		"description_i18n": map[string]string{"de": "Professionelles Model"},
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 3. Описание на русском и переводы на казахский и английский
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me", modelToken, map[string]interface{}{
+		"description":    "Профессиональная модель " + marker,
+		"content_locale": "ru",
+		"description_i18n": map[string]string{
+			"kk": kkDescription,
+			"en": "Professional model " + marker,
+		},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	// 4. ?lang= важнее Accept-Language
+	res, bodyStr = ts.SendRequestWithHeaders(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID+"?lang=kk", "", nil,
+		map[string]string{"Accept-Language": "en-US,en;q=0.9"})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"Description":"`+kkDescription+`"`)
+	assert.Contains(t, bodyStr, `"locale":"kk"`)
+	assert.Equal(t, "kk", res.Header.Get("Content-Language"))
+
+	res, bodyStr = ts.SendRequestWithHeaders(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, "", nil,
+		map[string]string{"Accept-Language": "de-DE,en;q=0.8,ru;q=0.5"})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"Description":"Professional model `+marker+`"`)
+	assert.Contains(t, bodyStr, `"locale":"en"`)
+	t.Logf("ЯЗЫКИ: Выбор языка профиля по ?lang= и Accept-Language (200) - Успешно.")
+
+	// 5. Удаленный перевод: английский читатель получает русский текст (fallback en -> ru)
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me", modelToken, map[string]interface{}{
+		"description_i18n": map[string]string{"en": ""},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID+"?lang=en", "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"Description":"Профессиональная модель `+marker+`"`)
+	assert.Contains(t, bodyStr, `"locale":"ru"`)
+	assert.Contains(t, bodyStr, kkDescription, "Казахский перевод сохраняется")
+
+	// 6. Поиск моделей по казахскому тексту
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/models/search?lang=kk&query="+url.QueryEscape("Кәсіби фотомодельмін "+marker), "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, kkDescription)
+	t.Logf("ЯЗЫКИ: Fallback и поиск моделей на казахском (200) - Успешно.")
+
+	// 7. Кастинг с заголовком и описанием на трех языках
+	kkTitle := "Жарнамаға модельдер " + marker
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/castings", employerToken, map[string]interface{}{
+		"title":          "Модели для рекламы " + marker,
+		"description":    "Съемка рекламы",
+		"city":           "Almaty",
+		"content_locale": "ru",
+		"title_i18n":     map[string]string{"kk": kkTitle, "en": "Models for a commercial " + marker},
+		"description_i18n": map[string]string{
+			"kk": "Жарнама түсірілімі",
+		},
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+
+	var casting models.Casting
+	assert.NoError(t, tx.Where("employer_id = ?", employerProfile.ID).First(&casting).Error)
+	assert.NoError(t, tx.Model(&casting).Update("status", models.CastingStatusActive).Error)
+
+	res, bodyStr = ts.SendRequestWithHeaders(t, tx, http.MethodGet, "/api/v1/castings/"+casting.ID, "", nil,
+		map[string]string{"Accept-Language": "kk-KZ"})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"title":"`+kkTitle+`"`)
+	assert.Contains(t, bodyStr, `"description":"Жарнама түсірілімі"`)
+	assert.Contains(t, bodyStr, `"locale":"kk"`)
+
+	// Английского описания нет: заголовок на английском, описание - на русском
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/castings/"+casting.ID+"?lang=en", "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"title":"Models for a commercial `+marker+`"`)
+	assert.Contains(t, bodyStr, `"description":"Съемка рекламы"`)
+
+	// 8. Поиск кастингов по казахскому заголовку
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/castings?query="+url.QueryEscape(kkTitle), "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, casting.ID)
+	t.Logf("ЯЗЫКИ: Переводы кастинга и поиск на казахском (200) - Успешно.")
+}
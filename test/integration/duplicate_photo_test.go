@@ -0,0 +1,126 @@
+package integration_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	stdjpeg "image/jpeg"
+	"net/http"
+	"testing"
+	"time"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDuplicatePhotoDetection - фото, похожее на фото другого пользователя, уходит на проверку
+// и попадает в очередь совпадений; подтверждение совпадения отклоняет копию
+func TestDuplicatePhotoDetection(t *testing.T) {
+	t.Parallel()
+
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	ownerToken, ownerUser, ownerProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	thiefToken, thiefUser, thiefProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Test Admin",
+		fmt.Sprintf("admin_%d@test.com", time.Now().UnixNano()), "password123", models.UserRoleAdmin)
+
+	// Обе модели доверенные - без совпадения фото публикуются сразу
+	require.NoError(t, tx.Model(&models.User{}).Where("id IN ?", []string{ownerUser.ID, thiefUser.ID}).
+		Update("photo_auto_approve", true).Error)
+
+	original := uploadImage(t, ts, tx, ownerToken, ownerProfile.ID, "portfolio", "portfolio_photo", "original.jpg", buildPatternJPEG(t, 320, 240, 90))
+	assert.Equal(t, models.ModerationStatusApproved, original.ModerationStatus)
+
+	// Уменьшенная и пережатая копия чужого фото
+	copied := uploadImage(t, ts, tx, thiefToken, thiefProfile.ID, "portfolio", "portfolio_photo", "copy.jpg", buildPatternJPEG(t, 160, 120, 60))
+
+	t.Run("Copy of another user's photo is held for review", func(t *testing.T) {
+		assert.Equal(t, models.ModerationStatusPending, copied.ModerationStatus)
+	})
+
+	t.Run("Own re-upload is not flagged", func(t *testing.T) {
+		again := uploadImage(t, ts, tx, ownerToken, ownerProfile.ID, "portfolio", "portfolio_photo", "again.jpg", buildPatternJPEG(t, 320, 240, 80))
+		assert.Equal(t, models.ModerationStatusApproved, again.ModerationStatus)
+	})
+
+	t.Run("Different photo is not flagged", func(t *testing.T) {
+		other := uploadImage(t, ts, tx, thiefToken, thiefProfile.ID, "portfolio", "portfolio_photo", "other.jpg", buildEXIFJPEG(t, 240, 160, 1))
+		assert.Equal(t, models.ModerationStatusApproved, other.ModerationStatus)
+	})
+
+	var matchID string
+	t.Run("Admin sees the match", func(t *testing.T) {
+		res, _ := ts.SendRequest(t, tx, http.MethodGet, "/api/v1/admin/photos/duplicates", thiefToken, nil)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+		res, body := ts.SendRequest(t, tx, http.MethodGet, "/api/v1/admin/photos/duplicates?user_id="+thiefUser.ID, adminToken, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, "Body: "+body)
+
+		var resp struct {
+			Matches []struct {
+				Match      models.DuplicatePhotoMatch `json:"match"`
+				URL        string                     `json:"url"`
+				MatchedURL string                     `json:"matched_url"`
+			} `json:"matches"`
+			Total int64 `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &resp))
+		require.Equal(t, int64(1), resp.Total)
+
+		match := resp.Matches[0].Match
+		assert.Equal(t, copied.ID, match.UploadID)
+		assert.Equal(t, original.ID, match.MatchedUploadID)
+		assert.Equal(t, ownerUser.ID, match.MatchedUserID)
+		assert.LessOrEqual(t, match.Distance, 6)
+		assert.Equal(t, models.DuplicateMatchPending, match.Status)
+		assert.NotEmpty(t, resp.Matches[0].URL)
+		assert.NotEmpty(t, resp.Matches[0].MatchedURL)
+		matchID = match.ID
+	})
+
+	t.Run("Confirming the match rejects the copy", func(t *testing.T) {
+		require.NotEmpty(t, matchID)
+		path := "/api/v1/admin/photos/duplicates/" + matchID + "/resolve"
+
+		res, body := ts.SendRequest(t, tx, http.MethodPost, path, adminToken, map[string]interface{}{"action": "confirm"})
+		require.Equal(t, http.StatusOK, res.StatusCode, "Body: "+body)
+		assert.Contains(t, body, `"status":"confirmed"`)
+
+		var upload models.Upload
+		require.NoError(t, tx.First(&upload, "id = ?", copied.ID).Error)
+		assert.Equal(t, models.ModerationStatusRejected, upload.ModerationStatus)
+
+		// Повторное решение по тому же совпадению
+		res, _ = ts.SendRequest(t, tx, http.MethodPost, path, adminToken, map[string]interface{}{"action": "dismiss"})
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+		res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/photos/duplicates/00000000-0000-0000-0000-000000000000/resolve", adminToken, map[string]interface{}{"action": "dismiss"})
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
+
+// buildPatternJPEG кодирует снимок с крупными светлыми и темными пятнами; копии разного
+// размера и качества дают близкие перцептивные хэши
+func buildPatternJPEG(t *testing.T, width, height, quality int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cx, cy := x*8/width, y*6/height
+			v := uint8((cx*37 + cy*91) % 200)
+			img.Set(x, y, color.RGBA{R: v, G: v / 2, B: 255 - v, A: 255})
+		}
+	}
+	var encoded bytes.Buffer
+	require.NoError(t, stdjpeg.Encode(&encoded, img, &stdjpeg.Options{Quality: quality}))
+	return encoded.Bytes()
+}
@@ -0,0 +1,140 @@
+package integration_test
+
+import (
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEmployerNotes_ResponseNotesAndFilter - пометки на откликах и фильтрация списка откликов
+func TestEmployerNotes_ResponseNotesAndFilter(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг с пометками", "Almaty")
+	_, model1, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	_, model2, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	response1 := CreateTestResponse(t, tx, casting.ID, model1.ID, models.ResponseStatusPending)
+	CreateTestResponse(t, tx, casting.ID, model2.ID, models.ResponseStatusPending)
+
+	// 2. Действие: Пометка на первый отклик
+	res, bodyStr := ts.SendRequest(t, tx, "PUT", "/api/v1/employer/notes/responses/"+response1.ID, employerToken, map[string]interface{}{
+		"note":   "Отличный типаж",
+		"tags":   []string{"green"},
+		"rating": 5,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"rating":5`)
+	t.Logf("ПОМЕТКИ: Пометка на отклик (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 3. Невалидный цвет и рейтинг (400)
+	res, _ = ts.SendRequest(t, tx, "PUT", "/api/v1/employer/notes/responses/"+response1.ID, employerToken, map[string]interface{}{
+		"tags":   []string{"pink"},
+		"rating": 6,
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 4. Фильтр по тегу: только помеченный отклик
+	listURL := "/api/v1/responses/castings/" + casting.ID + "/list"
+	res, bodyStr = ts.SendRequest(t, tx, "GET", listURL+"?tag=green", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, response1.ID)
+	assert.NotContains(t, bodyStr, model2.ID)
+	assert.Contains(t, bodyStr, `"private_notes"`)
+
+	// 5. Фильтр по рейтингу
+	res, bodyStr = ts.SendRequest(t, tx, "GET", listURL+"?min_rating=4", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, response1.ID)
+	assert.NotContains(t, bodyStr, model2.ID)
+	t.Logf("ПОМЕТКИ: Фильтрация откликов по тегу и рейтингу (200) - Успешно. Ответ: %s", bodyStr)
+}
+
+// TestEmployerNotes_ModelNotes - пометки на профиле модели
+func TestEmployerNotes_ModelNotes(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	_, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+
+	// 2. Действие: Пометка на модель
+	res, bodyStr := ts.SendRequest(t, tx, "PUT", "/api/v1/employer/notes/models/"+modelUser.ID, employerToken, map[string]interface{}{
+		"note":   "Пригласить на следующий проект",
+		"tags":   []string{"blue", "yellow"},
+		"rating": 4,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	t.Logf("ПОМЕТКИ: Пометка на модель (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 3. Список с фильтром
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/employer/notes/models?tag=blue&min_rating=4", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, modelUser.ID)
+
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/employer/notes/models?tag=red", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotContains(t, bodyStr, modelUser.ID)
+
+	// Тег вне списка допустимых (в том числе с кавычками) отклоняется
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/employer/notes/models?tag="+url.QueryEscape(`blue","red`), employerToken, nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// 4. Удаление
+	res, _ = ts.SendRequest(t, tx, "DELETE", "/api/v1/employer/notes/models/"+modelUser.ID, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/employer/notes/models/"+modelUser.ID, employerToken, nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	t.Logf("ПОМЕТКИ: Фильтр и удаление пометки на модель - Успешно.")
+}
+
+// TestEmployerNotes_Security - пометки не видны модели и чужим работодателям
+func TestEmployerNotes_Security(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerTokenA, _, profileA := helpers.CreateAndLoginEmployer(t, ts, tx)
+	employerTokenB, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, profileA.ID, "Casting A", "Almaty")
+	response := CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusPending)
+
+	noteURL := "/api/v1/employer/notes/responses/" + response.ID
+	res, _ := ts.SendRequest(t, tx, "PUT", noteURL, employerTokenA, map[string]interface{}{
+		"note": "Секретная заметка",
+		"tags": []string{"red"},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// 2. Чужой работодатель (403)
+	res, _ = ts.SendRequest(t, tx, "GET", noteURL, employerTokenB, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 3. Модель не имеет доступа к пометкам (403)
+	res, _ = ts.SendRequest(t, tx, "GET", noteURL, modelToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 4. Модель видит свой отклик без пометок
+	res, bodyStr := ts.SendRequest(t, tx, "GET", "/api/v1/responses/"+response.ID, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotContains(t, bodyStr, "private_notes")
+	assert.NotContains(t, bodyStr, "Секретная заметка")
+	t.Logf("ПОМЕТКИ: Проверки безопасности - Успешно.")
+}
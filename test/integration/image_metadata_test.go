@@ -0,0 +1,160 @@
+package integration_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"image"
+	"image/color"
+	stdjpeg "image/jpeg"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"testing"
+
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/pkg/contextkeys"
+	"mwork_backend/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestImageMetadataStripping - EXIF удаляется при загрузке, ориентация применяется,
+// размеры и дата съёмки сохраняются в метаданных
+func TestImageMetadataStripping(t *testing.T) {
+	t.Parallel()
+
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, _, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+
+	// Снимок телефона 40x20, повёрнутый тегом ориентации 6 (показывать как 20x40)
+	original := buildEXIFJPEG(t, 40, 20, 6)
+	require.True(t, bytes.Contains(original, []byte("Exif")))
+
+	upload := uploadImage(t, ts, tx, modelToken, modelProfile.ID, "profile", "cover_photo", "phone.jpg", original)
+
+	t.Run("Metadata records oriented dimensions and capture date", func(t *testing.T) {
+		assert.Equal(t, "20", upload.Metadata["width"])
+		assert.Equal(t, "40", upload.Metadata["height"])
+		assert.Equal(t, "2024-05-17T14:30:00+03:00", upload.Metadata["captured_at"])
+		assert.Equal(t, "true", upload.Metadata["exif_stripped"])
+		assert.Equal(t, "true", upload.Metadata["orientation_corrected"])
+	})
+
+	t.Run("Served file has no EXIF and is upright", func(t *testing.T) {
+		res, body := ts.SendRequest(t, tx, http.MethodGet, "/api/v1/files/"+upload.ID, "", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		assert.False(t, bytes.Contains([]byte(body), []byte("Exif")))
+		assert.False(t, bytes.Contains([]byte(body), []byte("2024:05:17")))
+
+		cfg, format, err := image.DecodeConfig(bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 20, cfg.Width)
+		assert.Equal(t, 40, cfg.Height)
+	})
+
+	t.Run("Upright image is stripped without re-encoding", func(t *testing.T) {
+		upright := uploadImage(t, ts, tx, modelToken, modelProfile.ID, "profile", "cover_photo", "upright.jpg", buildEXIFJPEG(t, 30, 10, 1))
+		assert.Equal(t, "30", upright.Metadata["width"])
+		assert.Equal(t, "10", upright.Metadata["height"])
+		assert.Equal(t, "false", upright.Metadata["orientation_corrected"])
+		assert.Less(t, upright.Size, int64(len(buildEXIFJPEG(t, 30, 10, 1))))
+	})
+}
+
+// uploadImage загружает публичное изображение в модуль с указанным назначением и возвращает ответ
+func uploadImage(t *testing.T, ts *helpers.TestServer, tx *gorm.DB, token, entityID, module, usage, filename string, data []byte) dto.UploadResponse {
+	t.Helper()
+
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("module", module)
+	_ = writer.WriteField("entity_type", "model_profile")
+	_ = writer.WriteField("entity_id", entityID)
+	_ = writer.WriteField("usage", usage)
+	_ = writer.WriteField("is_public", "true")
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	header.Set("Content-Type", "image/jpeg")
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest(http.MethodPost, ts.Server.URL+"/api/v1/uploads", body)
+	require.NoError(t, err)
+	req = req.WithContext(context.WithValue(req.Context(), contextkeys.DBContextKey, tx))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	res, err := ts.Server.Client().Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	resBody, _ := io.ReadAll(res.Body)
+	require.Equal(t, http.StatusCreated, res.StatusCode, "Upload failed: "+string(resBody))
+
+	var upload dto.UploadResponse
+	require.NoError(t, json.Unmarshal(resBody, &upload))
+	return upload
+}
+
+// buildEXIFJPEG кодирует JPEG и вставляет APP1 с ориентацией, датой съёмки и GPS-блоком
+func buildEXIFJPEG(t *testing.T, width, height int, orientation uint16) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 6), G: uint8(y * 6), B: 128, A: 255})
+		}
+	}
+	var encoded bytes.Buffer
+	require.NoError(t, stdjpeg.Encode(&encoded, img, &stdjpeg.Options{Quality: 90}))
+
+	// TIFF (big-endian): IFD0 @8, Exif IFD @50, DateTimeOriginal @80, OffsetTime @100, GPS IFD @108
+	tiff := new(bytes.Buffer)
+	w := func(v interface{}) { _ = binary.Write(tiff, binary.BigEndian, v) }
+	entry := func(tag, typ uint16, count, value uint32) { w(tag); w(typ); w(count); w(value) }
+
+	tiff.WriteString("MM")
+	w(uint16(42))
+	w(uint32(8))
+	w(uint16(3))
+	entry(0x0112, 3, 1, uint32(orientation)<<16)
+	entry(0x8769, 4, 1, 50)
+	entry(0x8825, 4, 1, 108)
+	w(uint32(0))
+
+	w(uint16(2))
+	entry(0x9003, 2, 20, 80)
+	entry(0x9011, 2, 7, 100)
+	w(uint32(0))
+	tiff.WriteString("2024:05:17 14:30:00\x00")
+	tiff.WriteString("+03:00\x00\x00")
+
+	w(uint16(1))
+	entry(0x0001, 2, 2, uint32('N')<<24)
+	w(uint32(0))
+
+	payload := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	jpegBytes := encoded.Bytes()
+	result := append([]byte{}, jpegBytes[:2]...)
+	result = append(result, segment...)
+	return append(result, jpegBytes[2:]...)
+}
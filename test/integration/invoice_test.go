@@ -0,0 +1,103 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInvoice_IssuedOnPaymentAndCreditNoteOnRefund - счет по оплате, кредит-нота по возврату, сквозная нумерация
+func TestInvoice_IssuedOnPaymentAndCreditNoteOnRefund(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: два платежа за продвижение
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, employerUser, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	otherToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Admin", "admin@invoice.com", "adminpass", models.UserRoleAdmin)
+	tx.Model(&models.EmployerProfile{}).Where("id = ?", employerProfile.ID).Update("company_name", "ТОО Ромашка")
+
+	var payments []models.PaymentTransaction
+	for _, title := range []string{"Первый кастинг", "Второй кастинг"} {
+		casting := CreateTestCasting(t, tx, employerProfile.ID, title, "Almaty")
+		res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+casting.ID+"/promotions", employerToken, map[string]interface{}{
+			"days": 2,
+		})
+		assert.Equal(t, http.StatusCreated, res.StatusCode)
+
+		var created struct {
+			Promotion models.CastingPromotion `json:"promotion"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(bodyStr), &created))
+		if !assert.NotNil(t, created.Promotion.PaymentID) {
+			return
+		}
+		var payment models.PaymentTransaction
+		assert.NoError(t, tx.First(&payment, "id = ?", *created.Promotion.PaymentID).Error)
+		payments = append(payments, payment)
+	}
+
+	// 2. До оплаты счета нет (409)
+	res, _ := ts.SendRequest(t, tx, "GET", "/api/v1/payments/"+payments[0].ID+"/invoice", employerToken, nil)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	// 3. Действие: оплата обоих платежей
+	for _, payment := range payments {
+		res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/robokassa/callback", "", map[string]interface{}{
+			"InvId": payment.InvID, "OutSum": payment.Amount,
+		})
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+
+	// 4. Проверка: номера идут подряд, реквизиты покупателя из профиля работодателя
+	var invoices []models.Invoice
+	tx.Where("user_id = ? AND kind = ?", employerUser.ID, models.InvoiceKindInvoice).Order("sequence ASC").Find(&invoices)
+	if assert.Len(t, invoices, 2) {
+		assert.Equal(t, invoices[0].Sequence+1, invoices[1].Sequence)
+		assert.True(t, strings.HasPrefix(invoices[0].Number, "INV-"))
+		assert.Equal(t, "ТОО Ромашка", invoices[0].GetBuyer().Name)
+		assert.Equal(t, invoices[0].TotalAmount, invoices[0].NetAmount+invoices[0].VATAmount)
+	}
+
+	// 5. Скачивание PDF владельцем
+	res, bodyStr := ts.SendRequest(t, tx, "GET", "/api/v1/payments/"+payments[0].ID+"/invoice", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/pdf", res.Header.Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(bodyStr, "%PDF"))
+	t.Logf("СЧЕТА: Скачивание счета (200) - Успешно.")
+
+	// 6. Чужой счет недоступен (403)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/payments/"+payments[0].ID+"/invoice", otherToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 7. Возврат - кредит-нота на сумму счета со ссылкой на него
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/admin/payments/"+payments[0].ID+"/refund", adminToken, map[string]interface{}{
+		"reason": "Кастинг отменен",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var creditNote models.Invoice
+	assert.NoError(t, tx.First(&creditNote, "payment_id = ? AND kind = ?", payments[0].ID, models.InvoiceKindCreditNote).Error)
+	assert.True(t, strings.HasPrefix(creditNote.Number, "CN-"))
+	assert.Equal(t, -invoices[0].TotalAmount, creditNote.TotalAmount)
+	if assert.NotNil(t, creditNote.OriginalInvoiceID) {
+		assert.Equal(t, invoices[0].ID, *creditNote.OriginalInvoiceID)
+	}
+
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/payments/"+payments[0].ID+"/credit-note", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.True(t, strings.HasPrefix(bodyStr, "%PDF"))
+	t.Logf("СЧЕТА: Кредит-нота по возврату (200) - Успешно.")
+
+	// 8. По невозвращенному платежу кредит-ноты нет (409)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/payments/"+payments[1].ID+"/credit-note", employerToken, nil)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+}
@@ -0,0 +1,137 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLedger_PaymentAndRefund - оплата и возврат проводятся через журнал, сверка с выпиской
+func TestLedger_PaymentAndRefund(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: платеж за продвижение кастинга
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Admin", "admin@ledger.com", "adminpass", models.UserRoleAdmin)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг с оплатой", "Almaty")
+
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+casting.ID+"/promotions", employerToken, map[string]interface{}{
+		"days": 3,
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+
+	var created struct {
+		Promotion models.CastingPromotion `json:"promotion"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &created))
+	if !assert.NotNil(t, created.Promotion.PaymentID) {
+		return
+	}
+	var payment models.PaymentTransaction
+	assert.NoError(t, tx.First(&payment, "id = ?", *created.Promotion.PaymentID).Error)
+
+	// 2. Действие: callback провайдера (дважды - повтор не должен задвоить проводку)
+	callback := map[string]interface{}{"InvId": payment.InvID, "OutSum": payment.Amount}
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/robokassa/callback", "", callback)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/robokassa/callback", "", callback)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var entries int64
+	tx.Model(&models.LedgerEntry{}).Where("payment_id = ?", payment.ID).Count(&entries)
+	assert.Equal(t, int64(1), entries)
+
+	// Повтор не выставляет второй счет и не продлевает продвижение
+	var invoices int64
+	tx.Model(&models.Invoice{}).Where("payment_id = ?", payment.ID).Count(&invoices)
+	assert.Equal(t, int64(1), invoices)
+	var promotion models.CastingPromotion
+	assert.NoError(t, tx.First(&promotion, "id = ?", created.Promotion.ID).Error)
+
+	// 3. Кошелек работодателя: деньги прошли транзитом, остаток нулевой
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/ledger/wallet", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"balance":0`)
+	assert.Contains(t, bodyStr, `"total":2`)
+	t.Logf("ЖУРНАЛ: Кошелек после оплаты (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 4. Счета: выручка равна сумме платежа, журнал сбалансирован
+	amount := models.ToMinorUnits(payment.Amount, models.LedgerBaseCurrency)
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/admin/ledger/accounts?type=revenue", adminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, models.LedgerCodePlatformRevenue)
+	assert.Contains(t, bodyStr, `"trial_balance":{"KZT":0}`)
+
+	var accounts struct {
+		Accounts []struct {
+			Code    string `json:"code"`
+			Balance int64  `json:"balance"`
+		} `json:"accounts"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &accounts))
+	if assert.Len(t, accounts.Accounts, 1) {
+		assert.Equal(t, amount, accounts.Accounts[0].Balance)
+	}
+
+	// 5. Сверка: платеж совпал, лишняя строка выписки - расхождение
+	res, bodyStr = ts.SendRequest(t, tx, "POST", "/api/v1/admin/ledger/reconciliation", adminToken, map[string]interface{}{
+		"from": time.Now().Add(-time.Hour),
+		"to":   time.Now().Add(time.Hour),
+		"lines": []map[string]interface{}{
+			{"inv_id": payment.InvID, "type": "payment", "amount": payment.Amount},
+			{"inv_id": "UNKNOWN-INV", "type": "payment", "amount": 100},
+		},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"matched":1`)
+	assert.Contains(t, bodyStr, `"status":"missing_in_ledger"`)
+	assert.Contains(t, bodyStr, `"reconciled":false`)
+	t.Logf("ЖУРНАЛ: Сверка (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 6. Возврат: статус refunded, обратная проводка
+	res, bodyStr = ts.SendRequest(t, tx, "POST", "/api/v1/admin/payments/"+payment.ID+"/refund", adminToken, map[string]interface{}{
+		"reason": "Кастинг отменен",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"refunded"`)
+
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/admin/payments/"+payment.ID+"/refund", adminToken, map[string]interface{}{
+		"reason": "Повторный возврат",
+	})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/admin/ledger/accounts?type=revenue", adminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"balance":0`)
+	t.Logf("ЖУРНАЛ: Возврат (200) - Успешно. Ответ: %s", bodyStr)
+
+	// Повтор callback-а после возврата не возвращает платеж в paid и не активирует продвижение снова
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/robokassa/callback", "", callback)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var refunded models.PaymentTransaction
+	assert.NoError(t, tx.First(&refunded, "id = ?", payment.ID).Error)
+	assert.Equal(t, models.PaymentStatusRefunded, refunded.Status)
+
+	var afterReplay models.CastingPromotion
+	assert.NoError(t, tx.First(&afterReplay, "id = ?", created.Promotion.ID).Error)
+	assert.Equal(t, promotion.EndsAt, afterReplay.EndsAt)
+
+	tx.Model(&models.LedgerEntry{}).Where("payment_id = ?", payment.ID).Count(&entries)
+	assert.Equal(t, int64(2), entries)
+	tx.Model(&models.Invoice{}).Where("payment_id = ? AND kind = ?", payment.ID, models.InvoiceKindInvoice).Count(&invoices)
+	assert.Equal(t, int64(1), invoices)
+
+	// 7. Не-админ не видит счета платформы (403)
+	res, _ = ts.SendRequest(t, tx, "GET", "/api/v1/admin/ledger/accounts", employerToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+}
@@ -0,0 +1,120 @@
+package integration_test
+
+import (
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMeasurements_ConversionSearchAndCastingRequirements - дюймы и размеры US/UK хранятся в см и каноническом виде,
+// поиск и требования кастинга понимают любые системы размеров
+func TestMeasurements_ConversionSearchAndCastingRequirements(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка (модель из хелпера - female)
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	// 2. Действие: параметры в дюймах, одежда UK, обувь US
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me", modelToken, map[string]interface{}{
+		"measurement_units":    "imperial",
+		"bust":                 34,
+		"waist":                24,
+		"hips":                 35,
+		"clothing_size":        "10",
+		"clothing_size_system": "uk",
+		"shoe_size":            "US 8",
+		"hair_color":           "blonde",
+		"eye_color":            "green",
+		"has_tattoos":          true,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	// 3. Проверка: в базе - сантиметры, буква и размер EU
+	var profile models.ModelProfile
+	assert.NoError(t, tx.First(&profile, "user_id = ?", modelUser.ID).Error)
+	assert.Equal(t, 86.5, profile.Bust)
+	assert.Equal(t, 61.0, profile.Waist)
+	assert.Equal(t, 89.0, profile.Hips)
+	assert.Equal(t, "M", profile.ClothingSize)
+	assert.Equal(t, 39.5, profile.ShoeSizeEU)
+	assert.Equal(t, "39.5", profile.ShoeSize)
+	assert.True(t, profile.HasTattoos)
+	t.Logf("ПАРАМЕТРЫ: Перевод дюймов и размеров UK/US (200) - Успешно.")
+
+	// 4. Ошибки: неизвестный цвет, параметр вне диапазона, неизвестный размер (400)
+	for _, body := range []map[string]interface{}{
+		{"hair_color": "purple"},
+		{"bust": 300},
+		{"clothing_size": "99", "clothing_size_system": "eu"},
+	} {
+		res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me", modelToken, body)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	}
+
+	// 5. Поиск: "44" (RU) и "M" находят одну и ту же модель
+	for _, query := range []string{
+		"clothing_sizes=44&clothing_size_system=ru&gender=female",
+		"clothing_sizes=M&min_bust=80&max_waist=65&hair_colors=blonde&min_shoe_size=39&max_shoe_size=40",
+	} {
+		res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/models/search?city=Almaty&page=1&pageSize=10&"+query, "", nil)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+		assert.Contains(t, bodyStr, profile.ID)
+	}
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/models/search?city=Almaty&page=1&pageSize=10&clothing_sizes=S", "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NotContains(t, bodyStr, profile.ID)
+
+	// Числовому размеру нужен пол (400)
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/models/search?clothing_sizes=44", "", nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// Перепутанные границы (400)
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/models/search?min_bust=90&max_bust=80", "", nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	t.Logf("ПАРАМЕТРЫ: Поиск по размерам в разных системах - Успешно.")
+
+	// 6. Кастинг: требования в дюймах и размерах RU
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/castings", employerToken, map[string]interface{}{
+		"title":                "Measurements Casting",
+		"city":                 "Almaty",
+		"gender":               "female",
+		"measurement_units":    "imperial",
+		"waist_min":            22,
+		"waist_max":            26,
+		"clothing_size":        "44",
+		"clothing_size_system": "ru",
+		"shoe_size":            "38",
+		"shoe_size_system":     "ru",
+		"hair_colors":          []string{"blonde", "brown"},
+		"tattoos_allowed":      false,
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+
+	var casting models.Casting
+	assert.NoError(t, tx.First(&casting, "title = ?", "Measurements Casting").Error)
+	if assert.NotNil(t, casting.ClothingSize) && assert.NotNil(t, casting.ShoeSize) && assert.NotNil(t, casting.WaistMin) {
+		assert.Equal(t, "M", *casting.ClothingSize)
+		assert.Equal(t, "39", *casting.ShoeSize)
+		assert.Equal(t, 56.0, *casting.WaistMin)
+	}
+	assert.Equal(t, []string{"blonde", "brown"}, casting.GetHairColors())
+
+	// Одна граница без второй допустима, перепутанные границы - нет
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/castings", employerToken, map[string]interface{}{
+		"title": "Bust Max Casting", "city": "Almaty", "bust_max": 90,
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/castings", employerToken, map[string]interface{}{
+		"title": "Bust Range Casting", "city": "Almaty", "bust_min": 95, "bust_max": 90,
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	t.Logf("ПАРАМЕТРЫ: Требования кастинга в каноническом виде (201) - Успешно.")
+}
@@ -0,0 +1,118 @@
+package integration_test
+
+import (
+	"fmt"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPhotoModeration - фото на проверке скрыты от посторонних, очередь модерации,
+// массовое одобрение и отклонение с уведомлением, доверенные пользователи
+func TestPhotoModeration(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: модель с двумя фото на проверке
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Test Admin",
+		fmt.Sprintf("admin_%d@test.com", time.Now().UnixNano()), "password123", models.UserRoleAdmin)
+
+	first := createCompCardPhoto(t, tx, modelUser, modelProfile, "moderation-first", 1)
+	second := createCompCardPhoto(t, tx, modelUser, modelProfile, "moderation-second", 2)
+	assert.NoError(t, tx.Model(&models.Upload{}).Where("id IN ?", []string{*first.UploadID, *second.UploadID}).
+		Update("moderation_status", models.ModerationStatusPending).Error)
+
+	// 2. Гость не видит фото на проверке, владелец видит со статусом
+	portfolioPath := "/api/v1/portfolio/model/" + modelProfile.ID
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodGet, portfolioPath, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NotContains(t, bodyStr, first.ID)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/"+first.ID, employerToken, nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, portfolioPath, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, first.ID)
+	assert.Contains(t, bodyStr, `"moderation_status":"pending"`)
+	t.Logf("МОДЕРАЦИЯ ФОТО: Фото на проверке видит только владелец (200/404) - Успешно.")
+
+	// 3. Очередь доступна только администратору
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/admin/photos", modelToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/admin/photos?user_id="+modelUser.ID, adminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, *first.UploadID)
+	assert.Contains(t, bodyStr, *second.UploadID)
+	assert.Contains(t, bodyStr, `"total":2`)
+
+	// 4. Массовое одобрение: неизвестный ID попадает в результат с ошибкой
+	unknownID := "00000000-0000-0000-0000-000000000000"
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/photos/approve", adminToken, map[string]interface{}{
+		"upload_ids": []string{*first.UploadID, unknownID},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"succeeded":1`)
+	assert.Contains(t, bodyStr, `"failed":1`)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, portfolioPath, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, first.ID)
+	assert.NotContains(t, bodyStr, second.ID)
+	t.Logf("МОДЕРАЦИЯ ФОТО: Очередь и массовое одобрение (200) - Успешно.")
+
+	// 5. Отклонение без причины - 400, с причиной - уведомление владельцу
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/photos/reject", adminToken, map[string]interface{}{
+		"upload_ids": []string{*second.UploadID},
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/photos/reject", adminToken, map[string]interface{}{
+		"upload_ids": []string{*second.UploadID},
+		"reason":     "Фото низкого качества",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"succeeded":1`)
+
+	var notifications int64
+	tx.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ?", modelUser.ID, "photo_moderation").Count(&notifications)
+	assert.Equal(t, int64(1), notifications)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, portfolioPath, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"moderation_status":"rejected"`)
+	assert.Contains(t, bodyStr, "Фото низкого качества")
+	t.Logf("МОДЕРАЦИЯ ФОТО: Отклонение с причиной и уведомление (200) - Успешно.")
+
+	// 6. Доверенный пользователь: ожидающие фото одобряются сразу
+	third := createCompCardPhoto(t, tx, modelUser, modelProfile, "moderation-third", 3)
+	assert.NoError(t, tx.Model(&models.Upload{}).Where("id = ?", *third.UploadID).
+		Update("moderation_status", models.ModerationStatusPending).Error)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/admin/photos/trusted/"+modelUser.ID, adminToken, map[string]interface{}{
+		"enabled":         true,
+		"approve_pending": true,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	var user models.User
+	assert.NoError(t, tx.First(&user, "id = ?", modelUser.ID).Error)
+	assert.True(t, user.PhotoAutoApprove)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, portfolioPath, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, third.ID)
+	assert.NotContains(t, bodyStr, second.ID, "Отклоненное фото остается скрытым")
+	t.Logf("МОДЕРАЦИЯ ФОТО: Доверенный пользователь (200) - Успешно.")
+}
@@ -0,0 +1,159 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPortfolioAlbums - альбомы портфолио: перенос элементов, обложка, порядок внутри альбома
+// и самих альбомов, закрытые альбомы и удаление альбома без удаления работ
+func TestPortfolioAlbums(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: модель с тремя фото, чужое фото
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	otherToken, otherUser, otherProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	first := createCompCardPhoto(t, tx, modelUser, modelProfile, "album-first", 1)
+	second := createCompCardPhoto(t, tx, modelUser, modelProfile, "album-second", 2)
+	third := createCompCardPhoto(t, tx, modelUser, modelProfile, "album-third", 3)
+	foreign := createCompCardPhoto(t, tx, otherUser, otherProfile, "album-foreign", 1)
+
+	// 2. Создание альбомов
+	res, _ := ts.SendRequest(t, tx, http.MethodPost, "/api/v1/portfolio/albums", modelToken, map[string]interface{}{
+		"title": "Editorial", "visibility": "hidden",
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPost, "/api/v1/portfolio/albums", modelToken, map[string]interface{}{
+		"title": "Editorial",
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	var editorial dto.AlbumResponse
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &editorial))
+	assert.Equal(t, models.AlbumVisibilityPublic, editorial.Visibility)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/portfolio/albums", modelToken, map[string]interface{}{
+		"title": "Polaroids", "visibility": "private",
+	})
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	var polaroids dto.AlbumResponse
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &polaroids))
+
+	// 3. Перенос элементов: чужой элемент - 403
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/move", modelToken, map[string]interface{}{
+		"item_ids": []string{foreign.ID}, "album_id": editorial.ID,
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/move", modelToken, map[string]interface{}{
+		"item_ids": []string{first.ID, second.ID}, "album_id": editorial.ID,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/move", modelToken, map[string]interface{}{
+		"item_ids": []string{third.ID}, "album_id": polaroids.ID,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// В чужой альбом переносить нельзя
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/move", otherToken, map[string]interface{}{
+		"item_ids": []string{foreign.ID}, "album_id": editorial.ID,
+	})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("АЛЬБОМЫ: Создание и перенос элементов (201/200/403) - Успешно.")
+
+	// 4. Работодатель видит только открытый альбом, обложка - первый элемент
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/model/"+modelProfile.ID+"/albums", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	var list struct {
+		Albums []dto.AlbumResponse `json:"albums"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &list))
+	if assert.Len(t, list.Albums, 1) {
+		assert.Equal(t, editorial.ID, list.Albums[0].ID)
+		assert.Equal(t, 2, list.Albums[0].ItemCount)
+		if assert.NotNil(t, list.Albums[0].Cover) {
+			assert.Equal(t, first.ID, list.Albums[0].Cover.ID)
+		}
+	}
+
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/albums/"+polaroids.ID, employerToken, nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/"+third.ID, employerToken, nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/model/"+modelProfile.ID, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, first.ID)
+	assert.NotContains(t, bodyStr, third.ID, "Элемент закрытого альбома скрыт из общего списка")
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/model/"+modelProfile.ID+"/albums", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, polaroids.ID, "Владелец видит закрытый альбом")
+	t.Logf("АЛЬБОМЫ: Видимость открытых и закрытых альбомов (200/404) - Успешно.")
+
+	// 5. Порядок внутри альбома и обложка
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/reorder", modelToken, map[string]interface{}{
+		"album_id": editorial.ID, "item_ids": []string{second.ID, third.ID},
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "Элемент из другого альбома")
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/reorder", modelToken, map[string]interface{}{
+		"album_id": editorial.ID, "item_ids": []string{second.ID, first.ID},
+		"album_ids": []string{polaroids.ID, editorial.ID},
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/albums/"+editorial.ID, modelToken, map[string]interface{}{
+		"cover_item_id": third.ID,
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "Обложка только из своего альбома")
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/portfolio/albums/"+editorial.ID, modelToken, map[string]interface{}{
+		"cover_item_id": first.ID, "title": "Editorial 2025",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/albums/"+editorial.ID, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	var album dto.AlbumResponse
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &album))
+	assert.Equal(t, "Editorial 2025", album.Title)
+	if assert.Len(t, album.Items, 2) {
+		assert.Equal(t, second.ID, album.Items[0].ID)
+		assert.Equal(t, first.ID, album.Items[1].ID)
+	}
+	if assert.NotNil(t, album.Cover) {
+		assert.Equal(t, first.ID, album.Cover.ID)
+	}
+
+	var reordered models.PortfolioAlbum
+	assert.NoError(t, tx.First(&reordered, "id = ?", polaroids.ID).Error)
+	assert.Equal(t, 1, reordered.OrderIndex)
+	t.Logf("АЛЬБОМЫ: Порядок элементов, альбомов и обложка (200/400) - Успешно.")
+
+	// 6. Удаление альбома: работы остаются в портфолио
+	res, _ = ts.SendRequest(t, tx, http.MethodDelete, "/api/v1/portfolio/albums/"+polaroids.ID, otherToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodDelete, "/api/v1/portfolio/albums/"+polaroids.ID, modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var item models.PortfolioItem
+	assert.NoError(t, tx.First(&item, "id = ?", third.ID).Error)
+	assert.Nil(t, item.AlbumID)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/model/"+modelProfile.ID, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, third.ID)
+	t.Logf("АЛЬБОМЫ: Удаление альбома (200/403) - Успешно.")
+}
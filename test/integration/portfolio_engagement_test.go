@@ -0,0 +1,103 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPortfolioEngagement - лайки и сохранения работ, счетчики, сохраненные работы и
+// пересчет рейтинга featured
+func TestPortfolioEngagement(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: модель с двумя фото, работодатель, админ
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	otherToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Test Admin",
+		"admin_engagement_"+modelUser.ID+"@test.com", "password123", models.UserRoleAdmin)
+
+	popular := createCompCardPhoto(t, tx, modelUser, modelProfile, "engagement-popular", 1)
+	quiet := createCompCardPhoto(t, tx, modelUser, modelProfile, "engagement-quiet", 2)
+	likePath := "/api/v1/portfolio/" + popular.ID + "/like"
+
+	// 2. Лайки: только авторизованные, свои работы нельзя, повторный лайк не считается
+	res, _ := ts.SendRequest(t, tx, http.MethodPost, likePath, "", nil)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, likePath, modelToken, nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPost, likePath, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, likePath, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var engagement dto.PortfolioEngagementResponse
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &engagement))
+	assert.Equal(t, 1, engagement.LikesCount)
+	assert.True(t, engagement.Liked)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, likePath, otherToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodDelete, likePath, otherToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &engagement))
+	assert.Equal(t, 1, engagement.LikesCount)
+	assert.False(t, engagement.Liked)
+	t.Logf("ПОРТФОЛИО: Лайки и счетчики (200/400/401) - Успешно.")
+
+	// 3. Сохранения и список сохраненных
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/portfolio/"+popular.ID+"/save", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/saved", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, popular.ID)
+	assert.NotContains(t, bodyStr, quiet.ID)
+	assert.Contains(t, bodyStr, `"saved":true`)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/"+popular.ID, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"likes_count":1`)
+	assert.Contains(t, bodyStr, `"saves_count":1`)
+	assert.Contains(t, bodyStr, `"liked":true`)
+	t.Logf("ПОРТФОЛИО: Сохранения (200) - Успешно.")
+
+	// 4. Featured: пересчет только админом, работа с реакциями выше работы без них
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/portfolio/featured/recompute", employerToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/portfolio/featured/recompute", adminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	var popularScore, quietScore float64
+	tx.Model(&models.PortfolioItem{}).Where("id = ?", popular.ID).Pluck("featured_score", &popularScore)
+	tx.Model(&models.PortfolioItem{}).Where("id = ?", quiet.ID).Pluck("featured_score", &quietScore)
+	assert.Greater(t, quietScore, 0.0, "Новая работа без реакций тоже попадает в подборку")
+	assert.Greater(t, popularScore, quietScore)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/portfolio/featured?limit=1000", "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	popularPos, quietPos := strings.Index(bodyStr, popular.ID), strings.Index(bodyStr, quiet.ID)
+	assert.True(t, popularPos >= 0 && quietPos > popularPos, "Популярная работа выше в подборке")
+
+	// Отклоненное фото выпадает из подборки после пересчета
+	assert.NoError(t, tx.Model(&models.Upload{}).Where("id = ?", *popular.UploadID).
+		Update("moderation_status", models.ModerationStatusRejected).Error)
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/portfolio/featured/recompute", adminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	tx.Model(&models.PortfolioItem{}).Where("id = ?", popular.ID).Pluck("featured_score", &popularScore)
+	assert.Equal(t, 0.0, popularScore)
+	t.Logf("ПОРТФОЛИО: Рейтинг featured (200/403) - Успешно.")
+}
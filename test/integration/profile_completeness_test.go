@@ -0,0 +1,100 @@
+package integration_test
+
+import (
+	"fmt"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProfileCompleteness_ScoreAndChecklist - балл заполненности в профиле, чек-лист только у владельца,
+// пересчет после обновления профиля и понижение незаполненных профилей в поиске
+func TestProfileCompleteness_ScoreAndChecklist(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	// 2. Новый профиль: заполнены только рост и вес, владелец видит чек-лист
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/me/completeness", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"score":5`)
+	assert.Contains(t, bodyStr, `"checklist"`)
+	assert.Contains(t, bodyStr, `"portfolio_photos"`)
+	t.Logf("ЗАПОЛНЕННОСТЬ: Балл и чек-лист владельца (200) - Успешно.")
+
+	// 3. Дозаполнение профиля пересчитывает балл (без портфолио - 75)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me", modelToken, map[string]interface{}{
+		"bust":          86,
+		"waist":         61,
+		"hips":          89,
+		"clothing_size": "M",
+		"shoe_size":     "39",
+		"hair_color":    "blonde",
+		"eye_color":     "green",
+		"categories":    []string{"fashion"},
+		"languages":     []string{"ru", "en"},
+		"description":   "Профессиональная модель, опыт съемок для каталогов и показов в Алматы.",
+		"hourly_rate":   15000,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	var profile models.ModelProfile
+	assert.NoError(t, tx.First(&profile, "id = ?", modelProfile.ID).Error)
+	assert.Equal(t, 75, profile.CompletenessScore)
+	t.Logf("ЗАПОЛНЕННОСТЬ: Пересчет после обновления профиля (200) - Успешно.")
+
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/me/completeness", employerToken, nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	// 4. Работодатель видит балл в профиле модели, но не чек-лист
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"score":75`)
+	assert.NotContains(t, bodyStr, `"checklist"`)
+
+	// 5. Поиск с понижением незаполненных профилей
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/models/search?city=Almaty&down_rank_incomplete=true", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, modelProfile.ID)
+	t.Logf("ЗАПОЛНЕННОСТЬ: Балл для работодателя и поиск down_rank_incomplete (200) - Успешно.")
+
+	// 6. Фото на проверке не засчитываются; одобрение модератором пересчитывает балл
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Test Admin",
+		fmt.Sprintf("admin_%d@test.com", time.Now().UnixNano()), "password123", models.UserRoleAdmin)
+	var uploadIDs []string
+	for i := 0; i < 3; i++ {
+		item := createCompCardPhoto(t, tx, modelUser, modelProfile, fmt.Sprintf("completeness-%d", i), i)
+		uploadIDs = append(uploadIDs, *item.UploadID)
+	}
+	assert.NoError(t, tx.Model(&models.Upload{}).Where("id IN ?", uploadIDs).
+		Update("moderation_status", models.ModerationStatusPending).Error)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/me/completeness", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"score":75`)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/photos/approve", adminToken, map[string]interface{}{
+		"upload_ids": uploadIDs,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NoError(t, tx.First(&profile, "id = ?", modelProfile.ID).Error)
+	assert.Equal(t, 100, profile.CompletenessScore)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/photos/reject", adminToken, map[string]interface{}{
+		"upload_ids": uploadIDs[:1], "reason": "Low quality",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.NoError(t, tx.First(&profile, "id = ?", modelProfile.ID).Error)
+	assert.Equal(t, 75, profile.CompletenessScore)
+	t.Logf("ЗАПОЛНЕННОСТЬ: Пересчет после решения модератора (200) - Успешно.")
+}
@@ -0,0 +1,133 @@
+package integration_test
+
+import (
+	"fmt"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/repositories"
+	"mwork_backend/internal/services"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+// TestProfileViews_ViewersAndAnonymousBrowsing - запись просмотров работодателей, список зрителей
+// и сводка по тарифу, анонимный просмотр
+func TestProfileViews_ViewersAndAnonymousBrowsing(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	anonymousToken, _, anonymousEmployer := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	// 2. Анонимный режим у второго работодателя
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPut, "/api/v1/employer/privacy", anonymousToken, map[string]interface{}{
+		"anonymous_browsing": true,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"anonymous_browsing":true`)
+
+	// 3. Просмотры: повторный просмотр сразу же не записывается, гость не записывается
+	for i := 0; i < 2; i++ {
+		res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, employerToken, nil)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	}
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, anonymousToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var views int64
+	tx.Model(&models.ProfileView{}).Where("model_id = ?", modelProfile.ID).Count(&views)
+	assert.Equal(t, int64(2), views)
+	t.Logf("ПРОСМОТРЫ: Запись просмотров работодателей (200) - Успешно.")
+
+	// 4. Без тарифа список зрителей недоступен
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/me/viewers", modelToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// Тариф с функцией profile_viewers
+	plan := &models.SubscriptionPlan{
+		Name:          "Model Pro",
+		Description:   "Premium plan for models",
+		Slug:          fmt.Sprintf("model-pro-%d", time.Now().UnixNano()),
+		Price:         3000,
+		Currency:      "KZT",
+		Duration:      "monthly",
+		BillingPeriod: 1,
+		Features:      datatypes.JSON(`{"profile_viewers": true}`),
+		IsActive:      true,
+	}
+	assert.NoError(t, tx.Create(plan).Error)
+	tx.Where("user_id = ?", modelUser.ID).Delete(&models.UserSubscription{})
+	assert.NoError(t, tx.Create(&models.UserSubscription{
+		UserID:    modelUser.ID,
+		PlanID:    plan.ID,
+		Status:    models.SubscriptionStatusActive,
+		InvID:     fmt.Sprintf("inv-%d", time.Now().UnixNano()),
+		StartDate: time.Now(),
+		EndDate:   time.Now().AddDate(0, 1, 0),
+	}).Error)
+
+	// 5. Зрители: только неанонимный работодатель; в сводке анонимный просмотр - счетчиком
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/me/viewers", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, employerProfile.ID)
+	assert.NotContains(t, bodyStr, anonymousEmployer.ID)
+	assert.Contains(t, bodyStr, `"total":1`)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/me/viewers/summary", modelToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"total_views":2`)
+	assert.Contains(t, bodyStr, `"unique_viewers":1`)
+	assert.Contains(t, bodyStr, `"anonymous_views":1`)
+	t.Logf("ПРОСМОТРЫ: Список зрителей и сводка по тарифу (403/200) - Успешно.")
+}
+
+// TestProfileViews_WeeklySummarySchedule - ежедневный запуск сводки не дублирует ее,
+// следующая уходит через неделю после предыдущей
+func TestProfileViews_WeeklySummarySchedule(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: просмотр профиля модели работодателем
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	_, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	res, _ := ts.SendRequest(t, tx, http.MethodGet, "/api/v1/profiles/"+modelUser.ID, employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	service := services.NewProfileViewService(repositories.NewProfileViewRepository(), repositories.NewProfileRepository(),
+		repositories.NewSubscriptionRepository(), repositories.NewNotificationRepository())
+	countSummaries := func() int64 {
+		var count int64
+		tx.Model(&models.Notification{}).
+			Where("user_id = ? AND type = ? AND data->>'to' IS NOT NULL", modelUser.ID, repositories.NotificationTypeProfileView).
+			Count(&count)
+		return count
+	}
+
+	// 2. Два запуска подряд (как после перезапуска сервиса) - одна сводка
+	assert.NoError(t, service.SendWeeklySummaries(tx))
+	assert.NoError(t, service.SendWeeklySummaries(tx))
+	assert.Equal(t, int64(1), countSummaries())
+
+	// 3. Прошла неделя - уходит следующая сводка
+	weekAgo := time.Now().Add(-8 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	assert.NoError(t, tx.Model(&models.Notification{}).
+		Where("user_id = ? AND type = ? AND data->>'to' IS NOT NULL", modelUser.ID, repositories.NotificationTypeProfileView).
+		Update("data", datatypes.JSON(fmt.Sprintf(`{"to": %q}`, weekAgo))).Error)
+	assert.NoError(t, service.SendWeeklySummaries(tx))
+	assert.Equal(t, int64(2), countSummaries())
+	t.Logf("ПРОСМОТРЫ: Еженедельная сводка без дублей - Успешно.")
+}
@@ -0,0 +1,153 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPromotion_PaidFlow - платное продвижение: создание платежа и ранжирование после активации
+func TestPromotion_PaidFlow(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	regular := CreateTestCasting(t, tx, employerProfile.ID, "Обычный кастинг", "PromoCity")
+	promoted := CreateTestCasting(t, tx, employerProfile.ID, "Продвигаемый кастинг", "PromoCity")
+	// "Обычный" кастинг свежее, поэтому без продвижения он был бы первым
+	tx.Model(&models.Casting{}).Where("id = ?", promoted.ID).Update("created_at", time.Now().Add(-time.Hour))
+
+	// 2. Действие: Заказ платного продвижения
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+promoted.ID+"/promotions", employerToken, map[string]interface{}{
+		"days": 3,
+	})
+
+	// 3. Проверка: создан платеж и промо в статусе pending
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Contains(t, bodyStr, "payment_url")
+	assert.Contains(t, bodyStr, `"status":"pending"`)
+	t.Logf("ПРОДВИЖЕНИЕ: Создание платежа (201) - Успешно. Ответ: %s", bodyStr)
+
+	var created struct {
+		Promotion models.CastingPromotion `json:"promotion"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &created))
+	assert.NotNil(t, created.Promotion.PaymentID)
+
+	// Оплаченное промо, стоящее в очереди, еще не продвигает кастинг
+	tx.Model(&models.CastingPromotion{}).Where("id = ?", created.Promotion.ID).
+		Updates(map[string]interface{}{"status": models.PromotionStatusActive, "starts_at": time.Now().Add(24 * time.Hour), "ends_at": time.Now().Add(96 * time.Hour)})
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/castings/"+promoted.ID+"/promotions", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"is_promoted":false`)
+
+	// 4. Эмулируем успешную оплату (активация промо)
+	endsAt := time.Now().Add(72 * time.Hour)
+	tx.Model(&models.CastingPromotion{}).Where("id = ?", created.Promotion.ID).
+		Updates(map[string]interface{}{"status": models.PromotionStatusActive, "starts_at": time.Now(), "ends_at": endsAt})
+
+	// 5. Действие: Поиск - продвигаемый кастинг должен быть первым и с флагом
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/castings?city=PromoCity", "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"is_promoted":true`)
+	assert.Less(t, strings.Index(bodyStr, promoted.ID), strings.Index(bodyStr, regular.ID))
+	t.Logf("ПРОДВИЖЕНИЕ: Продвигаемый кастинг первым в поиске (200) - Успешно.")
+
+	// 6. Действие: Статистика продвижения
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/castings/"+promoted.ID+"/promotions", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"is_promoted":true`)
+	assert.Contains(t, bodyStr, "impressions")
+	t.Logf("ПРОДВИЖЕНИЕ: Статистика (200) - Успешно. Ответ: %s", bodyStr)
+}
+
+// TestPromotion_PlanNotIncluded - Free-план не включает продвижение
+func TestPromotion_PlanNotIncluded(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг", "Almaty")
+
+	// 2. Действие: Продвижение "по тарифу"
+	res, _ := ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+casting.ID+"/promotions", employerToken, map[string]interface{}{
+		"days":     3,
+		"use_plan": true,
+	})
+
+	// 3. Проверка: (403 Forbidden)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("ПРОДВИЖЕНИЕ: Free-план без продвижения (403) - Успешно.")
+}
+
+// TestPromotion_WithoutSubscription - продвижение оплачивается и без подписки
+func TestPromotion_WithoutSubscription(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: работодатель без подписки
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, employerUser, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг", "Almaty")
+	assert.NoError(t, tx.Where("user_id = ?", employerUser.ID).Delete(&models.UserSubscription{}).Error)
+
+	// 2. Действие: Заказ платного продвижения
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+casting.ID+"/promotions", employerToken, map[string]interface{}{
+		"days": 3,
+	})
+
+	// 3. Проверка: платеж создан без привязки к подписке
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, "payment_url")
+
+	var payment models.PaymentTransaction
+	assert.NoError(t, tx.Where("user_id = ?", employerUser.ID).First(&payment).Error)
+	assert.Nil(t, payment.SubscriptionID)
+	t.Logf("ПРОДВИЖЕНИЕ: Оплата без подписки (201) - Успешно.")
+}
+
+// TestPromotion_Security - чужой кастинг, модель и неверная длительность
+func TestPromotion_Security(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerTokenA, _, profileA := helpers.CreateAndLoginEmployer(t, ts, tx)
+	castingA := CreateTestCasting(t, tx, profileA.ID, "Casting A", "Almaty")
+	employerTokenB, _ := helpers.CreateAndLoginUser(t, ts, tx, "Employer B", "promo-b@test.com", "pass123", models.UserRoleEmployer)
+	modelToken, _, _ := helpers.CreateAndLoginModel(t, ts, tx)
+
+	body := map[string]interface{}{"days": 3}
+
+	// 2. Работодатель Б продвигает чужой кастинг (403)
+	res, _ := ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+castingA.ID+"/promotions", employerTokenB, body)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 3. Модель не может продвигать кастинги (403)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+castingA.ID+"/promotions", modelToken, body)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 4. Недопустимая длительность (400)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/castings/"+castingA.ID+"/promotions", employerTokenA, map[string]interface{}{"days": 365})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	t.Logf("ПРОДВИЖЕНИЕ: Проверки безопасности - Успешно.")
+}
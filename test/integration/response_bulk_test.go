@@ -0,0 +1,150 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"mwork_backend/internal/models"
+	"mwork_backend/internal/services/dto"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResponseBulk_RejectPending - массовое отклонение всех pending-откликов
+func TestResponseBulk_RejectPending(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг заполнен", "Almaty")
+	_, model1, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	_, model2, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	_, model3, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	pending1 := CreateTestResponse(t, tx, casting.ID, model1.ID, models.ResponseStatusPending)
+	pending2 := CreateTestResponse(t, tx, casting.ID, model2.ID, models.ResponseStatusPending)
+	accepted := CreateTestResponse(t, tx, casting.ID, model3.ID, models.ResponseStatusAccepted)
+
+	// 2. Действие: Отклонить все pending с шаблонным уведомлением
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/responses/castings/"+casting.ID+"/reject-pending", employerToken, map[string]interface{}{
+		"message": map[string]interface{}{
+			"channel":  "notification",
+			"template": "Спасибо за отклик на '{{casting_title}}', но набор закрыт.",
+		},
+	})
+
+	// 3. Проверка: (200 OK) и отчет по каждому отклику
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var result dto.BulkResponseResult
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &result))
+	assert.Equal(t, 2, result.Total)
+	assert.Equal(t, 2, result.Succeeded)
+	assert.Equal(t, 0, result.Failed)
+	t.Logf("МАССОВЫЕ ОТКЛИКИ: Отклонение pending (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 4. Проверка БД: pending отклонены, принятый не тронут
+	var statuses []models.CastingResponse
+	tx.Where("id IN ?", []string{pending1.ID, pending2.ID, accepted.ID}).Find(&statuses)
+	for _, response := range statuses {
+		if response.ID == accepted.ID {
+			assert.Equal(t, models.ResponseStatusAccepted, response.Status)
+		} else {
+			assert.Equal(t, models.ResponseStatusRejected, response.Status)
+		}
+	}
+}
+
+// TestResponseBulk_UpdateSelected - перевод выбранных откликов в статус с отчетом по ошибкам
+func TestResponseBulk_UpdateSelected(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг", "Almaty")
+	_, model1, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	_, model2, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	response1 := CreateTestResponse(t, tx, casting.ID, model1.ID, models.ResponseStatusPending)
+	withdrawn := CreateTestResponse(t, tx, casting.ID, model2.ID, models.ResponseStatusWithdrawn)
+
+	// 2. Действие: Одобрить выбранные (включая отозванный и несуществующий)
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/responses/castings/"+casting.ID+"/bulk-status", employerToken, map[string]interface{}{
+		"response_ids": []string{response1.ID, withdrawn.ID, "00000000-0000-0000-0000-000000000000"},
+		"status":       "approved",
+	})
+
+	// 3. Проверка: (200 OK), один успех и две ошибки
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var result dto.BulkResponseResult
+	assert.NoError(t, json.Unmarshal([]byte(bodyStr), &result))
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 2, result.Failed)
+	t.Logf("МАССОВЫЕ ОТКЛИКИ: Перевод выбранных (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 4. Недопустимый для работодателя статус (400)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/responses/castings/"+casting.ID+"/bulk-status", employerToken, map[string]interface{}{
+		"response_ids": []string{response1.ID},
+		"status":       "withdrawn",
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+// TestResponseBulk_CloseWithRejectPending - закрытие кастинга с автоотклонением остальных
+func TestResponseBulk_CloseWithRejectPending(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг к закрытию", "Almaty")
+	_, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	pending := CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusPending)
+
+	// 2. Действие: Закрыть с reject_pending
+	res, bodyStr := ts.SendRequest(t, tx, "PUT", "/api/v1/castings/"+casting.ID+"/status", employerToken, map[string]interface{}{
+		"status":         "closed",
+		"reject_pending": true,
+	})
+
+	// 3. Проверка: кастинг закрыт, отклик отклонен
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"status":"closed"`)
+	assert.Contains(t, bodyStr, `"succeeded":1`)
+
+	var updated models.CastingResponse
+	tx.First(&updated, "id = ?", pending.ID)
+	assert.Equal(t, models.ResponseStatusRejected, updated.Status)
+	t.Logf("МАССОВЫЕ ОТКЛИКИ: Закрытие с автоотклонением (200) - Успешно. Ответ: %s", bodyStr)
+}
+
+// TestResponseBulk_Security - чужой работодатель не может массово менять отклики
+func TestResponseBulk_Security(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	_, _, profileA := helpers.CreateAndLoginEmployer(t, ts, tx)
+	castingA := CreateTestCasting(t, tx, profileA.ID, "Casting A", "Almaty")
+	employerTokenB, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	// 2. Действие: Работодатель Б отклоняет отклики чужого кастинга
+	res, _ := ts.SendRequest(t, tx, "POST", "/api/v1/responses/castings/"+castingA.ID+"/reject-pending", employerTokenB, nil)
+
+	// 3. Проверка: (403 Forbidden)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("МАССОВЫЕ ОТКЛИКИ: Проверки безопасности (403) - Успешно.")
+}
@@ -62,19 +62,20 @@ func TestResponse_ModelFlow(t *testing.T) {
 	assert.Contains(t, bodyStr, responseID)
 	t.Logf("ОТКЛИК (Common): Модель читает свой отклик (200) - Успешно.")
 
-	// 7. Действие: Модель удаляет свой отклик (DELETE)
+	// 7. Действие: Модель отзывает свой отклик (DELETE - отзыв, запись не удаляется)
 	// ❗️ Добавлен 'tx'
 	res, bodyStr = ts.SendRequest(t, tx, "DELETE", "/api/v1/responses/"+responseID, modelToken, nil)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
-	assert.Contains(t, bodyStr, "Response deleted successfully")
+	assert.Contains(t, bodyStr, "Response withdrawn successfully")
 	t.Logf("ОТКЛИК (Model): DELETE /:id (200) - Успешно.")
 
-	// 8. Действие: Модель проверяет, что отклик удален (GET /my)
+	// 8. Действие: Модель проверяет, что отклик отозван (GET /my)
 	// ❗️ Добавлен 'tx'
 	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/responses/my", modelToken, nil)
 	assert.Equal(t, http.StatusOK, res.StatusCode)
-	assert.Contains(t, bodyStr, `"total":0`)
-	t.Logf("ОТКЛИК (Model): GET /my (пусто) (200) - Успешно.")
+	assert.Contains(t, bodyStr, `"total":1`)
+	assert.Contains(t, bodyStr, `"status":"withdrawn"`)
+	t.Logf("ОТКЛИК (Model): GET /my (отозван) (200) - Успешно.")
 }
 
 // TestResponse_EmployerFlow - проверяет E2E "золотой путь" для Работодателя
@@ -0,0 +1,126 @@
+package integration_test
+
+import (
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResponseWithdrawal_ReasonAndReapply - отзыв с причиной, cooldown и повторный отклик
+func TestResponseWithdrawal_ReasonAndReapply(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	otherEmployerToken, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	_, otherModel, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг с отзывом", "Almaty")
+	response := CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusPending)
+
+	// 2. Действие: Модель отзывает отклик с причиной
+	res, bodyStr := ts.SendRequest(t, tx, "POST", "/api/v1/responses/"+response.ID+"/withdraw", modelToken, map[string]interface{}{
+		"reason": "Совпадает с другой съемкой",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, "Response withdrawn successfully")
+	t.Logf("ОТЗЫВ ОТКЛИКА: Отзыв с причиной (200) - Успешно.")
+
+	// 3. Работодатель видит отозванный отклик и причину
+	res, bodyStr = ts.SendRequest(t, tx, "GET", "/api/v1/responses/castings/"+casting.ID+"/list", employerToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"status":"withdrawn"`)
+	assert.Contains(t, bodyStr, "Совпадает с другой съемкой")
+
+	// Работодатель не может вернуть отозванный отклик или сам отозвать отклик (400)
+	statusURL := "/api/v1/responses/" + response.ID + "/status"
+	res, _ = ts.SendRequest(t, tx, "PUT", statusURL, employerToken, map[string]interface{}{"status": "accepted"})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	other := CreateTestResponse(t, tx, casting.ID, otherModel.ID, models.ResponseStatusPending)
+	res, _ = ts.SendRequest(t, tx, "PUT", "/api/v1/responses/"+other.ID+"/status", employerToken, map[string]interface{}{"status": "withdrawn"})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// Чужой работодатель (403)
+	res, _ = ts.SendRequest(t, tx, "PUT", "/api/v1/responses/"+other.ID+"/status", otherEmployerToken, map[string]interface{}{"status": "accepted"})
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 4. Повторный отклик сразу после отзыва (429)
+	createURL := "/api/v1/responses/castings/" + casting.ID
+	res, _ = ts.SendRequest(t, tx, "POST", createURL, modelToken, map[string]interface{}{"message": "Снова свободна"})
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	t.Logf("ОТЗЫВ ОТКЛИКА: Повторный отклик во время cooldown (429) - Успешно.")
+
+	// 5. Cooldown истек: отклик возвращается в pending (та же запись)
+	tx.Model(&models.CastingResponse{}).Where("id = ?", response.ID).Update("withdrawn_at", time.Now().Add(-48*time.Hour))
+	res, bodyStr = ts.SendRequest(t, tx, "POST", createURL, modelToken, map[string]interface{}{"message": "Снова свободна"})
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	assert.Contains(t, bodyStr, response.ID)
+	assert.Contains(t, bodyStr, `"status":"pending"`)
+
+	var updated models.CastingResponse
+	tx.First(&updated, "id = ?", response.ID)
+	assert.Equal(t, 1, updated.WithdrawCount)
+	assert.Nil(t, updated.WithdrawalReason)
+	t.Logf("ОТЗЫВ ОТКЛИКА: Повторный отклик после cooldown (201) - Успешно. Ответ: %s", bodyStr)
+}
+
+// TestResponseWithdrawal_ReapplyLimit - цикл "отозвал - откликнулся" ограничен
+func TestResponseWithdrawal_ReapplyLimit(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка: отклик, уже отозванный сверх лимита
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	_, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг", "Almaty")
+	response := CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusWithdrawn)
+	tx.Model(&models.CastingResponse{}).Where("id = ?", response.ID).Updates(map[string]interface{}{
+		"withdrawn_at":   time.Now().Add(-48 * time.Hour),
+		"withdraw_count": 3,
+	})
+
+	// 2. Действие: Повторный отклик
+	res, _ := ts.SendRequest(t, tx, "POST", "/api/v1/responses/castings/"+casting.ID, modelToken, map[string]interface{}{"message": "Еще раз"})
+
+	// 3. Проверка: (409 Conflict)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+	t.Logf("ОТЗЫВ ОТКЛИКА: Лимит повторных откликов (409) - Успешно.")
+}
+
+// TestResponseWithdrawal_Security - чужой отклик и недопустимый статус
+func TestResponseWithdrawal_Security(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	_, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelTokenA, modelA, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	modelTokenB, _, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Кастинг", "Almaty")
+	response := CreateTestResponse(t, tx, casting.ID, modelA.ID, models.ResponseStatusPending)
+
+	// 2. Чужая модель не может отозвать отклик (403)
+	res, _ := ts.SendRequest(t, tx, "POST", "/api/v1/responses/"+response.ID+"/withdraw", modelTokenB, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 3. Отклоненный отклик отозвать нельзя (400)
+	tx.Model(&models.CastingResponse{}).Where("id = ?", response.ID).Update("status", models.ResponseStatusRejected)
+	res, _ = ts.SendRequest(t, tx, "POST", "/api/v1/responses/"+response.ID+"/withdraw", modelTokenA, nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	t.Logf("ОТЗЫВ ОТКЛИКА: Проверки безопасности - Успешно.")
+}
@@ -0,0 +1,107 @@
+package integration_test
+
+import (
+	"fmt"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestShare_SlugsAndPublicPages - выбор адреса, публичная страница с OpenGraph-метатегами,
+// перенаправление со старого адреса, закрытые профили и неопубликованные кастинги
+func TestShare_SlugsAndPublicPages(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, _, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	otherModelToken, _, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Share Casting", "Almaty")
+
+	suffix := time.Now().UnixNano()
+	slug := fmt.Sprintf("anna-%d", suffix)
+	newSlug := fmt.Sprintf("anna-model-%d", suffix)
+
+	// 2. Выбор адреса: зарезервированный - 400, свой - 200
+	res, _ := ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me/slug", modelToken, map[string]interface{}{
+		"slug": "admin",
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me/slug", modelToken, map[string]interface{}{
+		"slug": slug,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, "/share/models/"+slug)
+	t.Logf("ШЕРИНГ: Выбор адреса профиля (400/200) - Успешно.")
+
+	// 3. Публичная HTML-страница с метатегами
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/share/models/"+slug, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, res.Header.Get("Content-Type"), "text/html")
+	assert.Contains(t, bodyStr, `property="og:title"`)
+	assert.Contains(t, bodyStr, `name="twitter:card"`)
+	assert.Contains(t, bodyStr, modelProfile.Name)
+	t.Logf("ШЕРИНГ: HTML-страница с OpenGraph/Twitter метатегами (200) - Успешно.")
+
+	// 4. Смена адреса: старый перенаправляет на новый и не достается другим
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me/slug", modelToken, map[string]interface{}{
+		"slug": newSlug,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/share/models/"+slug, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, `"redirected":true`)
+	assert.Contains(t, bodyStr, "/share/models/"+newSlug)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me/slug", otherModelToken, map[string]interface{}{
+		"slug": slug,
+	})
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var redirects int64
+	tx.Model(&models.SlugRedirect{}).Where("entity_id = ?", modelProfile.ID).Count(&redirects)
+	assert.Equal(t, int64(1), redirects)
+	t.Logf("ШЕРИНГ: История адресов и перенаправление (200/409) - Успешно.")
+
+	// 5. Закрытый профиль недоступен ни по новому, ни по старому адресу
+	res, _ = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me/visibility", modelToken, map[string]interface{}{
+		"is_public": false,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/share/models/"+newSlug, "", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/share/models/"+slug, "", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	t.Logf("ШЕРИНГ: Закрытый профиль скрыт (404) - Успешно.")
+
+	// 6. Кастинг: адрес выбирает только владелец, черновик не публикуется
+	castingSlug := fmt.Sprintf("casting-%d", suffix)
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/castings/"+casting.ID+"/slug", employerToken, map[string]interface{}{
+		"slug": castingSlug,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/castings/"+casting.ID+"/share", modelToken, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/share/castings/"+castingSlug, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, "Share Casting")
+
+	assert.NoError(t, tx.Model(&models.Casting{}).Where("id = ?", casting.ID).
+		Update("status", models.CastingStatusDraft).Error)
+	res, _ = ts.SendRequest(t, tx, http.MethodGet, "/share/castings/"+castingSlug, "", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	t.Logf("ШЕРИНГ: Адрес кастинга и скрытие черновика (200/403/404) - Успешно.")
+}
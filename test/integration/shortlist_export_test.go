@@ -0,0 +1,92 @@
+package integration_test
+
+import (
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestShortlistExport_PDFAndCSV - контакт-лист выбранных откликов в PDF и CSV
+func TestShortlistExport_PDFAndCSV(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Реклама кофе", "Almaty")
+	_, model1, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	_, model2, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	response1 := CreateTestResponse(t, tx, casting.ID, model1.ID, models.ResponseStatusAccepted)
+	response2 := CreateTestResponse(t, tx, casting.ID, model2.ID, models.ResponseStatusPending)
+
+	exportURL := "/api/v1/responses/castings/" + casting.ID + "/shortlist/export"
+
+	// 2. Действие: PDF по выбранным откликам
+	res, bodyStr := ts.SendRequest(t, tx, "POST", exportURL, employerToken, map[string]interface{}{
+		"response_ids": []string{response1.ID, response2.ID},
+		"format":       "pdf",
+	})
+
+	// 3. Проверка: (200 OK) и валидный PDF
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/pdf", res.Header.Get("Content-Type"))
+	assert.Contains(t, res.Header.Get("Content-Disposition"), ".pdf")
+	assert.True(t, strings.HasPrefix(bodyStr, "%PDF-"))
+	t.Logf("ШОРТЛИСТ: Экспорт PDF (200) - Успешно. Размер: %d байт", len(bodyStr))
+
+	// 4. Действие: CSV без списка - только принятые отклики
+	// Сообщение модели похоже на формулу Excel
+	assert.NoError(t, tx.Model(&models.CastingResponse{}).Where("id = ?", response1.ID).
+		Update("message", `=HYPERLINK("http://evil.example","click")`).Error)
+	res, bodyStr = ts.SendRequest(t, tx, "POST", exportURL, employerToken, map[string]interface{}{
+		"format": "csv",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, res.Header.Get("Content-Type"), "text/csv")
+	assert.Contains(t, bodyStr, "response_id,model_id,name")
+	assert.Contains(t, bodyStr, response1.ID)
+	assert.NotContains(t, bodyStr, response2.ID)
+	assert.Contains(t, bodyStr, `"'=HYPERLINK(`)
+	t.Logf("ШОРТЛИСТ: Экспорт CSV (200) - Успешно. Ответ: %s", bodyStr)
+
+	// 5. Неизвестный формат (400)
+	res, _ = ts.SendRequest(t, tx, "POST", exportURL, employerToken, map[string]interface{}{
+		"format": "docx",
+	})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
+
+// TestShortlistExport_Security - экспорт чужого кастинга и экспорт моделью запрещены
+func TestShortlistExport_Security(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	_, _, profileA := helpers.CreateAndLoginEmployer(t, ts, tx)
+	employerTokenB, _, _ := helpers.CreateAndLoginEmployer(t, ts, tx)
+	modelToken, modelUser, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	castingA := CreateTestCasting(t, tx, profileA.ID, "Casting A", "Almaty")
+	CreateTestResponse(t, tx, castingA.ID, modelUser.ID, models.ResponseStatusAccepted)
+
+	exportURL := "/api/v1/responses/castings/" + castingA.ID + "/shortlist/export"
+	body := map[string]interface{}{"format": "csv"}
+
+	// 2. Чужой работодатель (403)
+	res, _ := ts.SendRequest(t, tx, "POST", exportURL, employerTokenB, body)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	// 3. Модель (403)
+	res, _ = ts.SendRequest(t, tx, "POST", exportURL, modelToken, body)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	t.Logf("ШОРТЛИСТ: Проверки безопасности (403) - Успешно.")
+}
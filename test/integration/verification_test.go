@@ -0,0 +1,115 @@
+package integration_test
+
+import (
+	"fmt"
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEmployerVerification_ReviewFlow - заявка с документами, запрос доп. информации,
+// одобрение модератором, значок "verified" в кастингах и поиске, история статусов
+func TestEmployerVerification_ReviewFlow(t *testing.T) {
+	t.Parallel() // ✅ Параллельный запуск
+
+	// 1. Подготовка
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	employerToken, employerUser, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+	adminEmail := fmt.Sprintf("admin_%d@test.com", time.Now().UnixNano())
+	adminToken, _ := helpers.CreateAndLoginUser(t, ts, tx, "Test Admin", adminEmail, "password123", models.UserRoleAdmin)
+	modelToken, _, _ := helpers.CreateAndLoginModel(t, ts, tx)
+	casting := CreateTestCasting(t, tx, employerProfile.ID, "Verified Casting", "Almaty")
+
+	// Документ загружен в приватный модуль "verification"
+	document := &models.Upload{
+		UserID:     employerUser.ID,
+		Module:     "verification",
+		EntityType: "employer_profile",
+		EntityID:   employerProfile.ID,
+		FileType:   "document",
+		Usage:      "business_registration",
+		Path:       fmt.Sprintf("verification/%d.pdf", time.Now().UnixNano()),
+		MimeType:   "application/pdf",
+		Size:       2048,
+	}
+	assert.NoError(t, tx.Create(document).Error)
+
+	// 2. Подача заявки: модель не может, повторная заявка - конфликт
+	submit := map[string]interface{}{
+		"bin":          "123456789012",
+		"legal_name":   "TOO Test Company",
+		"document_ids": []string{document.ID},
+	}
+	res, _ := ts.SendRequest(t, tx, http.MethodPost, "/api/v1/employer/verification", modelToken, submit)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res, bodyStr := ts.SendRequest(t, tx, http.MethodPost, "/api/v1/employer/verification", employerToken, submit)
+	assert.Equal(t, http.StatusCreated, res.StatusCode, "Body: "+bodyStr)
+	assert.Contains(t, bodyStr, document.ID)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/employer/verification", employerToken, submit)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	var verification models.EmployerVerification
+	assert.NoError(t, tx.First(&verification, "employer_profile_id = ?", employerProfile.ID).Error)
+	assert.Equal(t, models.VerificationStatusPending, verification.Status)
+	t.Logf("ВЕРИФИКАЦИЯ: Подача заявки (403/201/409) - Успешно.")
+
+	// 3. Модератор видит заявку в очереди и запрашивает дополнительные документы
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/admin/verifications", adminToken, nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, verification.ID)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/verifications/"+verification.ID+"/request-info", adminToken, map[string]interface{}{
+		"note": "Приложите свидетельство о регистрации с печатью",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	res, _ = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/verifications/"+verification.ID+"/approve", adminToken, map[string]interface{}{})
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPut, "/api/v1/employer/verification", employerToken, map[string]interface{}{
+		"comment": "Добавили свидетельство",
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+	t.Logf("ВЕРИФИКАЦИЯ: Запрос доп. информации и дополнение заявки (200/400/200) - Успешно.")
+
+	// 4. Одобрение: значок на профиле со сроком действия, уведомление работодателю
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodPost, "/api/v1/admin/verifications/"+verification.ID+"/approve", adminToken, map[string]interface{}{
+		"valid_months": 6,
+	})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "Body: "+bodyStr)
+
+	var profile models.EmployerProfile
+	assert.NoError(t, tx.First(&profile, "id = ?", employerProfile.ID).Error)
+	assert.True(t, profile.IsVerified)
+	if assert.NotNil(t, profile.VerifiedUntil) {
+		assert.True(t, profile.VerifiedUntil.After(time.Now().AddDate(0, 5, 0)))
+	}
+
+	var transitions int64
+	tx.Model(&models.EmployerVerificationTransition{}).Where("verification_id = ?", verification.ID).Count(&transitions)
+	assert.Equal(t, int64(4), transitions) // подача, needs_info, повторная подача, одобрение
+
+	var notifications int64
+	tx.Model(&models.Notification{}).Where("user_id = ? AND type = ?", employerUser.ID, "verification").Count(&notifications)
+	assert.Equal(t, int64(2), notifications)
+	t.Logf("ВЕРИФИКАЦИЯ: Одобрение, срок действия и история статусов (200) - Успешно.")
+
+	// 5. Значок в кастингах и фильтр поиска
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/castings/"+casting.ID, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, `"employer_verified":true`)
+
+	res, bodyStr = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/castings?verified_employer=true&city=Almaty", "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, bodyStr, casting.ID)
+	t.Logf("ВЕРИФИКАЦИЯ: Значок в кастингах и фильтр verified_employer (200) - Успешно.")
+}
@@ -0,0 +1,102 @@
+package integration_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"net/http"
+	"testing"
+	"time"
+
+	"mwork_backend/internal/models"
+	"mwork_backend/test/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPortfolioWatermark - публичные копии фото портфолио отдаются с водяным знаком,
+// оригинал без знака доступен владельцу и работодателю, принявшему отклик модели
+func TestPortfolioWatermark(t *testing.T) {
+	t.Parallel()
+
+	ts := GetTestServer(t)
+	tx := ts.BeginTransaction(t)
+	defer ts.RollbackTransaction(t, tx)
+
+	modelToken, modelUser, modelProfile := helpers.CreateAndLoginModel(t, ts, tx)
+	employerToken, _, employerProfile := helpers.CreateAndLoginEmployer(t, ts, tx)
+
+	// Доверенная модель - фото публикуются без очереди модерации
+	require.NoError(t, tx.Model(&models.User{}).Where("id = ?", modelUser.ID).Update("photo_auto_approve", true).Error)
+
+	photo := uploadImage(t, ts, tx, modelToken, modelProfile.ID, "portfolio", "portfolio_photo", "look.jpg", buildEXIFJPEG(t, 240, 160, 1))
+	filePath := "/api/v1/files/" + photo.ID
+
+	res, original := ts.SendRequest(t, tx, http.MethodGet, filePath, modelToken, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	t.Run("Owner gets the clean original", func(t *testing.T) {
+		assert.Equal(t, `"`+photo.ID+`"`, res.Header.Get("ETag"))
+		assert.Contains(t, res.Header.Get("Cache-Control"), "private")
+	})
+
+	t.Run("Anonymous viewer gets a watermarked copy", func(t *testing.T) {
+		res, body := ts.SendRequest(t, tx, http.MethodGet, filePath, "", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, `"`+photo.ID+`-wm_large"`, res.Header.Get("ETag"))
+		assert.NotEqual(t, original, body)
+
+		_, format, err := image.DecodeConfig(bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+	})
+
+	t.Run("Resized sizes are watermarked", func(t *testing.T) {
+		res, body := ts.SendRequest(t, tx, http.MethodGet, filePath+"/small", "", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, `"`+photo.ID+`-wm_small"`, res.Header.Get("ETag"))
+
+		cfg, _, err := image.DecodeConfig(bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		assert.LessOrEqual(t, cfg.Width, 400)
+
+		// Повторный запрос отдает закэшированную копию
+		res, cached := ts.SendRequest(t, tx, http.MethodGet, filePath+"/small", "", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, body, cached)
+	})
+
+	t.Run("Employer sees the original only after accepting the model", func(t *testing.T) {
+		res, _ := ts.SendRequest(t, tx, http.MethodGet, filePath, employerToken, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, `"`+photo.ID+`-wm_large"`, res.Header.Get("ETag"))
+
+		casting := CreateTestCasting(t, tx, employerProfile.ID, "Съемка каталога", "Almaty")
+		CreateTestResponse(t, tx, casting.ID, modelUser.ID, models.ResponseStatusAccepted)
+
+		res, body := ts.SendRequest(t, tx, http.MethodGet, filePath, employerToken, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, `"`+photo.ID+`"`, res.Header.Get("ETag"))
+		assert.Equal(t, original, body)
+	})
+
+	t.Run("Share preview links to the watermarked copy", func(t *testing.T) {
+		require.NoError(t, tx.Create(&models.PortfolioItem{ModelID: modelProfile.ID, UploadID: &photo.ID, Title: "Look"}).Error)
+		slug := fmt.Sprintf("watermark-%d", time.Now().UnixNano())
+		res, body := ts.SendRequest(t, tx, http.MethodPut, "/api/v1/profiles/me/slug", modelToken, map[string]interface{}{"slug": slug})
+		require.Equal(t, http.StatusOK, res.StatusCode, "Body: "+body)
+
+		res, body = ts.SendRequest(t, tx, http.MethodGet, "/api/v1/share/models/"+slug, "", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode, "Body: "+body)
+		assert.Contains(t, body, "/api/v1/files/"+photo.ID+"/large")
+	})
+
+	t.Run("Non-portfolio images are not watermarked", func(t *testing.T) {
+		cover := uploadImage(t, ts, tx, modelToken, modelProfile.ID, "profile", "cover_photo", "cover.jpg", buildEXIFJPEG(t, 240, 160, 1))
+
+		res, _ := ts.SendRequest(t, tx, http.MethodGet, "/api/v1/files/"+cover.ID, "", nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, `"`+cover.ID+`"`, res.Header.Get("ETag"))
+	})
+}